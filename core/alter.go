@@ -0,0 +1,77 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/disney/quanta/shared"
+	"github.com/hashicorp/consul/api"
+)
+
+// AlterTable - Apply an ALTER TABLE statement to a deployed table.  Returns a description of the changes.
+// If dryRun is true the changes are validated but not deployed.  Otherwise the schema is read and deployed
+// under the admin lock.
+func AlterTable(consul *api.Client, services *shared.BitmapIndex, stmt *shared.AlterTableStatement,
+	dryRun bool) ([]string, error) {
+
+	var changes []string
+	alter := func(table *shared.BasicTable) (*shared.BasicTable, error) {
+		altered, c, err := table.Alter(stmt.Actions)
+		if err != nil {
+			return nil, err
+		}
+		for _, action := range stmt.Actions {
+			if action.Op == shared.DropColumn {
+				continue
+			}
+			attr, err := altered.GetAttribute(action.Column)
+			if err != nil {
+				return nil, err
+			}
+			if err := validateMapper(attr); err != nil {
+				return nil, fmt.Errorf("column %s - %v", action.Column, err)
+			}
+		}
+		changes = c
+		if dryRun || len(changes) == 0 {
+			return nil, nil
+		}
+		return altered, nil
+	}
+
+	if dryRun {
+		table, err := shared.LoadSchema("", stmt.Table, consul)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load table %s - %v", stmt.Table, err)
+		}
+		if _, err := alter(table); err != nil {
+			return nil, err
+		}
+		return changes, nil
+	}
+	if err := shared.UpdateTable(consul, services, stmt.Table, alter); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// CreateTable - Validate the mappers of a new table and deploy it.
+func CreateTable(consul *api.Client, services *shared.BitmapIndex, table *shared.BasicTable) error {
+
+	for i := range table.Attributes {
+		if err := validateMapper(&table.Attributes[i]); err != nil {
+			return fmt.Errorf("column %s - %v", table.Attributes[i].FieldName, err)
+		}
+	}
+	return shared.CreateTable(consul, services, table)
+}
+
+// validateMapper - Verify that the mapping strategy of an attribute resolves to a mapper.
+func validateMapper(attr *shared.BasicAttribute) error {
+
+	switch attr.MappingStrategy {
+	case "ParentRelation", "ChildRelation", "Delegated":
+		return nil
+	}
+	_, err := ResolveMapper(&Attribute{BasicAttribute: attr})
+	return err
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"math"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -670,3 +671,620 @@ func (m IntToBoolDirectMapper) Transform(attr *Attribute, val interface{}, c *Se
 
 	return
 }
+
+// IntLinearMapper - Maps integer values to linear buckets of a fixed size.
+type IntLinearMapper struct {
+	DefaultMapper
+	min int64
+	max int64
+	res int64
+}
+
+// NewIntLinearMapper - Construct a NewIntLinearMapper.  Requires 'min', 'max' and 'resolution' config params.
+func NewIntLinearMapper(conf map[string]string) (Mapper, error) {
+
+	var err error
+	m := IntLinearMapper{DefaultMapper: DefaultMapper{IntLinear}}
+	if m.min, err = getConfInt(conf, IntLinear, "min"); err != nil {
+		return nil, err
+	}
+	if m.max, err = getConfInt(conf, IntLinear, "max"); err != nil {
+		return nil, err
+	}
+	if m.res, err = getConfInt(conf, IntLinear, "resolution"); err != nil {
+		return nil, err
+	}
+	if m.res <= 0 || m.max <= m.min {
+		return nil, fmt.Errorf("IntLinearMapper 'max' must be greater than 'min' and 'resolution' must be positive")
+	}
+	return m, nil
+}
+
+// MapValue - Map a value to a bucket row id.  Values outside of min/max are placed in the first/last bucket.
+func (m IntLinearMapper) MapValue(attr *Attribute, val interface{},
+	c *Session) (result uint64, err error) {
+
+	v, ok, isNull := toInt64(val)
+	if isNull {
+		return
+	}
+	if !ok {
+		return 0, fmt.Errorf("%s: No handling for type '%T' for '%s'", m.String(), val, attr.FieldName)
+	}
+	if v < m.min {
+		v = m.min
+	}
+	if v > m.max {
+		v = m.max
+	}
+	result = uint64((v - m.min) / m.res)
+	if c != nil {
+		err = m.UpdateBitmap(c, attr.Parent.Name, attr.FieldName, result, attr.IsTimeSeries)
+	}
+	return
+}
+
+// MapValueReverse - Return the lower bound of a bucket.
+func (m IntLinearMapper) MapValueReverse(attr *Attribute, id uint64, c *Session) (result interface{}, err error) {
+
+	if id > uint64((m.max-m.min)/m.res) {
+		return nil, fmt.Errorf("%s: row id %d is out of range for '%s'", m.String(), id, attr.FieldName)
+	}
+	result = m.min + int64(id)*m.res
+	return
+}
+
+// IntBucketsMapper - Maps integer values to variable size buckets (for logarithmic distributions, etc.)
+type IntBucketsMapper struct {
+	DefaultMapper
+	buckets []int64
+}
+
+// NewIntBucketsMapper - Construct a NewIntBucketsMapper.  Requires a 'buckets' config param containing
+// a comma separated list of ascending lower bounds.
+func NewIntBucketsMapper(conf map[string]string) (Mapper, error) {
+
+	s, err := getConfList(conf, IntBuckets, "buckets", ",")
+	if err != nil {
+		return nil, err
+	}
+	m := IntBucketsMapper{DefaultMapper: DefaultMapper{IntBuckets}, buckets: make([]int64, len(s))}
+	for i, v := range s {
+		if m.buckets[i], err = strconv.ParseInt(v, 10, 64); err != nil {
+			return nil, fmt.Errorf("IntBucketsMapper bucket '%s' - %v", v, err)
+		}
+		if i > 0 && m.buckets[i] <= m.buckets[i-1] {
+			return nil, fmt.Errorf("IntBucketsMapper buckets must be in ascending order")
+		}
+	}
+	return m, nil
+}
+
+// MapValue - Map a value to a bucket row id.  Values below the first bound are placed in the first bucket.
+func (m IntBucketsMapper) MapValue(attr *Attribute, val interface{},
+	c *Session) (result uint64, err error) {
+
+	v, ok, isNull := toInt64(val)
+	if isNull {
+		return
+	}
+	if !ok {
+		return 0, fmt.Errorf("%s: No handling for type '%T' for '%s'", m.String(), val, attr.FieldName)
+	}
+	i := sort.Search(len(m.buckets), func(i int) bool { return m.buckets[i] > v })
+	if i > 0 {
+		result = uint64(i - 1)
+	}
+	if c != nil {
+		err = m.UpdateBitmap(c, attr.Parent.Name, attr.FieldName, result, attr.IsTimeSeries)
+	}
+	return
+}
+
+// MapValueReverse - Return the lower bound of a bucket.
+func (m IntBucketsMapper) MapValueReverse(attr *Attribute, id uint64, c *Session) (result interface{}, err error) {
+
+	if id >= uint64(len(m.buckets)) {
+		return nil, fmt.Errorf("%s: row id %d is out of range for '%s'", m.String(), id, attr.FieldName)
+	}
+	result = m.buckets[id]
+	return
+}
+
+// FloatLinearMapper - Maps floating point values to linear buckets of a fixed size.
+type FloatLinearMapper struct {
+	DefaultMapper
+	min float64
+	max float64
+	res float64
+}
+
+// NewFloatLinearMapper - Construct a NewFloatLinearMapper.  Requires 'min', 'max' and 'resolution' config params.
+func NewFloatLinearMapper(conf map[string]string) (Mapper, error) {
+
+	var err error
+	m := FloatLinearMapper{DefaultMapper: DefaultMapper{FloatLinear}}
+	if m.min, err = getConfFloat(conf, FloatLinear, "min"); err != nil {
+		return nil, err
+	}
+	if m.max, err = getConfFloat(conf, FloatLinear, "max"); err != nil {
+		return nil, err
+	}
+	if m.res, err = getConfFloat(conf, FloatLinear, "resolution"); err != nil {
+		return nil, err
+	}
+	if m.res <= 0 || m.max <= m.min {
+		return nil, fmt.Errorf("FloatLinearMapper 'max' must be greater than 'min' and 'resolution' must be positive")
+	}
+	return m, nil
+}
+
+// MapValue - Map a value to a bucket row id.  Values outside of min/max are placed in the first/last bucket.
+func (m FloatLinearMapper) MapValue(attr *Attribute, val interface{},
+	c *Session) (result uint64, err error) {
+
+	v, ok, isNull := toFloat64(val)
+	if isNull {
+		return
+	}
+	if !ok {
+		return 0, fmt.Errorf("%s: No handling for type '%T' for '%s'", m.String(), val, attr.FieldName)
+	}
+	result = uint64(math.Floor((math.Min(math.Max(v, m.min), m.max) - m.min) / m.res))
+	if c != nil {
+		err = m.UpdateBitmap(c, attr.Parent.Name, attr.FieldName, result, attr.IsTimeSeries)
+	}
+	return
+}
+
+// MapValueReverse - Return the lower bound of a bucket.
+func (m FloatLinearMapper) MapValueReverse(attr *Attribute, id uint64, c *Session) (result interface{}, err error) {
+
+	if id > uint64(math.Floor((m.max-m.min)/m.res)) {
+		return nil, fmt.Errorf("%s: row id %d is out of range for '%s'", m.String(), id, attr.FieldName)
+	}
+	result = m.min + float64(id)*m.res
+	return
+}
+
+// FloatBucketsMapper - Maps floating point values to variable size buckets.
+type FloatBucketsMapper struct {
+	DefaultMapper
+	buckets []float64
+}
+
+// NewFloatBucketsMapper - Construct a NewFloatBucketsMapper.  Requires a 'buckets' config param containing
+// a comma separated list of ascending lower bounds.
+func NewFloatBucketsMapper(conf map[string]string) (Mapper, error) {
+
+	s, err := getConfList(conf, FloatBuckets, "buckets", ",")
+	if err != nil {
+		return nil, err
+	}
+	m := FloatBucketsMapper{DefaultMapper: DefaultMapper{FloatBuckets}, buckets: make([]float64, len(s))}
+	for i, v := range s {
+		if m.buckets[i], err = strconv.ParseFloat(v, 64); err != nil {
+			return nil, fmt.Errorf("FloatBucketsMapper bucket '%s' - %v", v, err)
+		}
+		if i > 0 && m.buckets[i] <= m.buckets[i-1] {
+			return nil, fmt.Errorf("FloatBucketsMapper buckets must be in ascending order")
+		}
+	}
+	return m, nil
+}
+
+// MapValue - Map a value to a bucket row id.  Values below the first bound are placed in the first bucket.
+func (m FloatBucketsMapper) MapValue(attr *Attribute, val interface{},
+	c *Session) (result uint64, err error) {
+
+	v, ok, isNull := toFloat64(val)
+	if isNull {
+		return
+	}
+	if !ok {
+		return 0, fmt.Errorf("%s: No handling for type '%T' for '%s'", m.String(), val, attr.FieldName)
+	}
+	i := sort.Search(len(m.buckets), func(i int) bool { return m.buckets[i] > v })
+	if i > 0 {
+		result = uint64(i - 1)
+	}
+	if c != nil {
+		err = m.UpdateBitmap(c, attr.Parent.Name, attr.FieldName, result, attr.IsTimeSeries)
+	}
+	return
+}
+
+// MapValueReverse - Return the lower bound of a bucket.
+func (m FloatBucketsMapper) MapValueReverse(attr *Attribute, id uint64, c *Session) (result interface{}, err error) {
+
+	if id >= uint64(len(m.buckets)) {
+		return nil, fmt.Errorf("%s: row id %d is out of range for '%s'", m.String(), id, attr.FieldName)
+	}
+	result = m.buckets[id]
+	return
+}
+
+// DatePartMapper - Maps a Date/DateTime to a row id derived from part of the date.
+//
+// YearToDay - YYYYMMDD
+// YearToMonth - YYYYMM
+// Year - YYYY
+// DayOfYear - 1 - 366
+// DayOfMonth - 1 - 31
+// DayOfWeek - 0 - 6 (Sunday = 0)
+// TimeOfDay - Seconds since midnight
+// HourOfDay - 0 - 23
+//
+// When loading, integer values are epoch timestamps in the unit set by the optional 'epochUnit' config param
+// (s, ms or us, default is ms).  Date parts are taken in UTC, strings without a zone are parsed as UTC.  In query predicates integer values (and strings containing integers) are
+// taken to be derived values, this allows for predicates such as "WHERE hour = 13" or
+// "WHERE day_of_week = 'Monday'".
+type DatePartMapper struct {
+	DefaultMapper
+	epochUnit time.Duration
+}
+
+// newDatePartMapper - Construct a DatePartMapper.
+func newDatePartMapper(mt MapperType, conf map[string]string) (Mapper, error) {
+
+	m := DatePartMapper{DefaultMapper: DefaultMapper{mt}, epochUnit: time.Millisecond}
+	switch conf["epochUnit"] {
+	case "", "ms":
+	case "s":
+		m.epochUnit = time.Second
+	case "us":
+		m.epochUnit = time.Microsecond
+	default:
+		return nil, fmt.Errorf("%s: epochUnit must be one of s, ms or us, not '%s'", mt.String(), conf["epochUnit"])
+	}
+	return m, nil
+}
+
+// NewYearToDayMapper - Construct a YearToDay DatePartMapper.
+func NewYearToDayMapper(conf map[string]string) (Mapper, error) {
+	return newDatePartMapper(YearToDay, conf)
+}
+
+// NewYearToMonthMapper - Construct a YearToMonth DatePartMapper.
+func NewYearToMonthMapper(conf map[string]string) (Mapper, error) {
+	return newDatePartMapper(YearToMonth, conf)
+}
+
+// NewYearMapper - Construct a Year DatePartMapper.
+func NewYearMapper(conf map[string]string) (Mapper, error) {
+	return newDatePartMapper(Year, conf)
+}
+
+// NewDayOfYearMapper - Construct a DayOfYear DatePartMapper.
+func NewDayOfYearMapper(conf map[string]string) (Mapper, error) {
+	return newDatePartMapper(DayOfYear, conf)
+}
+
+// NewDayOfMonthMapper - Construct a DayOfMonth DatePartMapper.
+func NewDayOfMonthMapper(conf map[string]string) (Mapper, error) {
+	return newDatePartMapper(DayOfMonth, conf)
+}
+
+// NewDayOfWeekMapper - Construct a DayOfWeek DatePartMapper.
+func NewDayOfWeekMapper(conf map[string]string) (Mapper, error) {
+	return newDatePartMapper(DayOfWeek, conf)
+}
+
+// NewTimeOfDayMapper - Construct a TimeOfDay DatePartMapper.
+func NewTimeOfDayMapper(conf map[string]string) (Mapper, error) {
+	return newDatePartMapper(TimeOfDay, conf)
+}
+
+// NewHourOfDayMapper - Construct a HourOfDay DatePartMapper.
+func NewHourOfDayMapper(conf map[string]string) (Mapper, error) {
+	return newDatePartMapper(HourOfDay, conf)
+}
+
+// MapValue - Map a value to a row id.
+func (m DatePartMapper) MapValue(attr *Attribute, val interface{},
+	c *Session) (result uint64, err error) {
+
+	if v, ok, isNull := toInt64(val); isNull {
+		return
+	} else if ok && c != nil {
+		result = m.datePart(time.Unix(0, v*int64(m.epochUnit)).UTC())
+	} else if ok {
+		if _, err = m.MapValueReverse(attr, uint64(v), c); err != nil {
+			return
+		}
+		result = uint64(v)
+	} else if result, ok = m.parseDerived(val); !ok {
+		var t time.Time
+		switch val.(type) {
+		case string:
+			t, err = dateparse.ParseIn(val.(string), time.UTC)
+		case []byte:
+			err = t.UnmarshalBinary(val.([]byte))
+		case time.Time:
+			t = val.(time.Time)
+		default:
+			err = fmt.Errorf("%s: No handling for type '%T'", m.String(), val)
+		}
+		if err != nil {
+			return
+		}
+		result = m.datePart(t.UTC())
+	}
+	if c != nil && err == nil {
+		err = m.UpdateBitmap(c, attr.Parent.Name, attr.FieldName, result, attr.IsTimeSeries)
+	}
+	return
+}
+
+// parseDerived - Handle derived values that are not integers (day names, times of day).
+func (m DatePartMapper) parseDerived(val interface{}) (uint64, bool) {
+
+	str, ok := val.(string)
+	if !ok {
+		return 0, false
+	}
+	str = strings.TrimSpace(str)
+	switch m.MapperType {
+	case DayOfWeek:
+		for d := time.Sunday; d <= time.Saturday; d++ {
+			if strings.EqualFold(str, d.String()) || strings.EqualFold(str, d.String()[:3]) {
+				return uint64(d), true
+			}
+		}
+	case TimeOfDay:
+		for _, layout := range []string{"15:04:05", "15:04"} {
+			if t, err := time.Parse(layout, str); err == nil {
+				return uint64(t.Hour()*3600 + t.Minute()*60 + t.Second()), true
+			}
+		}
+	}
+	return 0, false
+}
+
+// datePart - Extract the row id from a date.
+func (m DatePartMapper) datePart(t time.Time) uint64 {
+
+	switch m.MapperType {
+	case YearToDay:
+		return uint64(t.Year()*10000 + int(t.Month())*100 + t.Day())
+	case YearToMonth:
+		return uint64(t.Year()*100 + int(t.Month()))
+	case Year:
+		return uint64(t.Year())
+	case DayOfYear:
+		return uint64(t.YearDay())
+	case DayOfMonth:
+		return uint64(t.Day())
+	case DayOfWeek:
+		return uint64(t.Weekday())
+	case TimeOfDay:
+		return uint64(t.Hour()*3600 + t.Minute()*60 + t.Second())
+	default:
+		return uint64(t.Hour())
+	}
+}
+
+// MapValueReverse - Return the date part for a row id.  YearToDay and YearToMonth are returned as
+// date strings, DayOfWeek as the day name and TimeOfDay as HH:MM:SS.
+func (m DatePartMapper) MapValueReverse(attr *Attribute, id uint64, c *Session) (result interface{}, err error) {
+
+	v := int(id)
+	valid := false
+	switch m.MapperType {
+	case YearToDay:
+		t := time.Date(v/10000, time.Month(v/100%100), v%100, 0, 0, 0, 0, time.UTC)
+		valid = m.datePart(t) == id
+		result = t.Format("2006-01-02")
+	case YearToMonth:
+		t := time.Date(v/100, time.Month(v%100), 1, 0, 0, 0, 0, time.UTC)
+		valid = m.datePart(t) == id
+		result = t.Format("2006-01")
+	case Year:
+		valid = v > 0 && v <= 9999
+		result = int64(v)
+	case DayOfYear:
+		valid = v >= 1 && v <= 366
+		result = int64(v)
+	case DayOfMonth:
+		valid = v >= 1 && v <= 31
+		result = int64(v)
+	case DayOfWeek:
+		valid = v <= int(time.Saturday)
+		result = time.Weekday(v).String()
+	case TimeOfDay:
+		valid = v < 86400
+		result = fmt.Sprintf("%02d:%02d:%02d", v/3600, v/60%60, v%60)
+	case HourOfDay:
+		valid = v < 24
+		result = int64(v)
+	}
+	if !valid {
+		return nil, fmt.Errorf("%s: value %d is out of range", m.String(), id)
+	}
+	return
+}
+
+// ContainsMapper - Maps a string to the row ids of the configured values that it contains.
+type ContainsMapper struct {
+	DefaultMapper
+	values []string
+	delim  string
+}
+
+// NewContainsMapper - Construct a NewContainsMapper.  Requires a 'values' config param containing a
+// list of (non overlapping) strings separated by 'delim' (default is a comma).
+func NewContainsMapper(conf map[string]string) (Mapper, error) {
+
+	delim := ","
+	if d, ok := conf["delim"]; ok && d != "" {
+		delim = d
+	}
+	values, err := getConfList(conf, Contains, "values", delim)
+	if err != nil {
+		return nil, err
+	}
+	return ContainsMapper{DefaultMapper: DefaultMapper{Contains}, values: values, delim: delim}, nil
+}
+
+// MapValue - Set a row id for each configured value contained in the input.  An input that contains none of
+// them is NULL (no row id is set).  If there is no session (i.e. a query predicate) then the value must exactly
+// match one of the configured values.
+func (m ContainsMapper) MapValue(attr *Attribute, val interface{},
+	c *Session) (result uint64, err error) {
+
+	var strVal string
+	switch val.(type) {
+	case string:
+		strVal = val.(string)
+	case []byte:
+		strVal = string(val.([]byte))
+	default:
+		return 0, fmt.Errorf("cannot cast '%s' from '%T' to a string", attr.FieldName, val)
+	}
+	if strVal == "" {
+		return
+	}
+
+	if c == nil {
+		for i, v := range m.values {
+			if v == strVal {
+				return uint64(i), nil
+			}
+		}
+		return 0, fmt.Errorf("'%s' is not a configured value for '%s'", strVal, attr.FieldName)
+	}
+
+	found := false
+	for i, v := range m.values {
+		if !strings.Contains(strVal, v) {
+			continue
+		}
+		if !found {
+			result = uint64(i)
+			found = true
+		}
+		if err = m.UpdateBitmap(c, attr.Parent.Name, attr.FieldName, uint64(i), attr.IsTimeSeries); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// MapValueReverse - Return the configured value for a row id.
+func (m ContainsMapper) MapValueReverse(attr *Attribute, id uint64, c *Session) (result interface{}, err error) {
+
+	if id >= uint64(len(m.values)) {
+		return nil, fmt.Errorf("%s: row id %d is out of range for '%s'", m.String(), id, attr.FieldName)
+	}
+	result = m.values[id]
+	return
+}
+
+// GetMultiDelimiter - Return the delimiter used for multiple value support.
+func (m ContainsMapper) GetMultiDelimiter() string {
+	return m.delim
+}
+
+// toInt64 - Coerce a value to an int64.  Floats must be integral.
+func toInt64(val interface{}) (result int64, ok, isNull bool) {
+
+	ok = true
+	switch v := val.(type) {
+	case int:
+		result = int64(v)
+	case int32:
+		result = int64(v)
+	case int64:
+		result = v
+	case uint32:
+		result = int64(v)
+	case uint64:
+		result = int64(v)
+	case float32:
+		result = int64(v)
+		ok = float32(result) == v
+	case float64:
+		result = int64(v)
+		ok = float64(result) == v
+	case string:
+		str := strings.TrimSpace(v)
+		if str == "" || str == "NULL" {
+			return 0, false, true
+		}
+		var err error
+		result, err = strconv.ParseInt(str, 10, 64)
+		ok = err == nil
+	case nil:
+		return 0, false, true
+	default:
+		ok = false
+	}
+	return
+}
+
+// toFloat64 - Coerce a value to a float64.
+func toFloat64(val interface{}) (result float64, ok, isNull bool) {
+
+	ok = true
+	switch v := val.(type) {
+	case float32:
+		result = float64(v)
+	case float64:
+		result = v
+	case string:
+		str := strings.TrimSpace(v)
+		if str == "" || str == "NULL" {
+			return 0, false, true
+		}
+		var err error
+		result, err = strconv.ParseFloat(str, 64)
+		ok = err == nil
+	default:
+		var i int64
+		i, ok, isNull = toInt64(val)
+		result = float64(i)
+	}
+	return
+}
+
+// getConfInt - Get a required integer mapper config param.
+func getConfInt(conf map[string]string, mt MapperType, key string) (int64, error) {
+
+	s, ok := conf[key]
+	if !ok {
+		return 0, fmt.Errorf("'%s' config param must be supplied for %sMapper", key, mt.String())
+	}
+	v, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%sMapper config param '%s' - %v", mt.String(), key, err)
+	}
+	return v, nil
+}
+
+// getConfFloat - Get a required floating point mapper config param.
+func getConfFloat(conf map[string]string, mt MapperType, key string) (float64, error) {
+
+	s, ok := conf[key]
+	if !ok {
+		return 0, fmt.Errorf("'%s' config param must be supplied for %sMapper", key, mt.String())
+	}
+	v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return 0, fmt.Errorf("%sMapper config param '%s' - %v", mt.String(), key, err)
+	}
+	return v, nil
+}
+
+// getConfList - Get a required delimited list mapper config param.
+func getConfList(conf map[string]string, mt MapperType, key, delim string) ([]string, error) {
+
+	s, ok := conf[key]
+	if !ok || strings.TrimSpace(s) == "" {
+		return nil, fmt.Errorf("'%s' config param must be supplied for %sMapper", key, mt.String())
+	}
+	list := strings.Split(s, delim)
+	for i, v := range list {
+		list[i] = strings.TrimSpace(v)
+	}
+	return list, nil
+}
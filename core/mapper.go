@@ -380,6 +380,19 @@ func init() {
 	Register(SysMillisBSI.String(), NewSysMillisBSIMapper)
 	Register(SysMicroBSI.String(), NewSysMicroBSIMapper)
 	Register(SysSecBSI.String(), NewSysSecBSIMapper)
+	Register(IntLinear.String(), NewIntLinearMapper)
+	Register(IntBuckets.String(), NewIntBucketsMapper)
+	Register(FloatLinear.String(), NewFloatLinearMapper)
+	Register(FloatBuckets.String(), NewFloatBucketsMapper)
+	Register(YearToDay.String(), NewYearToDayMapper)
+	Register(YearToMonth.String(), NewYearToMonthMapper)
+	Register(Year.String(), NewYearMapper)
+	Register(DayOfYear.String(), NewDayOfYearMapper)
+	Register(DayOfMonth.String(), NewDayOfMonthMapper)
+	Register(DayOfWeek.String(), NewDayOfWeekMapper)
+	Register(TimeOfDay.String(), NewTimeOfDayMapper)
+	Register(HourOfDay.String(), NewHourOfDayMapper)
+	Register(Contains.String(), NewContainsMapper)
 }
 
 // Get64BitHash - Hash a string.
@@ -4,7 +4,9 @@ import (
 	"database/sql/driver"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/disney/quanta/shared"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -87,3 +89,111 @@ func TestBuiltinMappers(t *testing.T) {
 	}
 
 }
+
+func TestBucketMappers(t *testing.T) {
+
+	attr := &Attribute{BasicAttribute: &shared.BasicAttribute{FieldName: "test"}}
+
+	m, err := lookupMapper("IntLinear", map[string]string{"min": "0", "max": "100", "resolution": "10"})
+	require.Nil(t, err)
+	v, err := m.MapValue(attr, int64(25), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), v)
+	v, _ = m.MapValue(attr, "500", nil)
+	assert.Equal(t, uint64(10), v)
+	r, err := m.MapValueReverse(attr, 2, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(20), r)
+
+	_, err = lookupMapper("IntLinear", map[string]string{"min": "0"})
+	assert.NotNil(t, err)
+
+	m, err = lookupMapper("FloatBuckets", map[string]string{"buckets": "0.5, 1.0, 10.0"})
+	require.Nil(t, err)
+	v, _ = m.MapValue(attr, 5.5, nil)
+	assert.Equal(t, uint64(1), v)
+	v, _ = m.MapValue(attr, 0.1, nil)
+	assert.Equal(t, uint64(0), v)
+	r, _ = m.MapValueReverse(attr, 2, nil)
+	assert.Equal(t, 10.0, r)
+
+	m, err = lookupMapper("Contains", map[string]string{"values": "red,green,blue"})
+	require.Nil(t, err)
+	v, err = m.MapValue(attr, "green", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), v)
+	r, _ = m.MapValueReverse(attr, 2, nil)
+	assert.Equal(t, "blue", r)
+
+	// When loading a value that contains none of the configured values is NULL.
+	session := &Session{TableBuffers: conn.TableBuffers, BatchBuffer: shared.NewBatchBuffer(nil, nil, 1000)}
+	attr.Parent = schema
+	_, err = m.MapValue(attr, "yellow", session)
+	assert.Nil(t, err)
+	assert.True(t, session.BatchBuffer.IsEmpty())
+	_, err = m.MapValue(attr, "dark green and blue", session)
+	assert.Nil(t, err)
+	assert.False(t, session.BatchBuffer.IsEmpty())
+}
+
+func TestDatePartMappers(t *testing.T) {
+
+	attr := &Attribute{BasicAttribute: &shared.BasicAttribute{FieldName: "test"}}
+	ts := time.Date(2024, time.March, 15, 13, 45, 30, 0, time.UTC)
+
+	expected := map[string]uint64{"YearToDay": 20240315, "YearToMonth": 202403, "Year": 2024, "DayOfYear": 75,
+		"DayOfMonth": 15, "DayOfWeek": 5, "TimeOfDay": 49530, "HourOfDay": 13}
+	for name, want := range expected {
+		m, err := lookupMapper(name, nil)
+		require.Nil(t, err, name)
+		v, err := m.MapValue(attr, ts, nil)
+		assert.Nil(t, err, name)
+		assert.Equal(t, want, v, name)
+		// Derived values are taken literally
+		v, err = m.MapValue(attr, int64(want), nil)
+		assert.Nil(t, err, name)
+		assert.Equal(t, want, v, name)
+		_, err = m.MapValueReverse(attr, want, nil)
+		assert.Nil(t, err, name)
+	}
+
+	m, _ := lookupMapper("DayOfWeek", nil)
+	v, err := m.MapValue(attr, "friday", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(5), v)
+	r, _ := m.MapValueReverse(attr, 5, nil)
+	assert.Equal(t, "Friday", r)
+
+	m, _ = lookupMapper("YearToDay", nil)
+	r, _ = m.MapValueReverse(attr, 20240315, nil)
+	assert.Equal(t, "2024-03-15", r)
+	_, err = m.MapValue(attr, int64(20241340), nil)
+	assert.NotNil(t, err)
+
+	m, _ = lookupMapper("TimeOfDay", nil)
+	v, _ = m.MapValue(attr, "13:45:30", nil)
+	assert.Equal(t, uint64(49530), v)
+
+	// Dates are taken in UTC like epoch timestamps.
+	m, _ = lookupMapper("HourOfDay", nil)
+	v, err = m.MapValue(attr, ts.In(time.FixedZone("EST", -5*3600)), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(13), v)
+	v, err = m.MapValue(attr, "2024-03-15 13:45:30", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(13), v)
+
+	// When loading integer values are epoch timestamps.
+	session := &Session{TableBuffers: conn.TableBuffers, BatchBuffer: shared.NewBatchBuffer(nil, nil, 1000)}
+	attr.Parent = schema
+	m, _ = lookupMapper("YearToDay", nil)
+	v, err = m.MapValue(attr, ts.UnixMilli(), session)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(20240315), v)
+	m, _ = lookupMapper("HourOfDay", map[string]string{"epochUnit": "s"})
+	v, err = m.MapValue(attr, ts.Unix(), session)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(13), v)
+	_, err = lookupMapper("HourOfDay", map[string]string{"epochUnit": "days"})
+	assert.NotNil(t, err)
+}
@@ -3,6 +3,7 @@ package core
 // Projection functions including join projection handling.
 
 import (
+	"context"
 	"database/sql/driver"
 	"fmt"
 	"math"
@@ -23,6 +24,7 @@ const (
 
 // Projector - State of an in-flight projection
 type Projector struct {
+	ctx            context.Context // Cancels the projection requests sent to the nodes
 	connection     *Session
 	fromTime       int64
 	toTime         int64
@@ -39,6 +41,8 @@ type Projector struct {
 	Prefetch       bool                                      // Set to true to prefetch all bitmap related data for export.
 	bsiResults     map[string]map[string]*roaring64.BSI      // Prefetched BSIs
 	bitmapResults  map[string]map[string]*BitmapFieldResults // Prefetched Bitmaps
+	aggBSIs        map[string]map[string]*roaring64.BSI      // Aggregate inputs, retrieved once
+	aggBitmaps     map[string]map[string]*BitmapFieldResults // Aggregate inputs, retrieved once
 	negate         bool                                      // != join
 	innerJoin      bool
 }
@@ -54,9 +58,10 @@ type BitmapFieldRow struct {
 	bm    *roaring64.Bitmap
 }
 
-// NewProjection - Construct a Projection.
-func NewProjection(s *Session, foundSets map[string]*roaring64.Bitmap, joinNames, projNames []string,
-	child, left string, fromTime, toTime int64, joinTypes map[string]bool, negate bool) (*Projector, error) {
+// NewProjection - Construct a Projection.  Canceling ctx aborts data retrieval.
+func NewProjection(ctx context.Context, s *Session, foundSets map[string]*roaring64.Bitmap,
+	joinNames, projNames []string, child, left string, fromTime, toTime int64, joinTypes map[string]bool,
+	negate bool) (*Projector, error) {
 
 	projFieldMap := make(map[string]int)
 	j := 0
@@ -80,7 +85,7 @@ func NewProjection(s *Session, foundSets map[string]*roaring64.Bitmap, joinNames
 		return nil, err
 	}
 
-	p := &Projector{connection: s, projAttributes: projAttributes, joinTypes: joinTypes, leftTable: left,
+	p := &Projector{ctx: ctx, connection: s, projAttributes: projAttributes, joinTypes: joinTypes, leftTable: left,
 		foundSets: foundSets, fromTime: fromTime, toTime: toTime, childTable: child, negate: negate}
 
 	// Perform validation for join projections (if applicable)
@@ -245,7 +250,8 @@ func (p *Projector) retrieveBitmapResults(foundSets map[string]*roaring64.Bitmap
 			continue
 		}
 		u.Debugf("TABLE = %v, FIELDNAMES = %#v, FS = %d, NEGATE = %v", k, fieldNames[k], v.GetCardinality(), negate)
-		bsir, bitr, err := p.connection.BitIndex.Projection(k, fieldNames[k], p.fromTime, p.toTime, v, false)
+		bsir, bitr, err := p.connection.BitIndex.ProjectionContext(p.ctx, k, fieldNames[k], p.fromTime, p.toTime,
+			v, false)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -921,13 +927,85 @@ func (p *Projector) minMax(isMin bool, table, field string) (minmax int64, err e
 	return
 }
 
-func (p *Projector) getAggregateResult(table, field string) (result *roaring64.BSI, err error) {
+// Percentile - Exact (nearest rank) percentile aggregate, pct is a fraction between 0 and 1.
+// A count of zero means there were no values in the found set.
+func (p *Projector) Percentile(table, field string, pct float64) (value int64, count uint64, err error) {
 
-	bsiResults, bitmapResults, errx := p.retrieveBitmapResults(p.foundSets, p.projAttributes, false)
+	if pct < 0 || pct > 1 {
+		err = fmt.Errorf("percentile must be between 0 and 1, got %v", pct)
+		return
+	}
+	r, errx := p.getAggregateResult(table, field)
 	if errx != nil {
 		err = errx
 		return
 	}
+	ebm := r.GetExistenceBitmap()
+	count = ebm.GetCardinality()
+	if count == 0 {
+		return
+	}
+	rank := uint64(math.Ceil(pct * float64(count)))
+	if rank == 0 {
+		rank = 1
+	}
+	value, err = bsiRankValue(r, ebm, rank)
+	return
+}
+
+// bsiRankValue - Returns the value with the given (1 based) rank in ascending order.  The bit slices
+// are walked from the most significant down, narrowing the candidates to the half containing the rank.
+func bsiRankValue(bsi *roaring64.BSI, candidates *roaring64.Bitmap, rank uint64) (int64, error) {
+
+	data, err := bsi.NewBSIRetainSet(candidates).MarshalBinary()
+	if err != nil {
+		return 0, err
+	}
+	candidates = candidates.Clone()
+	for i := len(data) - 1; i > 0; i-- {
+		slice := roaring64.NewBitmap()
+		if len(data[i]) > 0 {
+			if err := slice.UnmarshalBinary(data[i]); err != nil {
+				return 0, err
+			}
+		}
+		// Values with the bit set are greater except for the sign bit.
+		lower := roaring64.AndNot(candidates, slice)
+		if i-1 == 63 {
+			lower = roaring64.And(candidates, slice)
+		}
+		if n := lower.GetCardinality(); rank > n {
+			rank -= n
+			candidates.AndNot(lower)
+		} else {
+			candidates = lower
+		}
+	}
+	// All remaining candidates have the same value.
+	value, ok := bsi.GetValue(candidates.Minimum())
+	if !ok {
+		return 0, fmt.Errorf("cannot locate value at rank %d", rank)
+	}
+	return value, nil
+}
+
+// getAggregateResult - Aggregate input for a field.  The projection is retrieved from the nodes on the
+// first call only, so that several aggregates (such as percentiles) of a query share one retrieval.
+func (p *Projector) getAggregateResult(table, field string) (result *roaring64.BSI, err error) {
+
+	p.stateGuard.Lock()
+	if p.aggBSIs == nil && p.aggBitmaps == nil {
+		bsiResults, bitmapResults, errx := p.retrieveBitmapResults(p.foundSets, p.projAttributes, false)
+		if errx != nil {
+			p.stateGuard.Unlock()
+			err = errx
+			return
+		}
+		p.aggBSIs = bsiResults
+		p.aggBitmaps = bitmapResults
+	}
+	bsiResults, bitmapResults := p.aggBSIs, p.aggBitmaps
+	p.stateGuard.Unlock()
 
 	var ok bool
 	result, ok = bsiResults[table][field]
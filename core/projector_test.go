@@ -0,0 +1,35 @@
+package core
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/RoaringBitmap/roaring/roaring64"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBSIRankValue(t *testing.T) {
+
+	values := []int64{42, -7, 1000, 0, 42, -300, 15, 8, 99, 3}
+	bsi := roaring64.NewDefaultBSI()
+	for i, v := range values {
+		bsi.SetValue(uint64(i+1), v)
+	}
+	sorted := make([]int64, len(values))
+	copy(sorted, values)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	ebm := bsi.GetExistenceBitmap()
+	for rank := range sorted {
+		v, err := bsiRankValue(bsi, ebm, uint64(rank+1))
+		require.NoError(t, err)
+		assert.Equal(t, sorted[rank], v, "rank %d", rank+1)
+	}
+	assert.Equal(t, uint64(len(values)), ebm.GetCardinality())
+
+	// Restricted to a subset of columns.
+	v, err := bsiRankValue(bsi, roaring64.BitmapOf(1, 3, 6), 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), v)
+}
@@ -946,6 +946,16 @@ func (s *Session) Flush() error {
 	return nil
 }
 
+// Discard - Drop the buffered row operations without sending them to the backend.
+func (s *Session) Discard() {
+
+	s.stateLock.Lock()
+	defer s.stateLock.Unlock()
+	if s.BatchBuffer != nil {
+		s.BatchBuffer.Discard()
+	}
+}
+
 // CloseSession - Close the session, flushing if necessary..
 func (s *Session) CloseSession() error {
 
@@ -522,6 +522,8 @@ func (a *Attribute) ToBackingValue(rowIDs []uint64, c *Session) (result string,
 			s[i] = fmt.Sprintf("%v", v)
 		case int, int32, int64:
 			s[i] = fmt.Sprintf("%d", v)
+		case float32, float64:
+			s[i] = fmt.Sprintf("%v", v)
 		default:
 			return "", fmt.Errorf("ToBackingValue: Unsupported type %T", t)
 		}
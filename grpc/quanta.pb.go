@@ -432,9 +432,12 @@ type BitmapQuery struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Query    []*QueryFragment `protobuf:"bytes,1,rep,name=query,proto3" json:"query,omitempty"`
-	FromTime int64            `protobuf:"varint,2,opt,name=fromTime,proto3" json:"fromTime,omitempty"`
-	ToTime   int64            `protobuf:"varint,3,opt,name=toTime,proto3" json:"toTime,omitempty"`
+	Query          []*QueryFragment `protobuf:"bytes,1,rep,name=query,proto3" json:"query,omitempty"`
+	FromTime       int64            `protobuf:"varint,2,opt,name=fromTime,proto3" json:"fromTime,omitempty"`
+	ToTime         int64            `protobuf:"varint,3,opt,name=toTime,proto3" json:"toTime,omitempty"`
+	Replica        int32            `protobuf:"varint,4,opt,name=replica,proto3" json:"replica,omitempty"`
+	Analyze        bool             `protobuf:"varint,5,opt,name=analyze,proto3" json:"analyze,omitempty"`
+	ShardChecksums bool             `protobuf:"varint,6,opt,name=shardChecksums,proto3" json:"shardChecksums,omitempty"`
 }
 
 func (x *BitmapQuery) Reset() {
@@ -490,6 +493,27 @@ func (x *BitmapQuery) GetToTime() int64 {
 	return 0
 }
 
+func (x *BitmapQuery) GetReplica() int32 {
+	if x != nil {
+		return x.Replica
+	}
+	return 0
+}
+
+func (x *BitmapQuery) GetAnalyze() bool {
+	if x != nil {
+		return x.Analyze
+	}
+	return false
+}
+
+func (x *BitmapQuery) GetShardChecksums() bool {
+	if x != nil {
+		return x.ShardChecksums
+	}
+	return false
+}
+
 type QueryFragment struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -717,14 +741,17 @@ type QueryResult struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Unions              []byte          `protobuf:"bytes,1,opt,name=unions,proto3" json:"unions,omitempty"`
-	Intersects          [][]byte        `protobuf:"bytes,2,rep,name=intersects,proto3" json:"intersects,omitempty"`
-	Differences         [][]byte        `protobuf:"bytes,3,rep,name=differences,proto3" json:"differences,omitempty"`
-	Samples             []*BitmapResult `protobuf:"bytes,4,rep,name=samples,proto3" json:"samples,omitempty"`
-	SamplePct           float32         `protobuf:"fixed32,5,opt,name=samplePct,proto3" json:"samplePct,omitempty"`
-	SampleIsUnion       bool            `protobuf:"varint,6,opt,name=sampleIsUnion,proto3" json:"sampleIsUnion,omitempty"`
-	Existences          []byte          `protobuf:"bytes,7,opt,name=existences,proto3" json:"existences,omitempty"`
-	AndDifferencesCount int32           `protobuf:"varint,8,opt,name=andDifferencesCount,proto3" json:"andDifferencesCount,omitempty"`
+	Unions              []byte           `protobuf:"bytes,1,opt,name=unions,proto3" json:"unions,omitempty"`
+	Intersects          [][]byte         `protobuf:"bytes,2,rep,name=intersects,proto3" json:"intersects,omitempty"`
+	Differences         [][]byte         `protobuf:"bytes,3,rep,name=differences,proto3" json:"differences,omitempty"`
+	Samples             []*BitmapResult  `protobuf:"bytes,4,rep,name=samples,proto3" json:"samples,omitempty"`
+	SamplePct           float32          `protobuf:"fixed32,5,opt,name=samplePct,proto3" json:"samplePct,omitempty"`
+	SampleIsUnion       bool             `protobuf:"varint,6,opt,name=sampleIsUnion,proto3" json:"sampleIsUnion,omitempty"`
+	Existences          []byte           `protobuf:"bytes,7,opt,name=existences,proto3" json:"existences,omitempty"`
+	AndDifferencesCount int32            `protobuf:"varint,8,opt,name=andDifferencesCount,proto3" json:"andDifferencesCount,omitempty"`
+	ColdPartitions      int32            `protobuf:"varint,9,opt,name=coldPartitions,proto3" json:"coldPartitions,omitempty"`
+	FragmentStats       []*FragmentStats `protobuf:"bytes,10,rep,name=fragmentStats,proto3" json:"fragmentStats,omitempty"`
+	Shards              []*ShardChecksum `protobuf:"bytes,11,rep,name=shards,proto3" json:"shards,omitempty"`
 }
 
 func (x *QueryResult) Reset() {
@@ -815,6 +842,98 @@ func (x *QueryResult) GetAndDifferencesCount() int32 {
 	return 0
 }
 
+func (x *QueryResult) GetColdPartitions() int32 {
+	if x != nil {
+		return x.ColdPartitions
+	}
+	return 0
+}
+
+func (x *QueryResult) GetFragmentStats() []*FragmentStats {
+	if x != nil {
+		return x.FragmentStats
+	}
+	return nil
+}
+
+func (x *QueryResult) GetShards() []*ShardChecksum {
+	if x != nil {
+		return x.Shards
+	}
+	return nil
+}
+
+type FragmentStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Cardinality uint64 `protobuf:"varint,2,opt,name=cardinality,proto3" json:"cardinality,omitempty"`
+	Elapsed     int64  `protobuf:"varint,3,opt,name=elapsed,proto3" json:"elapsed,omitempty"`
+	Node        string `protobuf:"bytes,4,opt,name=node,proto3" json:"node,omitempty"`
+}
+
+func (x *FragmentStats) Reset() {
+	*x = FragmentStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_quanta_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FragmentStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FragmentStats) ProtoMessage() {}
+
+func (x *FragmentStats) ProtoReflect() protoreflect.Message {
+	mi := &file_quanta_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FragmentStats.ProtoReflect.Descriptor instead.
+func (*FragmentStats) Descriptor() ([]byte, []int) {
+	return file_quanta_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *FragmentStats) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FragmentStats) GetCardinality() uint64 {
+	if x != nil {
+		return x.Cardinality
+	}
+	return 0
+}
+
+func (x *FragmentStats) GetElapsed() int64 {
+	if x != nil {
+		return x.Elapsed
+	}
+	return 0
+}
+
+func (x *FragmentStats) GetNode() string {
+	if x != nil {
+		return x.Node
+	}
+	return ""
+}
+
 type JoinRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -832,7 +951,7 @@ type JoinRequest struct {
 func (x *JoinRequest) Reset() {
 	*x = JoinRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_quanta_proto_msgTypes[7]
+		mi := &file_quanta_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -845,7 +964,7 @@ func (x *JoinRequest) String() string {
 func (*JoinRequest) ProtoMessage() {}
 
 func (x *JoinRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_quanta_proto_msgTypes[7]
+	mi := &file_quanta_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -858,7 +977,7 @@ func (x *JoinRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JoinRequest.ProtoReflect.Descriptor instead.
 func (*JoinRequest) Descriptor() ([]byte, []int) {
-	return file_quanta_proto_rawDescGZIP(), []int{7}
+	return file_quanta_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *JoinRequest) GetDriverIndex() string {
@@ -915,13 +1034,14 @@ type JoinResponse struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Results [][]byte `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Results        [][]byte `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	ColdPartitions int32    `protobuf:"varint,2,opt,name=coldPartitions,proto3" json:"coldPartitions,omitempty"`
 }
 
 func (x *JoinResponse) Reset() {
 	*x = JoinResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_quanta_proto_msgTypes[8]
+		mi := &file_quanta_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -934,7 +1054,7 @@ func (x *JoinResponse) String() string {
 func (*JoinResponse) ProtoMessage() {}
 
 func (x *JoinResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_quanta_proto_msgTypes[8]
+	mi := &file_quanta_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -947,7 +1067,7 @@ func (x *JoinResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JoinResponse.ProtoReflect.Descriptor instead.
 func (*JoinResponse) Descriptor() ([]byte, []int) {
-	return file_quanta_proto_rawDescGZIP(), []int{8}
+	return file_quanta_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *JoinResponse) GetResults() [][]byte {
@@ -957,6 +1077,13 @@ func (x *JoinResponse) GetResults() [][]byte {
 	return nil
 }
 
+func (x *JoinResponse) GetColdPartitions() int32 {
+	if x != nil {
+		return x.ColdPartitions
+	}
+	return 0
+}
+
 type BulkClearRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -971,7 +1098,7 @@ type BulkClearRequest struct {
 func (x *BulkClearRequest) Reset() {
 	*x = BulkClearRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_quanta_proto_msgTypes[9]
+		mi := &file_quanta_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -984,7 +1111,7 @@ func (x *BulkClearRequest) String() string {
 func (*BulkClearRequest) ProtoMessage() {}
 
 func (x *BulkClearRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_quanta_proto_msgTypes[9]
+	mi := &file_quanta_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -997,7 +1124,7 @@ func (x *BulkClearRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BulkClearRequest.ProtoReflect.Descriptor instead.
 func (*BulkClearRequest) Descriptor() ([]byte, []int) {
-	return file_quanta_proto_rawDescGZIP(), []int{9}
+	return file_quanta_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *BulkClearRequest) GetIndex() string {
@@ -1043,7 +1170,7 @@ type UpdateRequest struct {
 func (x *UpdateRequest) Reset() {
 	*x = UpdateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_quanta_proto_msgTypes[10]
+		mi := &file_quanta_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1056,7 +1183,7 @@ func (x *UpdateRequest) String() string {
 func (*UpdateRequest) ProtoMessage() {}
 
 func (x *UpdateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_quanta_proto_msgTypes[10]
+	mi := &file_quanta_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1069,7 +1196,7 @@ func (x *UpdateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateRequest.ProtoReflect.Descriptor instead.
 func (*UpdateRequest) Descriptor() ([]byte, []int) {
-	return file_quanta_proto_rawDescGZIP(), []int{10}
+	return file_quanta_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *UpdateRequest) GetIndex() string {
@@ -1125,7 +1252,7 @@ type SyncStatusRequest struct {
 func (x *SyncStatusRequest) Reset() {
 	*x = SyncStatusRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_quanta_proto_msgTypes[11]
+		mi := &file_quanta_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1138,7 +1265,7 @@ func (x *SyncStatusRequest) String() string {
 func (*SyncStatusRequest) ProtoMessage() {}
 
 func (x *SyncStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_quanta_proto_msgTypes[11]
+	mi := &file_quanta_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1151,7 +1278,7 @@ func (x *SyncStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SyncStatusRequest.ProtoReflect.Descriptor instead.
 func (*SyncStatusRequest) Descriptor() ([]byte, []int) {
-	return file_quanta_proto_rawDescGZIP(), []int{11}
+	return file_quanta_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *SyncStatusRequest) GetIndex() string {
@@ -1225,7 +1352,7 @@ type SyncStatusResponse struct {
 func (x *SyncStatusResponse) Reset() {
 	*x = SyncStatusResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_quanta_proto_msgTypes[12]
+		mi := &file_quanta_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1238,7 +1365,7 @@ func (x *SyncStatusResponse) String() string {
 func (*SyncStatusResponse) ProtoMessage() {}
 
 func (x *SyncStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_quanta_proto_msgTypes[12]
+	mi := &file_quanta_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1251,7 +1378,7 @@ func (x *SyncStatusResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SyncStatusResponse.ProtoReflect.Descriptor instead.
 func (*SyncStatusResponse) Descriptor() ([]byte, []int) {
-	return file_quanta_proto_rawDescGZIP(), []int{12}
+	return file_quanta_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *SyncStatusResponse) GetOk() bool {
@@ -1289,31 +1416,32 @@ func (x *SyncStatusResponse) GetData() [][]byte {
 	return nil
 }
 
-type IndexInfoRequest struct {
+type PartitionInfoRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	IndexPath string `protobuf:"bytes,1,opt,name=indexPath,proto3" json:"indexPath,omitempty"`
+	Index string `protobuf:"bytes,1,opt,name=index,proto3" json:"index,omitempty"`
+	Time  int64  `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
 }
 
-func (x *IndexInfoRequest) Reset() {
-	*x = IndexInfoRequest{}
+func (x *PartitionInfoRequest) Reset() {
+	*x = PartitionInfoRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_quanta_proto_msgTypes[13]
+		mi := &file_quanta_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *IndexInfoRequest) String() string {
+func (x *PartitionInfoRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IndexInfoRequest) ProtoMessage() {}
+func (*PartitionInfoRequest) ProtoMessage() {}
 
-func (x *IndexInfoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_quanta_proto_msgTypes[13]
+func (x *PartitionInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quanta_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1324,51 +1452,57 @@ func (x *IndexInfoRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IndexInfoRequest.ProtoReflect.Descriptor instead.
-func (*IndexInfoRequest) Descriptor() ([]byte, []int) {
-	return file_quanta_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use PartitionInfoRequest.ProtoReflect.Descriptor instead.
+func (*PartitionInfoRequest) Descriptor() ([]byte, []int) {
+	return file_quanta_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *IndexInfoRequest) GetIndexPath() string {
+func (x *PartitionInfoRequest) GetIndex() string {
 	if x != nil {
-		return x.IndexPath
+		return x.Index
 	}
 	return ""
 }
 
-type IndexInfoResponse struct {
+func (x *PartitionInfoRequest) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+type PartitionInfoResult struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	IndexPath      string `protobuf:"bytes,1,opt,name=indexPath,proto3" json:"indexPath,omitempty"`
-	Exists         bool   `protobuf:"varint,2,opt,name=exists,proto3" json:"exists,omitempty"`
-	WasOpen        bool   `protobuf:"varint,3,opt,name=wasOpen,proto3" json:"wasOpen,omitempty"`
-	Count          uint32 `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
-	Puts           int64  `protobuf:"varint,5,opt,name=puts,proto3" json:"puts,omitempty"`
-	Gets           int64  `protobuf:"varint,6,opt,name=gets,proto3" json:"gets,omitempty"`
-	Dels           int64  `protobuf:"varint,7,opt,name=dels,proto3" json:"dels,omitempty"`
-	HashCollisions int64  `protobuf:"varint,8,opt,name=hashCollisions,proto3" json:"hashCollisions,omitempty"`
-	FileSize       int64  `protobuf:"varint,9,opt,name=fileSize,proto3" json:"fileSize,omitempty"`
+	Index       string `protobuf:"bytes,1,opt,name=index,proto3" json:"index,omitempty"`
+	Field       string `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
+	RowIdOrBits int64  `protobuf:"varint,3,opt,name=rowIdOrBits,proto3" json:"rowIdOrBits,omitempty"`
+	Time        int64  `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
+	TqType      string `protobuf:"bytes,5,opt,name=tqType,proto3" json:"tqType,omitempty"`
+	Bytes       uint64 `protobuf:"varint,6,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	ModTime     int64  `protobuf:"varint,7,opt,name=modTime,proto3" json:"modTime,omitempty"`
+	Archived    bool   `protobuf:"varint,8,opt,name=archived,proto3" json:"archived,omitempty"`
 }
 
-func (x *IndexInfoResponse) Reset() {
-	*x = IndexInfoResponse{}
+func (x *PartitionInfoResult) Reset() {
+	*x = PartitionInfoResult{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_quanta_proto_msgTypes[14]
+		mi := &file_quanta_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *IndexInfoResponse) String() string {
+func (x *PartitionInfoResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IndexInfoResponse) ProtoMessage() {}
+func (*PartitionInfoResult) ProtoMessage() {}
 
-func (x *IndexInfoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_quanta_proto_msgTypes[14]
+func (x *PartitionInfoResult) ProtoReflect() protoreflect.Message {
+	mi := &file_quanta_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1379,104 +1513,92 @@ func (x *IndexInfoResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IndexInfoResponse.ProtoReflect.Descriptor instead.
-func (*IndexInfoResponse) Descriptor() ([]byte, []int) {
-	return file_quanta_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use PartitionInfoResult.ProtoReflect.Descriptor instead.
+func (*PartitionInfoResult) Descriptor() ([]byte, []int) {
+	return file_quanta_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *IndexInfoResponse) GetIndexPath() string {
+func (x *PartitionInfoResult) GetIndex() string {
 	if x != nil {
-		return x.IndexPath
+		return x.Index
 	}
 	return ""
 }
 
-func (x *IndexInfoResponse) GetExists() bool {
-	if x != nil {
-		return x.Exists
-	}
-	return false
-}
-
-func (x *IndexInfoResponse) GetWasOpen() bool {
+func (x *PartitionInfoResult) GetField() string {
 	if x != nil {
-		return x.WasOpen
+		return x.Field
 	}
-	return false
+	return ""
 }
 
-func (x *IndexInfoResponse) GetCount() uint32 {
+func (x *PartitionInfoResult) GetRowIdOrBits() int64 {
 	if x != nil {
-		return x.Count
+		return x.RowIdOrBits
 	}
 	return 0
 }
 
-func (x *IndexInfoResponse) GetPuts() int64 {
+func (x *PartitionInfoResult) GetTime() int64 {
 	if x != nil {
-		return x.Puts
+		return x.Time
 	}
 	return 0
 }
 
-func (x *IndexInfoResponse) GetGets() int64 {
+func (x *PartitionInfoResult) GetTqType() string {
 	if x != nil {
-		return x.Gets
+		return x.TqType
 	}
-	return 0
+	return ""
 }
 
-func (x *IndexInfoResponse) GetDels() int64 {
+func (x *PartitionInfoResult) GetBytes() uint64 {
 	if x != nil {
-		return x.Dels
+		return x.Bytes
 	}
 	return 0
 }
 
-func (x *IndexInfoResponse) GetHashCollisions() int64 {
+func (x *PartitionInfoResult) GetModTime() int64 {
 	if x != nil {
-		return x.HashCollisions
+		return x.ModTime
 	}
 	return 0
 }
 
-func (x *IndexInfoResponse) GetFileSize() int64 {
+func (x *PartitionInfoResult) GetArchived() bool {
 	if x != nil {
-		return x.FileSize
+		return x.Archived
 	}
-	return 0
+	return false
 }
 
-type ProjectionRequest struct {
+type PartitionInfoResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	FromTime int64    `protobuf:"varint,1,opt,name=fromTime,proto3" json:"fromTime,omitempty"`
-	ToTime   int64    `protobuf:"varint,2,opt,name=toTime,proto3" json:"toTime,omitempty"`
-	FoundSet []byte   `protobuf:"bytes,3,opt,name=foundSet,proto3" json:"foundSet,omitempty"`
-	Index    string   `protobuf:"bytes,4,opt,name=index,proto3" json:"index,omitempty"`
-	Fields   []string `protobuf:"bytes,5,rep,name=fields,proto3" json:"fields,omitempty"`
-	Negate   bool     `protobuf:"varint,6,opt,name=negate,proto3" json:"negate,omitempty"`
+	PartitionInfo []*PartitionInfoResult `protobuf:"bytes,1,rep,name=partitionInfo,proto3" json:"partitionInfo,omitempty"`
 }
 
-func (x *ProjectionRequest) Reset() {
-	*x = ProjectionRequest{}
+func (x *PartitionInfoResponse) Reset() {
+	*x = PartitionInfoResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_quanta_proto_msgTypes[15]
+		mi := &file_quanta_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ProjectionRequest) String() string {
+func (x *PartitionInfoResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ProjectionRequest) ProtoMessage() {}
+func (*PartitionInfoResponse) ProtoMessage() {}
 
-func (x *ProjectionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_quanta_proto_msgTypes[15]
+func (x *PartitionInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quanta_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1487,80 +1609,1283 @@ func (x *ProjectionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ProjectionRequest.ProtoReflect.Descriptor instead.
-func (*ProjectionRequest) Descriptor() ([]byte, []int) {
-	return file_quanta_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use PartitionInfoResponse.ProtoReflect.Descriptor instead.
+func (*PartitionInfoResponse) Descriptor() ([]byte, []int) {
+	return file_quanta_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *ProjectionRequest) GetFromTime() int64 {
+func (x *PartitionInfoResponse) GetPartitionInfo() []*PartitionInfoResult {
 	if x != nil {
-		return x.FromTime
+		return x.PartitionInfo
 	}
-	return 0
+	return nil
 }
 
-func (x *ProjectionRequest) GetToTime() int64 {
-	if x != nil {
-		return x.ToTime
-	}
-	return 0
+type DecommissionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Start bool `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
 }
 
-func (x *ProjectionRequest) GetFoundSet() []byte {
-	if x != nil {
-		return x.FoundSet
+func (x *DecommissionRequest) Reset() {
+	*x = DecommissionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_quanta_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func (x *ProjectionRequest) GetIndex() string {
-	if x != nil {
-		return x.Index
-	}
-	return ""
+func (x *DecommissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *ProjectionRequest) GetFields() []string {
+func (*DecommissionRequest) ProtoMessage() {}
+
+func (x *DecommissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quanta_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecommissionRequest.ProtoReflect.Descriptor instead.
+func (*DecommissionRequest) Descriptor() ([]byte, []int) {
+	return file_quanta_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DecommissionRequest) GetStart() bool {
+	if x != nil {
+		return x.Start
+	}
+	return false
+}
+
+type DecommissionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NodeState string `protobuf:"bytes,1,opt,name=nodeState,proto3" json:"nodeState,omitempty"`
+	Shards    uint64 `protobuf:"varint,2,opt,name=shards,proto3" json:"shards,omitempty"`
+	Pushed    uint64 `protobuf:"varint,3,opt,name=pushed,proto3" json:"pushed,omitempty"`
+	Skipped   uint64 `protobuf:"varint,4,opt,name=skipped,proto3" json:"skipped,omitempty"`
+	Complete  bool   `protobuf:"varint,5,opt,name=complete,proto3" json:"complete,omitempty"`
+	Error     string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *DecommissionResponse) Reset() {
+	*x = DecommissionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_quanta_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DecommissionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DecommissionResponse) ProtoMessage() {}
+
+func (x *DecommissionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quanta_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DecommissionResponse.ProtoReflect.Descriptor instead.
+func (*DecommissionResponse) Descriptor() ([]byte, []int) {
+	return file_quanta_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DecommissionResponse) GetNodeState() string {
+	if x != nil {
+		return x.NodeState
+	}
+	return ""
+}
+
+func (x *DecommissionResponse) GetShards() uint64 {
+	if x != nil {
+		return x.Shards
+	}
+	return 0
+}
+
+func (x *DecommissionResponse) GetPushed() uint64 {
+	if x != nil {
+		return x.Pushed
+	}
+	return 0
+}
+
+func (x *DecommissionResponse) GetSkipped() uint64 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+func (x *DecommissionResponse) GetComplete() bool {
+	if x != nil {
+		return x.Complete
+	}
+	return false
+}
+
+func (x *DecommissionResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type RepairRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Start  bool             `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	Index  string           `protobuf:"bytes,2,opt,name=index,proto3" json:"index,omitempty"`
+	Shards []*ShardChecksum `protobuf:"bytes,3,rep,name=shards,proto3" json:"shards,omitempty"`
+}
+
+func (x *RepairRequest) Reset() {
+	*x = RepairRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_quanta_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RepairRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepairRequest) ProtoMessage() {}
+
+func (x *RepairRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quanta_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepairRequest.ProtoReflect.Descriptor instead.
+func (*RepairRequest) Descriptor() ([]byte, []int) {
+	return file_quanta_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RepairRequest) GetStart() bool {
+	if x != nil {
+		return x.Start
+	}
+	return false
+}
+
+func (x *RepairRequest) GetIndex() string {
+	if x != nil {
+		return x.Index
+	}
+	return ""
+}
+
+func (x *RepairRequest) GetShards() []*ShardChecksum {
+	if x != nil {
+		return x.Shards
+	}
+	return nil
+}
+
+type RepairResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Running    bool   `protobuf:"varint,1,opt,name=running,proto3" json:"running,omitempty"`
+	Shards     uint64 `protobuf:"varint,2,opt,name=shards,proto3" json:"shards,omitempty"`
+	Mismatched uint64 `protobuf:"varint,3,opt,name=mismatched,proto3" json:"mismatched,omitempty"`
+	Pushed     uint64 `protobuf:"varint,4,opt,name=pushed,proto3" json:"pushed,omitempty"`
+	Merged     uint64 `protobuf:"varint,5,opt,name=merged,proto3" json:"merged,omitempty"`
+	Error      string `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	Started    bool   `protobuf:"varint,7,opt,name=started,proto3" json:"started,omitempty"`
+}
+
+func (x *RepairResponse) Reset() {
+	*x = RepairResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_quanta_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RepairResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepairResponse) ProtoMessage() {}
+
+func (x *RepairResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quanta_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepairResponse.ProtoReflect.Descriptor instead.
+func (*RepairResponse) Descriptor() ([]byte, []int) {
+	return file_quanta_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RepairResponse) GetRunning() bool {
+	if x != nil {
+		return x.Running
+	}
+	return false
+}
+
+func (x *RepairResponse) GetShards() uint64 {
+	if x != nil {
+		return x.Shards
+	}
+	return 0
+}
+
+func (x *RepairResponse) GetMismatched() uint64 {
+	if x != nil {
+		return x.Mismatched
+	}
+	return 0
+}
+
+func (x *RepairResponse) GetPushed() uint64 {
+	if x != nil {
+		return x.Pushed
+	}
+	return 0
+}
+
+func (x *RepairResponse) GetMerged() uint64 {
+	if x != nil {
+		return x.Merged
+	}
+	return 0
+}
+
+func (x *RepairResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *RepairResponse) GetStarted() bool {
+	if x != nil {
+		return x.Started
+	}
+	return false
+}
+
+type RepairChecksumRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NodeId  string   `protobuf:"bytes,1,opt,name=nodeId,proto3" json:"nodeId,omitempty"`
+	Index   string   `protobuf:"bytes,2,opt,name=index,proto3" json:"index,omitempty"`
+	Buckets []uint32 `protobuf:"varint,3,rep,packed,name=buckets,proto3" json:"buckets,omitempty"`
+}
+
+func (x *RepairChecksumRequest) Reset() {
+	*x = RepairChecksumRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_quanta_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RepairChecksumRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepairChecksumRequest) ProtoMessage() {}
+
+func (x *RepairChecksumRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quanta_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepairChecksumRequest.ProtoReflect.Descriptor instead.
+func (*RepairChecksumRequest) Descriptor() ([]byte, []int) {
+	return file_quanta_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RepairChecksumRequest) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *RepairChecksumRequest) GetIndex() string {
+	if x != nil {
+		return x.Index
+	}
+	return ""
+}
+
+func (x *RepairChecksumRequest) GetBuckets() []uint32 {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+type ShardChecksum struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index       string `protobuf:"bytes,1,opt,name=index,proto3" json:"index,omitempty"`
+	Field       string `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
+	RowIdOrBits int64  `protobuf:"varint,3,opt,name=rowIdOrBits,proto3" json:"rowIdOrBits,omitempty"`
+	Time        int64  `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
+	Checksum    uint64 `protobuf:"varint,5,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	ModTime     int64  `protobuf:"varint,6,opt,name=modTime,proto3" json:"modTime,omitempty"`
+}
+
+func (x *ShardChecksum) Reset() {
+	*x = ShardChecksum{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_quanta_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShardChecksum) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShardChecksum) ProtoMessage() {}
+
+func (x *ShardChecksum) ProtoReflect() protoreflect.Message {
+	mi := &file_quanta_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShardChecksum.ProtoReflect.Descriptor instead.
+func (*ShardChecksum) Descriptor() ([]byte, []int) {
+	return file_quanta_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ShardChecksum) GetIndex() string {
+	if x != nil {
+		return x.Index
+	}
+	return ""
+}
+
+func (x *ShardChecksum) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *ShardChecksum) GetRowIdOrBits() int64 {
+	if x != nil {
+		return x.RowIdOrBits
+	}
+	return 0
+}
+
+func (x *ShardChecksum) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *ShardChecksum) GetChecksum() uint64 {
+	if x != nil {
+		return x.Checksum
+	}
+	return 0
+}
+
+func (x *ShardChecksum) GetModTime() int64 {
+	if x != nil {
+		return x.ModTime
+	}
+	return 0
+}
+
+type RepairChecksumResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BucketHashes []uint64         `protobuf:"varint,1,rep,packed,name=bucketHashes,proto3" json:"bucketHashes,omitempty"`
+	Shards       []*ShardChecksum `protobuf:"bytes,2,rep,name=shards,proto3" json:"shards,omitempty"`
+}
+
+func (x *RepairChecksumResponse) Reset() {
+	*x = RepairChecksumResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_quanta_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RepairChecksumResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepairChecksumResponse) ProtoMessage() {}
+
+func (x *RepairChecksumResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quanta_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepairChecksumResponse.ProtoReflect.Descriptor instead.
+func (*RepairChecksumResponse) Descriptor() ([]byte, []int) {
+	return file_quanta_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RepairChecksumResponse) GetBucketHashes() []uint64 {
+	if x != nil {
+		return x.BucketHashes
+	}
+	return nil
+}
+
+func (x *RepairChecksumResponse) GetShards() []*ShardChecksum {
+	if x != nil {
+		return x.Shards
+	}
+	return nil
+}
+
+type IndexInfoRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IndexPath string `protobuf:"bytes,1,opt,name=indexPath,proto3" json:"indexPath,omitempty"`
+}
+
+func (x *IndexInfoRequest) Reset() {
+	*x = IndexInfoRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_quanta_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IndexInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndexInfoRequest) ProtoMessage() {}
+
+func (x *IndexInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quanta_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndexInfoRequest.ProtoReflect.Descriptor instead.
+func (*IndexInfoRequest) Descriptor() ([]byte, []int) {
+	return file_quanta_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *IndexInfoRequest) GetIndexPath() string {
+	if x != nil {
+		return x.IndexPath
+	}
+	return ""
+}
+
+type IndexInfoResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IndexPath      string `protobuf:"bytes,1,opt,name=indexPath,proto3" json:"indexPath,omitempty"`
+	Exists         bool   `protobuf:"varint,2,opt,name=exists,proto3" json:"exists,omitempty"`
+	WasOpen        bool   `protobuf:"varint,3,opt,name=wasOpen,proto3" json:"wasOpen,omitempty"`
+	Count          uint32 `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	Puts           int64  `protobuf:"varint,5,opt,name=puts,proto3" json:"puts,omitempty"`
+	Gets           int64  `protobuf:"varint,6,opt,name=gets,proto3" json:"gets,omitempty"`
+	Dels           int64  `protobuf:"varint,7,opt,name=dels,proto3" json:"dels,omitempty"`
+	HashCollisions int64  `protobuf:"varint,8,opt,name=hashCollisions,proto3" json:"hashCollisions,omitempty"`
+	FileSize       int64  `protobuf:"varint,9,opt,name=fileSize,proto3" json:"fileSize,omitempty"`
+}
+
+func (x *IndexInfoResponse) Reset() {
+	*x = IndexInfoResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_quanta_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IndexInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndexInfoResponse) ProtoMessage() {}
+
+func (x *IndexInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quanta_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndexInfoResponse.ProtoReflect.Descriptor instead.
+func (*IndexInfoResponse) Descriptor() ([]byte, []int) {
+	return file_quanta_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *IndexInfoResponse) GetIndexPath() string {
+	if x != nil {
+		return x.IndexPath
+	}
+	return ""
+}
+
+func (x *IndexInfoResponse) GetExists() bool {
+	if x != nil {
+		return x.Exists
+	}
+	return false
+}
+
+func (x *IndexInfoResponse) GetWasOpen() bool {
+	if x != nil {
+		return x.WasOpen
+	}
+	return false
+}
+
+func (x *IndexInfoResponse) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *IndexInfoResponse) GetPuts() int64 {
+	if x != nil {
+		return x.Puts
+	}
+	return 0
+}
+
+func (x *IndexInfoResponse) GetGets() int64 {
+	if x != nil {
+		return x.Gets
+	}
+	return 0
+}
+
+func (x *IndexInfoResponse) GetDels() int64 {
+	if x != nil {
+		return x.Dels
+	}
+	return 0
+}
+
+func (x *IndexInfoResponse) GetHashCollisions() int64 {
+	if x != nil {
+		return x.HashCollisions
+	}
+	return 0
+}
+
+func (x *IndexInfoResponse) GetFileSize() int64 {
+	if x != nil {
+		return x.FileSize
+	}
+	return 0
+}
+
+type ProjectionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FromTime int64    `protobuf:"varint,1,opt,name=fromTime,proto3" json:"fromTime,omitempty"`
+	ToTime   int64    `protobuf:"varint,2,opt,name=toTime,proto3" json:"toTime,omitempty"`
+	FoundSet []byte   `protobuf:"bytes,3,opt,name=foundSet,proto3" json:"foundSet,omitempty"`
+	Index    string   `protobuf:"bytes,4,opt,name=index,proto3" json:"index,omitempty"`
+	Fields   []string `protobuf:"bytes,5,rep,name=fields,proto3" json:"fields,omitempty"`
+	Negate   bool     `protobuf:"varint,6,opt,name=negate,proto3" json:"negate,omitempty"`
+}
+
+func (x *ProjectionRequest) Reset() {
+	*x = ProjectionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_quanta_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProjectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProjectionRequest) ProtoMessage() {}
+
+func (x *ProjectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quanta_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProjectionRequest.ProtoReflect.Descriptor instead.
+func (*ProjectionRequest) Descriptor() ([]byte, []int) {
+	return file_quanta_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ProjectionRequest) GetFromTime() int64 {
+	if x != nil {
+		return x.FromTime
+	}
+	return 0
+}
+
+func (x *ProjectionRequest) GetToTime() int64 {
+	if x != nil {
+		return x.ToTime
+	}
+	return 0
+}
+
+func (x *ProjectionRequest) GetFoundSet() []byte {
+	if x != nil {
+		return x.FoundSet
+	}
+	return nil
+}
+
+func (x *ProjectionRequest) GetIndex() string {
+	if x != nil {
+		return x.Index
+	}
+	return ""
+}
+
+func (x *ProjectionRequest) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *ProjectionRequest) GetNegate() bool {
+	if x != nil {
+		return x.Negate
+	}
+	return false
+}
+
+type BitmapResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Field  string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	RowId  uint64 `protobuf:"varint,2,opt,name=rowId,proto3" json:"rowId,omitempty"`
+	Bitmap []byte `protobuf:"bytes,3,opt,name=bitmap,proto3" json:"bitmap,omitempty"`
+}
+
+func (x *BitmapResult) Reset() {
+	*x = BitmapResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_quanta_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BitmapResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BitmapResult) ProtoMessage() {}
+
+func (x *BitmapResult) ProtoReflect() protoreflect.Message {
+	mi := &file_quanta_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BitmapResult.ProtoReflect.Descriptor instead.
+func (*BitmapResult) Descriptor() ([]byte, []int) {
+	return file_quanta_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *BitmapResult) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *BitmapResult) GetRowId() uint64 {
+	if x != nil {
+		return x.RowId
+	}
+	return 0
+}
+
+func (x *BitmapResult) GetBitmap() []byte {
+	if x != nil {
+		return x.Bitmap
+	}
+	return nil
+}
+
+type BSIResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Field   string   `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Bitmaps [][]byte `protobuf:"bytes,2,rep,name=bitmaps,proto3" json:"bitmaps,omitempty"`
+}
+
+func (x *BSIResult) Reset() {
+	*x = BSIResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_quanta_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BSIResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BSIResult) ProtoMessage() {}
+
+func (x *BSIResult) ProtoReflect() protoreflect.Message {
+	mi := &file_quanta_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BSIResult.ProtoReflect.Descriptor instead.
+func (*BSIResult) Descriptor() ([]byte, []int) {
+	return file_quanta_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *BSIResult) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *BSIResult) GetBitmaps() [][]byte {
+	if x != nil {
+		return x.Bitmaps
+	}
+	return nil
+}
+
+type ProjectionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BitmapResults  []*BitmapResult `protobuf:"bytes,1,rep,name=bitmapResults,proto3" json:"bitmapResults,omitempty"`
+	BsiResults     []*BSIResult    `protobuf:"bytes,2,rep,name=bsiResults,proto3" json:"bsiResults,omitempty"`
+	ColdPartitions int32           `protobuf:"varint,3,opt,name=coldPartitions,proto3" json:"coldPartitions,omitempty"`
+}
+
+func (x *ProjectionResponse) Reset() {
+	*x = ProjectionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_quanta_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProjectionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProjectionResponse) ProtoMessage() {}
+
+func (x *ProjectionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quanta_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProjectionResponse.ProtoReflect.Descriptor instead.
+func (*ProjectionResponse) Descriptor() ([]byte, []int) {
+	return file_quanta_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ProjectionResponse) GetBitmapResults() []*BitmapResult {
+	if x != nil {
+		return x.BitmapResults
+	}
+	return nil
+}
+
+func (x *ProjectionResponse) GetBsiResults() []*BSIResult {
+	if x != nil {
+		return x.BsiResults
+	}
+	return nil
+}
+
+func (x *ProjectionResponse) GetColdPartitions() int32 {
+	if x != nil {
+		return x.ColdPartitions
+	}
+	return 0
+}
+
+type GroupByRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FromTime  int64    `protobuf:"varint,1,opt,name=fromTime,proto3" json:"fromTime,omitempty"`
+	ToTime    int64    `protobuf:"varint,2,opt,name=toTime,proto3" json:"toTime,omitempty"`
+	Index     string   `protobuf:"bytes,3,opt,name=index,proto3" json:"index,omitempty"`
+	Fields    []string `protobuf:"bytes,4,rep,name=fields,proto3" json:"fields,omitempty"`
+	GroupSets [][]byte `protobuf:"bytes,5,rep,name=groupSets,proto3" json:"groupSets,omitempty"`
+}
+
+func (x *GroupByRequest) Reset() {
+	*x = GroupByRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_quanta_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GroupByRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupByRequest) ProtoMessage() {}
+
+func (x *GroupByRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quanta_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupByRequest.ProtoReflect.Descriptor instead.
+func (*GroupByRequest) Descriptor() ([]byte, []int) {
+	return file_quanta_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GroupByRequest) GetFromTime() int64 {
+	if x != nil {
+		return x.FromTime
+	}
+	return 0
+}
+
+func (x *GroupByRequest) GetToTime() int64 {
+	if x != nil {
+		return x.ToTime
+	}
+	return 0
+}
+
+func (x *GroupByRequest) GetIndex() string {
+	if x != nil {
+		return x.Index
+	}
+	return ""
+}
+
+func (x *GroupByRequest) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *GroupByRequest) GetGroupSets() [][]byte {
+	if x != nil {
+		return x.GroupSets
+	}
+	return nil
+}
+
+type GroupByResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Group     uint32 `protobuf:"varint,1,opt,name=group,proto3" json:"group,omitempty"`
+	Field     string `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
+	Partition int64  `protobuf:"varint,3,opt,name=partition,proto3" json:"partition,omitempty"`
+	Sum       int64  `protobuf:"varint,4,opt,name=sum,proto3" json:"sum,omitempty"`
+	Count     uint64 `protobuf:"varint,5,opt,name=count,proto3" json:"count,omitempty"`
+	Min       int64  `protobuf:"varint,6,opt,name=min,proto3" json:"min,omitempty"`
+	Max       int64  `protobuf:"varint,7,opt,name=max,proto3" json:"max,omitempty"`
+}
+
+func (x *GroupByResult) Reset() {
+	*x = GroupByResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_quanta_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GroupByResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupByResult) ProtoMessage() {}
+
+func (x *GroupByResult) ProtoReflect() protoreflect.Message {
+	mi := &file_quanta_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupByResult.ProtoReflect.Descriptor instead.
+func (*GroupByResult) Descriptor() ([]byte, []int) {
+	return file_quanta_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GroupByResult) GetGroup() uint32 {
+	if x != nil {
+		return x.Group
+	}
+	return 0
+}
+
+func (x *GroupByResult) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *GroupByResult) GetPartition() int64 {
+	if x != nil {
+		return x.Partition
+	}
+	return 0
+}
+
+func (x *GroupByResult) GetSum() int64 {
+	if x != nil {
+		return x.Sum
+	}
+	return 0
+}
+
+func (x *GroupByResult) GetCount() uint64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *GroupByResult) GetMin() int64 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *GroupByResult) GetMax() int64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+type GroupByResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*GroupByResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *GroupByResponse) Reset() {
+	*x = GroupByResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_quanta_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GroupByResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupByResponse) ProtoMessage() {}
+
+func (x *GroupByResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quanta_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupByResponse.ProtoReflect.Descriptor instead.
+func (*GroupByResponse) Descriptor() ([]byte, []int) {
+	return file_quanta_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GroupByResponse) GetResults() []*GroupByResult {
 	if x != nil {
-		return x.Fields
+		return x.Results
 	}
 	return nil
 }
 
-func (x *ProjectionRequest) GetNegate() bool {
+type TopKRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FromTime   int64  `protobuf:"varint,1,opt,name=fromTime,proto3" json:"fromTime,omitempty"`
+	ToTime     int64  `protobuf:"varint,2,opt,name=toTime,proto3" json:"toTime,omitempty"`
+	Index      string `protobuf:"bytes,3,opt,name=index,proto3" json:"index,omitempty"`
+	Field      string `protobuf:"bytes,4,opt,name=field,proto3" json:"field,omitempty"`
+	FoundSet   []byte `protobuf:"bytes,5,opt,name=foundSet,proto3" json:"foundSet,omitempty"`
+	K          uint32 `protobuf:"varint,6,opt,name=k,proto3" json:"k,omitempty"`
+	Descending bool   `protobuf:"varint,7,opt,name=descending,proto3" json:"descending,omitempty"`
+}
+
+func (x *TopKRequest) Reset() {
+	*x = TopKRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_quanta_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TopKRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopKRequest) ProtoMessage() {}
+
+func (x *TopKRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quanta_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopKRequest.ProtoReflect.Descriptor instead.
+func (*TopKRequest) Descriptor() ([]byte, []int) {
+	return file_quanta_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *TopKRequest) GetFromTime() int64 {
 	if x != nil {
-		return x.Negate
+		return x.FromTime
+	}
+	return 0
+}
+
+func (x *TopKRequest) GetToTime() int64 {
+	if x != nil {
+		return x.ToTime
+	}
+	return 0
+}
+
+func (x *TopKRequest) GetIndex() string {
+	if x != nil {
+		return x.Index
+	}
+	return ""
+}
+
+func (x *TopKRequest) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *TopKRequest) GetFoundSet() []byte {
+	if x != nil {
+		return x.FoundSet
+	}
+	return nil
+}
+
+func (x *TopKRequest) GetK() uint32 {
+	if x != nil {
+		return x.K
+	}
+	return 0
+}
+
+func (x *TopKRequest) GetDescending() bool {
+	if x != nil {
+		return x.Descending
 	}
 	return false
 }
 
-type BitmapResult struct {
+type TopKResult struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Field  string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
-	RowId  uint64 `protobuf:"varint,2,opt,name=rowId,proto3" json:"rowId,omitempty"`
-	Bitmap []byte `protobuf:"bytes,3,opt,name=bitmap,proto3" json:"bitmap,omitempty"`
+	ColumnId uint64 `protobuf:"varint,1,opt,name=columnId,proto3" json:"columnId,omitempty"`
+	Value    int64  `protobuf:"varint,2,opt,name=value,proto3" json:"value,omitempty"`
 }
 
-func (x *BitmapResult) Reset() {
-	*x = BitmapResult{}
+func (x *TopKResult) Reset() {
+	*x = TopKResult{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_quanta_proto_msgTypes[16]
+		mi := &file_quanta_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *BitmapResult) String() string {
+func (x *TopKResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BitmapResult) ProtoMessage() {}
+func (*TopKResult) ProtoMessage() {}
 
-func (x *BitmapResult) ProtoReflect() protoreflect.Message {
-	mi := &file_quanta_proto_msgTypes[16]
+func (x *TopKResult) ProtoReflect() protoreflect.Message {
+	mi := &file_quanta_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1571,58 +2896,101 @@ func (x *BitmapResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BitmapResult.ProtoReflect.Descriptor instead.
-func (*BitmapResult) Descriptor() ([]byte, []int) {
-	return file_quanta_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use TopKResult.ProtoReflect.Descriptor instead.
+func (*TopKResult) Descriptor() ([]byte, []int) {
+	return file_quanta_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *BitmapResult) GetField() string {
+func (x *TopKResult) GetColumnId() uint64 {
 	if x != nil {
-		return x.Field
+		return x.ColumnId
 	}
-	return ""
+	return 0
 }
 
-func (x *BitmapResult) GetRowId() uint64 {
+func (x *TopKResult) GetValue() int64 {
 	if x != nil {
-		return x.RowId
+		return x.Value
 	}
 	return 0
 }
 
-func (x *BitmapResult) GetBitmap() []byte {
+type TopKResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*TopKResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *TopKResponse) Reset() {
+	*x = TopKResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_quanta_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TopKResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopKResponse) ProtoMessage() {}
+
+func (x *TopKResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quanta_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopKResponse.ProtoReflect.Descriptor instead.
+func (*TopKResponse) Descriptor() ([]byte, []int) {
+	return file_quanta_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *TopKResponse) GetResults() []*TopKResult {
 	if x != nil {
-		return x.Bitmap
+		return x.Results
 	}
 	return nil
 }
 
-type BSIResult struct {
+type CountDistinctRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Field   string   `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
-	Bitmaps [][]byte `protobuf:"bytes,2,rep,name=bitmaps,proto3" json:"bitmaps,omitempty"`
+	FromTime int64                 `protobuf:"varint,1,opt,name=fromTime,proto3" json:"fromTime,omitempty"`
+	ToTime   int64                 `protobuf:"varint,2,opt,name=toTime,proto3" json:"toTime,omitempty"`
+	Index    string                `protobuf:"bytes,3,opt,name=index,proto3" json:"index,omitempty"`
+	Fields   []*CountDistinctField `protobuf:"bytes,4,rep,name=fields,proto3" json:"fields,omitempty"`
+	FoundSet []byte                `protobuf:"bytes,5,opt,name=foundSet,proto3" json:"foundSet,omitempty"`
 }
 
-func (x *BSIResult) Reset() {
-	*x = BSIResult{}
+func (x *CountDistinctRequest) Reset() {
+	*x = CountDistinctRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_quanta_proto_msgTypes[17]
+		mi := &file_quanta_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *BSIResult) String() string {
+func (x *CountDistinctRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BSIResult) ProtoMessage() {}
+func (*CountDistinctRequest) ProtoMessage() {}
 
-func (x *BSIResult) ProtoReflect() protoreflect.Message {
-	mi := &file_quanta_proto_msgTypes[17]
+func (x *CountDistinctRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_quanta_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1633,51 +3001,174 @@ func (x *BSIResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BSIResult.ProtoReflect.Descriptor instead.
-func (*BSIResult) Descriptor() ([]byte, []int) {
-	return file_quanta_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use CountDistinctRequest.ProtoReflect.Descriptor instead.
+func (*CountDistinctRequest) Descriptor() ([]byte, []int) {
+	return file_quanta_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *BSIResult) GetField() string {
+func (x *CountDistinctRequest) GetFromTime() int64 {
+	if x != nil {
+		return x.FromTime
+	}
+	return 0
+}
+
+func (x *CountDistinctRequest) GetToTime() int64 {
+	if x != nil {
+		return x.ToTime
+	}
+	return 0
+}
+
+func (x *CountDistinctRequest) GetIndex() string {
+	if x != nil {
+		return x.Index
+	}
+	return ""
+}
+
+func (x *CountDistinctRequest) GetFields() []*CountDistinctField {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *CountDistinctRequest) GetFoundSet() []byte {
+	if x != nil {
+		return x.FoundSet
+	}
+	return nil
+}
+
+type CountDistinctField struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Field     string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Precision uint32 `protobuf:"varint,2,opt,name=precision,proto3" json:"precision,omitempty"`
+}
+
+func (x *CountDistinctField) Reset() {
+	*x = CountDistinctField{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_quanta_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CountDistinctField) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountDistinctField) ProtoMessage() {}
+
+func (x *CountDistinctField) ProtoReflect() protoreflect.Message {
+	mi := &file_quanta_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountDistinctField.ProtoReflect.Descriptor instead.
+func (*CountDistinctField) Descriptor() ([]byte, []int) {
+	return file_quanta_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *CountDistinctField) GetField() string {
 	if x != nil {
 		return x.Field
 	}
 	return ""
 }
 
-func (x *BSIResult) GetBitmaps() [][]byte {
+func (x *CountDistinctField) GetPrecision() uint32 {
 	if x != nil {
-		return x.Bitmaps
+		return x.Precision
+	}
+	return 0
+}
+
+type CountDistinctResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*CountDistinctResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *CountDistinctResponse) Reset() {
+	*x = CountDistinctResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_quanta_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CountDistinctResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountDistinctResponse) ProtoMessage() {}
+
+func (x *CountDistinctResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_quanta_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountDistinctResponse.ProtoReflect.Descriptor instead.
+func (*CountDistinctResponse) Descriptor() ([]byte, []int) {
+	return file_quanta_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *CountDistinctResponse) GetResults() []*CountDistinctResult {
+	if x != nil {
+		return x.Results
 	}
 	return nil
 }
 
-type ProjectionResponse struct {
+type CountDistinctResult struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	BitmapResults []*BitmapResult `protobuf:"bytes,1,rep,name=bitmapResults,proto3" json:"bitmapResults,omitempty"`
-	BsiResults    []*BSIResult    `protobuf:"bytes,2,rep,name=bsiResults,proto3" json:"bsiResults,omitempty"`
+	Values []byte `protobuf:"bytes,1,opt,name=values,proto3" json:"values,omitempty"`
+	Sketch []byte `protobuf:"bytes,2,opt,name=sketch,proto3" json:"sketch,omitempty"`
 }
 
-func (x *ProjectionResponse) Reset() {
-	*x = ProjectionResponse{}
+func (x *CountDistinctResult) Reset() {
+	*x = CountDistinctResult{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_quanta_proto_msgTypes[18]
+		mi := &file_quanta_proto_msgTypes[39]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ProjectionResponse) String() string {
+func (x *CountDistinctResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ProjectionResponse) ProtoMessage() {}
+func (*CountDistinctResult) ProtoMessage() {}
 
-func (x *ProjectionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_quanta_proto_msgTypes[18]
+func (x *CountDistinctResult) ProtoReflect() protoreflect.Message {
+	mi := &file_quanta_proto_msgTypes[39]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1688,21 +3179,21 @@ func (x *ProjectionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ProjectionResponse.ProtoReflect.Descriptor instead.
-func (*ProjectionResponse) Descriptor() ([]byte, []int) {
-	return file_quanta_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use CountDistinctResult.ProtoReflect.Descriptor instead.
+func (*CountDistinctResult) Descriptor() ([]byte, []int) {
+	return file_quanta_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *ProjectionResponse) GetBitmapResults() []*BitmapResult {
+func (x *CountDistinctResult) GetValues() []byte {
 	if x != nil {
-		return x.BitmapResults
+		return x.Values
 	}
 	return nil
 }
 
-func (x *ProjectionResponse) GetBsiResults() []*BSIResult {
+func (x *CountDistinctResult) GetSketch() []byte {
 	if x != nil {
-		return x.BsiResults
+		return x.Sketch
 	}
 	return nil
 }
@@ -1721,7 +3212,7 @@ type CheckoutSequenceRequest struct {
 func (x *CheckoutSequenceRequest) Reset() {
 	*x = CheckoutSequenceRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_quanta_proto_msgTypes[19]
+		mi := &file_quanta_proto_msgTypes[40]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1734,7 +3225,7 @@ func (x *CheckoutSequenceRequest) String() string {
 func (*CheckoutSequenceRequest) ProtoMessage() {}
 
 func (x *CheckoutSequenceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_quanta_proto_msgTypes[19]
+	mi := &file_quanta_proto_msgTypes[40]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1747,7 +3238,7 @@ func (x *CheckoutSequenceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckoutSequenceRequest.ProtoReflect.Descriptor instead.
 func (*CheckoutSequenceRequest) Descriptor() ([]byte, []int) {
-	return file_quanta_proto_rawDescGZIP(), []int{19}
+	return file_quanta_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *CheckoutSequenceRequest) GetIndex() string {
@@ -1790,7 +3281,7 @@ type CheckoutSequenceResponse struct {
 func (x *CheckoutSequenceResponse) Reset() {
 	*x = CheckoutSequenceResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_quanta_proto_msgTypes[20]
+		mi := &file_quanta_proto_msgTypes[41]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1803,7 +3294,7 @@ func (x *CheckoutSequenceResponse) String() string {
 func (*CheckoutSequenceResponse) ProtoMessage() {}
 
 func (x *CheckoutSequenceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_quanta_proto_msgTypes[20]
+	mi := &file_quanta_proto_msgTypes[41]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1816,7 +3307,7 @@ func (x *CheckoutSequenceResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckoutSequenceResponse.ProtoReflect.Descriptor instead.
 func (*CheckoutSequenceResponse) Descriptor() ([]byte, []int) {
-	return file_quanta_proto_rawDescGZIP(), []int{20}
+	return file_quanta_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *CheckoutSequenceResponse) GetStart() uint64 {
@@ -1845,7 +3336,7 @@ type DeleteIndicesWithPrefixRequest struct {
 func (x *DeleteIndicesWithPrefixRequest) Reset() {
 	*x = DeleteIndicesWithPrefixRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_quanta_proto_msgTypes[21]
+		mi := &file_quanta_proto_msgTypes[42]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1858,7 +3349,7 @@ func (x *DeleteIndicesWithPrefixRequest) String() string {
 func (*DeleteIndicesWithPrefixRequest) ProtoMessage() {}
 
 func (x *DeleteIndicesWithPrefixRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_quanta_proto_msgTypes[21]
+	mi := &file_quanta_proto_msgTypes[42]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1871,7 +3362,7 @@ func (x *DeleteIndicesWithPrefixRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteIndicesWithPrefixRequest.ProtoReflect.Descriptor instead.
 func (*DeleteIndicesWithPrefixRequest) Descriptor() ([]byte, []int) {
-	return file_quanta_proto_rawDescGZIP(), []int{21}
+	return file_quanta_proto_rawDescGZIP(), []int{42}
 }
 
 func (x *DeleteIndicesWithPrefixRequest) GetPrefix() string {
@@ -1923,310 +3414,522 @@ var file_quanta_proto_rawDesc = []byte{
 	0x69, 0x6e, 0x67, 0x45, 0x6e, 0x75, 0x6d, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x64, 0x65, 0x78,
 	0x50, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x6e, 0x64, 0x65,
 	0x78, 0x50, 0x61, 0x74, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x6e, 0x0a, 0x0b, 0x42,
-	0x69, 0x74, 0x6d, 0x61, 0x70, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x2b, 0x0a, 0x05, 0x71, 0x75,
-	0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x73, 0x68, 0x61, 0x72,
-	0x65, 0x64, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74,
-	0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x54,
-	0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x54,
-	0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x6f, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x06, 0x74, 0x6f, 0x54, 0x69, 0x6d, 0x65, 0x22, 0xf0, 0x04, 0x0a, 0x0d,
-	0x51, 0x75, 0x65, 0x72, 0x79, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a,
-	0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6e,
-	0x64, 0x65, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x77,
-	0x49, 0x44, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x72, 0x6f, 0x77, 0x49, 0x44, 0x12,
-	0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65,
-	0x6e, 0x49, 0x64, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x68, 0x69, 0x6c,
-	0x64, 0x72, 0x65, 0x6e, 0x49, 0x64, 0x73, 0x12, 0x3a, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x73, 0x68, 0x61,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0xca, 0x01, 0x0a, 0x0b,
+	0x42, 0x69, 0x74, 0x6d, 0x61, 0x70, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x2b, 0x0a, 0x05, 0x71,
+	0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x73, 0x68, 0x61,
 	0x72, 0x65, 0x64, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e,
-	0x74, 0x2e, 0x4f, 0x70, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x12, 0x31, 0x0a, 0x05, 0x62, 0x73, 0x69, 0x4f, 0x70, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x51, 0x75, 0x65, 0x72,
-	0x79, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x42, 0x53, 0x49, 0x4f, 0x70, 0x52,
-	0x05, 0x62, 0x73, 0x69, 0x4f, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x65, 0x67, 0x69, 0x6e, 0x18,
-	0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x65, 0x67, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03,
-	0x65, 0x6e, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x12, 0x0e,
-	0x0a, 0x02, 0x66, 0x6b, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x66, 0x6b, 0x12, 0x16,
-	0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x03, 0x52, 0x06,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65,
-	0x50, 0x63, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x02, 0x52, 0x09, 0x73, 0x61, 0x6d, 0x70, 0x6c,
-	0x65, 0x50, 0x63, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x75, 0x6c, 0x6c, 0x43, 0x68, 0x65, 0x63,
-	0x6b, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x6e, 0x75, 0x6c, 0x6c, 0x43, 0x68, 0x65,
-	0x63, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x65, 0x18, 0x0f, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x06, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6f, 0x72,
-	0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x6f,
-	0x72, 0x43, 0x6f, 0x6e, 0x74, 0x65, 0x78, 0x74, 0x22, 0x52, 0x0a, 0x06, 0x4f, 0x70, 0x54, 0x79,
-	0x70, 0x65, 0x12, 0x0d, 0x0a, 0x09, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x53, 0x45, 0x43, 0x54, 0x10,
-	0x00, 0x12, 0x09, 0x0a, 0x05, 0x55, 0x4e, 0x49, 0x4f, 0x4e, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a,
-	0x44, 0x49, 0x46, 0x46, 0x45, 0x52, 0x45, 0x4e, 0x43, 0x45, 0x10, 0x02, 0x12, 0x0e, 0x0a, 0x0a,
-	0x49, 0x4e, 0x4e, 0x45, 0x52, 0x5f, 0x4a, 0x4f, 0x49, 0x4e, 0x10, 0x03, 0x12, 0x0e, 0x0a, 0x0a,
-	0x4f, 0x55, 0x54, 0x45, 0x52, 0x5f, 0x4a, 0x4f, 0x49, 0x4e, 0x10, 0x04, 0x22, 0x50, 0x0a, 0x05,
-	0x42, 0x53, 0x49, 0x4f, 0x70, 0x12, 0x06, 0x0a, 0x02, 0x4e, 0x41, 0x10, 0x00, 0x12, 0x06, 0x0a,
-	0x02, 0x4c, 0x54, 0x10, 0x01, 0x12, 0x06, 0x0a, 0x02, 0x4c, 0x45, 0x10, 0x02, 0x12, 0x06, 0x0a,
-	0x02, 0x45, 0x51, 0x10, 0x03, 0x12, 0x06, 0x0a, 0x02, 0x47, 0x45, 0x10, 0x04, 0x12, 0x06, 0x0a,
-	0x02, 0x47, 0x54, 0x10, 0x05, 0x12, 0x09, 0x0a, 0x05, 0x52, 0x41, 0x4e, 0x47, 0x45, 0x10, 0x06,
-	0x12, 0x0c, 0x0a, 0x08, 0x42, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x45, 0x51, 0x10, 0x07, 0x22, 0x9f,
-	0x01, 0x0a, 0x15, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c,
-	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x42,
-	0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0e, 0x32, 0x24, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65,
-	0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x2e, 0x4f, 0x70, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x22, 0x2c, 0x0a, 0x06, 0x4f, 0x70, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0a, 0x0a, 0x06,
-	0x44, 0x45, 0x50, 0x4c, 0x4f, 0x59, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x44, 0x52, 0x4f, 0x50,
-	0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x54, 0x52, 0x55, 0x4e, 0x43, 0x41, 0x54, 0x45, 0x10, 0x02,
-	0x22, 0xad, 0x02, 0x0a, 0x0b, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
-	0x12, 0x16, 0x0a, 0x06, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x06, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x6e, 0x74, 0x65,
-	0x72, 0x73, 0x65, 0x63, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0a, 0x69, 0x6e,
-	0x74, 0x65, 0x72, 0x73, 0x65, 0x63, 0x74, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x69, 0x66, 0x66,
-	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0b, 0x64,
-	0x69, 0x66, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x07, 0x73, 0x61,
-	0x6d, 0x70, 0x6c, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x68,
-	0x61, 0x72, 0x65, 0x64, 0x2e, 0x42, 0x69, 0x74, 0x6d, 0x61, 0x70, 0x52, 0x65, 0x73, 0x75, 0x6c,
-	0x74, 0x52, 0x07, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x61,
-	0x6d, 0x70, 0x6c, 0x65, 0x50, 0x63, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x02, 0x52, 0x09, 0x73,
-	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x50, 0x63, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x73, 0x61, 0x6d, 0x70,
-	0x6c, 0x65, 0x49, 0x73, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x0d, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x49, 0x73, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x12, 0x1e,
-	0x0a, 0x0a, 0x65, 0x78, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x0a, 0x65, 0x78, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x30,
-	0x0a, 0x13, 0x61, 0x6e, 0x64, 0x44, 0x69, 0x66, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73,
-	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x13, 0x61, 0x6e, 0x64,
-	0x44, 0x69, 0x66, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74,
-	0x22, 0xd3, 0x01, 0x0a, 0x0b, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x20, 0x0a, 0x0b, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x64,
-	0x65, 0x78, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x6b, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x02,
-	0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x66, 0x6b, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x1a,
-	0x0a, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x6f,
-	0x54, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x74, 0x6f, 0x54, 0x69,
-	0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x53, 0x65, 0x74, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x53, 0x65, 0x74, 0x12, 0x1e,
-	0x0a, 0x0a, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x53, 0x65, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03,
-	0x28, 0x0c, 0x52, 0x0a, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x53, 0x65, 0x74, 0x73, 0x12, 0x16,
-	0x0a, 0x06, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
-	0x6e, 0x65, 0x67, 0x61, 0x74, 0x65, 0x22, 0x28, 0x0a, 0x0c, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
-	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73,
-	0x22, 0x78, 0x0a, 0x10, 0x42, 0x75, 0x6c, 0x6b, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x72,
-	0x6f, 0x6d, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x66, 0x72,
-	0x6f, 0x6d, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x6f, 0x54, 0x69, 0x6d, 0x65,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x74, 0x6f, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1a,
-	0x0a, 0x08, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x53, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x08, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x53, 0x65, 0x74, 0x22, 0x8f, 0x01, 0x0a, 0x0d, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x74, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x72, 0x6f, 0x6d,
+	0x54, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x66, 0x72, 0x6f, 0x6d,
+	0x54, 0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x6f, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x74, 0x6f, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x72,
+	0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x7a,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x7a, 0x65,
+	0x12, 0x26, 0x0a, 0x0e, 0x73, 0x68, 0x61, 0x72, 0x64, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75,
+	0x6d, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x73, 0x68, 0x61, 0x72, 0x64, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x73, 0x22, 0xf0, 0x04, 0x0a, 0x0d, 0x51, 0x75, 0x65,
+	0x72, 0x79, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x77, 0x49, 0x44, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x72, 0x6f, 0x77, 0x49, 0x44, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x49, 0x64,
+	0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65,
+	0x6e, 0x49, 0x64, 0x73, 0x12, 0x3a, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1c, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64,
+	0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x4f,
+	0x70, 0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x31, 0x0a, 0x05, 0x62, 0x73, 0x69, 0x4f, 0x70, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x1b, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x46, 0x72,
+	0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x42, 0x53, 0x49, 0x4f, 0x70, 0x52, 0x05, 0x62, 0x73,
+	0x69, 0x4f, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x65, 0x67, 0x69, 0x6e, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x62, 0x65, 0x67, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x66,
+	0x6b, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x66, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x03, 0x52, 0x06, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x50, 0x63, 0x74,
+	0x18, 0x0d, 0x20, 0x01, 0x28, 0x02, 0x52, 0x09, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x50, 0x63,
+	0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x75, 0x6c, 0x6c, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x18, 0x0e,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x6e, 0x75, 0x6c, 0x6c, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12,
+	0x16, 0x0a, 0x06, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x06, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6f, 0x72, 0x43, 0x6f, 0x6e,
+	0x74, 0x65, 0x78, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x6f, 0x72, 0x43, 0x6f,
+	0x6e, 0x74, 0x65, 0x78, 0x74, 0x22, 0x52, 0x0a, 0x06, 0x4f, 0x70, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x0d, 0x0a, 0x09, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x53, 0x45, 0x43, 0x54, 0x10, 0x00, 0x12, 0x09,
+	0x0a, 0x05, 0x55, 0x4e, 0x49, 0x4f, 0x4e, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x44, 0x49, 0x46,
+	0x46, 0x45, 0x52, 0x45, 0x4e, 0x43, 0x45, 0x10, 0x02, 0x12, 0x0e, 0x0a, 0x0a, 0x49, 0x4e, 0x4e,
+	0x45, 0x52, 0x5f, 0x4a, 0x4f, 0x49, 0x4e, 0x10, 0x03, 0x12, 0x0e, 0x0a, 0x0a, 0x4f, 0x55, 0x54,
+	0x45, 0x52, 0x5f, 0x4a, 0x4f, 0x49, 0x4e, 0x10, 0x04, 0x22, 0x50, 0x0a, 0x05, 0x42, 0x53, 0x49,
+	0x4f, 0x70, 0x12, 0x06, 0x0a, 0x02, 0x4e, 0x41, 0x10, 0x00, 0x12, 0x06, 0x0a, 0x02, 0x4c, 0x54,
+	0x10, 0x01, 0x12, 0x06, 0x0a, 0x02, 0x4c, 0x45, 0x10, 0x02, 0x12, 0x06, 0x0a, 0x02, 0x45, 0x51,
+	0x10, 0x03, 0x12, 0x06, 0x0a, 0x02, 0x47, 0x45, 0x10, 0x04, 0x12, 0x06, 0x0a, 0x02, 0x47, 0x54,
+	0x10, 0x05, 0x12, 0x09, 0x0a, 0x05, 0x52, 0x41, 0x4e, 0x47, 0x45, 0x10, 0x06, 0x12, 0x0c, 0x0a,
+	0x08, 0x42, 0x41, 0x54, 0x43, 0x48, 0x5f, 0x45, 0x51, 0x10, 0x07, 0x22, 0x9f, 0x01, 0x0a, 0x15,
+	0x54, 0x61, 0x62, 0x6c, 0x65, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x42, 0x0a, 0x09, 0x6f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x24,
+	0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x4f, 0x70, 0x65,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4f, 0x70,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22,
+	0x2c, 0x0a, 0x06, 0x4f, 0x70, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x44, 0x45, 0x50,
+	0x4c, 0x4f, 0x59, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x44, 0x52, 0x4f, 0x50, 0x10, 0x01, 0x12,
+	0x0c, 0x0a, 0x08, 0x54, 0x52, 0x55, 0x4e, 0x43, 0x41, 0x54, 0x45, 0x10, 0x02, 0x22, 0xc1, 0x03,
+	0x0a, 0x0b, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x75, 0x6e, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x75,
+	0x6e, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x65,
+	0x63, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x73, 0x65, 0x63, 0x74, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x69, 0x66, 0x66, 0x65, 0x72, 0x65,
+	0x6e, 0x63, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0b, 0x64, 0x69, 0x66, 0x66,
+	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x07, 0x73, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65,
+	0x64, 0x2e, 0x42, 0x69, 0x74, 0x6d, 0x61, 0x70, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07,
+	0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x50, 0x63, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x02, 0x52, 0x09, 0x73, 0x61, 0x6d, 0x70,
+	0x6c, 0x65, 0x50, 0x63, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x49,
+	0x73, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x73, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x49, 0x73, 0x55, 0x6e, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x65,
+	0x78, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x0a, 0x65, 0x78, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x13, 0x61,
+	0x6e, 0x64, 0x44, 0x69, 0x66, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x13, 0x61, 0x6e, 0x64, 0x44, 0x69, 0x66,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x26, 0x0a,
+	0x0e, 0x63, 0x6f, 0x6c, 0x64, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x64, 0x50, 0x61, 0x72, 0x74, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x3b, 0x0a, 0x0d, 0x66, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e,
+	0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x73,
+	0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x52, 0x0d, 0x66, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x12, 0x2d, 0x0a, 0x06, 0x73, 0x68, 0x61, 0x72, 0x64, 0x73, 0x18, 0x0b, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x53, 0x68, 0x61, 0x72,
+	0x64, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x52, 0x06, 0x73, 0x68, 0x61, 0x72, 0x64,
+	0x73, 0x22, 0x6f, 0x0a, 0x0d, 0x46, 0x72, 0x61, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74,
+	0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x63, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x61,
+	0x6c, 0x69, 0x74, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x65, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f,
+	0x64, 0x65, 0x22, 0xd3, 0x01, 0x0a, 0x0b, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x49, 0x6e, 0x64, 0x65,
+	0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x6b, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x66, 0x6b, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73,
+	0x12, 0x1a, 0x0a, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x74, 0x6f, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x74, 0x6f,
+	0x54, 0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x53, 0x65, 0x74,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x53, 0x65, 0x74,
+	0x12, 0x1e, 0x0a, 0x0a, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x53, 0x65, 0x74, 0x73, 0x18, 0x06,
+	0x20, 0x03, 0x28, 0x0c, 0x52, 0x0a, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x53, 0x65, 0x74, 0x73,
+	0x12, 0x16, 0x0a, 0x06, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x06, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x65, 0x22, 0x50, 0x0a, 0x0c, 0x4a, 0x6f, 0x69, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6c, 0x64, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x64,
+	0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x78, 0x0a, 0x10, 0x42, 0x75,
+	0x6c, 0x6b, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x54, 0x69, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x54, 0x69, 0x6d, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x74, 0x6f, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x06, 0x74, 0x6f, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x6f, 0x75, 0x6e,
+	0x64, 0x53, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x66, 0x6f, 0x75, 0x6e,
+	0x64, 0x53, 0x65, 0x74, 0x22, 0x8f, 0x01, 0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x14, 0x0a, 0x05,
+	0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65,
+	0x6c, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x49, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x49, 0x64, 0x12, 0x22,
+	0x0a, 0x0c, 0x72, 0x6f, 0x77, 0x49, 0x64, 0x4f, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x72, 0x6f, 0x77, 0x49, 0x64, 0x4f, 0x72, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x22, 0xe3, 0x01, 0x0a, 0x11, 0x53, 0x79, 0x6e, 0x63, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05,
 	0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6e, 0x64,
 	0x65, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x6c, 0x75,
-	0x6d, 0x6e, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x63, 0x6f, 0x6c, 0x75,
-	0x6d, 0x6e, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x72, 0x6f, 0x77, 0x49, 0x64, 0x4f, 0x72, 0x56,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x72, 0x6f, 0x77, 0x49,
-	0x64, 0x4f, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x22, 0xe3, 0x01, 0x0a,
-	0x11, 0x53, 0x79, 0x6e, 0x63, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c,
-	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x14,
-	0x0a, 0x05, 0x72, 0x6f, 0x77, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x72,
-	0x6f, 0x77, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x61, 0x72, 0x64,
-	0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x63,
-	0x61, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x62, 0x53,
-	0x49, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x0b, 0x62, 0x53, 0x49, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x12, 0x18, 0x0a, 0x07,
-	0x6d, 0x6f, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6d,
-	0x6f, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x44, 0x61,
-	0x74, 0x61, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x44, 0x61,
-	0x74, 0x61, 0x22, 0x96, 0x01, 0x0a, 0x12, 0x53, 0x79, 0x6e, 0x63, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x61, 0x72,
-	0x64, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b,
-	0x63, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x42,
-	0x53, 0x49, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
-	0x52, 0x0b, 0x42, 0x53, 0x49, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x12, 0x18, 0x0a,
-	0x07, 0x6d, 0x6f, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07,
-	0x6d, 0x6f, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18,
-	0x05, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x30, 0x0a, 0x10, 0x49,
-	0x6e, 0x64, 0x65, 0x78, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x50, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x09, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x50, 0x61, 0x74, 0x68, 0x22, 0xf9, 0x01,
-	0x0a, 0x11, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x50, 0x61, 0x74, 0x68,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x50, 0x61, 0x74,
-	0x68, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x61, 0x73,
-	0x4f, 0x70, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x77, 0x61, 0x73, 0x4f,
-	0x70, 0x65, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x75, 0x74,
-	0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x70, 0x75, 0x74, 0x73, 0x12, 0x12, 0x0a,
-	0x04, 0x67, 0x65, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x67, 0x65, 0x74,
-	0x73, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x65, 0x6c, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x04, 0x64, 0x65, 0x6c, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x68, 0x61, 0x73, 0x68, 0x43, 0x6f, 0x6c,
-	0x6c, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x68,
-	0x61, 0x73, 0x68, 0x43, 0x6f, 0x6c, 0x6c, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1a, 0x0a,
-	0x08, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x08, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x22, 0xa9, 0x01, 0x0a, 0x11, 0x50, 0x72,
-	0x6f, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x1a, 0x0a, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74,
-	0x6f, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x74, 0x6f, 0x54,
-	0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x53, 0x65, 0x74, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x53, 0x65, 0x74, 0x12,
-	0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18,
-	0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x16, 0x0a,
-	0x06, 0x6e, 0x65, 0x67, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6e,
-	0x65, 0x67, 0x61, 0x74, 0x65, 0x22, 0x52, 0x0a, 0x0c, 0x42, 0x69, 0x74, 0x6d, 0x61, 0x70, 0x52,
+	0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x77, 0x49,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x72, 0x6f, 0x77, 0x49, 0x64, 0x12, 0x12,
+	0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69,
+	0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x6c, 0x69, 0x74,
+	0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x63, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x61,
+	0x6c, 0x69, 0x74, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x62, 0x53, 0x49, 0x43, 0x68, 0x65, 0x63, 0x6b,
+	0x73, 0x75, 0x6d, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x62, 0x53, 0x49, 0x43, 0x68,
+	0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x6f, 0x64, 0x54, 0x69, 0x6d,
+	0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6d, 0x6f, 0x64, 0x54, 0x69, 0x6d, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x44, 0x61, 0x74, 0x61, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x08, 0x73, 0x65, 0x6e, 0x64, 0x44, 0x61, 0x74, 0x61, 0x22, 0x96, 0x01, 0x0a,
+	0x12, 0x53, 0x79, 0x6e, 0x63, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x02, 0x6f, 0x6b, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x61, 0x6c, 0x69,
+	0x74, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x63, 0x61, 0x72, 0x64, 0x69, 0x6e,
+	0x61, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x42, 0x53, 0x49, 0x43, 0x68, 0x65, 0x63,
+	0x6b, 0x73, 0x75, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x42, 0x53, 0x49, 0x43,
+	0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x6f, 0x64, 0x54, 0x69,
+	0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6d, 0x6f, 0x64, 0x54, 0x69, 0x6d,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0c, 0x52,
+	0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x40, 0x0a, 0x14, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x22, 0xdb, 0x01, 0x0a, 0x13, 0x50, 0x61, 0x72, 0x74,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x72,
+	0x6f, 0x77, 0x49, 0x64, 0x4f, 0x72, 0x42, 0x69, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0b, 0x72, 0x6f, 0x77, 0x49, 0x64, 0x4f, 0x72, 0x42, 0x69, 0x74, 0x73, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x71, 0x54, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x74, 0x71, 0x54, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x79, 0x74,
+	0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x12,
+	0x18, 0x0a, 0x07, 0x6d, 0x6f, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x6d, 0x6f, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x72, 0x63,
+	0x68, 0x69, 0x76, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x61, 0x72, 0x63,
+	0x68, 0x69, 0x76, 0x65, 0x64, 0x22, 0x5a, 0x0a, 0x15, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41,
+	0x0a, 0x0d, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x50,
+	0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x52, 0x0d, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66,
+	0x6f, 0x22, 0x2b, 0x0a, 0x13, 0x44, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x22, 0xb0,
+	0x01, 0x0a, 0x14, 0x44, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x6f, 0x64, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x6f, 0x64, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x68, 0x61, 0x72, 0x64, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x73, 0x68, 0x61, 0x72, 0x64, 0x73, 0x12, 0x16, 0x0a,
+	0x06, 0x70, 0x75, 0x73, 0x68, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x70,
+	0x75, 0x73, 0x68, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64, 0x12,
+	0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x08, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x22, 0x6a, 0x0a, 0x0d, 0x52, 0x65, 0x70, 0x61, 0x69, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65,
+	0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x2d,
+	0x0a, 0x06, 0x73, 0x68, 0x61, 0x72, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x64, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x73, 0x75, 0x6d, 0x52, 0x06, 0x73, 0x68, 0x61, 0x72, 0x64, 0x73, 0x22, 0xc2, 0x01,
+	0x0a, 0x0e, 0x52, 0x65, 0x70, 0x61, 0x69, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x68,
+	0x61, 0x72, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x73, 0x68, 0x61, 0x72,
+	0x64, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x6d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x65, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x6d, 0x69, 0x73, 0x6d, 0x61, 0x74, 0x63, 0x68,
+	0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x75, 0x73, 0x68, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x06, 0x70, 0x75, 0x73, 0x68, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65,
+	0x72, 0x67, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x6d, 0x65, 0x72, 0x67,
+	0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x65, 0x64, 0x22, 0x5f, 0x0a, 0x15, 0x52, 0x65, 0x70, 0x61, 0x69, 0x72, 0x43, 0x68, 0x65, 0x63,
+	0x6b, 0x73, 0x75, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6e,
+	0x6f, 0x64, 0x65, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6e, 0x6f, 0x64,
+	0x65, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x75, 0x63,
+	0x6b, 0x65, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x07, 0x62, 0x75, 0x63, 0x6b,
+	0x65, 0x74, 0x73, 0x22, 0xa7, 0x01, 0x0a, 0x0d, 0x53, 0x68, 0x61, 0x72, 0x64, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x73, 0x75, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x66,
+	0x69, 0x65, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c,
+	0x64, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x6f, 0x77, 0x49, 0x64, 0x4f, 0x72, 0x42, 0x69, 0x74, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x72, 0x6f, 0x77, 0x49, 0x64, 0x4f, 0x72, 0x42,
+	0x69, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x73, 0x75, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x73, 0x75, 0x6d, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x6f, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x6d, 0x6f, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x6b, 0x0a,
+	0x16, 0x52, 0x65, 0x70, 0x61, 0x69, 0x72, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x62, 0x75, 0x63, 0x6b, 0x65,
+	0x74, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x04, 0x52, 0x0c, 0x62,
+	0x75, 0x63, 0x6b, 0x65, 0x74, 0x48, 0x61, 0x73, 0x68, 0x65, 0x73, 0x12, 0x2d, 0x0a, 0x06, 0x73,
+	0x68, 0x61, 0x72, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x73, 0x68,
+	0x61, 0x72, 0x65, 0x64, 0x2e, 0x53, 0x68, 0x61, 0x72, 0x64, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73,
+	0x75, 0x6d, 0x52, 0x06, 0x73, 0x68, 0x61, 0x72, 0x64, 0x73, 0x22, 0x30, 0x0a, 0x10, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c,
+	0x0a, 0x09, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x50, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x50, 0x61, 0x74, 0x68, 0x22, 0xf9, 0x01, 0x0a,
+	0x11, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x50, 0x61, 0x74, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x50, 0x61, 0x74, 0x68,
+	0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x06, 0x65, 0x78, 0x69, 0x73, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x61, 0x73, 0x4f,
+	0x70, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x77, 0x61, 0x73, 0x4f, 0x70,
+	0x65, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x75, 0x74, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x70, 0x75, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04,
+	0x67, 0x65, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x67, 0x65, 0x74, 0x73,
+	0x12, 0x12, 0x0a, 0x04, 0x64, 0x65, 0x6c, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04,
+	0x64, 0x65, 0x6c, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x68, 0x61, 0x73, 0x68, 0x43, 0x6f, 0x6c, 0x6c,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x68, 0x61,
+	0x73, 0x68, 0x43, 0x6f, 0x6c, 0x6c, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1a, 0x0a, 0x08,
+	0x66, 0x69, 0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08,
+	0x66, 0x69, 0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x22, 0xa9, 0x01, 0x0a, 0x11, 0x50, 0x72, 0x6f,
+	0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a,
+	0x0a, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x6f,
+	0x54, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x74, 0x6f, 0x54, 0x69,
+	0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x53, 0x65, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x53, 0x65, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x16, 0x0a, 0x06,
+	0x6e, 0x65, 0x67, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x6e, 0x65,
+	0x67, 0x61, 0x74, 0x65, 0x22, 0x52, 0x0a, 0x0c, 0x42, 0x69, 0x74, 0x6d, 0x61, 0x70, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f,
+	0x77, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x72, 0x6f, 0x77, 0x49, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x62, 0x69, 0x74, 0x6d, 0x61, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x06, 0x62, 0x69, 0x74, 0x6d, 0x61, 0x70, 0x22, 0x3b, 0x0a, 0x09, 0x42, 0x53, 0x49, 0x52,
 	0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x72,
-	0x6f, 0x77, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x72, 0x6f, 0x77, 0x49,
-	0x64, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x69, 0x74, 0x6d, 0x61, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x06, 0x62, 0x69, 0x74, 0x6d, 0x61, 0x70, 0x22, 0x3b, 0x0a, 0x09, 0x42, 0x53, 0x49,
-	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x18, 0x0a, 0x07,
-	0x62, 0x69, 0x74, 0x6d, 0x61, 0x70, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x07, 0x62,
-	0x69, 0x74, 0x6d, 0x61, 0x70, 0x73, 0x22, 0x83, 0x01, 0x0a, 0x12, 0x50, 0x72, 0x6f, 0x6a, 0x65,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a,
-	0x0d, 0x62, 0x69, 0x74, 0x6d, 0x61, 0x70, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x42, 0x69,
-	0x74, 0x6d, 0x61, 0x70, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x0d, 0x62, 0x69, 0x74, 0x6d,
-	0x61, 0x70, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x31, 0x0a, 0x0a, 0x62, 0x73, 0x69,
-	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e,
-	0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x42, 0x53, 0x49, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
-	0x52, 0x0a, 0x62, 0x73, 0x69, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x87, 0x01, 0x0a,
-	0x17, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65,
-	0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x18,
-	0x0a, 0x07, 0x70, 0x6b, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x70, 0x6b, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x28, 0x0a, 0x0f,
-	0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x69, 0x7a, 0x65, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x46, 0x0a, 0x18, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x6f,
-	0x75, 0x74, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e,
-	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x5a,
-	0x0a, 0x1e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x49, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x57,
-	0x69, 0x74, 0x68, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x74, 0x61,
-	0x69, 0x6e, 0x45, 0x6e, 0x75, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x72,
-	0x65, 0x74, 0x61, 0x69, 0x6e, 0x45, 0x6e, 0x75, 0x6d, 0x73, 0x32, 0x87, 0x01, 0x0a, 0x0c, 0x43,
-	0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x12, 0x39, 0x0a, 0x06, 0x53,
-	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x15, 0x2e,
-	0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x4d, 0x65, 0x73,
-	0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x08, 0x53, 0x68, 0x75, 0x74, 0x64, 0x6f,
-	0x77, 0x6e, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
-	0x74, 0x79, 0x22, 0x00, 0x32, 0x97, 0x04, 0x0a, 0x07, 0x4b, 0x56, 0x53, 0x74, 0x6f, 0x72, 0x65,
-	0x12, 0x34, 0x0a, 0x03, 0x50, 0x75, 0x74, 0x12, 0x13, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64,
-	0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4b, 0x56, 0x50, 0x61, 0x69, 0x72, 0x1a, 0x16, 0x2e, 0x67,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x62,
+	0x69, 0x74, 0x6d, 0x61, 0x70, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x07, 0x62, 0x69,
+	0x74, 0x6d, 0x61, 0x70, 0x73, 0x22, 0xab, 0x01, 0x0a, 0x12, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x0d,
+	0x62, 0x69, 0x74, 0x6d, 0x61, 0x70, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x42, 0x69, 0x74,
+	0x6d, 0x61, 0x70, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x0d, 0x62, 0x69, 0x74, 0x6d, 0x61,
+	0x70, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x31, 0x0a, 0x0a, 0x62, 0x73, 0x69, 0x52,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x73,
+	0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x42, 0x53, 0x49, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52,
+	0x0a, 0x62, 0x73, 0x69, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x63,
+	0x6f, 0x6c, 0x64, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0e, 0x63, 0x6f, 0x6c, 0x64, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x22, 0x90, 0x01, 0x0a, 0x0e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x42, 0x79, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x54, 0x69,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x54, 0x69,
+	0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x6f, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x06, 0x74, 0x6f, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x53, 0x65, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x09, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x53, 0x65, 0x74, 0x73, 0x22, 0xa5, 0x01, 0x0a, 0x0d, 0x47, 0x72, 0x6f, 0x75, 0x70,
+	0x42, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x14,
+	0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66,
+	0x69, 0x65, 0x6c, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x75, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x03, 0x73, 0x75, 0x6d, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69,
+	0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03,
+	0x6d, 0x61, 0x78, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x22, 0x42,
+	0x0a, 0x0f, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x42, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x2f, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x42, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x73, 0x22, 0xb7, 0x01, 0x0a, 0x0b, 0x54, 0x6f, 0x70, 0x4b, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x74, 0x6f, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06,
+	0x74, 0x6f, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x14, 0x0a, 0x05,
+	0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65,
+	0x6c, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x53, 0x65, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x53, 0x65, 0x74, 0x12, 0x0c,
+	0x0a, 0x01, 0x6b, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x01, 0x6b, 0x12, 0x1e, 0x0a, 0x0a,
+	0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0a, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x22, 0x3e, 0x0a, 0x0a,
+	0x54, 0x6f, 0x70, 0x4b, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f,
+	0x6c, 0x75, 0x6d, 0x6e, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x63, 0x6f,
+	0x6c, 0x75, 0x6d, 0x6e, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x3c, 0x0a, 0x0c,
+	0x54, 0x6f, 0x70, 0x4b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x07,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x6f, 0x70, 0x4b, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0xb0, 0x01, 0x0a, 0x14, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x44, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x54, 0x69, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x66, 0x72, 0x6f, 0x6d, 0x54, 0x69, 0x6d, 0x65, 0x12,
+	0x16, 0x0a, 0x06, 0x74, 0x6f, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x06, 0x74, 0x6f, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x32, 0x0a,
+	0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x44, 0x69, 0x73, 0x74,
+	0x69, 0x6e, 0x63, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64,
+	0x73, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x53, 0x65, 0x74, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x08, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x53, 0x65, 0x74, 0x22, 0x48, 0x0a,
+	0x12, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x44, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x46, 0x69,
+	0x65, 0x6c, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x72, 0x65,
+	0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x70, 0x72,
+	0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x4e, 0x0a, 0x15, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x44, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x35, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x44, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x45, 0x0a, 0x13, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x44, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6b, 0x65, 0x74, 0x63, 0x68,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x6b, 0x65, 0x74, 0x63, 0x68, 0x22, 0x87,
+	0x01, 0x0a, 0x17, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x71, 0x75, 0x65,
+	0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x12, 0x18, 0x0a, 0x07, 0x70, 0x6b, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x70, 0x6b, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x28,
+	0x0a, 0x0f, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x69, 0x7a,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x46, 0x0a, 0x18, 0x43, 0x68, 0x65, 0x63,
+	0x6b, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x22, 0x5a, 0x0a, 0x1e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x49, 0x6e, 0x64, 0x69, 0x63, 0x65,
+	0x73, 0x57, 0x69, 0x74, 0x68, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65,
+	0x74, 0x61, 0x69, 0x6e, 0x45, 0x6e, 0x75, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0b, 0x72, 0x65, 0x74, 0x61, 0x69, 0x6e, 0x45, 0x6e, 0x75, 0x6d, 0x73, 0x32, 0x87, 0x01, 0x0a,
+	0x0c, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x12, 0x39, 0x0a,
+	0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a,
+	0x15, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x08, 0x53, 0x68, 0x75, 0x74,
+	0x64, 0x6f, 0x77, 0x6e, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x67,
 	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x08, 0x42, 0x61, 0x74, 0x63, 0x68, 0x50,
-	0x75, 0x74, 0x12, 0x13, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x49, 0x6e, 0x64, 0x65,
-	0x78, 0x4b, 0x56, 0x50, 0x61, 0x69, 0x72, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22,
-	0x00, 0x28, 0x01, 0x12, 0x34, 0x0a, 0x06, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x12, 0x13, 0x2e,
-	0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4b, 0x56, 0x50, 0x61,
-	0x69, 0x72, 0x1a, 0x13, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x49, 0x6e, 0x64, 0x65,
-	0x78, 0x4b, 0x56, 0x50, 0x61, 0x69, 0x72, 0x22, 0x00, 0x12, 0x3d, 0x0a, 0x0b, 0x42, 0x61, 0x74,
-	0x63, 0x68, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x12, 0x13, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65,
-	0x64, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4b, 0x56, 0x50, 0x61, 0x69, 0x72, 0x1a, 0x13, 0x2e,
-	0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4b, 0x56, 0x50, 0x61,
-	0x69, 0x72, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x3e, 0x0a, 0x05, 0x49, 0x74, 0x65, 0x6d,
-	0x73, 0x12, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x1a,
+	0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x32, 0x97, 0x04, 0x0a, 0x07, 0x4b, 0x56, 0x53, 0x74, 0x6f,
+	0x72, 0x65, 0x12, 0x34, 0x0a, 0x03, 0x50, 0x75, 0x74, 0x12, 0x13, 0x2e, 0x73, 0x68, 0x61, 0x72,
+	0x65, 0x64, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4b, 0x56, 0x50, 0x61, 0x69, 0x72, 0x1a, 0x16,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x08, 0x42, 0x61, 0x74, 0x63,
+	0x68, 0x50, 0x75, 0x74, 0x12, 0x13, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x4b, 0x56, 0x50, 0x61, 0x69, 0x72, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x22, 0x00, 0x28, 0x01, 0x12, 0x34, 0x0a, 0x06, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x12,
+	0x13, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4b, 0x56,
+	0x50, 0x61, 0x69, 0x72, 0x1a, 0x13, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x4b, 0x56, 0x50, 0x61, 0x69, 0x72, 0x22, 0x00, 0x12, 0x3d, 0x0a, 0x0b, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x4c, 0x6f, 0x6f, 0x6b, 0x75, 0x70, 0x12, 0x13, 0x2e, 0x73, 0x68, 0x61,
+	0x72, 0x65, 0x64, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4b, 0x56, 0x50, 0x61, 0x69, 0x72, 0x1a,
 	0x13, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4b, 0x56,
-	0x50, 0x61, 0x69, 0x72, 0x22, 0x00, 0x30, 0x01, 0x12, 0x43, 0x0a, 0x0d, 0x50, 0x75, 0x74, 0x53,
-	0x74, 0x72, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x75, 0x6d, 0x12, 0x12, 0x2e, 0x73, 0x68, 0x61, 0x72,
-	0x65, 0x64, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x75, 0x6d, 0x1a, 0x1c, 0x2e,
+	0x50, 0x61, 0x69, 0x72, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x12, 0x3e, 0x0a, 0x05, 0x49, 0x74,
+	0x65, 0x6d, 0x73, 0x12, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x1a, 0x13, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x4b, 0x56, 0x50, 0x61, 0x69, 0x72, 0x22, 0x00, 0x30, 0x01, 0x12, 0x43, 0x0a, 0x0d, 0x50, 0x75,
+	0x74, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x75, 0x6d, 0x12, 0x12, 0x2e, 0x73, 0x68,
+	0x61, 0x72, 0x65, 0x64, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x45, 0x6e, 0x75, 0x6d, 0x1a,
+	0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x55, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x00, 0x12,
+	0x5b, 0x0a, 0x17, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x49, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73,
+	0x57, 0x69, 0x74, 0x68, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x26, 0x2e, 0x73, 0x68, 0x61,
+	0x72, 0x65, 0x64, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x49, 0x6e, 0x64, 0x69, 0x63, 0x65,
+	0x73, 0x57, 0x69, 0x74, 0x68, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x09,
+	0x49, 0x6e, 0x64, 0x65, 0x78, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x18, 0x2e, 0x73, 0x68, 0x61, 0x72,
+	0x65, 0x64, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x49, 0x6e, 0x64,
+	0x65, 0x78, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x32, 0xe9, 0x01, 0x0a, 0x0c, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x61, 0x72, 0x63,
+	0x68, 0x12, 0x46, 0x0a, 0x0a, 0x42, 0x61, 0x74, 0x63, 0x68, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12,
+	0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x16, 0x2e,
 	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x55, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x00, 0x12, 0x5b, 0x0a,
-	0x17, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x49, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x57, 0x69,
-	0x74, 0x68, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x26, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65,
-	0x64, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x49, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x57,
-	0x69, 0x74, 0x68, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x09, 0x49, 0x6e,
-	0x64, 0x65, 0x78, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x18, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64,
-	0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x19, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78,
-	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0xa0,
-	0x01, 0x0a, 0x0c, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12,
-	0x46, 0x0a, 0x0a, 0x42, 0x61, 0x74, 0x63, 0x68, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1c, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x28, 0x01, 0x12, 0x48, 0x0a, 0x06, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x12, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x1a, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x55, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22,
+	0x00, 0x30, 0x01, 0x12, 0x47, 0x0a, 0x07, 0x52, 0x65, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1c,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x1c, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x55,
+	0x49, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x00, 0x32, 0xdc, 0x09, 0x0a,
+	0x0b, 0x42, 0x69, 0x74, 0x6d, 0x61, 0x70, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x39, 0x0a, 0x06,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x15, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e,
 	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x16, 0x2e, 0x67, 0x6f,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x0b, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x4d, 0x75, 0x74, 0x61, 0x74, 0x65, 0x12, 0x13, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e,
+	0x49, 0x6e, 0x64, 0x65, 0x78, 0x4b, 0x56, 0x50, 0x61, 0x69, 0x72, 0x1a, 0x16, 0x2e, 0x67, 0x6f,
 	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
-	0x70, 0x74, 0x79, 0x22, 0x00, 0x28, 0x01, 0x12, 0x48, 0x0a, 0x06, 0x53, 0x65, 0x61, 0x72, 0x63,
-	0x68, 0x12, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x1a,
+	0x70, 0x74, 0x79, 0x22, 0x00, 0x28, 0x01, 0x12, 0x3f, 0x0a, 0x09, 0x42, 0x75, 0x6c, 0x6b, 0x43,
+	0x6c, 0x65, 0x61, 0x72, 0x12, 0x18, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x42, 0x75,
+	0x6c, 0x6b, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x33, 0x0a, 0x05, 0x51, 0x75, 0x65, 0x72,
+	0x79, 0x12, 0x13, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x42, 0x69, 0x74, 0x6d, 0x61,
+	0x70, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x13, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e,
+	0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x00, 0x12, 0x33, 0x0a,
+	0x04, 0x4a, 0x6f, 0x69, 0x6e, 0x12, 0x13, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x4a,
+	0x6f, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x73, 0x68, 0x61,
+	0x72, 0x65, 0x64, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x45, 0x0a, 0x0a, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x19, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x73, 0x68,
+	0x61, 0x72, 0x65, 0x64, 0x2e, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x07, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x42, 0x79, 0x12, 0x16, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x42, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x73,
+	0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x42, 0x79, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x33, 0x0a, 0x04, 0x54, 0x6f, 0x70, 0x4b, 0x12,
+	0x13, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x6f, 0x70, 0x4b, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x54, 0x6f,
+	0x70, 0x4b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x0d,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x44, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x63, 0x74, 0x12, 0x1c, 0x2e,
+	0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x44, 0x69, 0x73, 0x74,
+	0x69, 0x6e, 0x63, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x73, 0x68,
+	0x61, 0x72, 0x65, 0x64, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x44, 0x69, 0x73, 0x74, 0x69, 0x6e,
+	0x63, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x57, 0x0a, 0x10,
+	0x43, 0x68, 0x65, 0x63, 0x6b, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65,
+	0x12, 0x1f, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x6f,
+	0x75, 0x74, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x20, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b,
+	0x6f, 0x75, 0x74, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x0e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x4f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64,
+	0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00,
+	0x12, 0x4a, 0x0a, 0x0b, 0x53, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x65, 0x12,
 	0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x55, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x00, 0x30,
-	0x01, 0x32, 0xed, 0x05, 0x0a, 0x0b, 0x42, 0x69, 0x74, 0x6d, 0x61, 0x70, 0x49, 0x6e, 0x64, 0x65,
-	0x78, 0x12, 0x39, 0x0a, 0x06, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x15, 0x2e, 0x73, 0x68,
-	0x61, 0x72, 0x65, 0x64, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x0b,
-	0x42, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x75, 0x74, 0x61, 0x74, 0x65, 0x12, 0x13, 0x2e, 0x73, 0x68,
-	0x61, 0x72, 0x65, 0x64, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x4b, 0x56, 0x50, 0x61, 0x69, 0x72,
-	0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x28, 0x01, 0x12, 0x3f, 0x0a, 0x09,
-	0x42, 0x75, 0x6c, 0x6b, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x12, 0x18, 0x2e, 0x73, 0x68, 0x61, 0x72,
-	0x65, 0x64, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x33, 0x0a,
-	0x05, 0x51, 0x75, 0x65, 0x72, 0x79, 0x12, 0x13, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e,
-	0x42, 0x69, 0x74, 0x6d, 0x61, 0x70, 0x51, 0x75, 0x65, 0x72, 0x79, 0x1a, 0x13, 0x2e, 0x73, 0x68,
-	0x61, 0x72, 0x65, 0x64, 0x2e, 0x51, 0x75, 0x65, 0x72, 0x79, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
-	0x22, 0x00, 0x12, 0x33, 0x0a, 0x04, 0x4a, 0x6f, 0x69, 0x6e, 0x12, 0x13, 0x2e, 0x73, 0x68, 0x61,
-	0x72, 0x65, 0x64, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x14, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x4a, 0x6f, 0x69, 0x6e, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0a, 0x50, 0x72, 0x6f, 0x6a, 0x65,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x50,
-	0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x1a, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x57,
-	0x0a, 0x10, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e,
-	0x63, 0x65, 0x12, 0x1f, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x43, 0x68, 0x65, 0x63,
-	0x6b, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x43, 0x68, 0x65,
-	0x63, 0x6b, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x0e, 0x54, 0x61, 0x62, 0x6c, 0x65,
-	0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x2e, 0x73, 0x68, 0x61, 0x72,
-	0x65, 0x64, 0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x22, 0x00, 0x12, 0x4a, 0x0a, 0x0b, 0x53, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a,
-	0x65, 0x12, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x1a,
-	0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x00, 0x12, 0x45,
-	0x0a, 0x0a, 0x53, 0x79, 0x6e, 0x63, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x19, 0x2e, 0x73,
-	0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64,
-	0x2e, 0x53, 0x79, 0x6e, 0x63, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3a, 0x0a, 0x06, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12,
-	0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22,
-	0x00, 0x42, 0x4c, 0x0a, 0x15, 0x69, 0x6f, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x64, 0x69, 0x73,
-	0x6e, 0x65, 0x79, 0x2e, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x61, 0x42, 0x0b, 0x51, 0x75, 0x61, 0x6e,
-	0x74, 0x61, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x24, 0x67, 0x69, 0x74, 0x68, 0x75,
-	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x69, 0x73, 0x6e, 0x65, 0x79, 0x2f, 0x71, 0x75, 0x61,
-	0x6e, 0x74, 0x61, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x3b, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x66, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x1b, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x49, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0a,
+	0x53, 0x79, 0x6e, 0x63, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x19, 0x2e, 0x73, 0x68, 0x61,
+	0x72, 0x65, 0x64, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x53,
+	0x79, 0x6e, 0x63, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x3a, 0x0a, 0x06, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x16, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12,
+	0x4e, 0x0a, 0x0d, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f,
+	0x12, 0x1c, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d,
+	0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x4b, 0x0a, 0x0c, 0x44, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x1b, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x44, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x73,
+	0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x44, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x39, 0x0a, 0x06,
+	0x52, 0x65, 0x70, 0x61, 0x69, 0x72, 0x12, 0x15, 0x2e, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e,
+	0x52, 0x65, 0x70, 0x61, 0x69, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e,
+	0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x52, 0x65, 0x70, 0x61, 0x69, 0x72, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x52, 0x0a, 0x0f, 0x52, 0x65, 0x70, 0x61, 0x69,
+	0x72, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x73, 0x12, 0x1d, 0x2e, 0x73, 0x68, 0x61,
+	0x72, 0x65, 0x64, 0x2e, 0x52, 0x65, 0x70, 0x61, 0x69, 0x72, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73,
+	0x75, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x73, 0x68, 0x61, 0x72,
+	0x65, 0x64, 0x2e, 0x52, 0x65, 0x70, 0x61, 0x69, 0x72, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75,
+	0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x4c, 0x0a, 0x15, 0x69,
+	0x6f, 0x2e, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x64, 0x69, 0x73, 0x6e, 0x65, 0x79, 0x2e, 0x71, 0x75,
+	0x61, 0x6e, 0x74, 0x61, 0x42, 0x0b, 0x51, 0x75, 0x61, 0x6e, 0x74, 0x61, 0x50, 0x72, 0x6f, 0x74,
+	0x6f, 0x50, 0x01, 0x5a, 0x24, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x64, 0x69, 0x73, 0x6e, 0x65, 0x79, 0x2f, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x61, 0x2f, 0x67, 0x72,
+	0x70, 0x63, 0x3b, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -2242,7 +3945,7 @@ func file_quanta_proto_rawDescGZIP() []byte {
 }
 
 var file_quanta_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_quanta_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_quanta_proto_msgTypes = make([]protoimpl.MessageInfo, 43)
 var file_quanta_proto_goTypes = []interface{}{
 	(QueryFragment_OpType)(0),              // 0: shared.QueryFragment.OpType
 	(QueryFragment_BSIOp)(0),               // 1: shared.QueryFragment.BSIOp
@@ -2254,85 +3957,131 @@ var file_quanta_proto_goTypes = []interface{}{
 	(*QueryFragment)(nil),                  // 7: shared.QueryFragment
 	(*TableOperationRequest)(nil),          // 8: shared.TableOperationRequest
 	(*QueryResult)(nil),                    // 9: shared.QueryResult
-	(*JoinRequest)(nil),                    // 10: shared.JoinRequest
-	(*JoinResponse)(nil),                   // 11: shared.JoinResponse
-	(*BulkClearRequest)(nil),               // 12: shared.BulkClearRequest
-	(*UpdateRequest)(nil),                  // 13: shared.UpdateRequest
-	(*SyncStatusRequest)(nil),              // 14: shared.SyncStatusRequest
-	(*SyncStatusResponse)(nil),             // 15: shared.SyncStatusResponse
-	(*IndexInfoRequest)(nil),               // 16: shared.IndexInfoRequest
-	(*IndexInfoResponse)(nil),              // 17: shared.IndexInfoResponse
-	(*ProjectionRequest)(nil),              // 18: shared.ProjectionRequest
-	(*BitmapResult)(nil),                   // 19: shared.BitmapResult
-	(*BSIResult)(nil),                      // 20: shared.BSIResult
-	(*ProjectionResponse)(nil),             // 21: shared.ProjectionResponse
-	(*CheckoutSequenceRequest)(nil),        // 22: shared.CheckoutSequenceRequest
-	(*CheckoutSequenceResponse)(nil),       // 23: shared.CheckoutSequenceResponse
-	(*DeleteIndicesWithPrefixRequest)(nil), // 24: shared.DeleteIndicesWithPrefixRequest
-	(*emptypb.Empty)(nil),                  // 25: google.protobuf.Empty
-	(*wrapperspb.StringValue)(nil),         // 26: google.protobuf.StringValue
-	(*wrapperspb.UInt64Value)(nil),         // 27: google.protobuf.UInt64Value
-	(*wrapperspb.Int64Value)(nil),          // 28: google.protobuf.Int64Value
+	(*FragmentStats)(nil),                  // 10: shared.FragmentStats
+	(*JoinRequest)(nil),                    // 11: shared.JoinRequest
+	(*JoinResponse)(nil),                   // 12: shared.JoinResponse
+	(*BulkClearRequest)(nil),               // 13: shared.BulkClearRequest
+	(*UpdateRequest)(nil),                  // 14: shared.UpdateRequest
+	(*SyncStatusRequest)(nil),              // 15: shared.SyncStatusRequest
+	(*SyncStatusResponse)(nil),             // 16: shared.SyncStatusResponse
+	(*PartitionInfoRequest)(nil),           // 17: shared.PartitionInfoRequest
+	(*PartitionInfoResult)(nil),            // 18: shared.PartitionInfoResult
+	(*PartitionInfoResponse)(nil),          // 19: shared.PartitionInfoResponse
+	(*DecommissionRequest)(nil),            // 20: shared.DecommissionRequest
+	(*DecommissionResponse)(nil),           // 21: shared.DecommissionResponse
+	(*RepairRequest)(nil),                  // 22: shared.RepairRequest
+	(*RepairResponse)(nil),                 // 23: shared.RepairResponse
+	(*RepairChecksumRequest)(nil),          // 24: shared.RepairChecksumRequest
+	(*ShardChecksum)(nil),                  // 25: shared.ShardChecksum
+	(*RepairChecksumResponse)(nil),         // 26: shared.RepairChecksumResponse
+	(*IndexInfoRequest)(nil),               // 27: shared.IndexInfoRequest
+	(*IndexInfoResponse)(nil),              // 28: shared.IndexInfoResponse
+	(*ProjectionRequest)(nil),              // 29: shared.ProjectionRequest
+	(*BitmapResult)(nil),                   // 30: shared.BitmapResult
+	(*BSIResult)(nil),                      // 31: shared.BSIResult
+	(*ProjectionResponse)(nil),             // 32: shared.ProjectionResponse
+	(*GroupByRequest)(nil),                 // 33: shared.GroupByRequest
+	(*GroupByResult)(nil),                  // 34: shared.GroupByResult
+	(*GroupByResponse)(nil),                // 35: shared.GroupByResponse
+	(*TopKRequest)(nil),                    // 36: shared.TopKRequest
+	(*TopKResult)(nil),                     // 37: shared.TopKResult
+	(*TopKResponse)(nil),                   // 38: shared.TopKResponse
+	(*CountDistinctRequest)(nil),           // 39: shared.CountDistinctRequest
+	(*CountDistinctField)(nil),             // 40: shared.CountDistinctField
+	(*CountDistinctResponse)(nil),          // 41: shared.CountDistinctResponse
+	(*CountDistinctResult)(nil),            // 42: shared.CountDistinctResult
+	(*CheckoutSequenceRequest)(nil),        // 43: shared.CheckoutSequenceRequest
+	(*CheckoutSequenceResponse)(nil),       // 44: shared.CheckoutSequenceResponse
+	(*DeleteIndicesWithPrefixRequest)(nil), // 45: shared.DeleteIndicesWithPrefixRequest
+	(*emptypb.Empty)(nil),                  // 46: google.protobuf.Empty
+	(*wrapperspb.StringValue)(nil),         // 47: google.protobuf.StringValue
+	(*wrapperspb.UInt64Value)(nil),         // 48: google.protobuf.UInt64Value
+	(*wrapperspb.Int64Value)(nil),          // 49: google.protobuf.Int64Value
 }
 var file_quanta_proto_depIdxs = []int32{
 	7,  // 0: shared.BitmapQuery.query:type_name -> shared.QueryFragment
 	0,  // 1: shared.QueryFragment.operation:type_name -> shared.QueryFragment.OpType
 	1,  // 2: shared.QueryFragment.bsiOp:type_name -> shared.QueryFragment.BSIOp
 	2,  // 3: shared.TableOperationRequest.operation:type_name -> shared.TableOperationRequest.OpType
-	19, // 4: shared.QueryResult.samples:type_name -> shared.BitmapResult
-	19, // 5: shared.ProjectionResponse.bitmapResults:type_name -> shared.BitmapResult
-	20, // 6: shared.ProjectionResponse.bsiResults:type_name -> shared.BSIResult
-	25, // 7: shared.ClusterAdmin.Status:input_type -> google.protobuf.Empty
-	25, // 8: shared.ClusterAdmin.Shutdown:input_type -> google.protobuf.Empty
-	4,  // 9: shared.KVStore.Put:input_type -> shared.IndexKVPair
-	4,  // 10: shared.KVStore.BatchPut:input_type -> shared.IndexKVPair
-	4,  // 11: shared.KVStore.Lookup:input_type -> shared.IndexKVPair
-	4,  // 12: shared.KVStore.BatchLookup:input_type -> shared.IndexKVPair
-	26, // 13: shared.KVStore.Items:input_type -> google.protobuf.StringValue
-	5,  // 14: shared.KVStore.PutStringEnum:input_type -> shared.StringEnum
-	24, // 15: shared.KVStore.DeleteIndicesWithPrefix:input_type -> shared.DeleteIndicesWithPrefixRequest
-	16, // 16: shared.KVStore.IndexInfo:input_type -> shared.IndexInfoRequest
-	26, // 17: shared.StringSearch.BatchIndex:input_type -> google.protobuf.StringValue
-	26, // 18: shared.StringSearch.Search:input_type -> google.protobuf.StringValue
-	13, // 19: shared.BitmapIndex.Update:input_type -> shared.UpdateRequest
-	4,  // 20: shared.BitmapIndex.BatchMutate:input_type -> shared.IndexKVPair
-	12, // 21: shared.BitmapIndex.BulkClear:input_type -> shared.BulkClearRequest
-	6,  // 22: shared.BitmapIndex.Query:input_type -> shared.BitmapQuery
-	10, // 23: shared.BitmapIndex.Join:input_type -> shared.JoinRequest
-	18, // 24: shared.BitmapIndex.Projection:input_type -> shared.ProjectionRequest
-	22, // 25: shared.BitmapIndex.CheckoutSequence:input_type -> shared.CheckoutSequenceRequest
-	8,  // 26: shared.BitmapIndex.TableOperation:input_type -> shared.TableOperationRequest
-	26, // 27: shared.BitmapIndex.Synchronize:input_type -> google.protobuf.StringValue
-	14, // 28: shared.BitmapIndex.SyncStatus:input_type -> shared.SyncStatusRequest
-	25, // 29: shared.BitmapIndex.Commit:input_type -> google.protobuf.Empty
-	3,  // 30: shared.ClusterAdmin.Status:output_type -> shared.StatusMessage
-	25, // 31: shared.ClusterAdmin.Shutdown:output_type -> google.protobuf.Empty
-	25, // 32: shared.KVStore.Put:output_type -> google.protobuf.Empty
-	25, // 33: shared.KVStore.BatchPut:output_type -> google.protobuf.Empty
-	4,  // 34: shared.KVStore.Lookup:output_type -> shared.IndexKVPair
-	4,  // 35: shared.KVStore.BatchLookup:output_type -> shared.IndexKVPair
-	4,  // 36: shared.KVStore.Items:output_type -> shared.IndexKVPair
-	27, // 37: shared.KVStore.PutStringEnum:output_type -> google.protobuf.UInt64Value
-	25, // 38: shared.KVStore.DeleteIndicesWithPrefix:output_type -> google.protobuf.Empty
-	17, // 39: shared.KVStore.IndexInfo:output_type -> shared.IndexInfoResponse
-	25, // 40: shared.StringSearch.BatchIndex:output_type -> google.protobuf.Empty
-	27, // 41: shared.StringSearch.Search:output_type -> google.protobuf.UInt64Value
-	25, // 42: shared.BitmapIndex.Update:output_type -> google.protobuf.Empty
-	25, // 43: shared.BitmapIndex.BatchMutate:output_type -> google.protobuf.Empty
-	25, // 44: shared.BitmapIndex.BulkClear:output_type -> google.protobuf.Empty
-	9,  // 45: shared.BitmapIndex.Query:output_type -> shared.QueryResult
-	11, // 46: shared.BitmapIndex.Join:output_type -> shared.JoinResponse
-	21, // 47: shared.BitmapIndex.Projection:output_type -> shared.ProjectionResponse
-	23, // 48: shared.BitmapIndex.CheckoutSequence:output_type -> shared.CheckoutSequenceResponse
-	25, // 49: shared.BitmapIndex.TableOperation:output_type -> google.protobuf.Empty
-	28, // 50: shared.BitmapIndex.Synchronize:output_type -> google.protobuf.Int64Value
-	15, // 51: shared.BitmapIndex.SyncStatus:output_type -> shared.SyncStatusResponse
-	25, // 52: shared.BitmapIndex.Commit:output_type -> google.protobuf.Empty
-	30, // [30:53] is the sub-list for method output_type
-	7,  // [7:30] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	30, // 4: shared.QueryResult.samples:type_name -> shared.BitmapResult
+	10, // 5: shared.QueryResult.fragmentStats:type_name -> shared.FragmentStats
+	25, // 6: shared.QueryResult.shards:type_name -> shared.ShardChecksum
+	18, // 7: shared.PartitionInfoResponse.partitionInfo:type_name -> shared.PartitionInfoResult
+	25, // 8: shared.RepairRequest.shards:type_name -> shared.ShardChecksum
+	25, // 9: shared.RepairChecksumResponse.shards:type_name -> shared.ShardChecksum
+	30, // 10: shared.ProjectionResponse.bitmapResults:type_name -> shared.BitmapResult
+	31, // 11: shared.ProjectionResponse.bsiResults:type_name -> shared.BSIResult
+	34, // 12: shared.GroupByResponse.results:type_name -> shared.GroupByResult
+	37, // 13: shared.TopKResponse.results:type_name -> shared.TopKResult
+	40, // 14: shared.CountDistinctRequest.fields:type_name -> shared.CountDistinctField
+	42, // 15: shared.CountDistinctResponse.results:type_name -> shared.CountDistinctResult
+	46, // 16: shared.ClusterAdmin.Status:input_type -> google.protobuf.Empty
+	46, // 17: shared.ClusterAdmin.Shutdown:input_type -> google.protobuf.Empty
+	4,  // 18: shared.KVStore.Put:input_type -> shared.IndexKVPair
+	4,  // 19: shared.KVStore.BatchPut:input_type -> shared.IndexKVPair
+	4,  // 20: shared.KVStore.Lookup:input_type -> shared.IndexKVPair
+	4,  // 21: shared.KVStore.BatchLookup:input_type -> shared.IndexKVPair
+	47, // 22: shared.KVStore.Items:input_type -> google.protobuf.StringValue
+	5,  // 23: shared.KVStore.PutStringEnum:input_type -> shared.StringEnum
+	45, // 24: shared.KVStore.DeleteIndicesWithPrefix:input_type -> shared.DeleteIndicesWithPrefixRequest
+	27, // 25: shared.KVStore.IndexInfo:input_type -> shared.IndexInfoRequest
+	47, // 26: shared.StringSearch.BatchIndex:input_type -> google.protobuf.StringValue
+	47, // 27: shared.StringSearch.Search:input_type -> google.protobuf.StringValue
+	47, // 28: shared.StringSearch.Reindex:input_type -> google.protobuf.StringValue
+	14, // 29: shared.BitmapIndex.Update:input_type -> shared.UpdateRequest
+	4,  // 30: shared.BitmapIndex.BatchMutate:input_type -> shared.IndexKVPair
+	13, // 31: shared.BitmapIndex.BulkClear:input_type -> shared.BulkClearRequest
+	6,  // 32: shared.BitmapIndex.Query:input_type -> shared.BitmapQuery
+	11, // 33: shared.BitmapIndex.Join:input_type -> shared.JoinRequest
+	29, // 34: shared.BitmapIndex.Projection:input_type -> shared.ProjectionRequest
+	33, // 35: shared.BitmapIndex.GroupBy:input_type -> shared.GroupByRequest
+	36, // 36: shared.BitmapIndex.TopK:input_type -> shared.TopKRequest
+	39, // 37: shared.BitmapIndex.CountDistinct:input_type -> shared.CountDistinctRequest
+	43, // 38: shared.BitmapIndex.CheckoutSequence:input_type -> shared.CheckoutSequenceRequest
+	8,  // 39: shared.BitmapIndex.TableOperation:input_type -> shared.TableOperationRequest
+	47, // 40: shared.BitmapIndex.Synchronize:input_type -> google.protobuf.StringValue
+	15, // 41: shared.BitmapIndex.SyncStatus:input_type -> shared.SyncStatusRequest
+	46, // 42: shared.BitmapIndex.Commit:input_type -> google.protobuf.Empty
+	17, // 43: shared.BitmapIndex.PartitionInfo:input_type -> shared.PartitionInfoRequest
+	20, // 44: shared.BitmapIndex.Decommission:input_type -> shared.DecommissionRequest
+	22, // 45: shared.BitmapIndex.Repair:input_type -> shared.RepairRequest
+	24, // 46: shared.BitmapIndex.RepairChecksums:input_type -> shared.RepairChecksumRequest
+	3,  // 47: shared.ClusterAdmin.Status:output_type -> shared.StatusMessage
+	46, // 48: shared.ClusterAdmin.Shutdown:output_type -> google.protobuf.Empty
+	46, // 49: shared.KVStore.Put:output_type -> google.protobuf.Empty
+	46, // 50: shared.KVStore.BatchPut:output_type -> google.protobuf.Empty
+	4,  // 51: shared.KVStore.Lookup:output_type -> shared.IndexKVPair
+	4,  // 52: shared.KVStore.BatchLookup:output_type -> shared.IndexKVPair
+	4,  // 53: shared.KVStore.Items:output_type -> shared.IndexKVPair
+	48, // 54: shared.KVStore.PutStringEnum:output_type -> google.protobuf.UInt64Value
+	46, // 55: shared.KVStore.DeleteIndicesWithPrefix:output_type -> google.protobuf.Empty
+	28, // 56: shared.KVStore.IndexInfo:output_type -> shared.IndexInfoResponse
+	46, // 57: shared.StringSearch.BatchIndex:output_type -> google.protobuf.Empty
+	48, // 58: shared.StringSearch.Search:output_type -> google.protobuf.UInt64Value
+	48, // 59: shared.StringSearch.Reindex:output_type -> google.protobuf.UInt64Value
+	46, // 60: shared.BitmapIndex.Update:output_type -> google.protobuf.Empty
+	46, // 61: shared.BitmapIndex.BatchMutate:output_type -> google.protobuf.Empty
+	46, // 62: shared.BitmapIndex.BulkClear:output_type -> google.protobuf.Empty
+	9,  // 63: shared.BitmapIndex.Query:output_type -> shared.QueryResult
+	12, // 64: shared.BitmapIndex.Join:output_type -> shared.JoinResponse
+	32, // 65: shared.BitmapIndex.Projection:output_type -> shared.ProjectionResponse
+	35, // 66: shared.BitmapIndex.GroupBy:output_type -> shared.GroupByResponse
+	38, // 67: shared.BitmapIndex.TopK:output_type -> shared.TopKResponse
+	41, // 68: shared.BitmapIndex.CountDistinct:output_type -> shared.CountDistinctResponse
+	44, // 69: shared.BitmapIndex.CheckoutSequence:output_type -> shared.CheckoutSequenceResponse
+	46, // 70: shared.BitmapIndex.TableOperation:output_type -> google.protobuf.Empty
+	49, // 71: shared.BitmapIndex.Synchronize:output_type -> google.protobuf.Int64Value
+	16, // 72: shared.BitmapIndex.SyncStatus:output_type -> shared.SyncStatusResponse
+	46, // 73: shared.BitmapIndex.Commit:output_type -> google.protobuf.Empty
+	19, // 74: shared.BitmapIndex.PartitionInfo:output_type -> shared.PartitionInfoResponse
+	21, // 75: shared.BitmapIndex.Decommission:output_type -> shared.DecommissionResponse
+	23, // 76: shared.BitmapIndex.Repair:output_type -> shared.RepairResponse
+	26, // 77: shared.BitmapIndex.RepairChecksums:output_type -> shared.RepairChecksumResponse
+	47, // [47:78] is the sub-list for method output_type
+	16, // [16:47] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_quanta_proto_init() }
@@ -2426,7 +4175,7 @@ func file_quanta_proto_init() {
 			}
 		}
 		file_quanta_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JoinRequest); i {
+			switch v := v.(*FragmentStats); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2438,7 +4187,7 @@ func file_quanta_proto_init() {
 			}
 		}
 		file_quanta_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*JoinResponse); i {
+			switch v := v.(*JoinRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2450,7 +4199,7 @@ func file_quanta_proto_init() {
 			}
 		}
 		file_quanta_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BulkClearRequest); i {
+			switch v := v.(*JoinResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2462,7 +4211,7 @@ func file_quanta_proto_init() {
 			}
 		}
 		file_quanta_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateRequest); i {
+			switch v := v.(*BulkClearRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2474,7 +4223,7 @@ func file_quanta_proto_init() {
 			}
 		}
 		file_quanta_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SyncStatusRequest); i {
+			switch v := v.(*UpdateRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2486,7 +4235,7 @@ func file_quanta_proto_init() {
 			}
 		}
 		file_quanta_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SyncStatusResponse); i {
+			switch v := v.(*SyncStatusRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2498,7 +4247,7 @@ func file_quanta_proto_init() {
 			}
 		}
 		file_quanta_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IndexInfoRequest); i {
+			switch v := v.(*SyncStatusResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2510,7 +4259,7 @@ func file_quanta_proto_init() {
 			}
 		}
 		file_quanta_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IndexInfoResponse); i {
+			switch v := v.(*PartitionInfoRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2522,7 +4271,7 @@ func file_quanta_proto_init() {
 			}
 		}
 		file_quanta_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProjectionRequest); i {
+			switch v := v.(*PartitionInfoResult); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2534,7 +4283,7 @@ func file_quanta_proto_init() {
 			}
 		}
 		file_quanta_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BitmapResult); i {
+			switch v := v.(*PartitionInfoResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2546,7 +4295,7 @@ func file_quanta_proto_init() {
 			}
 		}
 		file_quanta_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BSIResult); i {
+			switch v := v.(*DecommissionRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2558,7 +4307,7 @@ func file_quanta_proto_init() {
 			}
 		}
 		file_quanta_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProjectionResponse); i {
+			switch v := v.(*DecommissionResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2570,7 +4319,7 @@ func file_quanta_proto_init() {
 			}
 		}
 		file_quanta_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CheckoutSequenceRequest); i {
+			switch v := v.(*RepairRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2582,7 +4331,7 @@ func file_quanta_proto_init() {
 			}
 		}
 		file_quanta_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CheckoutSequenceResponse); i {
+			switch v := v.(*RepairResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2594,6 +4343,258 @@ func file_quanta_proto_init() {
 			}
 		}
 		file_quanta_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RepairChecksumRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_quanta_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShardChecksum); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_quanta_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RepairChecksumResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_quanta_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IndexInfoRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_quanta_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IndexInfoResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_quanta_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProjectionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_quanta_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BitmapResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_quanta_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BSIResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_quanta_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProjectionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_quanta_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GroupByRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_quanta_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GroupByResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_quanta_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GroupByResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_quanta_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TopKRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_quanta_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TopKResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_quanta_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TopKResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_quanta_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CountDistinctRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_quanta_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CountDistinctField); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_quanta_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CountDistinctResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_quanta_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CountDistinctResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_quanta_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckoutSequenceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_quanta_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckoutSequenceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_quanta_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DeleteIndicesWithPrefixRequest); i {
 			case 0:
 				return &v.state
@@ -2612,7 +4613,7 @@ func file_quanta_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_quanta_proto_rawDesc,
 			NumEnums:      3,
-			NumMessages:   22,
+			NumMessages:   43,
 			NumExtensions: 0,
 			NumServices:   4,
 		},
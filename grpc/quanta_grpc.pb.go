@@ -589,6 +589,7 @@ var KVStore_ServiceDesc = grpc.ServiceDesc{
 const (
 	StringSearch_BatchIndex_FullMethodName = "/shared.StringSearch/BatchIndex"
 	StringSearch_Search_FullMethodName     = "/shared.StringSearch/Search"
+	StringSearch_Reindex_FullMethodName    = "/shared.StringSearch/Reindex"
 )
 
 // StringSearchClient is the client API for StringSearch service.
@@ -597,6 +598,7 @@ const (
 type StringSearchClient interface {
 	BatchIndex(ctx context.Context, opts ...grpc.CallOption) (StringSearch_BatchIndexClient, error)
 	Search(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (StringSearch_SearchClient, error)
+	Reindex(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*wrapperspb.UInt64Value, error)
 }
 
 type stringSearchClient struct {
@@ -673,12 +675,22 @@ func (x *stringSearchSearchClient) Recv() (*wrapperspb.UInt64Value, error) {
 	return m, nil
 }
 
+func (c *stringSearchClient) Reindex(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*wrapperspb.UInt64Value, error) {
+	out := new(wrapperspb.UInt64Value)
+	err := c.cc.Invoke(ctx, StringSearch_Reindex_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StringSearchServer is the server API for StringSearch service.
 // All implementations should embed UnimplementedStringSearchServer
 // for forward compatibility
 type StringSearchServer interface {
 	BatchIndex(StringSearch_BatchIndexServer) error
 	Search(*wrapperspb.StringValue, StringSearch_SearchServer) error
+	Reindex(context.Context, *wrapperspb.StringValue) (*wrapperspb.UInt64Value, error)
 }
 
 // UnimplementedStringSearchServer should be embedded to have forward compatible implementations.
@@ -691,6 +703,9 @@ func (UnimplementedStringSearchServer) BatchIndex(StringSearch_BatchIndexServer)
 func (UnimplementedStringSearchServer) Search(*wrapperspb.StringValue, StringSearch_SearchServer) error {
 	return status.Errorf(codes.Unimplemented, "method Search not implemented")
 }
+func (UnimplementedStringSearchServer) Reindex(context.Context, *wrapperspb.StringValue) (*wrapperspb.UInt64Value, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reindex not implemented")
+}
 
 // UnsafeStringSearchServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to StringSearchServer will
@@ -750,13 +765,36 @@ func (x *stringSearchSearchServer) Send(m *wrapperspb.UInt64Value) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _StringSearch_Reindex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(wrapperspb.StringValue)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StringSearchServer).Reindex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StringSearch_Reindex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StringSearchServer).Reindex(ctx, req.(*wrapperspb.StringValue))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // StringSearch_ServiceDesc is the grpc.ServiceDesc for StringSearch service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var StringSearch_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "shared.StringSearch",
 	HandlerType: (*StringSearchServer)(nil),
-	Methods:     []grpc.MethodDesc{},
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Reindex",
+			Handler:    _StringSearch_Reindex_Handler,
+		},
+	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "BatchIndex",
@@ -779,11 +817,18 @@ const (
 	BitmapIndex_Query_FullMethodName            = "/shared.BitmapIndex/Query"
 	BitmapIndex_Join_FullMethodName             = "/shared.BitmapIndex/Join"
 	BitmapIndex_Projection_FullMethodName       = "/shared.BitmapIndex/Projection"
+	BitmapIndex_GroupBy_FullMethodName          = "/shared.BitmapIndex/GroupBy"
+	BitmapIndex_TopK_FullMethodName             = "/shared.BitmapIndex/TopK"
+	BitmapIndex_CountDistinct_FullMethodName    = "/shared.BitmapIndex/CountDistinct"
 	BitmapIndex_CheckoutSequence_FullMethodName = "/shared.BitmapIndex/CheckoutSequence"
 	BitmapIndex_TableOperation_FullMethodName   = "/shared.BitmapIndex/TableOperation"
 	BitmapIndex_Synchronize_FullMethodName      = "/shared.BitmapIndex/Synchronize"
 	BitmapIndex_SyncStatus_FullMethodName       = "/shared.BitmapIndex/SyncStatus"
 	BitmapIndex_Commit_FullMethodName           = "/shared.BitmapIndex/Commit"
+	BitmapIndex_PartitionInfo_FullMethodName    = "/shared.BitmapIndex/PartitionInfo"
+	BitmapIndex_Decommission_FullMethodName     = "/shared.BitmapIndex/Decommission"
+	BitmapIndex_Repair_FullMethodName           = "/shared.BitmapIndex/Repair"
+	BitmapIndex_RepairChecksums_FullMethodName  = "/shared.BitmapIndex/RepairChecksums"
 )
 
 // BitmapIndexClient is the client API for BitmapIndex service.
@@ -796,11 +841,18 @@ type BitmapIndexClient interface {
 	Query(ctx context.Context, in *BitmapQuery, opts ...grpc.CallOption) (*QueryResult, error)
 	Join(ctx context.Context, in *JoinRequest, opts ...grpc.CallOption) (*JoinResponse, error)
 	Projection(ctx context.Context, in *ProjectionRequest, opts ...grpc.CallOption) (*ProjectionResponse, error)
+	GroupBy(ctx context.Context, in *GroupByRequest, opts ...grpc.CallOption) (*GroupByResponse, error)
+	TopK(ctx context.Context, in *TopKRequest, opts ...grpc.CallOption) (*TopKResponse, error)
+	CountDistinct(ctx context.Context, in *CountDistinctRequest, opts ...grpc.CallOption) (*CountDistinctResponse, error)
 	CheckoutSequence(ctx context.Context, in *CheckoutSequenceRequest, opts ...grpc.CallOption) (*CheckoutSequenceResponse, error)
 	TableOperation(ctx context.Context, in *TableOperationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	Synchronize(ctx context.Context, in *wrapperspb.StringValue, opts ...grpc.CallOption) (*wrapperspb.Int64Value, error)
 	SyncStatus(ctx context.Context, in *SyncStatusRequest, opts ...grpc.CallOption) (*SyncStatusResponse, error)
 	Commit(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	PartitionInfo(ctx context.Context, in *PartitionInfoRequest, opts ...grpc.CallOption) (*PartitionInfoResponse, error)
+	Decommission(ctx context.Context, in *DecommissionRequest, opts ...grpc.CallOption) (*DecommissionResponse, error)
+	Repair(ctx context.Context, in *RepairRequest, opts ...grpc.CallOption) (*RepairResponse, error)
+	RepairChecksums(ctx context.Context, in *RepairChecksumRequest, opts ...grpc.CallOption) (*RepairChecksumResponse, error)
 }
 
 type bitmapIndexClient struct {
@@ -890,6 +942,33 @@ func (c *bitmapIndexClient) Projection(ctx context.Context, in *ProjectionReques
 	return out, nil
 }
 
+func (c *bitmapIndexClient) GroupBy(ctx context.Context, in *GroupByRequest, opts ...grpc.CallOption) (*GroupByResponse, error) {
+	out := new(GroupByResponse)
+	err := c.cc.Invoke(ctx, BitmapIndex_GroupBy_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bitmapIndexClient) TopK(ctx context.Context, in *TopKRequest, opts ...grpc.CallOption) (*TopKResponse, error) {
+	out := new(TopKResponse)
+	err := c.cc.Invoke(ctx, BitmapIndex_TopK_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bitmapIndexClient) CountDistinct(ctx context.Context, in *CountDistinctRequest, opts ...grpc.CallOption) (*CountDistinctResponse, error) {
+	out := new(CountDistinctResponse)
+	err := c.cc.Invoke(ctx, BitmapIndex_CountDistinct_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *bitmapIndexClient) CheckoutSequence(ctx context.Context, in *CheckoutSequenceRequest, opts ...grpc.CallOption) (*CheckoutSequenceResponse, error) {
 	out := new(CheckoutSequenceResponse)
 	err := c.cc.Invoke(ctx, BitmapIndex_CheckoutSequence_FullMethodName, in, out, opts...)
@@ -935,6 +1014,42 @@ func (c *bitmapIndexClient) Commit(ctx context.Context, in *emptypb.Empty, opts
 	return out, nil
 }
 
+func (c *bitmapIndexClient) PartitionInfo(ctx context.Context, in *PartitionInfoRequest, opts ...grpc.CallOption) (*PartitionInfoResponse, error) {
+	out := new(PartitionInfoResponse)
+	err := c.cc.Invoke(ctx, BitmapIndex_PartitionInfo_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bitmapIndexClient) Decommission(ctx context.Context, in *DecommissionRequest, opts ...grpc.CallOption) (*DecommissionResponse, error) {
+	out := new(DecommissionResponse)
+	err := c.cc.Invoke(ctx, BitmapIndex_Decommission_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bitmapIndexClient) Repair(ctx context.Context, in *RepairRequest, opts ...grpc.CallOption) (*RepairResponse, error) {
+	out := new(RepairResponse)
+	err := c.cc.Invoke(ctx, BitmapIndex_Repair_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bitmapIndexClient) RepairChecksums(ctx context.Context, in *RepairChecksumRequest, opts ...grpc.CallOption) (*RepairChecksumResponse, error) {
+	out := new(RepairChecksumResponse)
+	err := c.cc.Invoke(ctx, BitmapIndex_RepairChecksums_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BitmapIndexServer is the server API for BitmapIndex service.
 // All implementations should embed UnimplementedBitmapIndexServer
 // for forward compatibility
@@ -945,11 +1060,18 @@ type BitmapIndexServer interface {
 	Query(context.Context, *BitmapQuery) (*QueryResult, error)
 	Join(context.Context, *JoinRequest) (*JoinResponse, error)
 	Projection(context.Context, *ProjectionRequest) (*ProjectionResponse, error)
+	GroupBy(context.Context, *GroupByRequest) (*GroupByResponse, error)
+	TopK(context.Context, *TopKRequest) (*TopKResponse, error)
+	CountDistinct(context.Context, *CountDistinctRequest) (*CountDistinctResponse, error)
 	CheckoutSequence(context.Context, *CheckoutSequenceRequest) (*CheckoutSequenceResponse, error)
 	TableOperation(context.Context, *TableOperationRequest) (*emptypb.Empty, error)
 	Synchronize(context.Context, *wrapperspb.StringValue) (*wrapperspb.Int64Value, error)
 	SyncStatus(context.Context, *SyncStatusRequest) (*SyncStatusResponse, error)
 	Commit(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	PartitionInfo(context.Context, *PartitionInfoRequest) (*PartitionInfoResponse, error)
+	Decommission(context.Context, *DecommissionRequest) (*DecommissionResponse, error)
+	Repair(context.Context, *RepairRequest) (*RepairResponse, error)
+	RepairChecksums(context.Context, *RepairChecksumRequest) (*RepairChecksumResponse, error)
 }
 
 // UnimplementedBitmapIndexServer should be embedded to have forward compatible implementations.
@@ -974,6 +1096,15 @@ func (UnimplementedBitmapIndexServer) Join(context.Context, *JoinRequest) (*Join
 func (UnimplementedBitmapIndexServer) Projection(context.Context, *ProjectionRequest) (*ProjectionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Projection not implemented")
 }
+func (UnimplementedBitmapIndexServer) GroupBy(context.Context, *GroupByRequest) (*GroupByResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GroupBy not implemented")
+}
+func (UnimplementedBitmapIndexServer) TopK(context.Context, *TopKRequest) (*TopKResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TopK not implemented")
+}
+func (UnimplementedBitmapIndexServer) CountDistinct(context.Context, *CountDistinctRequest) (*CountDistinctResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountDistinct not implemented")
+}
 func (UnimplementedBitmapIndexServer) CheckoutSequence(context.Context, *CheckoutSequenceRequest) (*CheckoutSequenceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CheckoutSequence not implemented")
 }
@@ -989,6 +1120,18 @@ func (UnimplementedBitmapIndexServer) SyncStatus(context.Context, *SyncStatusReq
 func (UnimplementedBitmapIndexServer) Commit(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Commit not implemented")
 }
+func (UnimplementedBitmapIndexServer) PartitionInfo(context.Context, *PartitionInfoRequest) (*PartitionInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PartitionInfo not implemented")
+}
+func (UnimplementedBitmapIndexServer) Decommission(context.Context, *DecommissionRequest) (*DecommissionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Decommission not implemented")
+}
+func (UnimplementedBitmapIndexServer) Repair(context.Context, *RepairRequest) (*RepairResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Repair not implemented")
+}
+func (UnimplementedBitmapIndexServer) RepairChecksums(context.Context, *RepairChecksumRequest) (*RepairChecksumResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RepairChecksums not implemented")
+}
 
 // UnsafeBitmapIndexServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to BitmapIndexServer will
@@ -1117,6 +1260,60 @@ func _BitmapIndex_Projection_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BitmapIndex_GroupBy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupByRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BitmapIndexServer).GroupBy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BitmapIndex_GroupBy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BitmapIndexServer).GroupBy(ctx, req.(*GroupByRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BitmapIndex_TopK_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TopKRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BitmapIndexServer).TopK(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BitmapIndex_TopK_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BitmapIndexServer).TopK(ctx, req.(*TopKRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BitmapIndex_CountDistinct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountDistinctRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BitmapIndexServer).CountDistinct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BitmapIndex_CountDistinct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BitmapIndexServer).CountDistinct(ctx, req.(*CountDistinctRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BitmapIndex_CheckoutSequence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CheckoutSequenceRequest)
 	if err := dec(in); err != nil {
@@ -1207,6 +1404,78 @@ func _BitmapIndex_Commit_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BitmapIndex_PartitionInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PartitionInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BitmapIndexServer).PartitionInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BitmapIndex_PartitionInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BitmapIndexServer).PartitionInfo(ctx, req.(*PartitionInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BitmapIndex_Decommission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecommissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BitmapIndexServer).Decommission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BitmapIndex_Decommission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BitmapIndexServer).Decommission(ctx, req.(*DecommissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BitmapIndex_Repair_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RepairRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BitmapIndexServer).Repair(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BitmapIndex_Repair_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BitmapIndexServer).Repair(ctx, req.(*RepairRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BitmapIndex_RepairChecksums_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RepairChecksumRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BitmapIndexServer).RepairChecksums(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BitmapIndex_RepairChecksums_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BitmapIndexServer).RepairChecksums(ctx, req.(*RepairChecksumRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BitmapIndex_ServiceDesc is the grpc.ServiceDesc for BitmapIndex service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1234,6 +1503,18 @@ var BitmapIndex_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Projection",
 			Handler:    _BitmapIndex_Projection_Handler,
 		},
+		{
+			MethodName: "GroupBy",
+			Handler:    _BitmapIndex_GroupBy_Handler,
+		},
+		{
+			MethodName: "TopK",
+			Handler:    _BitmapIndex_TopK_Handler,
+		},
+		{
+			MethodName: "CountDistinct",
+			Handler:    _BitmapIndex_CountDistinct_Handler,
+		},
 		{
 			MethodName: "CheckoutSequence",
 			Handler:    _BitmapIndex_CheckoutSequence_Handler,
@@ -1254,6 +1535,22 @@ var BitmapIndex_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Commit",
 			Handler:    _BitmapIndex_Commit_Handler,
 		},
+		{
+			MethodName: "PartitionInfo",
+			Handler:    _BitmapIndex_PartitionInfo_Handler,
+		},
+		{
+			MethodName: "Decommission",
+			Handler:    _BitmapIndex_Decommission_Handler,
+		},
+		{
+			MethodName: "Repair",
+			Handler:    _BitmapIndex_Repair_Handler,
+		},
+		{
+			MethodName: "RepairChecksums",
+			Handler:    _BitmapIndex_RepairChecksums_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
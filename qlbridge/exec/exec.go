@@ -19,6 +19,7 @@ const (
 	WHERE_MAKER      = "UseWhere"
 	GROUPBY_MAKER    = "UseGroupBy"
 	PROJECTION_MAKER = "UseProjection"
+	ORDER_MAKER      = "UseOrder"
 )
 
 var (
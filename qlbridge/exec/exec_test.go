@@ -3,6 +3,7 @@ package exec_test
 import (
 	"database/sql"
 	"database/sql/driver"
+	"fmt"
 	"testing"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/disney/quanta/qlbridge/datasource/mockcsv"
 	td "github.com/disney/quanta/qlbridge/datasource/mockcsvtestdata"
 	"github.com/disney/quanta/qlbridge/exec"
+	"github.com/disney/quanta/qlbridge/plan"
 	"github.com/disney/quanta/qlbridge/schema"
 	"github.com/disney/quanta/qlbridge/testutil"
 )
@@ -514,3 +516,39 @@ func testSubselect(t *testing.T) {
 	assert.True(t, err == nil, "no error %v", err)
 	assert.True(t, len(msgs) == 1, "should have filtered out 2 messages")
 }
+
+type failingSink struct {
+	rows    int
+	cleanup bool
+}
+
+func (m *failingSink) Open(ctx *plan.Context, destination string, params map[string]interface{}) error {
+	return nil
+}
+func (m *failingSink) Next(dest []driver.Value, colIndex map[string]int) error {
+	m.rows++
+	if m.rows > 1 {
+		return fmt.Errorf("write failed")
+	}
+	return nil
+}
+func (m *failingSink) Cleanup() error { m.cleanup = true; return nil }
+func (m *failingSink) Close() error   { return nil }
+
+func TestIntoSinkError(t *testing.T) {
+
+	sink := &failingSink{}
+	exec.Register("failsink", func(ctx *plan.Context, dest string, params map[string]interface{}) (exec.Sink, error) {
+		return sink, nil
+	})
+
+	ctx := td.TestContext(`SELECT user_id INTO "failsink://out" FROM users`)
+	job, err := exec.BuildSqlJob(ctx)
+	assert.Nil(t, err)
+	err = job.Setup()
+	assert.Nil(t, err)
+	err = job.Run()
+	assert.NotNil(t, err)
+	assert.Equal(t, 2, sink.rows)
+	assert.True(t, sink.cleanup)
+}
@@ -259,7 +259,19 @@ func (m *JobExecutor) WalkGroupBy(p *plan.GroupBy) (Task, error) {
 	return tr, nil
 }
 func (m *JobExecutor) WalkOrder(p *plan.Order) (Task, error) {
-	return NewOrder(m.Ctx, p), nil
+
+	var tr TaskRunner
+	tr = NewOrder(m.Ctx, p)
+	if m.Ctx.Session != nil {
+		if v, ok := m.Ctx.Session.Get(ORDER_MAKER); ok {
+			if factory, ok2 := v.Value().(func(ctx *plan.Context, p *plan.Order) TaskRunner); !ok2 {
+				return nil, fmt.Errorf("Cannot cast [%T] to OrderMaker factory.", v.Value)
+			} else {
+				tr = factory(m.Ctx, p)
+			}
+		}
+	}
+	return tr, nil
 }
 func (m *JobExecutor) WalkInto(p *plan.Into) (Task, error) {
 	return NewInto(m.Ctx, p), nil
@@ -58,7 +58,7 @@ func (m *Into) Open(ctx *plan.Context, destination string) (err error) {
 		params = m.TaskBase.Ctx.Stmt.(*rel.SqlSelect).With
 	}
 
-	if url, err := url.Parse(destination); err == nil {
+	if url, err := url.Parse(destination); err == nil && url.Scheme != "" {
 		if newSink, ok := sinkFactories[url.Scheme]; !ok {
 			m := fmt.Sprintf("scheme [%s] not registered!", url.Scheme)
 			panic(m)
@@ -129,6 +129,7 @@ func (m *Into) Run() error {
 	}
 
 	var rowCount, lastMsgId int64
+	var sinkErr error
 
 msgReadLoop:
 	for {
@@ -151,7 +152,9 @@ msgReadLoop:
 				switch mt := msg.(type) {
 				case *datasource.SqlDriverMessageMap:
 					sdm = mt
-					m.sink.Next(sdm.Values(), m.colIndexes) // FIX: handle error return from Next()
+					if sinkErr = m.sink.Next(sdm.Values(), m.colIndexes); sinkErr != nil {
+						break msgReadLoop
+					}
 					rowCount++
 					lastMsgId = int64(mt.Id())
 				default:
@@ -165,13 +168,22 @@ msgReadLoop:
 					}
 
 					sdm = datasource.NewSqlDriverMessageMapCtx(msg.Id(), msgReader, m.colIndexes)
-					m.sink.Next(sdm.Values(), m.colIndexes) // FIX: handle error return from Next()
+					if sinkErr = m.sink.Next(sdm.Values(), m.colIndexes); sinkErr != nil {
+						break msgReadLoop
+					}
 					rowCount++
 					lastMsgId = int64(msg.Id())
 				}
 			}
 		}
 	}
+	// A failed write stops the statement, returning the error shuts down the upstream tasks.
+	if sinkErr != nil {
+		u.Errorf("Into sink write failed after %d rows - %v", rowCount, sinkErr)
+		m.sink.Cleanup()
+		close(m.complete)
+		return sinkErr
+	}
 	// u.Warnf("HERE 1 %#v, %p, LEN = %d", m.ErrChan(), m.ErrChan(), len(m.ErrChan()))
 errLoop:
 	for {
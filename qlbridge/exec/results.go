@@ -9,6 +9,7 @@ import (
 	"github.com/disney/quanta/qlbridge/datasource"
 	"github.com/disney/quanta/qlbridge/plan"
 	"github.com/disney/quanta/qlbridge/schema"
+	"github.com/disney/quanta/qlbridge/value"
 )
 
 const (
@@ -21,6 +22,12 @@ var (
 	// ensure our resultwriter implements database/sql/driver `driver.Rows`
 	_ driver.Rows = (*ResultWriter)(nil)
 
+	// and describes the column types of the result set
+	_ driver.RowsColumnTypeDatabaseTypeName = (*ResultWriter)(nil)
+	_ driver.RowsColumnTypeNullable         = (*ResultWriter)(nil)
+	_ driver.RowsColumnTypeLength           = (*ResultWriter)(nil)
+	_ driver.RowsColumnTypePrecisionScale   = (*ResultWriter)(nil)
+
 	// Ensure that we implement the Task Runner interface
 	// required for usage as tasks in Executor
 	_ TaskRunner = (*ResultExecWriter)(nil)
@@ -42,6 +49,7 @@ type (
 		*TaskBase
 		closed bool
 		cols   []string
+		fields []*schema.Field // Column type metadata, parallel to cols
 	}
 	// ResultBuffer for writing tasks results
 	ResultBuffer struct {
@@ -164,7 +172,13 @@ func (m *ResultBuffer) Close() error {
 
 // Next his is implementation of the sql/driver Rows() Next() interface
 func (m *ResultWriter) Next(dest []driver.Value) error {
+	var done <-chan struct{}
+	if m.Ctx != nil && m.Ctx.Context != nil {
+		done = m.Ctx.Done() // Canceled or timed out query
+	}
 	select {
+	case <-done:
+		return m.Ctx.Err()
 	case <-m.SigChan():
 		return ErrShuttingDown
 	case err := <-m.ErrChan():
@@ -203,6 +217,75 @@ func (m *ResultWriter) Columns() []string {
 	return m.cols
 }
 
+// ColumnTypeDatabaseTypeName type name of column, the native type of schema fields (if the source
+// provides one) otherwise the SQL type of the value type.
+func (m *ResultWriter) ColumnTypeDatabaseTypeName(index int) string {
+	f := m.field(index)
+	if f == nil {
+		return ""
+	}
+	if f.Data != "" {
+		return f.Data
+	}
+	return sqlTypeName(f.ValueType())
+}
+
+// ColumnTypeNullable can column contain nulls.
+func (m *ResultWriter) ColumnTypeNullable(index int) (nullable, ok bool) {
+	f := m.field(index)
+	if f == nil {
+		return false, false
+	}
+	return !f.NoNulls, true
+}
+
+// ColumnTypeLength maximum length of variable length columns.
+func (m *ResultWriter) ColumnTypeLength(index int) (length int64, ok bool) {
+	f := m.field(index)
+	if f == nil || f.Length == 0 {
+		return 0, false
+	}
+	return int64(f.Length), true
+}
+
+// ColumnTypePrecisionScale number of fractional digits of decimal and time columns.
+func (m *ResultWriter) ColumnTypePrecisionScale(index int) (precision, scale int64, ok bool) {
+	f := m.field(index)
+	if f == nil {
+		return 0, 0, false
+	}
+	s, found := f.Context[schema.FieldScaleKey].(int)
+	if !found {
+		return 0, 0, false
+	}
+	return int64(f.Length), int64(s), true
+}
+
+func (m *ResultWriter) field(index int) *schema.Field {
+	if index < 0 || index >= len(m.fields) {
+		return nil
+	}
+	return m.fields[index]
+}
+
+// sqlTypeName the SQL type name for a value type.
+func sqlTypeName(vt value.ValueType) string {
+	switch vt {
+	case value.IntType:
+		return "BIGINT"
+	case value.NumberType:
+		return "DOUBLE"
+	case value.BoolType:
+		return "BOOLEAN"
+	case value.TimeType:
+		return "DATETIME"
+	case value.JsonType:
+		return "JSON"
+	default:
+		return "VARCHAR"
+	}
+}
+
 func resultWrite(m *ResultWriter) MessageHandler {
 	out := m.MessageOut()
 	return func(ctx *plan.Context, msg schema.Message) bool {
@@ -257,11 +340,14 @@ func msgToRow(msg schema.Message, cols []string, dest []driver.Value) error {
 			if ok && val != nil && !val.Nil() {
 				dest[i] = val.Value()
 				//u.Infof("key=%v   val=%v", key, val)
+				continue
 			} else if val == nil {
 				u.Errorf("could not evaluate? %v  %#v", key, mt)
-			} else {
+			} else if !ok {
 				u.Warnf("missing value? %v %T %v", key, val.Value(), val.Value())
 			}
+			// dest is re-used across rows, don't leak the previous value
+			dest[i] = nil
 		}
 		//u.Debugf("got msg in row result writer: %#v", dest)
 	default:
@@ -2,6 +2,7 @@ package exec
 
 import (
 	"bytes"
+	"context"
 	"database/sql"
 	"database/sql/driver"
 	"fmt"
@@ -23,13 +24,15 @@ import (
 
 var (
 	// Ensure our driver implements appropriate database/sql interfaces
-	_ driver.Conn    = (*qlbConn)(nil)
-	_ driver.Driver  = (*qlbdriver)(nil)
-	_ driver.Execer  = (*qlbConn)(nil)
-	_ driver.Queryer = (*qlbConn)(nil)
-	_ driver.Result  = (*qlbResult)(nil)
-	_ driver.Rows    = (*qlbRows)(nil)
-	_ driver.Stmt    = (*qlbStmt)(nil)
+	_ driver.Conn             = (*qlbConn)(nil)
+	_ driver.Driver           = (*qlbdriver)(nil)
+	_ driver.Execer           = (*qlbConn)(nil)
+	_ driver.Queryer          = (*qlbConn)(nil)
+	_ driver.QueryerContext   = (*qlbConn)(nil)
+	_ driver.StmtQueryContext = (*qlbStmt)(nil)
+	_ driver.Result           = (*qlbResult)(nil)
+	_ driver.Rows             = (*qlbRows)(nil)
+	_ driver.Stmt             = (*qlbStmt)(nil)
 	//_ driver.Tx	  = (*driverConn)(nil)
 
 	// Create an instance of our driver
@@ -134,6 +137,18 @@ func (m *qlbConn) Query(query string, args []driver.Value) (driver.Rows, error)
 	return stmt.Query(args)
 }
 
+// QueryContext implementation, canceling ctx stops the query.
+func (m *qlbConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+
+	vals, err := namedValues(args)
+	if err != nil {
+		return nil, err
+	}
+	stmt := &qlbStmt{conn: m, query: query}
+	stmt.numInput = strings.Count(query, "?")
+	return stmt.runQuery(ctx, vals)
+}
+
 // Prepare returns a prepared statement, bound to this connection.
 func (m *qlbConn) Prepare(query string) (driver.Stmt, error) {
 
@@ -278,6 +293,22 @@ func (m *qlbStmt) Exec(args []driver.Value) (driver.Result, error) {
 
 // Query executes a query that may return rows, such as a SELECT
 func (m *qlbStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return m.runQuery(context.Background(), args)
+}
+
+// QueryContext executes a query that may return rows, canceling ctx stops the query.
+func (m *qlbStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+
+	vals, err := namedValues(args)
+	if err != nil {
+		return nil, err
+	}
+	return m.runQuery(ctx, vals)
+}
+
+// runQuery - The job tasks and the data sources see ctx as the context of the plan.  Once it is done the
+// result rows return its error and database/sql closes them which stops the tasks.
+func (m *qlbStmt) runQuery(goCtx context.Context, args []driver.Value) (driver.Rows, error) {
 
 	var err error
 	qry := m.query
@@ -291,6 +322,7 @@ func (m *qlbStmt) Query(args []driver.Value) (driver.Rows, error) {
 
 	// Create a Job, which is Dag of Tasks that Run()
 	ctx := plan.NewContext(qry)
+	ctx.Context = goCtx
 	ctx.Schema = m.conn.schema
 	ctx.Session = m.conn.session
 	job, err := BuildSqlJob(ctx)
@@ -303,7 +335,7 @@ func (m *qlbStmt) Query(args []driver.Value) (driver.Rows, error) {
 	// The only type of stmt that makes sense for Query is SELECT
 	//  and we need list of columns that requires casing
 	//sqlSelect, ok := job.Ctx.Stmt.(*rel.SqlSelect)
-	_, ok := job.Ctx.Stmt.(*rel.SqlSelect)
+	sqlSelect, ok := job.Ctx.Stmt.(*rel.SqlSelect)
 	if !ok {
 		u.Warnf("ctx? %v", job.Ctx)
 		return nil, fmt.Errorf("We could not recognize that as a select query: %T", job.Ctx.Stmt)
@@ -319,6 +351,7 @@ func (m *qlbStmt) Query(args []driver.Value) (driver.Rows, error) {
 		cols[i] = col.As
 	}
 	resultWriter := NewResultRows(ctx, cols)
+	resultWriter.fields = columnFields(ctx, sqlSelect, projCols)
 	job.RootTask.Add(resultWriter)
 	job.Setup()
 
@@ -340,6 +373,19 @@ func (m *qlbStmt) Query(args []driver.Value) (driver.Rows, error) {
 	return resultWriter, nil
 }
 
+// namedValues - Positional values of query arguments, named arguments are not supported.
+func namedValues(args []driver.NamedValue) ([]driver.Value, error) {
+
+	vals := make([]driver.Value, len(args))
+	for i, arg := range args {
+		if arg.Name != "" {
+			return nil, fmt.Errorf("named argument %s is not supported", arg.Name)
+		}
+		vals[i] = arg.Value
+	}
+	return vals, nil
+}
+
 // driver.ColumnConverter Interface implementation.
 //
 // ColumnConverter may be optionally implemented by driver.Stmt if the
@@ -557,3 +603,61 @@ func argsToValueColumns(vals []driver.Value) []*rel.ValueColumn {
 	}
 	return row
 }
+
+// columnFields resolve the schema fields of the projected columns.  Functions are
+// described by their return type, other expressions by the value type of the projection.
+func columnFields(ctx *plan.Context, stmt *rel.SqlSelect, projCols rel.ResultColumns) []*schema.Field {
+
+	tables := make([]*schema.Table, 0, len(stmt.From))
+	for _, from := range stmt.From {
+		if ctx.Schema == nil {
+			break
+		}
+		tbl, err := ctx.Schema.Table(strings.ToLower(from.SourceName()))
+		if err != nil || tbl == nil {
+			continue
+		}
+		tables = append(tables, tbl)
+	}
+
+	fieldMap := make(map[string]*schema.Field)
+	for _, col := range stmt.Columns {
+		if col.Star {
+			for _, tbl := range tables {
+				for _, f := range tbl.Fields {
+					if _, found := fieldMap[f.Name]; !found {
+						fieldMap[f.Name] = f
+					}
+				}
+			}
+			continue
+		}
+		switch n := col.Expr.(type) {
+		case *expr.IdentityNode:
+			for _, tbl := range tables {
+				if f, found := tbl.FieldMap[col.SourceField]; found {
+					fieldMap[col.As] = f
+					break
+				}
+			}
+		case *expr.FuncNode:
+			// The projection carries the type of the field the function is applied to
+			if n.F.CustomFunc == nil {
+				continue
+			}
+			if vt := n.F.Type(); vt != value.UnknownType && vt != value.ValueInterfaceType {
+				fieldMap[col.As] = schema.NewFieldBase(col.As, vt, 0, "")
+			}
+		}
+	}
+
+	fields := make([]*schema.Field, len(projCols))
+	for i, col := range projCols {
+		if f, found := fieldMap[col.As]; found {
+			fields[i] = f
+			continue
+		}
+		fields[i] = schema.NewFieldBase(col.As, col.Type, 0, "")
+	}
+	return fields
+}
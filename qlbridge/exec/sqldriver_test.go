@@ -379,3 +379,25 @@ func XXTestSqlDbConnFailure(t *testing.T) {
 	assert.True(t, uo1.Price == 22.5, "? %#v", uo1)
 	rows2.Close()
 }
+
+func TestSqlDriverColumnTypes(t *testing.T) {
+
+	db, err := sql.Open("qlbridge", "mockcsv")
+	assert.True(t, err == nil, "no error: %v", err)
+	defer db.Close()
+
+	rows, err := db.Query("select user_id, referral_count, yy(reg_date) AS yr FROM users")
+	assert.True(t, err == nil, "no error: %v", err)
+	defer rows.Close()
+	types, err := rows.ColumnTypes()
+	assert.True(t, err == nil, "no error: %v", err)
+	assert.Equal(t, 3, len(types))
+	assert.Equal(t, "VARCHAR", types[0].DatabaseTypeName())
+	assert.Equal(t, "BIGINT", types[1].DatabaseTypeName())
+	assert.Equal(t, "yr", types[2].Name())
+	assert.Equal(t, "BIGINT", types[2].DatabaseTypeName())
+	nullable, ok := types[0].Nullable()
+	assert.True(t, ok && nullable)
+	for rows.Next() {
+	}
+}
@@ -146,6 +146,70 @@ func (m *Count) Validate(n *expr.FuncNode) (expr.EvaluatorFunc, error) {
 	return incrementEval, nil
 }
 
+// ApproxCountDistinct Estimated count of distinct values with an optional
+// precision (sketch size).  The estimate is computed by the data source, per row
+// it behaves the same as count().
+//
+//	approx_count_distinct(field)       =>  1, true
+//	approx_count_distinct(field, 12)   =>  1, true
+type ApproxCountDistinct struct{}
+
+// Type is Integer
+func (m *ApproxCountDistinct) Type() value.ValueType { return value.IntType }
+func (m *ApproxCountDistinct) IsAgg() bool           { return true }
+
+func (m *ApproxCountDistinct) Validate(n *expr.FuncNode) (expr.EvaluatorFunc, error) {
+	if len(n.Args) < 1 || len(n.Args) > 2 {
+		return nil, fmt.Errorf("Expected 1 or 2 args for approx_count_distinct(arg, [precision]) but got %s", n)
+	}
+	return incrementEval, nil
+}
+
+// Percentile Exact (nearest rank) percentile of a numeric field where the
+// percentile is a fraction between 0 and 1.  The percentile is computed by the
+// data source, per row it returns the value.
+//
+//	percentile(latency, 0.95)   =>  latency, true
+type Percentile struct{}
+
+// Type is NumberType
+func (m *Percentile) Type() value.ValueType { return value.NumberType }
+func (m *Percentile) IsAgg() bool           { return true }
+
+func (m *Percentile) Validate(n *expr.FuncNode) (expr.EvaluatorFunc, error) {
+	if len(n.Args) != 2 {
+		return nil, fmt.Errorf("Expected 2 args for percentile(arg, fraction) but got %s", n)
+	}
+	return numberEval, nil
+}
+
+// Median Median (50th percentile) of a numeric field.  The median is computed by
+// the data source, per row it returns the value.
+//
+//	median(latency)   =>  latency, true
+type Median struct{}
+
+// Type is NumberType
+func (m *Median) Type() value.ValueType { return value.NumberType }
+func (m *Median) IsAgg() bool           { return true }
+
+func (m *Median) Validate(n *expr.FuncNode) (expr.EvaluatorFunc, error) {
+	if len(n.Args) != 1 {
+		return nil, fmt.Errorf("Expected 1 arg for median(arg) but got %s", n)
+	}
+	return numberEval, nil
+}
+
+func numberEval(ctx expr.EvalContext, vals []value.Value) (value.Value, bool) {
+	if vals[0] == nil || vals[0].Err() || vals[0].Nil() {
+		return value.NumberNaNValue, false
+	}
+	if fv, ok := value.ValueToFloat64(vals[0]); ok {
+		return value.NewNumberValue(fv), true
+	}
+	return value.NumberNaNValue, false
+}
+
 func incrementEval(ctx expr.EvalContext, vals []value.Value) (value.Value, bool) {
 	if vals[0] == nil || vals[0].Err() || vals[0].Nil() {
 		return value.NewIntValue(0), false
@@ -27,8 +27,11 @@ func LoadAllBuiltins() {
 
 		// aggregate ops
 		expr.FuncAdd("count", &Count{})
+		expr.FuncAdd("approx_count_distinct", &ApproxCountDistinct{})
 		expr.FuncAdd("avg", &Avg{})
 		expr.FuncAdd("sum", &Sum{})
+		expr.FuncAdd("percentile", &Percentile{})
+		expr.FuncAdd("median", &Median{})
 
 		// logical
 		expr.FuncAdd("gt", &Gt{})
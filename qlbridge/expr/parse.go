@@ -734,6 +734,14 @@ func (t *tree) Func(depth int, funcTok lex.Token) (fn *FuncNode) {
 				lastComma = true
 				t.Next()
 				continue
+			case lex.TokenIdentity:
+				if len(fn.Args) == 0 && strings.EqualFold(firstToken.V, "distinct") &&
+					t.Peek().T == lex.TokenIdentity {
+					// COUNT(DISTINCT x) is the same as COUNT(DISTINCT(x))
+					node = t.distinctArg(depth + 1)
+				} else {
+					node = t.O(depth + 1)
+				}
 			default:
 				node = t.O(depth + 1)
 			}
@@ -776,6 +784,22 @@ func (t *tree) Func(depth int, funcTok lex.Token) (fn *FuncNode) {
 	}
 }
 
+// distinctArg parses the argument of a function qualified with DISTINCT, i.e. COUNT(DISTINCT x)
+// and wraps it in a distinct() function node.
+func (t *tree) distinctArg(depth int) Node {
+	distinctTok := t.Next() // Consume DISTINCT
+	funcImpl, ok := t.getFunction(distinctTok.V)
+	if !ok {
+		funcImpl = Func{Name: "distinct", Eval: EmptyEvalFunc}
+	}
+	fn := NewFuncNode("distinct", funcImpl)
+	fn.Missing = !ok
+	if node := t.O(depth + 1); node != nil {
+		fn.append(node)
+	}
+	return fn
+}
+
 // get Function from Global function registry.
 func (t *tree) getFunction(name string) (fn Func, ok bool) {
 	if t.fr != nil {
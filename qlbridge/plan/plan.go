@@ -274,6 +274,11 @@ func WalkStmt(ctx *Context, stmt rel.SqlStatement, planner Planner) (Task, error
 	base := NewPlanBase(false)
 	switch st := stmt.(type) {
 	case *rel.SqlSelect:
+		if ctx.Schema != nil {
+			if err := RewriteViews(st, ctx.Schema.Table); err != nil {
+				return nil, err
+			}
+		}
 		p = &Select{Stmt: st, PlanBase: base, Ctx: ctx}
 	case *rel.SqlInsert:
 		p = &Insert{Stmt: st, PlanBase: base}
@@ -9,6 +9,7 @@ import (
 	"github.com/disney/quanta/qlbridge/expr"
 	"github.com/disney/quanta/qlbridge/lex"
 	"github.com/disney/quanta/qlbridge/rel"
+	"github.com/disney/quanta/qlbridge/schema"
 	"github.com/disney/quanta/qlbridge/value"
 )
 
@@ -243,3 +244,203 @@ func RewriteDescribeAsSelect(stmt *rel.SqlDescribe, ctx *Context) (*rel.SqlSelec
 	s := &rel.SqlShow{ShowType: "columns", Identity: stmt.Identity, Raw: stmt.Raw}
 	return RewriteShowAsSelect(s, ctx)
 }
+
+// RewriteViews Rewrite the views in the FROM clause of a SELECT statement as queries on
+// their base tables.  The view predicate is ANDed with the WHERE clause, * is expanded to
+// the view columns and references to columns that are not part of a view are rejected.
+//   - SELECT * FROM v WHERE x = 1  ==>  SELECT a, b FROM t AS v WHERE x = 1 AND (view predicate)
+func RewriteViews(stmt *rel.SqlSelect, table func(name string) (*schema.Table, error)) error {
+
+	views := make(map[string]*schema.Table) // alias -> view
+	for _, from := range stmt.From {
+		if from.Name == "" {
+			continue
+		}
+		tbl, err := table(from.SourceName())
+		if err != nil || tbl == nil || tbl.IsViewOf == "" {
+			continue
+		}
+		if from.Alias == "" {
+			from.Alias = from.SourceName()
+		}
+		views[strings.ToLower(from.Alias)] = tbl
+	}
+	if stmt.Where != nil && stmt.Where.Source != nil {
+		if len(views) > 0 {
+			return fmt.Errorf("views are not supported in statements with sub-queries")
+		}
+		for _, from := range stmt.Where.Source.From {
+			if tbl, err := table(from.SourceName()); err == nil && tbl != nil && tbl.IsViewOf != "" {
+				return fmt.Errorf("views are not supported in statements with sub-queries")
+			}
+		}
+	}
+	if len(views) == 0 {
+		return nil
+	}
+
+	// Column aliases may only be referenced by ORDER BY and HAVING
+	colAliases := make(map[string]struct{})
+	for _, col := range stmt.Columns {
+		if col.As != "" && col.As != col.SourceField {
+			colAliases[col.As] = struct{}{}
+		}
+	}
+	check := func(n expr.Node, aliases map[string]struct{}) error {
+		if n == nil {
+			return nil
+		}
+		for _, id := range expr.FindAllIdentities(n) {
+			if err := checkViewColumn(stmt, views, aliases, id, table); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	nodes := make([]expr.Node, 0)
+	for _, col := range stmt.Columns {
+		if !col.Star {
+			nodes = append(nodes, col.Expr)
+		}
+	}
+	if stmt.Where != nil {
+		nodes = append(nodes, stmt.Where.Expr)
+	}
+	for _, from := range stmt.From {
+		nodes = append(nodes, from.JoinExpr)
+	}
+	for _, col := range stmt.GroupBy {
+		nodes = append(nodes, col.Expr)
+	}
+	for _, n := range nodes {
+		if err := check(n, nil); err != nil {
+			return err
+		}
+	}
+	nodes = []expr.Node{stmt.Having}
+	for _, col := range stmt.OrderBy {
+		nodes = append(nodes, col.Expr)
+	}
+	for _, n := range nodes {
+		if err := check(n, colAliases); err != nil {
+			return err
+		}
+	}
+
+	if err := expandViewStar(stmt, views, table); err != nil {
+		return err
+	}
+
+	for _, from := range stmt.From {
+		tbl, isView := views[strings.ToLower(from.Alias)]
+		if !isView || from.Name == "" {
+			continue
+		}
+		from.View = tbl.Name
+		from.Name = tbl.IsViewOf
+		if tbl.DefaultPredicate == "" {
+			continue
+		}
+		pred, err := expr.ParseExpression(tbl.DefaultPredicate)
+		if err != nil {
+			return fmt.Errorf("invalid predicate for view %s - %v", tbl.Name, err)
+		}
+		if len(stmt.From) > 1 {
+			// Qualify the view columns so that the predicate is pushed down to the base table.
+			for _, id := range expr.FindAllIdentities(pred) {
+				if _, _, hasLeft := id.LeftRight(); !hasLeft && !id.IsBooleanIdentity() {
+					*id = *expr.NewIdentityNodeVal(from.Alias + "." + id.Text)
+				}
+			}
+		}
+		if stmt.Where == nil || stmt.Where.Expr == nil {
+			stmt.Where = &rel.SqlWhere{Expr: pred}
+			continue
+		}
+		for _, n := range []expr.Node{stmt.Where.Expr, pred} {
+			if bn, ok := n.(*expr.BinaryNode); ok {
+				bn.Paren = true
+			}
+		}
+		stmt.Where.Expr = expr.NewBinaryNode(lex.Token{T: lex.TokenLogicAnd, V: "AND"}, stmt.Where.Expr, pred)
+	}
+	return nil
+}
+
+// checkViewColumn Verify that a column reference to a view is one of the view columns.  Unqualified
+// columns in a join are rejected if they belong to the base table of a view but not to the view.
+func checkViewColumn(stmt *rel.SqlSelect, views map[string]*schema.Table, colAliases map[string]struct{},
+	id *expr.IdentityNode, table func(name string) (*schema.Table, error)) error {
+
+	if id.IsBooleanIdentity() || id.Text == "*" || strings.HasPrefix(id.Text, "@") {
+		return nil
+	}
+	left, right, hasLeft := id.LeftRight()
+	if hasLeft {
+		if tbl, isView := views[strings.ToLower(left)]; isView && right != "*" && !tbl.HasField(right) {
+			return fmt.Errorf("column %s is not part of view %s", right, tbl.Name)
+		}
+		return nil
+	}
+	if _, isAlias := colAliases[right]; isAlias {
+		return nil
+	}
+	for _, tbl := range views {
+		if tbl.HasField(right) {
+			continue
+		}
+		if len(stmt.From) == 1 {
+			return fmt.Errorf("column %s is not part of view %s", right, tbl.Name)
+		}
+		if base, err := table(tbl.IsViewOf); err == nil && base != nil && base.HasField(right) {
+			return fmt.Errorf("column %s is not part of view %s", right, tbl.Name)
+		}
+	}
+	return nil
+}
+
+// expandViewStar Replace * and <view>.* with the view columns, for joins * is expanded for all tables
+// so that the base table columns hidden by a view are not selected.
+func expandViewStar(stmt *rel.SqlSelect, views map[string]*schema.Table,
+	table func(name string) (*schema.Table, error)) error {
+
+	cols := make(rel.Columns, 0, len(stmt.Columns))
+	for _, col := range stmt.Columns {
+		left, right, hasLeft := col.LeftRight()
+		switch {
+		case col.Star && len(stmt.From) == 1:
+			for _, name := range views[strings.ToLower(stmt.From[0].Alias)].Columns() {
+				cols = append(cols, rel.NewColumn(name))
+			}
+		case col.Star:
+			for _, from := range stmt.From {
+				tbl, isView := views[strings.ToLower(from.Alias)]
+				if !isView {
+					var err error
+					if tbl, err = table(from.SourceName()); err != nil {
+						return err
+					}
+				}
+				alias := from.Alias
+				if alias == "" {
+					alias = from.SourceName()
+				}
+				for _, name := range tbl.Columns() {
+					cols = append(cols, rel.NewColumn(alias+"."+name))
+				}
+			}
+		case hasLeft && right == "*" && views[strings.ToLower(left)] != nil:
+			for _, name := range views[strings.ToLower(left)].Columns() {
+				cols = append(cols, rel.NewColumn(left+"."+name))
+			}
+		default:
+			cols = append(cols, col)
+		}
+	}
+	for i, col := range cols {
+		col.Index = i
+	}
+	stmt.Columns = cols
+	stmt.Star = false
+	return nil
+}
@@ -1 +1,80 @@
 package plan_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/disney/quanta/qlbridge/plan"
+	"github.com/disney/quanta/qlbridge/rel"
+	"github.com/disney/quanta/qlbridge/schema"
+	"github.com/disney/quanta/qlbridge/value"
+)
+
+func TestRewriteViews(t *testing.T) {
+
+	tables := make(map[string]*schema.Table)
+	addTable := func(name, viewOf, predicate string, cols ...string) {
+		tbl := schema.NewTable(name)
+		tbl.IsViewOf, tbl.DefaultPredicate = viewOf, predicate
+		for _, c := range cols {
+			tbl.AddFieldType(c, value.StringType)
+		}
+		tbl.SetColumns(cols)
+		tables[name] = tbl
+	}
+	addTable("orders", "", "", "id", "status", "region", "total")
+	addTable("customers", "", "", "id", "name")
+	addTable("open_orders", "orders", "status = \"open\"", "id", "total")
+	lookup := func(name string) (*schema.Table, error) {
+		if tbl, ok := tables[name]; ok {
+			return tbl, nil
+		}
+		return nil, fmt.Errorf("Could not find that table: %v", name)
+	}
+	rewrite := func(sql string) (*rel.SqlSelect, error) {
+		sel, err := rel.ParseSqlSelect(sql)
+		require.NoError(t, err)
+		return sel, plan.RewriteViews(sel, lookup)
+	}
+
+	sel, err := rewrite("SELECT * FROM open_orders WHERE total > 10 OR id = 1")
+	require.NoError(t, err)
+	assert.Equal(t, "orders", sel.From[0].Name)
+	assert.Equal(t, "open_orders", sel.From[0].Alias)
+	assert.Equal(t, "open_orders", sel.From[0].View)
+	assert.Equal(t, []string{"id", "total"}, sel.Columns.FieldNames())
+	assert.Equal(t, `(total > 10 OR id = 1) AND (status = "open")`, sel.Where.Expr.String())
+
+	sel, err = rewrite("SELECT o.id, c.name FROM open_orders AS o INNER JOIN customers AS c ON o.id = c.id")
+	require.NoError(t, err)
+	assert.Equal(t, "orders", sel.From[0].Name)
+	assert.Equal(t, `o.status = "open"`, sel.Where.Expr.String())
+
+	sel, err = rewrite("SELECT id, sum(total) AS amount FROM open_orders GROUP BY id ORDER BY amount")
+	require.NoError(t, err)
+	assert.Equal(t, `status = "open"`, sel.Where.Expr.String())
+	_, err = rewrite("SELECT id, sum(total) AS amount FROM open_orders GROUP BY id HAVING amount > 10")
+	require.NoError(t, err)
+
+	sel, err = rewrite("SELECT id FROM orders WHERE status = 'open'")
+	require.NoError(t, err)
+	assert.Equal(t, "", sel.From[0].Alias)
+
+	for _, sql := range []string{
+		"SELECT status FROM open_orders",
+		"SELECT sum(total) FROM open_orders GROUP BY region",
+		"SELECT id FROM open_orders WHERE region = 'west'",
+		"SELECT id AS region FROM open_orders WHERE region = 'west'",
+		"SELECT id AS region FROM open_orders GROUP BY region",
+		"SELECT id FROM open_orders ORDER BY status",
+		"SELECT o.region FROM open_orders AS o INNER JOIN customers AS c ON o.id = c.id",
+		"SELECT region FROM open_orders AS o INNER JOIN customers AS c ON o.id = c.id",
+		"SELECT id FROM customers WHERE id IN (SELECT id FROM open_orders)",
+	} {
+		_, err := rewrite(sql)
+		assert.Error(t, err, sql)
+	}
+}
@@ -274,6 +274,7 @@ func TestSqlParseOnly(t *testing.T) {
 	parseSqlTest(t, `select director, year from movies where director like 'Quentin'`)
 	parseSqlTest(t, `select director, year from movies where !exists(user_id) OR toint(not_a_field) > 21`)
 	parseSqlTest(t, `select count(*) from user;   `)
+	parseSqlTest(t, `select count(DISTINCT user_id) AS users, approx_count_distinct(email, 12) from user`)
 	parseSqlTest(t, `select name from movies where director IN ("Quentin","copola","Bay","another")`)
 	parseSqlTest(t, `select id, name from users LIMIT 100 OFFSET 1000`)
 	parseSqlTest(t, `SELECT count(*), email FROM users WHERE emaildomain(email) = "gmail.com" GROUP BY email WITH distributed = true;`)
@@ -123,6 +123,7 @@ type (
 		Name        string             // From Name (optional, empty if join, subselect)
 		Alias       string             // From name aliased
 		Schema      string             //  FROM `schema`.`table`
+		View        string             // View name if Name was rewritten to the base table of a view
 		Op          lex.TokenType      // In, =, ON
 		LeftOrRight lex.TokenType      // Left, Right
 		JoinType    lex.TokenType      // INNER, OUTER
@@ -60,6 +60,8 @@ const (
 	NoNulls = false
 	// AllowNulls ?
 	AllowNulls = true
+	// FieldScaleKey Field context key for the number of fractional digits of decimal and time fields.
+	FieldScaleKey = "scale"
 )
 
 type (
@@ -111,7 +113,9 @@ type (
 		cols           []string               // array of column names
 		lastRefreshed  time.Time              // Last time we refreshed this schema
 		rows           [][]driver.Value
-		IsViewOf       string
+		IsViewOf       string // Base table of a view
+		// DefaultPredicate is the WHERE clause of a view, it is ANDed with the WHERE clause of queries on the view.
+		DefaultPredicate string
 	}
 
 	// Field Describes the column info, name, data type, defaults, index, null
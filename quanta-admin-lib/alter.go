@@ -0,0 +1,45 @@
+package admin
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/disney/quanta/core"
+	"github.com/disney/quanta/shared"
+)
+
+// AlterCmd - Alter table command
+type AlterCmd struct {
+	Table   string   `arg:"" name:"table" help:"Table name."`
+	Clause  []string `arg:"" name:"clause" help:"ADD|DROP|MODIFY [COLUMN] <column> [<type>] [option=value ...]"`
+	Confirm bool     `help:"Confirm deployment."`
+}
+
+// Run - Alter table implementation
+func (c *AlterCmd) Run(ctx *Context) error {
+
+	stmt, err := shared.ParseAlterTable(fmt.Sprintf("ALTER TABLE %s %s", c.Table, strings.Join(c.Clause, " ")))
+	if err != nil {
+		return err
+	}
+
+	conn := shared.GetClientConnection(ctx.ConsulAddr, ctx.Port, "admin-alter")
+	defer conn.Disconnect()
+	changes, err := core.AlterTable(conn.Consul, shared.NewBitmapIndex(conn), stmt, !c.Confirm)
+	if err != nil {
+		return fmt.Errorf("cannot alter table %s - %v", c.Table, err)
+	}
+	if len(changes) == 0 {
+		fmt.Printf("No differences detected for table %s.\n", c.Table)
+		return nil
+	}
+	fmt.Println("Changes:")
+	for _, change := range changes {
+		fmt.Printf("    -> %v\n", change)
+	}
+	if !c.Confirm {
+		return fmt.Errorf("if you wish to deploy the changes then re-run with --confirm flag")
+	}
+	fmt.Printf("Successfully altered table %s\n", c.Table)
+	return nil
+}
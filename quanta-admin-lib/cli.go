@@ -1,19 +1,24 @@
 package admin
 
 var Cli struct {
-	ConsulAddr  string         `default:"127.0.0.1:8500"`
-	Port        int            `default:"4000"`
-	Debug       bool           `default:"false"`
-	Create      CreateCmd      `cmd:"" help:"Create table."`
-	Drop        DropCmd        `cmd:"" help:"Drop table."`
-	Truncate    TruncateCmd    `cmd:"" help:"Truncate table."`
-	Status      StatusCmd      `cmd:"" help:"Show status."`
-	Version     VersionCmd     `cmd:"" help:"Show version."`
-	Tables      TablesCmd      `cmd:"" help:"Show tables."`
-	Shutdown    ShutdownCmd    `cmd:"" help:"Shutdown cluster or one node."`
-	FindKey     FindKeyCmd     `cmd:"" help:"Find nodes for key debug tool."`
-	Config      ConfigCmd      `cmd:"" help:"Configuration key/value pair."`
-	Verify      VerifyCmd      `cmd:"" help:"Verify data for key debug tool."`
-	VerifyEnum  VerifyEnumCmd  `cmd:"" help:"Verify a string enum for key debug tool."`
-	VerifyIndex VerifyIndexCmd `cmd:"" help:"Verify indices debug tool."`
+	ConsulAddr   string          `default:"127.0.0.1:8500"`
+	Port         int             `default:"4000"`
+	Debug        bool            `default:"false"`
+	Create       CreateCmd       `cmd:"" help:"Create table."`
+	Drop         DropCmd         `cmd:"" help:"Drop table."`
+	Alter        AlterCmd        `cmd:"" help:"Add, drop or modify table columns."`
+	Truncate     TruncateCmd     `cmd:"" help:"Truncate table."`
+	Status       StatusCmd       `cmd:"" help:"Show status."`
+	Version      VersionCmd      `cmd:"" help:"Show version."`
+	Tables       TablesCmd       `cmd:"" help:"Show tables."`
+	Shutdown     ShutdownCmd     `cmd:"" help:"Shutdown cluster or one node."`
+	Decommission DecommissionCmd `cmd:"" help:"Move the data of a node to the remaining nodes and remove it."`
+	Repair       RepairCmd       `cmd:"" help:"Exchange differences between replicas."`
+	FindKey      FindKeyCmd      `cmd:"" help:"Find nodes for key debug tool."`
+	Config       ConfigCmd       `cmd:"" help:"Configuration key/value pair."`
+	Verify       VerifyCmd       `cmd:"" help:"Verify data for key debug tool."`
+	VerifyEnum   VerifyEnumCmd   `cmd:"" help:"Verify a string enum for key debug tool."`
+	VerifyIndex  VerifyIndexCmd  `cmd:"" help:"Verify indices debug tool."`
+	Reindex      ReindexCmd      `cmd:"" help:"Rebuild the search index for searchable fields."`
+	Retention    RetentionCmd    `cmd:"" help:"Dry run listing of partitions that would be purged by the table retention policy."`
 }
@@ -2,7 +2,6 @@ package admin
 
 import (
 	"fmt"
-	"strings"
 	"time"
 
 	u "github.com/araddon/gou"
@@ -13,13 +12,12 @@ import (
 
 // CreateCmd - Create command
 type CreateCmd struct {
-	Table     string `arg:"" name:"table" help:"Table name."`
-	SchemaDir string `help:"Base directory containing schema files." default:"config"`
-	Confirm   bool   `help:"Confirm deployment."`
+	Table      string `arg:"" name:"table" help:"Table name."`
+	SchemaDir  string `help:"Base directory containing schema files." default:"config"`
+	Confirm    bool   `help:"Confirm deployment."`
+	AllowDrops bool   `help:"Allow attributes to be dropped (data for dropped attributes is purged)."`
 }
 
-const DropAttributesAllowed = false
-
 // Run - Create command implementation
 func (c *CreateCmd) Run(ctx *Context) error {
 
@@ -35,12 +33,8 @@ func (c *CreateCmd) Run(ctx *Context) error {
 		return fmt.Errorf("Error loading schema %v", err3)
 	}
 	// let's check the types of the fields
-	for i := 0; i < len(table.Attributes); i++ {
-		u.Info(table.Attributes[i].FieldName, " ", table.Attributes[i].Type)
-		typ := shared.TypeFromString(table.Attributes[i].Type)
-		if typ == shared.NotDefined && table.Attributes[i].MappingStrategy != "ChildRelation" {
-			return fmt.Errorf("unknown type %s for field %s", table.Attributes[i].Type, table.Attributes[i].FieldName)
-		}
+	if err := table.CheckAttributeTypes(); err != nil {
+		return err
 	}
 
 	// Check if the table already exists, if not deploy and verify.  Else, compare and verify.
@@ -69,23 +63,13 @@ func (c *CreateCmd) Run(ctx *Context) error {
 		if err5 != nil {
 			return fmt.Errorf("Error loading schema from consul %v", err5)
 		}
-		ok2, warnings, err6 := table2.Compare(table)
+		compare := table2.Compare
+		if c.AllowDrops {
+			compare = table2.CompareAllowDrops
+		}
+		ok2, warnings, err6 := compare(table)
 		if err6 != nil {
-			if DropAttributesAllowed { // if drop attributes is allowed
-				str := fmt.Sprintf("%v", err6)
-				if strings.HasPrefix(str, "attribute cannot be dropped:") {
-					u.Infof("Warnings: %v\n", err6)
-					// do reverse compare to get dropped attributes TODO:
-					ok2, warnings, err6 = table.Compare(table2)
-					if err6 != nil {
-						return fmt.Errorf("error comparing deployed table reverse %v", err6)
-					}
-				} else {
-					return fmt.Errorf("error comparing deployed table %v", err6)
-				}
-			} else {
-				return fmt.Errorf("error comparing deployed table %v", err6)
-			}
+			return fmt.Errorf("error comparing deployed table %v", err6)
 		}
 		if ok2 {
 			u.Infof("Table already exists.  No differences detected.\n")
@@ -100,8 +84,6 @@ func (c *CreateCmd) Run(ctx *Context) error {
 			}
 			return fmt.Errorf("if you wish to deploy the changes then re-run with --confirm flag")
 		}
-		// delete attributes dropped ?
-
 		err = performCreate(consulClient, table, ctx.Port)
 		if err != nil {
 			return fmt.Errorf("errors during performCreate (table exists): %v", err)
@@ -114,12 +96,6 @@ func (c *CreateCmd) Run(ctx *Context) error {
 
 func performCreate(consul *api.Client, table *shared.BasicTable, port int) error {
 
-	lock, errx := shared.Lock(consul, "admin-tool", "admin-tool")
-	if errx != nil {
-		return errx
-	}
-	defer shared.Unlock(consul, lock)
-
 	u.Infof("Connecting to Quanta services at port: [%d] ...\n", port)
 	conn := shared.NewDefaultConnection("performCreate")
 	defer conn.Disconnect()
@@ -129,38 +105,5 @@ func performCreate(consul *api.Client, table *shared.BasicTable, port int) error
 		u.Log(u.FATAL, err)
 	}
 	services := shared.NewBitmapIndex(conn)
-
-	err := shared.DeleteTable(consul, table.Name)
-	if err != nil {
-		return fmt.Errorf("DeleteTable error %v", err)
-	}
-
-	// Go ahead and update Consul
-	err = shared.UpdateModTimeForTable(consul, table.Name)
-	if err != nil {
-		return fmt.Errorf("updateModTimeForTable  error %v", err)
-	}
-	err = shared.MarshalConsul(table, consul)
-	if err != nil {
-		return fmt.Errorf("Error marshalling table %v", err)
-	}
-
-	// Verify table persistence.  Read schema back from Consul and compare.
-	table2, err1 := shared.LoadSchema("", table.Name, consul)
-	if err1 != nil {
-		return fmt.Errorf("Error loading schema from consul %v", err1)
-	}
-	ok, warnings, err2 := table2.Compare(table)
-	if err2 != nil {
-		return fmt.Errorf("error comparing deployed table %v", err2)
-	}
-	if !ok {
-		fmt.Printf("HERE Warnings:\n")
-		for _, warning := range warnings {
-			fmt.Printf("    -> %v\n", warning)
-		}
-		return fmt.Errorf("differences detected with deployed table %v", table.Name)
-	}
-
-	return services.TableOperation(table.Name, "deploy")
+	return shared.DeployTable(consul, services, table)
 }
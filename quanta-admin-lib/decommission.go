@@ -0,0 +1,82 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	pb "github.com/disney/quanta/grpc"
+	"github.com/disney/quanta/shared"
+	"github.com/golang/protobuf/ptypes/empty"
+)
+
+// DecommissionCmd - Decommission command
+type DecommissionCmd struct {
+	Node     string        `arg:"" name:"node" help:"Node ID or IP address of node to decommission."`
+	Interval time.Duration `help:"Progress polling interval." default:"5s"`
+}
+
+// Run - Decommission command implementation.  The node pushes its shards to their new owners and is then shut
+// down, which removes it from Consul.  Re-running the command after an interruption resumes the process.
+func (c *DecommissionCmd) Run(ctx *Context) error {
+
+	conn := shared.GetClientConnection(ctx.ConsulAddr, ctx.Port, "admin-decommission")
+	defer conn.Disconnect()
+
+	ci, err := conn.GetClientIndexForNodeID(c.Node)
+	if err != nil {
+		ci = -1
+		for i, cc := range conn.ClientConnections() {
+			if strings.HasPrefix(cc.Target(), c.Node+":") {
+				ci = i
+				break
+			}
+		}
+	}
+	if ci < 0 {
+		return fmt.Errorf("node %s not found", c.Node)
+	}
+	target := conn.ClientConnections()[ci].Target()
+	client := shared.NewBitmapIndex(conn).Client(ci)
+
+	req := &pb.DecommissionRequest{Start: true}
+	for {
+		cx, cancel := context.WithTimeout(context.Background(), shared.Deadline)
+		res, err := client.Decommission(cx, req)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("%v.Decommission(_) = _, %v, node = %s", client, err, target)
+		}
+		fmt.Printf("Node %s %s: %d of %d shards pushed, %d skipped.\n", target, res.NodeState, res.Pushed,
+			res.Shards, res.Skipped)
+		if res.Error != "" {
+			return fmt.Errorf("decommission of %s failed, re-run to resume - %s", target, res.Error)
+		}
+		if res.Complete {
+			break
+		}
+		req.Start = false
+		time.Sleep(c.Interval)
+	}
+
+	cx, cancel := context.WithTimeout(context.Background(), shared.Deadline)
+	defer cancel()
+	if _, err := conn.Admin[ci].Shutdown(cx, &empty.Empty{}); err != nil {
+		return fmt.Errorf("shutdown of %s failed, re-run to retry - %v", target, err)
+	}
+
+	// The cluster is now one node smaller.
+	size, err := shared.GetClusterSizeTarget(conn.Consul)
+	if err != nil {
+		return err
+	}
+	if size > 1 {
+		if err := shared.SetClusterSizeTarget(conn.Consul, size-1); err != nil {
+			return err
+		}
+		fmt.Printf("Cluster size target set to %d.\n", size-1)
+	}
+	fmt.Printf("Node %s decommissioned.\n", target)
+	return nil
+}
@@ -3,7 +3,6 @@ package admin
 import (
 	"fmt"
 	"log"
-	"time"
 
 	"github.com/disney/quanta/shared"
 	"github.com/hashicorp/consul/api"
@@ -27,36 +26,18 @@ func (c *DropCmd) Run(ctx *Context) error {
 	if ctx.Debug {
 		fmt.Println("Checking for child dependencies.")
 	}
-	if err = checkForChildDependencies(consulClient, c.Table, "drop"); err != nil {
+	if err = shared.CheckForChildDependencies(consulClient, c.Table, "drop"); err != nil {
 		return err
 	}
 
-	if ctx.Debug {
-		fmt.Println("Acquiring distributed lock.")
-	}
-	lock, errx := shared.Lock(consulClient, "admin-tool", "admin-tool")
-	if errx != nil {
-		return errx
-	}
-	defer shared.Unlock(consulClient, lock)
-
-	if ctx.Debug {
-		fmt.Println("Calling DeleteTable to remove table from consul and notify upstream clients.")
-	}
-
-	err = shared.DeleteTable(consulClient, c.Table)
-	if err != nil {
-		return fmt.Errorf("DeleteTable error %v", err)
-	}
-
-	// Give consumers time to flush and restart.
-	time.Sleep(time.Second * 5)
+	conn := connectServices(consulClient, ctx.Port, "drop")
+	defer conn.Disconnect()
 
 	if ctx.Debug {
-		fmt.Println("Calling nukeData.")
+		fmt.Println("Calling DropTable to remove table from consul and purge data.")
 	}
-
-	err = nukeData(consulClient, ctx.Port, c.Table, "drop", false)
+	err = shared.DropTable(consulClient, shared.NewBitmapIndex(conn), shared.NewKVStore(conn), c.Table,
+		shared.ConsumerFlushWait)
 	if err != nil {
 		return err
 	}
@@ -65,52 +46,14 @@ func (c *DropCmd) Run(ctx *Context) error {
 	return nil
 }
 
-func checkForChildDependencies(consul *api.Client, tableName, operation string) error {
-
-	ok, errx := shared.TableExists(consul, tableName)
-	if errx != nil {
-		return fmt.Errorf("tableExists error %v", errx)
-	}
-	if !ok {
-		return fmt.Errorf("table %s doesn't exist", tableName)
-	}
-	dependencies, err := shared.CheckChildRelation(consul, tableName)
-	if err != nil {
-		return fmt.Errorf("checkChildRelation  error %v", err)
-	}
-	if len(dependencies) > 0 {
-		fmt.Printf("Dependencies:\n")
-		for _, dep := range dependencies {
-			fmt.Printf("    -> %v\n", dep)
-		}
-		return fmt.Errorf("cannot %s table with dependencies", operation)
-	}
-	return nil
-}
-
-func nukeData(consul *api.Client, port int, tableName, operation string, dropEnums bool) error {
+func connectServices(consul *api.Client, port int, name string) *shared.Conn {
 
 	fmt.Printf("Connecting to Quanta services at port: [%d] ...\n", port)
-	conn := shared.NewDefaultConnection("drop")
-	defer conn.Disconnect()
+	conn := shared.NewDefaultConnection(name)
 	conn.ServicePort = port
 	conn.Quorum = 3
 	if err := conn.Connect(consul); err != nil {
 		log.Fatal(err)
 	}
-	services := shared.NewBitmapIndex(conn)
-	kvStore := shared.NewKVStore(conn)
-	err := services.TableOperation(tableName, operation)
-	if err != nil {
-		return fmt.Errorf("TableOperation error %v", err)
-	}
-	retainEnums := true
-	if dropEnums || operation == "drop" {
-		retainEnums = false
-	}
-	err = kvStore.DeleteIndicesWithPrefix(tableName, retainEnums)
-	if err != nil {
-		return fmt.Errorf("DeleteIndicesWithPrefix error %v", err)
-	}
-	return nil
+	return conn
 }
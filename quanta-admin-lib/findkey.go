@@ -56,6 +56,7 @@ func (f *FindKeyCmd) Run(ctx *Context) error {
 
 	fmt.Println("")
 	fmt.Printf("KEY = %s\n", key)
+	fmt.Printf("REPLICAS = %d\n", conn.GetReplicas(f.Table))
 	cx, cancel := context.WithTimeout(context.Background(), shared.Deadline)
 	defer cancel()
 	// Not realy intending to write here but we want all qualifying nodes.
@@ -94,6 +95,9 @@ func (f *FindKeyCmd) Run(ctx *Context) error {
 		}
 		fmt.Printf("%-7d   %-16s   %-11s    %-8s      %11d   %s\n", i+1, ip, nodeState, status, card, modTime)
 	}
+	if len(indices) < conn.GetReplicas(f.Table) {
+		fmt.Printf("\nOnly %d of %d replicas are available.\n", len(indices), conn.GetReplicas(f.Table))
+	}
 	fmt.Println("")
 	return nil
 }
@@ -0,0 +1,41 @@
+package admin
+
+import (
+	"fmt"
+
+	"github.com/disney/quanta/shared"
+)
+
+// ReindexCmd - Rebuild search index command
+type ReindexCmd struct {
+	Table string `arg:"" name:"table" help:"Table name."`
+}
+
+// Run - Reindex command implementation
+func (c *ReindexCmd) Run(ctx *Context) error {
+
+	conn := shared.GetClientConnection(ctx.ConsulAddr, ctx.Port, "reindex")
+	defer conn.Disconnect()
+	table, err := shared.LoadSchema("", c.Table, conn.Consul)
+	if err != nil {
+		return fmt.Errorf("Error loading table %s - %v", c.Table, err)
+	}
+	searchable := 0
+	for _, v := range table.Attributes {
+		if v.Searchable {
+			searchable++
+		}
+	}
+	if searchable == 0 {
+		return fmt.Errorf("table %s has no searchable fields", c.Table)
+	}
+
+	search := shared.NewStringSearch(conn, 1000)
+	count, err := search.Reindex(c.Table)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Successfully re-indexed %d values for %d searchable fields of table %s\n", count,
+		searchable, c.Table)
+	return nil
+}
@@ -0,0 +1,76 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "github.com/disney/quanta/grpc"
+	"github.com/disney/quanta/shared"
+)
+
+// RepairCmd - Repair command
+type RepairCmd struct {
+	Table    string        `help:"Only repair the shards of this table."`
+	Interval time.Duration `help:"Progress polling interval." default:"5s"`
+}
+
+// Run - Repair command implementation.  Every node compares the shards it shares with its peers and exchanges
+// the differences.  Nodes repair in the background on their own every few hours.
+func (c *RepairCmd) Run(ctx *Context) error {
+
+	conn := shared.GetClientConnection(ctx.ConsulAddr, ctx.Port, "admin-repair")
+	defer conn.Disconnect()
+
+	if c.Table != "" {
+		if _, err := shared.LoadSchema("", c.Table, conn.Consul); err != nil {
+			return fmt.Errorf("cannot load table %s - %v", c.Table, err)
+		}
+	}
+
+	bitmap := shared.NewBitmapIndex(conn)
+	running := make(map[int]bool)
+	var failed bool
+	for ci, cc := range conn.ClientConnections() {
+		cx, cancel := context.WithTimeout(context.Background(), shared.Deadline)
+		res, err := bitmap.Client(ci).Repair(cx, &pb.RepairRequest{Start: true, Index: c.Table})
+		cancel()
+		if err != nil {
+			fmt.Printf("Node %s repair not started - %v\n", cc.Target(), err)
+			failed = true
+			continue
+		}
+		running[ci] = res.Running
+	}
+
+	for len(running) > 0 {
+		time.Sleep(c.Interval)
+		for ci := range running {
+			target := conn.ClientConnections()[ci].Target()
+			cx, cancel := context.WithTimeout(context.Background(), shared.Deadline)
+			res, err := bitmap.Client(ci).Repair(cx, &pb.RepairRequest{})
+			cancel()
+			if err != nil {
+				fmt.Printf("Node %s repair status failed - %v\n", target, err)
+				failed = true
+				delete(running, ci)
+				continue
+			}
+			if res.Running {
+				continue
+			}
+			delete(running, ci)
+			fmt.Printf("Node %s: %d shards compared, %d mismatched, %d differences pushed, %d merged.\n", target,
+				res.Shards, res.Mismatched, res.Pushed, res.Merged)
+			if res.Error != "" {
+				fmt.Printf("Node %s repair failed - %s\n", target, res.Error)
+				failed = true
+			}
+		}
+	}
+	if failed {
+		return fmt.Errorf("repair did not complete on all nodes")
+	}
+	fmt.Println("Repair complete.")
+	return nil
+}
@@ -0,0 +1,125 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/disney/quanta/core"
+	pb "github.com/disney/quanta/grpc"
+	"github.com/disney/quanta/shared"
+)
+
+// RetentionCmd - Retention dry run command
+type RetentionCmd struct {
+	Table     string `arg:"" name:"table" help:"Table name."`
+	Retention string `help:"Retention period override (i.e. 90d, 12w, 36h)."`
+}
+
+// Run - Retention dry run implementation.  Lists expired partitions across the cluster, nothing is purged.
+func (c *RetentionCmd) Run(ctx *Context) error {
+
+	conn := shared.GetClientConnection(ctx.ConsulAddr, ctx.Port, "admin-retention")
+	defer conn.Disconnect()
+	table, err := shared.LoadSchema("", c.Table, conn.Consul)
+	if err != nil {
+		return fmt.Errorf("Error loading table %s - %v", c.Table, err)
+	}
+	if c.Retention != "" {
+		table.Retention = c.Retention
+	}
+	if table.TimeQuantumType == "" {
+		return fmt.Errorf("Table %s is not time partitioned", c.Table)
+	}
+	cutoff, err := table.RetentionCutoff(time.Now())
+	if err != nil {
+		return err
+	}
+	if cutoff.IsZero() {
+		return fmt.Errorf("Table %s does not have a retention policy, use --retention to specify one", c.Table)
+	}
+
+	// Partitions are replicated, aggregate replicas by key
+	type entry struct {
+		field    string
+		rowID    int64
+		ts       int64
+		archived bool
+		bytes    uint64
+		replicas int
+	}
+	entries := make(map[string]*entry)
+	bitClient := shared.NewBitmapIndex(conn)
+	req := &pb.PartitionInfoRequest{Index: c.Table, Time: cutoff.UnixNano()}
+	for i := range conn.ClientConnections() {
+		cx, cancel := context.WithTimeout(context.Background(), shared.Deadline)
+		res, err := bitClient.Client(i).PartitionInfo(cx, req)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("%v.PartitionInfo(_) = _, %v, node = %s", bitClient.Client(i), err,
+				conn.ClientConnections()[i].Target())
+		}
+		for _, p := range res.PartitionInfo {
+			if p.TqType == "" {
+				continue
+			}
+			key := fmt.Sprintf("%s/%d/%d/%v", p.Field, p.RowIdOrBits, p.Time, p.Archived)
+			e, found := entries[key]
+			if !found {
+				e = &entry{field: p.Field, rowID: p.RowIdOrBits, ts: p.Time, archived: p.Archived}
+				entries[key] = e
+			}
+			e.bytes += p.Bytes
+			e.replicas++
+		}
+	}
+
+	list := make([]*entry, 0, len(entries))
+	for _, e := range entries {
+		list = append(list, e)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].ts != list[j].ts {
+			return list[i].ts < list[j].ts
+		}
+		if list[i].field != list[j].field {
+			return list[i].field < list[j].field
+		}
+		if list[i].rowID != list[j].rowID {
+			return list[i].rowID < list[j].rowID
+		}
+		return !list[i].archived
+	})
+
+	action := shared.RetentionArchive
+	if table.IsRetentionDelete() {
+		action = shared.RetentionDelete
+	}
+	fmt.Println()
+	fmt.Printf("Table %s, retention %s, action %s, cutoff %s\n", c.Table, table.Retention, action,
+		cutoff.Format(time.RFC3339))
+	fmt.Println()
+	fmt.Println("TIME                  FIELD                            ROW ID   ARCHIVED   REPLICAS       SIZE")
+	fmt.Println("===================   ==============================   ======   ========   ========   ========")
+	var total uint64
+	var purged int
+	for _, e := range list {
+		// Archived partitions are retained unless the action is delete
+		if e.archived && !table.IsRetentionDelete() {
+			continue
+		}
+		rowID := "BSI"
+		if e.rowID >= 0 {
+			rowID = fmt.Sprintf("%d", e.rowID)
+		}
+		fmt.Printf("%-19s   %-30s   %6s   %-8v   %8d   %8s\n", time.Unix(0, e.ts).UTC().Format("2006-01-02T15:04"),
+			e.field, rowID, e.archived, e.replicas, core.Bytes(e.bytes))
+		total += e.bytes
+		purged++
+	}
+	fmt.Println()
+	fmt.Printf("%d partitions, %s would be purged (dry run).\n", purged, core.Bytes(total))
+	fmt.Println()
+	return nil
+}
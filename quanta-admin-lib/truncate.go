@@ -2,7 +2,6 @@ package admin
 
 import (
 	"fmt"
-	"time"
 
 	"github.com/disney/quanta/shared"
 	"github.com/hashicorp/consul/api"
@@ -25,25 +24,15 @@ func (c *TruncateCmd) Run(ctx *Context) error {
 		return fmt.Errorf("Error connecting to consul %v", err)
 	}
 
-	if err = checkForChildDependencies(consulClient, c.Table, "truncate"); err != nil && !c.Force {
+	if err = shared.CheckForChildDependencies(consulClient, c.Table, "truncate"); err != nil && !c.Force {
 		return err
 	}
 
-	lock, errx := shared.Lock(consulClient, "admin-tool", "admin-tool")
-	if errx != nil {
-		return errx
-	}
-	defer shared.Unlock(consulClient, lock)
-
-	err = shared.UpdateModTimeForTable(consulClient, c.Table)
-	if err != nil {
-		return fmt.Errorf("updateModTimeForTable  error %v", err)
-	}
-
-	// Give consumers time to flush and restart.
-	time.Sleep(time.Second * 5)
+	conn := connectServices(consulClient, ctx.Port, "truncate")
+	defer conn.Disconnect()
 
-	err = nukeData(consulClient, ctx.Port, c.Table, "truncate", c.DropEnums)
+	err = shared.TruncateTable(consulClient, shared.NewBitmapIndex(conn), shared.NewKVStore(conn), c.Table,
+		c.DropEnums, shared.ConsumerFlushWait)
 	if err != nil {
 		return err
 	}
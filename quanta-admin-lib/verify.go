@@ -57,6 +57,7 @@ func (f *VerifyCmd) Run(ctx *Context) error {
 
 	fmt.Println("")
 	fmt.Printf("KEY = %s\n", key)
+	fmt.Printf("REPLICAS = %d\n", conn.GetReplicas(f.Table))
 	cx, cancel := context.WithTimeout(context.Background(), shared.Deadline)
 	defer cancel()
 
@@ -0,0 +1,239 @@
+package q_kafka_lib
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/disney/quanta/core"
+	"github.com/disney/quanta/qlbridge/expr"
+	"github.com/disney/quanta/shared"
+	"github.com/hamba/avro/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/confluentinc/confluent-kafka-go.v1/kafka"
+)
+
+// mockBroker - In-process broker, cancels the consumer once all messages have been read.
+type mockBroker struct {
+	lock     sync.Mutex
+	messages []*kafka.Message
+	commits  [][]kafka.TopicPartition
+	cancel   context.CancelFunc
+}
+
+func (b *mockBroker) SubscribeTopics(topics []string, rebalanceCb kafka.RebalanceCb) error {
+	return nil
+}
+
+func (b *mockBroker) ReadMessage(timeout time.Duration) (*kafka.Message, error) {
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if len(b.messages) == 0 {
+		b.cancel()
+		return nil, kafka.NewError(kafka.ErrTimedOut, "timed out", false)
+	}
+	msg := b.messages[0]
+	b.messages = b.messages[1:]
+	return msg, nil
+}
+
+func (b *mockBroker) CommitOffsets(offsets []kafka.TopicPartition) ([]kafka.TopicPartition, error) {
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.commits = append(b.commits, offsets)
+	return offsets, nil
+}
+
+func (b *mockBroker) Close() error {
+	return nil
+}
+
+func (b *mockBroker) committed() map[int32]kafka.Offset {
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	out := make(map[int32]kafka.Offset)
+	for _, c := range b.commits {
+		for _, tp := range c {
+			out[tp.Partition] = tp.Offset
+		}
+	}
+	return out
+}
+
+// mockWriter - Captures rows written to a table.
+type mockWriter struct {
+	lock     sync.Mutex
+	rows     map[string][]map[string]interface{}
+	flushErr error
+	putErr   func(row map[string]interface{}) error
+}
+
+func (w *mockWriter) PutRow(name string, row interface{}, providedColID uint64, ignoreSourcePath,
+	useNerd bool) error {
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.putErr != nil {
+		if err := w.putErr(row.(map[string]interface{})); err != nil {
+			return err
+		}
+	}
+	w.rows[name] = append(w.rows[name], row.(map[string]interface{}))
+	return nil
+}
+
+func (w *mockWriter) Flush() error {
+	return w.flushErr
+}
+
+func (w *mockWriter) CloseSession() error {
+	return nil
+}
+
+func newTestMain(t *testing.T, broker *mockBroker, writer *mockWriter, tables map[string]string) *Main {
+
+	m := NewMain()
+	m.Topics = []string{"events"}
+	m.ShardCount = 3
+	m.CommitIntervalMs = 60000
+	m.Consumer = broker
+	m.OpenWriter = func(tableName string) (Writer, error) {
+		return writer, nil
+	}
+	for name, selector := range tables {
+		node, err := expr.ParseExpression(selector)
+		require.NoError(t, err)
+		m.tableCache.TableCache[name] = &core.Table{
+			BasicTable:         &shared.BasicTable{Name: name},
+			SelectorNode:       node,
+			SelectorIdentities: expr.FindAllIdentityField(node),
+		}
+	}
+	return m
+}
+
+func testMessage(partition int32, offset int64, key string, value []byte) *kafka.Message {
+
+	topic := "events"
+	return &kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: partition, Offset: kafka.Offset(offset)},
+		Key:            []byte(key),
+		Value:          value,
+	}
+}
+
+func runToCompletion(t *testing.T, m *Main, broker *mockBroker) error {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	broker.cancel = cancel
+	done := make(chan error, 1)
+	go func() {
+		done <- m.Run(ctx)
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(10 * time.Second):
+		t.Fatal("consumer did not stop")
+	}
+	return nil
+}
+
+func TestJSONRoutingAndCommit(t *testing.T) {
+
+	broker := &mockBroker{}
+	for i := 0; i < 10; i++ {
+		typ := "order"
+		if i%2 == 1 {
+			typ = "refund"
+		}
+		payload := fmt.Sprintf(`{"type": "%s", "id": %d}`, typ, i)
+		broker.messages = append(broker.messages, testMessage(int32(i%2), int64(100+i), fmt.Sprint(i),
+			[]byte(payload)))
+	}
+	broker.messages = append(broker.messages, testMessage(0, 110, "x", []byte(`{"type": "other"}`)))
+	broker.messages = append(broker.messages, testMessage(1, 111, "y", []byte(`not json`)))
+
+	writer := &mockWriter{rows: make(map[string][]map[string]interface{})}
+	m := newTestMain(t, broker, writer, map[string]string{
+		"orders":  `type == "order"`,
+		"refunds": `type == "refund"`,
+	})
+	require.NoError(t, runToCompletion(t, m, broker))
+
+	assert.Len(t, writer.rows["orders"], 5)
+	assert.Len(t, writer.rows["refunds"], 5)
+	assert.Equal(t, int64(12), m.TotalRecs.Get())
+	assert.Equal(t, int64(10), m.processedRecs.Get())
+	assert.Equal(t, int64(12), m.committedRecs.Get())
+	// Unmatched and undecodable messages are still committed.
+	assert.Equal(t, map[int32]kafka.Offset{0: 111, 1: 112}, broker.committed())
+}
+
+func TestFlushFailureDoesNotCommit(t *testing.T) {
+
+	broker := &mockBroker{}
+	broker.messages = append(broker.messages, testMessage(0, 5, "a", []byte(`{"type": "order"}`)))
+
+	writer := &mockWriter{rows: make(map[string][]map[string]interface{}),
+		flushErr: fmt.Errorf("server unavailable")}
+	m := newTestMain(t, broker, writer, map[string]string{"orders": `type == "order"`})
+	err := runToCompletion(t, m, broker)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "server unavailable")
+	assert.Empty(t, broker.committed())
+	assert.Equal(t, int64(0), m.committedRecs.Get())
+}
+
+func TestBadRecordIsSkipped(t *testing.T) {
+
+	broker := &mockBroker{}
+	for i := 0; i < 3; i++ {
+		payload := fmt.Sprintf(`{"type": "order", "id": %d}`, i)
+		broker.messages = append(broker.messages, testMessage(0, int64(20+i), "a", []byte(payload)))
+	}
+
+	writer := &mockWriter{rows: make(map[string][]map[string]interface{})}
+	writer.putErr = func(row map[string]interface{}) error {
+		if row["id"] == float64(1) {
+			return fmt.Errorf("invalid value")
+		}
+		return nil
+	}
+	m := newTestMain(t, broker, writer, map[string]string{"orders": `type == "order"`})
+	require.NoError(t, runToCompletion(t, m, broker))
+
+	assert.Len(t, writer.rows["orders"], 2)
+	assert.Equal(t, int64(2), m.processedRecs.Get())
+	assert.Equal(t, int64(1), m.errorCount.Get())
+	// The offset advances past the bad record.
+	assert.Equal(t, map[int32]kafka.Offset{0: 23}, broker.committed())
+}
+
+func TestAvroDecoding(t *testing.T) {
+
+	schema := avro.MustParse(`{"type": "record", "name": "order", "fields": [
+		{"name": "type", "type": "string"}, {"name": "amount", "type": "long"}]}`)
+	payload, err := avro.Marshal(schema, map[string]interface{}{"type": "order", "amount": int64(42)})
+	require.NoError(t, err)
+
+	broker := &mockBroker{}
+	broker.messages = append(broker.messages, testMessage(2, 7, "a", payload))
+
+	writer := &mockWriter{rows: make(map[string][]map[string]interface{})}
+	m := newTestMain(t, broker, writer, map[string]string{"orders": `type == "order"`})
+	m.IsAvro = true
+	m.ShardKey = "amount"
+	m.tableCache.TableCache["orders"].AvroSchema = schema
+	require.NoError(t, runToCompletion(t, m, broker))
+
+	require.Len(t, writer.rows["orders"], 1)
+	assert.Equal(t, int64(42), writer.rows["orders"][0]["amount"])
+	assert.Equal(t, map[int32]kafka.Offset{2: 8}, broker.committed())
+}
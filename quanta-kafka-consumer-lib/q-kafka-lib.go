@@ -0,0 +1,557 @@
+package q_kafka_lib
+
+//
+// Kafka consumer.  Messages are decoded (JSON or Avro), routed to a table by evaluating the table
+// selector expressions and then sent to a worker channel selected by the shard key.  Offsets are
+// committed manually, only after every worker has successfully flushed its sessions.  This gives
+// at-least-once delivery.
+//
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	u "github.com/araddon/gou"
+	"github.com/disney/quanta/core"
+	"github.com/disney/quanta/qlbridge/datasource"
+	"github.com/disney/quanta/qlbridge/expr"
+	"github.com/disney/quanta/qlbridge/value"
+	"github.com/disney/quanta/qlbridge/vm"
+	"github.com/disney/quanta/shared"
+	"github.com/hamba/avro/v2"
+	"github.com/hashicorp/consul/api"
+	"github.com/stvp/rendezvous"
+	"golang.org/x/sync/errgroup"
+	"gopkg.in/confluentinc/confluent-kafka-go.v1/kafka"
+)
+
+const (
+	Success          = 0 // Exit code for success
+	AppName          = "Kafka-Consumer"
+	ShardChannelSize = 10000
+	pollTimeout      = 100 * time.Millisecond
+)
+
+// Broker - The subset of the Kafka consumer API used by the processing loop.  Satisfied by
+// *kafka.Consumer and by the in-process mock broker used for testing.
+type Broker interface {
+	SubscribeTopics(topics []string, rebalanceCb kafka.RebalanceCb) error
+	ReadMessage(timeout time.Duration) (*kafka.Message, error)
+	CommitOffsets(offsets []kafka.TopicPartition) ([]kafka.TopicPartition, error)
+	Close() error
+}
+
+// Writer - Table writer used by the shard workers.  Satisfied by *core.Session.
+type Writer interface {
+	PutRow(name string, row interface{}, providedColID uint64, ignoreSourcePath, useNerd bool) error
+	Flush() error
+	CloseSession() error
+}
+
+// Main struct defines command line arguments variables and various global meta-data associated with record loads.
+type Main struct {
+	Brokers          string
+	Group            string
+	Topics           []string
+	Port             int
+	ConsulAddr       string
+	ShardCount       int
+	ShardKey         string
+	IsAvro           bool
+	CommitIntervalMs int
+	TotalBytes       *Counter
+	TotalRecs        *Counter
+	processedRecs    *Counter
+	committedRecs    *Counter
+	errorCount       *Counter
+	Consumer         Broker
+	OpenWriter       func(tableName string) (Writer, error)
+	CancelFunc       context.CancelFunc
+	HashTable        *rendezvous.Table
+	shardChannels    map[string]chan DataRecord
+	offsets          map[partitionKey]kafka.Offset // Highest offset read (not yet committed) per partition
+	pendingRecs      int64
+	tableCache       *core.TableCacheStruct
+	consulClient     *api.Client
+	clientConn       *shared.Conn
+	schemaChanged    bool
+	stopping         bool
+	stateLock        sync.Mutex
+}
+
+// DataRecord - Unit of work sent to a shard worker.  If flushed is not nil the worker flushes its
+// sessions and replies with the result instead of writing a row.
+type DataRecord struct {
+	TableName string
+	Data      map[string]interface{}
+	flushed   chan error
+}
+
+type partitionKey struct {
+	topic     string
+	partition int32
+}
+
+// NewMain allocates a new pointer to Main struct with empty record counter
+func NewMain() *Main {
+	m := &Main{
+		TotalRecs:     &Counter{},
+		TotalBytes:    &Counter{},
+		processedRecs: &Counter{},
+		committedRecs: &Counter{},
+		errorCount:    &Counter{},
+	}
+	m.tableCache = core.NewTableCacheStruct()
+	return m
+}
+
+// Init function initilizations loader.
+// Establishes session with bitmap server and Kafka
+func (m *Main) Init() error {
+
+	consulConfig := &api.Config{Address: m.ConsulAddr}
+	var err error
+	m.consulClient, err = api.NewClient(consulConfig)
+	if err != nil {
+		return err
+	}
+
+	// Register for Schema changes
+	if err = shared.RegisterSchemaChangeListener(consulConfig, m.schemaChangeListener); err != nil {
+		return err
+	}
+
+	m.clientConn = shared.NewDefaultConnection("kafka-consumer")
+	m.clientConn.ServicePort = m.Port
+	if err := m.clientConn.Connect(m.consulClient); err != nil {
+		return err
+	}
+	m.OpenWriter = func(tableName string) (Writer, error) {
+		return core.OpenSession(m.tableCache, "", tableName, true, m.clientConn)
+	}
+	if err := m.LoadTables(); err != nil {
+		return err
+	}
+
+	consumer, err := kafka.NewConsumer(&kafka.ConfigMap{
+		"bootstrap.servers":  m.Brokers,
+		"group.id":           m.Group,
+		"auto.offset.reset":  "earliest",
+		"enable.auto.commit": false,
+	})
+	if err != nil {
+		return err
+	}
+	m.Consumer = consumer
+	u.Infof("Created consumer %v", consumer)
+	return nil
+}
+
+// LoadTables - Open all tables to populate the table cache (selectors and Avro schemas).
+func (m *Main) LoadTables() error {
+
+	for k := range m.tableCache.TableCache {
+		delete(m.tableCache.TableCache, k)
+	}
+	tables, err := shared.GetTables(m.consulClient)
+	if err != nil {
+		return err
+	}
+	for _, tableName := range tables {
+		u.Infof("Opening session for table %s", tableName)
+		conn, err := core.OpenSession(m.tableCache, "", tableName, true, m.clientConn)
+		if err != nil {
+			u.Errorf("cannot open table %s - %v", tableName, err)
+			continue
+		}
+		conn.CloseSession()
+	}
+	return nil
+}
+
+// MainProcessingLoop function is the main processing loop for the Kafka consumer.  Processing is
+// restarted after schema changes and continues until Stop is called or an error occurs.
+func (m *Main) MainProcessingLoop() error {
+
+	defer m.Consumer.Close()
+	for {
+		ctx, cancel := context.WithCancel(context.Background())
+		m.stateLock.Lock()
+		m.CancelFunc = cancel
+		m.stateLock.Unlock()
+		err := m.Run(ctx)
+		cancel()
+		if err != nil {
+			return err
+		}
+		m.stateLock.Lock()
+		stopping, reload := m.stopping, m.schemaChanged
+		m.schemaChanged = false
+		m.stateLock.Unlock()
+		if stopping {
+			return nil
+		}
+		if reload {
+			u.Warnf("Schema changed, re-initializing.")
+			if err := m.LoadTables(); err != nil {
+				return fmt.Errorf("initialization error: %v", err)
+			}
+		}
+	}
+}
+
+// Stop - Commit what has been processed and stop the processing loop.
+func (m *Main) Stop() {
+
+	m.stateLock.Lock()
+	defer m.stateLock.Unlock()
+	m.stopping = true
+	if m.CancelFunc != nil {
+		m.CancelFunc()
+	}
+}
+
+// Run - Consume messages until the context is cancelled.  Processed offsets are committed before
+// returning.
+func (m *Main) Run(ctx context.Context) error {
+
+	if m.ShardCount <= 0 {
+		m.ShardCount = 1
+	}
+	if err := m.Consumer.SubscribeTopics(m.Topics, m.rebalance); err != nil {
+		return err
+	}
+
+	// Start the shard workers
+	var eg errgroup.Group
+	m.shardChannels = make(map[string]chan DataRecord, m.ShardCount)
+	shardIds := make([]string, m.ShardCount)
+	for i := 0; i < m.ShardCount; i++ {
+		shardID := fmt.Sprintf("shard%v", i)
+		shardIds[i] = shardID
+		ch := make(chan DataRecord, ShardChannelSize)
+		m.shardChannels[shardID] = ch
+		eg.Go(func() error {
+			return m.shardWorker(shardID, ch)
+		})
+	}
+	m.HashTable = rendezvous.New(shardIds)
+	m.offsets = make(map[partitionKey]kafka.Offset)
+	m.pendingRecs = 0
+
+	err := m.consume(ctx)
+	if err == nil {
+		err = m.commit()
+	}
+	for _, ch := range m.shardChannels {
+		close(ch)
+	}
+	if errx := eg.Wait(); err == nil {
+		err = errx
+	}
+	return err
+}
+
+func (m *Main) consume(ctx context.Context) error {
+
+	interval := time.Millisecond * time.Duration(m.CommitIntervalMs)
+	nextCommit := time.Now().Add(interval)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+		msg, err := m.Consumer.ReadMessage(pollTimeout)
+		if err != nil {
+			if kerr, ok := err.(kafka.Error); !ok || kerr.Code() != kafka.ErrTimedOut {
+				// The client will automatically try to recover from all errors.
+				u.Warnf("Consumer error: %v (%v)", err, msg)
+			}
+		} else if err := m.dispatch(msg); err != nil {
+			return err
+		}
+		if time.Now().After(nextCommit) {
+			if err := m.commit(); err != nil {
+				return err
+			}
+			nextCommit = time.Now().Add(interval)
+		}
+	}
+}
+
+// dispatch - Decode a message, select the table and push it into a shard channel.
+func (m *Main) dispatch(msg *kafka.Message) error {
+
+	m.TotalRecs.Add(1)
+	m.TotalBytes.Add(len(msg.Value))
+	tp := msg.TopicPartition
+	var topic string
+	if tp.Topic != nil {
+		topic = *tp.Topic
+	}
+	m.offsets[partitionKey{topic: topic, partition: tp.Partition}] = tp.Offset
+	m.pendingRecs++
+
+	table, out := m.decode(msg.Value)
+	if table == nil { // no match, continue
+		return nil
+	}
+	ch, ok := m.shardChannels[m.shardFor(msg, out)]
+	if !ok {
+		return fmt.Errorf("cannot locate channel for message %v", msg)
+	}
+	ch <- DataRecord{TableName: table.Name, Data: out}
+	return nil
+}
+
+// decode - Decode the payload and find the table whose selector matches.
+func (m *Main) decode(data []byte) (*core.Table, map[string]interface{}) {
+
+	var out map[string]interface{}
+	if !m.IsAvro { // Default is JSON
+		if err := json.Unmarshal(data, &out); err != nil {
+			m.errorCount.Add(1)
+			u.Errorf("Unmarshal ERROR %v", err)
+			return nil, nil
+		}
+	}
+	names := make([]string, 0, len(m.tableCache.TableCache))
+	for k := range m.tableCache.TableCache {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		x := m.tableCache.TableCache[name]
+		if x.SelectorNode == nil {
+			continue
+		}
+		if m.IsAvro {
+			out = make(map[string]interface{})
+			if x.AvroSchema == nil || avro.Unmarshal(x.AvroSchema, data, &out) != nil {
+				// Most often the 'shape' of the data is different
+				continue
+			}
+		}
+		if m.preselect(x.SelectorNode, x.SelectorIdentities, out) {
+			return x, out
+		}
+	}
+	return nil, nil
+}
+
+// shardFor - Select the shard worker.  The shard key is used if configured, otherwise the message
+// key, otherwise the partition.  This keeps records with the same key in order.
+func (m *Main) shardFor(msg *kafka.Message, row map[string]interface{}) string {
+
+	key := strconv.Itoa(int(msg.TopicPartition.Partition))
+	if m.ShardKey != "" {
+		if val, err := shared.GetPath(m.ShardKey, row, false, false); err == nil && val != nil {
+			key = fmt.Sprintf("%v", val)
+		} else {
+			u.Warnf("shard key %s not found, routing by partition", m.ShardKey)
+		}
+	} else if len(msg.Key) > 0 {
+		key = string(msg.Key)
+	}
+	return m.HashTable.GetN(1, key)[0]
+}
+
+// shardWorker - Write rows for a shard.  Rows rejected by PutRow are logged, counted and skipped, a bad
+// message would otherwise be redelivered and stop the consumer after every restart.  A session that cannot
+// be opened is sticky, it is returned on the next flush request so that offsets are not committed past it.
+func (m *Main) shardWorker(shardID string, ch chan DataRecord) error {
+
+	sessions := make(map[string]Writer)
+	defer func() {
+		for _, v := range sessions {
+			v.CloseSession()
+		}
+	}()
+
+	var failure error
+	for rec := range ch {
+		if rec.flushed != nil {
+			err := failure
+			for table, conn := range sessions {
+				if errx := conn.Flush(); errx != nil && err == nil {
+					err = fmt.Errorf("flush of table %s failed, shard %s - %v", table, shardID, errx)
+				}
+			}
+			rec.flushed <- err
+			continue
+		}
+		if failure != nil {
+			continue // Drain, offsets will not be committed
+		}
+		conn, ok := sessions[rec.TableName]
+		if !ok {
+			var err error
+			if conn, err = m.OpenWriter(rec.TableName); err != nil {
+				failure = fmt.Errorf("cannot open session for table %s, shard %s - %v", rec.TableName, shardID, err)
+				m.errorCount.Add(1)
+				continue
+			}
+			sessions[rec.TableName] = conn
+		}
+		if err := conn.PutRow(rec.TableName, rec.Data, 0, false, false); err != nil {
+			u.Errorf("ERROR in PutRow for table %s, shard %s, record skipped - %v [%v]", rec.TableName, shardID,
+				err, rec.Data)
+			m.errorCount.Add(1)
+			continue
+		}
+		m.processedRecs.Add(1)
+	}
+	return failure
+}
+
+// commit - Have every shard worker flush and then commit the offsets read so far.  A flush failure
+// is returned and nothing is committed, the records will be redelivered after a restart.
+func (m *Main) commit() error {
+
+	if len(m.offsets) == 0 {
+		return nil
+	}
+	replies := make([]chan error, 0, len(m.shardChannels))
+	for _, ch := range m.shardChannels {
+		reply := make(chan error, 1)
+		ch <- DataRecord{flushed: reply}
+		replies = append(replies, reply)
+	}
+	var err error
+	for _, reply := range replies {
+		if errx := <-reply; errx != nil && err == nil {
+			err = errx
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("offsets not committed - %v", err)
+	}
+
+	offsets := make([]kafka.TopicPartition, 0, len(m.offsets))
+	for k, v := range m.offsets {
+		topic := k.topic
+		offsets = append(offsets, kafka.TopicPartition{Topic: &topic, Partition: k.partition, Offset: v + 1})
+	}
+	if _, err := m.Consumer.CommitOffsets(offsets); err != nil {
+		// Not fatal, the records are redelivered if the partition moves to another consumer.
+		u.Warnf("commit of offsets %v failed - %v", offsets, err)
+	} else {
+		m.committedRecs.Add(int(m.pendingRecs))
+	}
+	m.offsets = make(map[partitionKey]kafka.Offset)
+	m.pendingRecs = 0
+	return nil
+}
+
+// rebalance - Commit processed offsets before partitions are revoked.
+func (m *Main) rebalance(c *kafka.Consumer, e kafka.Event) error {
+
+	if _, ok := e.(kafka.RevokedPartitions); ok {
+		if err := m.commit(); err != nil {
+			u.Errorf("commit before rebalance failed - %v", err)
+			return err
+		}
+	}
+	return nil
+}
+
+// filter row per expression
+func (m *Main) preselect(selector expr.Node, identities []string, row map[string]interface{}) bool {
+
+	ctx := m.buildEvalContext(identities, row)
+	if ctx == nil {
+		return false
+	}
+	val, ok := vm.Eval(ctx, selector)
+	if !ok {
+		u.Errorf("Preselect expression %s failed to evaluate ", selector.String())
+		return false
+	}
+	if val.Type() != value.BoolType {
+		u.Errorf("select expression %s does not evaluate to a boolean value", selector.String())
+		return false
+	}
+	return val.Value().(bool)
+}
+
+func (m *Main) buildEvalContext(identities []string, row map[string]interface{}) *datasource.ContextSimple {
+
+	data := make(map[string]interface{})
+	for _, v := range identities {
+		var path string
+		if v[0] == '/' {
+			path = v[1:]
+		} else {
+			path = v
+		}
+		if l, err := shared.GetPath(path, row, false, false); err == nil {
+			data[v] = l
+		} else {
+			return nil
+		}
+	}
+	return datasource.NewContextSimpleNative(data)
+}
+
+func (m *Main) schemaChangeListener(e shared.SchemaChangeEvent) {
+
+	switch e.Event {
+	case shared.Drop:
+		u.Warnf("Dropped table %s", e.Table)
+	case shared.Modify:
+		u.Warnf("Truncated table %s", e.Table)
+	case shared.Create:
+		u.Warnf("Created table %s", e.Table)
+	}
+	// Processing is restarted by the main loop once processed offsets are committed.
+	m.stateLock.Lock()
+	defer m.stateLock.Unlock()
+	m.schemaChanged = true
+	if m.CancelFunc != nil {
+		m.CancelFunc()
+	}
+}
+
+// PrintStats outputs to Log current status of Kafka consumer
+// Includes data on processed: bytes, records, time duration in seconds, and rate of bytes per sec"
+func (m *Main) PrintStats() *time.Ticker {
+	t := time.NewTicker(time.Second * 10)
+	start := time.Now()
+	go func() {
+		for range t.C {
+			duration := time.Since(start)
+			bytes := m.TotalBytes.Get()
+			u.Infof("Bytes: %s, Records: %v, Processed: %v, Committed: %v, Errors: %v, Duration: %v, Rate: %v/s",
+				core.Bytes(bytes), m.TotalRecs.Get(), m.processedRecs.Get(), m.committedRecs.Get(),
+				m.errorCount.Get(), duration, core.Bytes(float64(bytes)/duration.Seconds()))
+		}
+	}()
+	return t
+}
+
+// Counter - Generic counter with mutex (threading) support
+type Counter struct {
+	num  int64
+	lock sync.Mutex
+}
+
+// Add function provides thread safe addition of counter value based on input parameter.
+func (c *Counter) Add(n int) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.num += int64(n)
+}
+
+// Get function provides thread safe read of counter value.
+func (c *Counter) Get() (ret int64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	ret = c.num
+	return
+}
@@ -37,6 +37,7 @@ func main() {
 	bindAddr := app.Arg("bind", "Bind address for this endpoint.").Default("0.0.0.0").String()
 	port := app.Arg("port", "Port for this endpoint.").Default("4000").Int32()
 	memLimit := app.Flag("mem-limit-mb", "Data partitions will expire after MB limit is exceeded (disabled if not specified).").Default("0").Int32()
+	coldLimit := app.Flag("cold-cache-mb", "Memory budget for archived partitions loaded by queries.").Default("1024").Int32()
 	tls := app.Flag("tls", "Connection uses TLS if true.").Bool()
 	certFile := app.Flag("cert-file", "TLS cert file path.").String()
 	keyFile := app.Flag("key-file", "TLS key file path.").String()
@@ -84,6 +85,7 @@ func main() {
 	m.AddNodeService(search)
 
 	bitmapIndex := server.NewBitmapIndex(m, int(*memLimit))
+	bitmapIndex.SetColdCacheLimit(int(*coldLimit))
 	m.AddNodeService(bitmapIndex)
 
 	fmt.Println("after AddNodeService ...")
@@ -0,0 +1,188 @@
+package proxy
+
+import (
+	"fmt"
+
+	"github.com/disney/quanta/core"
+	"github.com/disney/quanta/rbac"
+	"github.com/disney/quanta/shared"
+	"github.com/siddontang/go-mysql/mysql"
+
+	u "github.com/araddon/gou"
+)
+
+// authorizeDDL - Verify that the current user holds the CreateOrAlterTable permission (DomainAdmin or
+// SystemAdmin) for a table, or for the database if table is empty.
+func (h *ProxyHandler) authorizeDDL(table string) (string, *shared.Conn, *shared.KVStore, error) {
+	return h.authorizePermission(rbac.CreateOrAlterTable, table)
+}
+
+// authorizePermission - Verify that the current user holds a permission for a table, or for the database if
+// table is empty.
+func (h *ProxyHandler) authorizePermission(perm rbac.Permission, table string) (string, *shared.Conn,
+	*shared.KVStore, error) {
+
+	userID, ok := h.authProvider.GetCurrentUserID()
+	if !ok {
+		return "", nil, nil, fmt.Errorf("user ID must be set,  run exec  'set @userid = <userID>'")
+	}
+	conn := Src.GetConnection()
+	kvStore, ok := conn.GetService("KVStore").(*shared.KVStore)
+	if !ok {
+		kvStore = shared.NewKVStore(conn)
+	}
+	authCtx, err := rbac.NewAuthContext(kvStore, userID, false)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("RBAC error - %v", err)
+	}
+	if table == "" {
+		ok, err = authCtx.IsAuthorized(perm, "quanta")
+	} else {
+		ok, err = authCtx.IsAuthorizedForTable(perm, "quanta", table, nil)
+	}
+	if !ok {
+		return "", nil, nil, err
+	}
+	return userID, conn, kvStore, nil
+}
+
+// handleAlter - Process ALTER TABLE statements.  The schema change is deployed to the cluster and
+// picked up by the proxies through the schema change listener.
+func (h *ProxyHandler) handleAlter(query string) (*mysql.Result, error) {
+
+	stmt, err := shared.ParseAlterTable(query)
+	if err != nil {
+		return nil, err
+	}
+	userID, conn, _, err := h.authorizeDDL(stmt.Table)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := core.AlterTable(conn.Consul, shared.NewBitmapIndex(conn), stmt, false)
+	if err != nil {
+		u.Errorf("could not alter table %s: %v", stmt.Table, err)
+		return nil, err
+	}
+	for _, change := range changes {
+		u.Infof("ALTER TABLE %s by %s: %s", stmt.Table, userID, change)
+	}
+	return &mysql.Result{Status: 0, InsertId: 0, AffectedRows: 0, Resultset: nil}, nil
+}
+
+// handleCreate - Process CREATE TABLE statements.
+func (h *ProxyHandler) handleCreate(query string) (*mysql.Result, error) {
+
+	table, ifNotExists, err := shared.ParseCreateTable(query)
+	if err != nil {
+		return nil, err
+	}
+	userID, conn, _, err := h.authorizeDDL("")
+	if err != nil {
+		return nil, err
+	}
+	result := &mysql.Result{Status: 0, InsertId: 0, AffectedRows: 0, Resultset: nil}
+	if ok, _ := shared.TableExists(conn.Consul, table.Name); ok && ifNotExists {
+		return result, nil
+	}
+	if err := core.CreateTable(conn.Consul, shared.NewBitmapIndex(conn), table); err != nil {
+		u.Errorf("could not create table %s: %v", table.Name, err)
+		return nil, err
+	}
+	u.Infof("CREATE TABLE %s by %s", table.Name, userID)
+	return result, nil
+}
+
+// handleDrop - Process DROP TABLE and TRUNCATE TABLE statements.  TRUNCATE ... FORCE overrides the foreign key
+// constraints like quanta-admin truncate --force.
+func (h *ProxyHandler) handleDrop(query string) (*mysql.Result, error) {
+
+	op, table, ifExists, force, err := shared.ParseDropTable(query)
+	if err != nil {
+		return nil, err
+	}
+	userID, conn, kvStore, err := h.authorizeDDL(table)
+	if err != nil {
+		return nil, err
+	}
+	result := &mysql.Result{Status: 0, InsertId: 0, AffectedRows: 0, Resultset: nil}
+	if ok, _ := shared.TableExists(conn.Consul, table); !ok && ifExists {
+		return result, nil
+	}
+	if err := shared.CheckForChildDependencies(conn.Consul, table, op); err != nil && !force {
+		return nil, err
+	}
+
+	// Consumers pick up the change through the schema listener, they are given a bounded time to flush.
+	services := shared.NewBitmapIndex(conn)
+	if op == "DROP" {
+		err = shared.DropTable(conn.Consul, services, kvStore, table, shared.ConsumerFlushWait)
+	} else {
+		err = shared.TruncateTable(conn.Consul, services, kvStore, table, false, shared.ConsumerFlushWait)
+	}
+	if err != nil {
+		u.Errorf("could not %s table %s: %v", op, table, err)
+		return nil, err
+	}
+	u.Infof("%s TABLE %s by %s", op, table, userID)
+	return result, nil
+}
+
+// isViewDDL - CREATE [OR REPLACE] VIEW and DROP VIEW statements.
+func isViewDDL(splitQueryLower []string) bool {
+
+	if len(splitQueryLower) > 3 && splitQueryLower[1] == "or" {
+		return splitQueryLower[3] == "view"
+	}
+	return len(splitQueryLower) > 1 && splitQueryLower[1] == "view"
+}
+
+// handleCreateView - Process CREATE [OR REPLACE] VIEW statements.  Views require the CreateOrAlterView
+// permission on the base table and are picked up by the proxies through the view change listener.
+func (h *ProxyHandler) handleCreateView(query string) (*mysql.Result, error) {
+
+	view, orReplace, err := shared.ParseCreateView(query)
+	if err != nil {
+		return nil, err
+	}
+	userID, conn, _, err := h.authorizePermission(rbac.CreateOrAlterView, view.IsViewOf)
+	if err != nil {
+		return nil, err
+	}
+	if err := shared.CreateView(conn.Consul, view, orReplace); err != nil {
+		u.Errorf("could not create view %s: %v", view.Name, err)
+		return nil, err
+	}
+	u.Infof("CREATE VIEW %s on %s by %s", view.Name, view.IsViewOf, userID)
+	return &mysql.Result{Status: 0, InsertId: 0, AffectedRows: 0, Resultset: nil}, nil
+}
+
+// handleDropView - Process DROP VIEW statements.
+func (h *ProxyHandler) handleDropView(query string) (*mysql.Result, error) {
+
+	name, ifExists, err := shared.ParseDropView(query)
+	if err != nil {
+		return nil, err
+	}
+	result := &mysql.Result{Status: 0, InsertId: 0, AffectedRows: 0, Resultset: nil}
+	view, err := shared.LoadView(Src.GetConnection().Consul, name)
+	if err != nil {
+		return nil, err
+	}
+	if view == nil {
+		if ifExists {
+			return result, nil
+		}
+		return nil, fmt.Errorf("view %s doesn't exist", name)
+	}
+	userID, conn, _, err := h.authorizePermission(rbac.CreateOrAlterView, view.IsViewOf)
+	if err != nil {
+		return nil, err
+	}
+	if err := shared.DropView(conn.Consul, name); err != nil {
+		u.Errorf("could not drop view %s: %v", name, err)
+		return nil, err
+	}
+	u.Infof("DROP VIEW %s by %s", name, userID)
+	return result, nil
+}
@@ -0,0 +1,110 @@
+package proxy
+
+//
+// EXPLAIN [ANALYZE] for SELECT statements.
+//
+// The plan shows the bitmap query fragments sent to the nodes for each table, LIKE searches, joins and the
+// predicates evaluated by qlbridge (polyfill).  Steps form a tree through the parent column.  EXPLAIN ANALYZE
+// runs the statement, discards its rows and adds the cardinality and elapsed time of each step.
+//
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/disney/quanta/shared"
+	"github.com/disney/quanta/source"
+	"github.com/siddontang/go-mysql/mysql"
+)
+
+// isExplainSelect - EXPLAIN [ANALYZE] SELECT, other forms of EXPLAIN are a synonym of DESCRIBE.
+func isExplainSelect(splitQueryLower []string) bool {
+
+	if len(splitQueryLower) > 2 && splitQueryLower[1] == "analyze" {
+		return splitQueryLower[2] == "select"
+	}
+	return len(splitQueryLower) > 1 && splitQueryLower[1] == "select"
+}
+
+// handleExplain - Plan (and with ANALYZE run) a SELECT and return its plan.
+func (h *ProxyHandler) handleExplain(ctx context.Context, query string, args []interface{},
+	binary bool) (*mysql.Result, error) {
+
+	h.checkSessionUserID(true)
+
+	words := strings.SplitN(strings.TrimSpace(query), " ", 3)
+	analyze := strings.EqualFold(words[1], "analyze")
+	query = strings.TrimSpace(query)[len(words[0])+1:]
+	if analyze {
+		query = query[len(words[1])+1:]
+	}
+
+	e := source.NewExplain(analyze)
+	start := time.Now()
+	rows, err := h.db.QueryContext(source.WithExplain(ctx, e), query, args...)
+	if err != nil {
+		return nil, interrupted(ctx, err)
+	}
+	defer rows.Close()
+	var count uint64
+	for rows.Next() {
+		count++
+	}
+	if err = rows.Err(); err != nil {
+		return nil, interrupted(ctx, err)
+	}
+
+	steps := e.Steps()
+	if analyze {
+		steps = append(steps, &shared.PlanStep{ID: len(steps) + 1, Operation: "RESULT", Predicate: "rows returned",
+			Stats: &shared.FragmentStats{Cardinality: count, Elapsed: time.Since(start)}})
+	}
+	r, err := explainResultset(steps, analyze, binary)
+	if err != nil {
+		return nil, err
+	}
+	return &mysql.Result{Status: 0, InsertId: 0, AffectedRows: 0, Resultset: r}, nil
+}
+
+// explainResultset - One row per plan step, the parent of top level steps is NULL.
+func explainResultset(steps []*shared.PlanStep, analyze, binary bool) (*mysql.Resultset, error) {
+
+	cols := []*resultColumn{newColumn("id", shared.Integer), newColumn("parent", shared.Integer),
+		newColumn("table", shared.String), newColumn("operation", shared.String),
+		newColumn("predicate", shared.String)}
+	if analyze {
+		elapsed := newColumn("time_ms", shared.Float)
+		elapsed.field.Decimal = 3
+		cols = append(cols, newColumn("node", shared.String), newColumn("rows", shared.Integer), elapsed)
+	}
+
+	r := &mysql.Resultset{Fields: make([]*mysql.Field, len(cols)), RowDatas: make([]mysql.RowData, 0, len(steps))}
+	for i, c := range cols {
+		r.Fields[i] = c.field
+	}
+	for _, step := range steps {
+		vals := []interface{}{int64(step.ID), nil, step.Table, step.Operation, step.Predicate}
+		if step.Parent > 0 {
+			vals[1] = int64(step.Parent)
+		}
+		if step.Table == "" {
+			vals[2] = nil
+		}
+		if analyze {
+			if step.Node != "" {
+				vals = append(vals, step.Node)
+			} else {
+				vals = append(vals, nil)
+			}
+			if step.Stats != nil {
+				vals = append(vals, int64(step.Stats.Cardinality),
+					float64(step.Stats.Elapsed)/float64(time.Millisecond))
+			} else {
+				vals = append(vals, nil, nil)
+			}
+		}
+		r.RowDatas = append(r.RowDatas, encodeRow(cols, vals, binary))
+	}
+	return r, nil
+}
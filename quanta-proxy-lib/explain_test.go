@@ -0,0 +1,48 @@
+package proxy
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplain(t *testing.T) {
+
+	client := startTestProxy(t)
+
+	// The mock tables are not Quanta tables so the plan has no bitmap queries.
+	rows, err := client.Query("EXPLAIN select user_id FROM users")
+	require.NoError(t, err)
+	cols, err := rows.Columns()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id", "parent", "table", "operation", "predicate"}, cols)
+	assert.False(t, rows.Next())
+	require.NoError(t, rows.Close())
+
+	rows, err = client.Query("explain analyze select user_id FROM users")
+	require.NoError(t, err)
+	defer rows.Close()
+	cols, err = rows.Columns()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id", "parent", "table", "operation", "predicate", "node", "rows", "time_ms"}, cols)
+	require.True(t, rows.Next())
+	var id, count int64
+	var parent sql.NullInt64
+	var table, node sql.NullString
+	var operation, predicate string
+	var elapsed float64
+	require.NoError(t, rows.Scan(&id, &parent, &table, &operation, &predicate, &node, &count, &elapsed))
+	assert.Equal(t, int64(1), id)
+	assert.False(t, parent.Valid)
+	assert.False(t, table.Valid)
+	assert.Equal(t, "RESULT", operation)
+	assert.False(t, node.Valid)
+	assert.Equal(t, int64(3), count)
+	assert.False(t, rows.Next())
+	assert.NoError(t, rows.Err())
+
+	assert.True(t, isExplainSelect([]string{"explain", "select", "1"}))
+	assert.False(t, isExplainSelect([]string{"explain", "users"}))
+}
@@ -0,0 +1,56 @@
+package proxy
+
+import (
+	"fmt"
+
+	"github.com/disney/quanta/rbac"
+	"github.com/disney/quanta/shared"
+	"github.com/siddontang/go-mysql/mysql"
+
+	u "github.com/araddon/gou"
+)
+
+// handleGrant - Process GRANT, REVOKE and SHOW GRANTS statements on behalf of the session user.
+func (h *ProxyHandler) handleGrant(query string, binary bool) (*mysql.Result, error) {
+
+	userID, ok := h.authProvider.GetCurrentUserID()
+	if !ok {
+		return nil, fmt.Errorf("user ID must be set,  run exec  'set @userid = <userID>'")
+	}
+	stmt, err := rbac.ParseGrantStatement(query, h.schema)
+	if err != nil {
+		return nil, err
+	}
+
+	conn := Src.GetConnection()
+	kvStore, ok := conn.GetService("KVStore").(*shared.KVStore)
+	if !ok {
+		kvStore = shared.NewKVStore(conn)
+	}
+	authCtx, err := rbac.NewAuthContext(kvStore, userID, false)
+	if err != nil {
+		return nil, fmt.Errorf("RBAC error - %v", err)
+	}
+	grants, err := authCtx.ExecuteGrantStatement(stmt)
+	if err != nil {
+		u.Errorf("could not execute grant statement: %v", err)
+		return nil, err
+	}
+	if !stmt.Show {
+		return &mysql.Result{Status: 0, InsertId: 0, AffectedRows: 0, Resultset: nil}, nil
+	}
+
+	forUser := stmt.UserID
+	if forUser == "" {
+		forUser = userID
+	}
+	rows := make([][]interface{}, len(grants))
+	for i, v := range grants {
+		rows[i] = []interface{}{v}
+	}
+	r, err := mysql.BuildSimpleResultset([]string{"Grants for " + forUser}, rows, binary)
+	if err != nil {
+		return nil, err
+	}
+	return &mysql.Result{Status: 0, InsertId: 0, AffectedRows: 0, Resultset: r}, nil
+}
@@ -0,0 +1,210 @@
+package proxy
+
+//
+// Process list and query cancellation.
+//
+// Each client connection is registered under its MySQL connection ID.  The statement running on a connection
+// holds a cancel function that is called by KILL and when max_execution_time is exceeded.  The canceled context
+// closes the qlbridge tasks of the query (SigChan) and aborts the requests sent to the nodes.
+//
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/siddontang/go-mysql/mysql"
+	"github.com/siddontang/go-mysql/server"
+)
+
+// MaxExecutionTimeKey - Session variable for the query timeout in milliseconds (SET max_execution_time = 60000).
+const MaxExecutionTimeKey = "max_execution_time"
+
+const (
+	erQueryTimeout   = 3024 // ER_QUERY_TIMEOUT, not defined by the MySQL library
+	processInfoWidth = 100  // Statement text shown by SHOW PROCESSLIST without FULL
+)
+
+var (
+	// MaxExecutionTime - Default query timeout in milliseconds, zero is unlimited.
+	MaxExecutionTime int64
+
+	processes sync.Map // Connection ID -> *process
+)
+
+// process - State of a client connection shown by SHOW PROCESSLIST.  Ownership is decided by the RBAC user ID,
+// the same identity used by the admin check.  The MySQL login name is only displayed.
+type process struct {
+	id      uint32
+	user    string
+	userID  string
+	host    string
+	conn    net.Conn // Closed by KILL CONNECTION
+	lock    sync.Mutex
+	info    string // Running statement, empty if idle
+	started time.Time
+	cancel  context.CancelFunc
+}
+
+// register - Add a client connection to the process list.
+func (h *ProxyHandler) register(sconn *server.Conn, conn net.Conn) {
+
+	h.conn = sconn
+	h.proc = &process{id: sconn.ConnectionID(), user: sconn.GetUser(), conn: conn, started: time.Now()}
+	h.proc.userID, _ = h.authProvider.GetCurrentUserID()
+	if conn.RemoteAddr() != nil {
+		h.proc.host = conn.RemoteAddr().String()
+	}
+	processes.Store(h.proc.id, h.proc)
+}
+
+// unregister - Remove a closed client connection from the process list.
+func (h *ProxyHandler) unregister() {
+
+	if h.proc == nil {
+		return
+	}
+	processes.Delete(h.proc.id)
+	h.proc.lock.Lock()
+	defer h.proc.lock.Unlock()
+	if h.proc.cancel != nil {
+		h.proc.cancel()
+	}
+}
+
+// beginStatement - Record the running statement and create its context, canceled by KILL or once the session
+// max_execution_time has passed.  The returned function must be called when the statement completes.
+func (h *ProxyHandler) beginStatement(query string) (context.Context, context.CancelFunc) {
+
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if h.maxExecutionTime > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), time.Duration(h.maxExecutionTime)*time.Millisecond)
+	} else {
+		ctx, cancel = context.WithCancel(context.Background())
+	}
+	if h.proc == nil {
+		return ctx, cancel
+	}
+	h.proc.lock.Lock()
+	h.proc.info, h.proc.started, h.proc.cancel = query, time.Now(), cancel
+	h.proc.lock.Unlock()
+	return ctx, func() {
+		cancel()
+		h.proc.lock.Lock()
+		h.proc.info, h.proc.started, h.proc.cancel = "", time.Now(), nil
+		h.proc.lock.Unlock()
+	}
+}
+
+// interrupted - Translate the error of a statement whose context is done to the MySQL error for KILL QUERY
+// or max_execution_time.
+func interrupted(ctx context.Context, err error) error {
+
+	if err == nil {
+		return nil
+	}
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return mysql.NewError(erQueryTimeout, "Query execution was interrupted, maximum statement execution time exceeded")
+	case errors.Is(ctx.Err(), context.Canceled):
+		return mysql.NewError(mysql.ER_QUERY_INTERRUPTED, "Query execution was interrupted")
+	}
+	return err
+}
+
+// isOwner - Users can see and kill their own connections.
+func (h *ProxyHandler) isOwner(p *process) bool {
+	return h.proc != nil && h.proc.userID != "" && p.userID == h.proc.userID
+}
+
+// isProcessAdmin - Connections of other users can be seen and killed with the DomainAdmin or SystemAdmin role.
+func (h *ProxyHandler) isProcessAdmin() bool {
+
+	_, _, _, err := h.authorizeDDL("")
+	return err == nil
+}
+
+// handleKill - Process KILL [CONNECTION | QUERY] <id>.
+func (h *ProxyHandler) handleKill(query string) (*mysql.Result, error) {
+
+	args := strings.Fields(strings.TrimSuffix(strings.TrimSpace(query), ";"))
+	if len(args) == 3 && (strings.EqualFold(args[1], "query") || strings.EqualFold(args[1], "connection")) {
+		id, err := strconv.ParseUint(args[2], 10, 32)
+		if err == nil {
+			return nil, h.kill(uint32(id), strings.EqualFold(args[1], "query"))
+		}
+	} else if len(args) == 2 {
+		id, err := strconv.ParseUint(args[1], 10, 32)
+		if err == nil {
+			return nil, h.kill(uint32(id), false)
+		}
+	}
+	return nil, mysql.NewError(mysql.ER_PARSE_ERROR, "syntax is KILL [CONNECTION | QUERY] <processlist_id>")
+}
+
+// kill - Cancel the running statement of a connection and unless queryOnly is set close the connection.
+func (h *ProxyHandler) kill(id uint32, queryOnly bool) error {
+
+	v, ok := processes.Load(id)
+	if !ok {
+		return mysql.NewError(mysql.ER_NO_SUCH_THREAD, fmt.Sprintf("Unknown thread id: %d", id))
+	}
+	p := v.(*process)
+	if !h.isOwner(p) && !h.isProcessAdmin() {
+		return mysql.NewError(mysql.ER_KILL_DENIED_ERROR, fmt.Sprintf("You are not owner of thread %d", id))
+	}
+	p.lock.Lock()
+	if p.cancel != nil {
+		p.cancel()
+	}
+	p.lock.Unlock()
+	if !queryOnly {
+		p.conn.Close()
+	}
+	return nil
+}
+
+// showProcessList - Result set for SHOW [FULL] PROCESSLIST.
+func (h *ProxyHandler) showProcessList(full, binary bool) (*mysql.Resultset, error) {
+
+	var admin, checked bool
+	list := make([]*process, 0)
+	processes.Range(func(k, v interface{}) bool {
+		p := v.(*process)
+		if !h.isOwner(p) {
+			if !checked {
+				admin, checked = h.isProcessAdmin(), true
+			}
+			if !admin {
+				return true
+			}
+		}
+		list = append(list, p)
+		return true
+	})
+	sort.Slice(list, func(i, j int) bool { return list[i].id < list[j].id })
+
+	rows := make([][]interface{}, len(list))
+	for i, p := range list {
+		p.lock.Lock()
+		command, state, info := "Sleep", "", ""
+		if p.info != "" {
+			command, state, info = "Query", "executing", p.info
+			if !full && len(p.info) > processInfoWidth {
+				info = p.info[:processInfoWidth]
+			}
+		}
+		rows[i] = []interface{}{int64(p.id), p.user, p.host, "quanta", command,
+			int64(time.Since(p.started).Seconds()), state, info}
+		p.lock.Unlock()
+	}
+	return mysql.BuildSimpleResultset([]string{"Id", "User", "Host", "db", "Command", "Time", "State", "Info"},
+		rows, binary)
+}
@@ -0,0 +1,88 @@
+package proxy
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/siddontang/go-mysql/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessList(t *testing.T) {
+
+	client := startTestProxy(t)
+	client.SetMaxOpenConns(2)
+	ctx := context.Background()
+	a, err := client.Conn(ctx)
+	require.NoError(t, err)
+	defer a.Close()
+	b, err := client.Conn(ctx)
+	require.NoError(t, err)
+	defer b.Close()
+	require.NoError(t, b.PingContext(ctx))
+
+	rows, err := a.QueryContext(ctx, "show processlist")
+	require.NoError(t, err)
+	defer rows.Close()
+	var selfID, otherID int64
+	for rows.Next() {
+		var id, secs int64
+		var user, host, db, command string
+		var state, info sql.NullString
+		require.NoError(t, rows.Scan(&id, &user, &host, &db, &command, &secs, &state, &info))
+		if info.String == "show processlist" {
+			selfID = id
+			assert.Equal(t, "Query", command)
+		} else if id > otherID {
+			otherID = id
+			assert.Equal(t, "Sleep", command)
+		}
+	}
+	require.NoError(t, rows.Err())
+	rows.Close()
+	require.NotZero(t, selfID)
+	require.Greater(t, otherID, selfID)
+
+	// KILL QUERY of an idle connection leaves it open, KILL closes it.
+	_, err = a.ExecContext(ctx, fmt.Sprintf("kill query %d", otherID))
+	require.NoError(t, err)
+	require.NoError(t, b.PingContext(ctx))
+	_, err = a.ExecContext(ctx, fmt.Sprintf("KILL %d", otherID))
+	require.NoError(t, err)
+	assert.Error(t, b.PingContext(ctx))
+	_, err = a.ExecContext(ctx, "kill 1")
+	assert.ErrorContains(t, err, "Unknown thread id")
+	_, err = a.ExecContext(ctx, "set max_execution_time = 1000")
+	assert.NoError(t, err)
+}
+
+func TestIsOwner(t *testing.T) {
+
+	h := &ProxyHandler{proc: &process{user: "root", userID: "alice"}}
+	assert.True(t, h.isOwner(&process{user: "other", userID: "alice"}))
+	assert.False(t, h.isOwner(&process{user: "root", userID: "bob"}))
+	h.proc.userID = ""
+	assert.False(t, h.isOwner(&process{user: "root"}))
+}
+
+func TestInterrupted(t *testing.T) {
+
+	ctx, cancel := context.WithCancel(context.Background())
+	assert.Nil(t, interrupted(ctx, nil))
+	assert.Equal(t, context.Canceled, interrupted(context.Background(), context.Canceled))
+	cancel()
+	err := interrupted(ctx, context.Canceled)
+	require.IsType(t, &mysql.MyError{}, err)
+	assert.Equal(t, uint16(mysql.ER_QUERY_INTERRUPTED), err.(*mysql.MyError).Code)
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+	err = interrupted(ctx, context.DeadlineExceeded)
+	require.IsType(t, &mysql.MyError{}, err)
+	assert.Equal(t, uint16(erQueryTimeout), err.(*mysql.MyError).Code)
+}
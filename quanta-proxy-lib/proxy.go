@@ -3,6 +3,7 @@ package proxy
 import (
 	"crypto/tls"
 	"database/sql"
+	"encoding/binary"
 	"fmt"
 	"log"
 	"net"
@@ -21,6 +22,7 @@ import (
 	"github.com/disney/quanta/qlbridge/lex"
 	"github.com/disney/quanta/qlbridge/rel"
 	"github.com/disney/quanta/qlbridge/schema"
+	"github.com/disney/quanta/rbac"
 	"github.com/disney/quanta/shared"
 	"github.com/disney/quanta/source"
 	"github.com/lestrrat-go/jwx/jwk"
@@ -100,23 +102,31 @@ func SchemaChangeListener(e shared.SchemaChangeEvent) {
 		schema.DefaultRegistry().SchemaDrop("quanta", e.Table, lex.TokenTable)
 		log.Printf("Dropped table %s", e.Table)
 	case shared.Modify:
-		log.Printf("Truncated table %s", e.Table)
+		// Truncated or altered, reload the schema in case columns changed.
+		refreshSchema()
+		log.Printf("Modified table %s", e.Table)
 	case shared.Create:
 		log.Printf("SchemaChangeListener create %s", e.Table)
-		Src.GetSessionPool().Recover(nil)
-		schema.DefaultRegistry().SchemaDrop("quanta", "quanta", lex.TokenSource)
-		var err error
-		tableCache := core.NewTableCacheStruct()
-		Src, err = source.NewQuantaSource(tableCache, "", ConsulAddr, QuantaPort, SessionPoolSize)
-		if err != nil {
-			u.Error(err)
-		}
-		schema.RegisterSourceAsSchema("quanta", Src)
-		schema.DefaultRegistry().SchemaRefresh("quanta")
+		refreshSchema()
 		log.Printf("Created table %s", e.Table)
 	}
 }
 
+// refreshSchema - Replace the Quanta source so that table metadata is reloaded.
+func refreshSchema() {
+
+	Src.GetSessionPool().Recover(nil)
+	schema.DefaultRegistry().SchemaDrop("quanta", "quanta", lex.TokenSource)
+	var err error
+	tableCache := core.NewTableCacheStruct()
+	Src, err = source.NewQuantaSource(tableCache, "", ConsulAddr, QuantaPort, SessionPoolSize)
+	if err != nil {
+		u.Error(err)
+	}
+	schema.RegisterSourceAsSchema("quanta", Src)
+	schema.DefaultRegistry().SchemaRefresh("quanta")
+}
+
 func OnConn(conn net.Conn) {
 	var tlsConf = server.NewServerTLSConfig(test_keys.CaPem, test_keys.CertPem, test_keys.KeyPem, tls.VerifyClientCertIfGiven)
 	svr := server.NewServer("8.0.12", mysql.DEFAULT_COLLATION_ID, mysql.AUTH_NATIVE_PASSWORD, test_keys.PubPem, tlsConf)
@@ -132,13 +142,16 @@ func OnConn(conn net.Conn) {
 		return
 	}
 	defer handler.Close()
+	handler.register(sconn, conn)
 	connectCount.Add(1)
 	// Dispatch loop
 	for {
 		if sconn == nil {
 			break
 		}
-		if err := sconn.HandleCommand(); err != nil {
+		err := sconn.HandleCommand()
+		endStream(sconn)
+		if err != nil {
 			if err.Error() != "connection closed" {
 				u.Debug(err.Error())
 			}
@@ -152,6 +165,12 @@ type ProxyHandler struct {
 	authProvider *AuthProvider
 	db           *sql.DB
 	stmts        map[interface{}]*sql.Stmt
+	conn         *server.Conn // Client connection, result sets are streamed to it
+	proc         *process     // Process list entry of the connection
+	limits       resultLimits // Session result set limits
+	schema       string       // Session schema, set by USE
+
+	maxExecutionTime int64 // Session query timeout in milliseconds
 }
 
 // NewProxyHandler - Create a new proxy handler
@@ -159,9 +178,11 @@ func NewProxyHandler(authProvider *AuthProvider) *ProxyHandler {
 
 	exec.RegisterSqlDriver()
 
-	h := &ProxyHandler{authProvider: authProvider, stmts: make(map[interface{}]*sql.Stmt, 0)}
+	h := &ProxyHandler{authProvider: authProvider, stmts: make(map[interface{}]*sql.Stmt, 0),
+		limits:           resultLimits{maxRows: MaxResultRows, maxBytes: MaxResultBytes},
+		maxExecutionTime: MaxExecutionTime, schema: "quanta"}
 	var err error
-	h.db, err = sql.Open("qlbridge", "quanta")
+	h.db, err = sql.Open("qlbridge", h.schema)
 	if err != nil {
 		panic(err.Error())
 	}
@@ -186,6 +207,9 @@ func (h *ProxyHandler) UseDB(dbName string) error {
 
 	h.checkSessionUserID(true)
 	u.Debugf("UseDB handler called with '%s'\n", dbName)
+	if dbName != "" {
+		h.schema = dbName
+	}
 	return nil
 }
 
@@ -219,6 +243,8 @@ func (h *ProxyHandler) handleQuery(query string, args []interface{}, binary bool
 	if strings.ToLower(splitQuery[0]) == "select" && hasInto {
 		operation = "selectinto"
 	}
+	queryCtx, done := h.beginStatement(query)
+	defer done()
 	switch operation {
 
 	case "commit":
@@ -229,13 +255,52 @@ func (h *ProxyHandler) handleQuery(query string, args []interface{}, binary bool
 	case "begin", "rollback":
 		return nil, nil // Just returns an "OK" packet
 
+	case "grant", "revoke":
+		return h.handleGrant(query, binary)
+
+	case "kill":
+		return h.handleKill(query)
+
+	case "alter":
+		return h.handleAlter(query)
+
+	case "create":
+		if isViewDDL(splitQueryLower) {
+			return h.handleCreateView(query)
+		}
+		return h.handleCreate(query)
+
+	case "drop", "truncate":
+		if operation == "drop" && isViewDDL(splitQueryLower) {
+			return h.handleDropView(query)
+		}
+		return h.handleDrop(query)
+
+	case "explain":
+		if isExplainSelect(splitQueryLower) {
+			return h.handleExplain(queryCtx, query, args, binary)
+		}
+		fallthrough
+
 	case "select", "describe", "show":
 
+		if operation == "show" && rbac.IsGrantStatement(query) {
+			return h.handleGrant(query, binary)
+		}
+
 		h.checkSessionUserID(true)
 
 		var r *mysql.Resultset
 		var err error
 
+		if operation == "show" && strings.HasSuffix(strings.TrimSuffix(splitQueryLower[len(splitQueryLower)-1], ";"),
+			"processlist") {
+			if r, err = h.showProcessList(splitQueryLower[1] == "full", binary); err != nil {
+				return nil, err
+			}
+			return &mysql.Result{Status: 0, InsertId: 0, AffectedRows: 0, Resultset: r}, nil
+		}
+
 		// FIXME: stop scanning the query over and over. (atw) first ToLower and then contains.
 		// use splitQueryLower instead of query.
 		//for handling go mysql driver select @@max_allowed_packet
@@ -293,48 +358,29 @@ func (h *ProxyHandler) handleQuery(query string, args []interface{}, binary bool
 		var rows *sql.Rows
 		var err2 error
 		if stmt != nil {
-			rows, err2 = stmt.Query(args...)
+			rows, err2 = stmt.QueryContext(queryCtx, args...)
 			if err2 != nil {
 				u.Errorf("could not execute prepared query: %v - %v", err2, query)
-				return nil, err2
+				return nil, interrupted(queryCtx, err2)
 			}
 		} else {
 
-			rows, err2 = h.db.Query(query, args...)
+			rows, err2 = h.db.QueryContext(queryCtx, query, args...)
 			if err2 != nil {
 				u.Errorf("could not execute query: %v", err2)
-				return nil, err2
+				return nil, interrupted(queryCtx, err2)
 			}
 		}
 		defer rows.Close()
 		queryCount.Add(1)
 
-		cols, _ := rows.Columns()
-
-		// This code is a hack to work around mapping slices to interface pointers
-		readCols := make([]interface{}, len(cols))
-		writeCols := make([]string, len(cols))
-		for i := range writeCols {
-			readCols[i] = &writeCols[i]
-		}
-
-		rs := make([][]interface{}, 0)
-		for rows.Next() {
-			row := make([]interface{}, len(cols))
-			rows.Scan(readCols...)
-			for i := range writeCols {
-				row[i] = writeCols[i]
-			}
-			rs = append(rs, row)
+		if err = streamResultset(queryCtx, h.conn, rows, binary, h.limits); err != nil {
+			u.Errorf("could not stream result set: %v", err)
+			return nil, err
 		}
-		// End hack
 		elapsed := time.Since(start)
 		queryTime.Add(int(elapsed.Milliseconds()))
-		r, err = mysql.BuildSimpleResultset(cols, rs, binary)
-		if err != nil {
-			return nil, fmt.Errorf("%v", err)
-		}
-		return &mysql.Result{Status: 0, InsertId: 0, AffectedRows: 0, Resultset: r}, nil
+		return &mysql.Result{Status: 0, InsertId: 0, AffectedRows: 0, Resultset: nil}, nil // Already sent
 	case "insert", "delete", "update", "replace", "selectinto":
 		h.checkSessionUserID(true)
 		start := time.Now()
@@ -379,6 +425,9 @@ func (h *ProxyHandler) handleQuery(query string, args []interface{}, binary bool
 		return &mysql.Result{Status: 0, InsertId: uint64(insertID), AffectedRows: uint64(rowCount), Resultset: nil}, nil
 	case "set":
 		h.checkSessionUserID(false)
+		if err := h.setSessionLimits(query); err != nil {
+			return nil, err
+		}
 		_, err := h.db.Exec(query, args...)
 		if err != nil {
 			u.Errorf("could not execute set: %v", err)
@@ -467,6 +516,7 @@ func (h *ProxyHandler) Close() {
 		delete(h.stmts, k)
 	}
 	h.db.Close()
+	h.unregister()
 }
 
 // HandleStmtExecute - Handle Execute
@@ -477,6 +527,9 @@ func (h *ProxyHandler) HandleStmtExecute(ctx interface{}, query string, args []i
 // HandleOtherCommand - Handle Command
 func (h *ProxyHandler) HandleOtherCommand(cmd byte, data []byte) error {
 
+	if cmd == mysql.COM_PROCESS_KILL && len(data) >= 4 {
+		return h.kill(binary.LittleEndian.Uint32(data), false)
+	}
 	return mysql.NewError(mysql.ER_UNKNOWN_ERROR, fmt.Sprintf("command %d is not supported now", cmd))
 }
 
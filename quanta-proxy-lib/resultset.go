@@ -0,0 +1,325 @@
+package proxy
+
+//
+// Typed MySQL result sets.
+//
+// Column types come from the database/sql column metadata of the query which for Quanta tables carries the
+// attribute type (shared.DataType).  Values are coerced to the column type and encoded for the text or binary
+// (prepared statement) protocol.  Only nil values are NULL.  A value that cannot be coerced to the column type is
+// returned as text, or as NULL in the binary protocol where the column type fixes the encoding.  Rows are streamed
+// to the client (see stream.go).
+//
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	u "github.com/araddon/gou"
+	"github.com/disney/quanta/qlbridge/value"
+	"github.com/disney/quanta/shared"
+	"github.com/siddontang/go-mysql/mysql"
+)
+
+const (
+	charsetUTF8   = 33 // utf8_general_ci
+	charsetBinary = 63
+	notFixedDec   = 31 // Decimals of floating point columns without a fixed scale
+	maxVarchar    = 65535
+)
+
+// resultColumn - Result set field and the Quanta type its values are coerced to.
+type resultColumn struct {
+	field *mysql.Field
+	typ   shared.DataType
+}
+
+// columnDataType - Map the database type name of a column to a Quanta data type.  Quanta tables report
+// the attribute type, computed columns the SQL type of their value.
+func columnDataType(ct *sql.ColumnType) shared.DataType {
+
+	name := ct.DatabaseTypeName()
+	if typ := shared.TypeFromString(name); typ != shared.NotDefined {
+		return typ
+	}
+	switch strings.ToUpper(name) {
+	case "BIGINT", "INT", "INTEGER":
+		return shared.Integer
+	case "DOUBLE", "FLOAT":
+		return shared.Float
+	case "BOOLEAN", "BOOL":
+		return shared.Boolean
+	case "DATE":
+		return shared.Date
+	case "DATETIME", "TIMESTAMP":
+		return shared.DateTime
+	case "JSON":
+		return shared.JSON
+	default:
+		return shared.String
+	}
+}
+
+// newResultColumn - Construct the result set field for a column.
+func newResultColumn(ct *sql.ColumnType) *resultColumn {
+
+	c := newColumn(ct.Name(), columnDataType(ct))
+	f := c.field
+	_, scale, hasScale := ct.DecimalSize()
+	switch {
+	case c.typ == shared.Float:
+		if hasScale && scale < notFixedDec {
+			f.Decimal = uint8(scale)
+		}
+	case c.typ == shared.DateTime:
+		if hasScale && scale > 0 && scale <= 6 {
+			f.Decimal = uint8(scale)
+			f.ColumnLength += uint32(scale) + 1
+		}
+	case f.Type == mysql.MYSQL_TYPE_VAR_STRING:
+		if length, ok := ct.Length(); ok && length > 0 && length*3 < maxVarchar {
+			f.ColumnLength = uint32(length * 3)
+		}
+	}
+	if nullable, ok := ct.Nullable(); ok && !nullable {
+		f.Flag |= mysql.NOT_NULL_FLAG
+	}
+	return c
+}
+
+// newColumn - Construct a nullable result set field of a Quanta type with the default length and precision.
+func newColumn(name string, typ shared.DataType) *resultColumn {
+
+	c := &resultColumn{typ: typ}
+	f := &mysql.Field{Name: []byte(name), Charset: charsetBinary, Flag: mysql.BINARY_FLAG}
+	switch c.typ {
+	case shared.Integer:
+		f.Type = mysql.MYSQL_TYPE_LONGLONG
+		f.ColumnLength = 20
+		f.Flag |= mysql.NUM_FLAG
+	case shared.Float:
+		f.Type = mysql.MYSQL_TYPE_DOUBLE
+		f.ColumnLength = 22
+		f.Flag |= mysql.NUM_FLAG
+		f.Decimal = notFixedDec
+	case shared.Boolean:
+		f.Type = mysql.MYSQL_TYPE_TINY
+		f.ColumnLength = 1
+		f.Flag |= mysql.NUM_FLAG
+	case shared.Date:
+		f.Type = mysql.MYSQL_TYPE_DATE
+		f.ColumnLength = 10
+	case shared.DateTime:
+		f.Type = mysql.MYSQL_TYPE_DATETIME
+		f.ColumnLength = 19
+	case shared.JSON:
+		f.Type = mysql.MYSQL_TYPE_JSON
+		f.ColumnLength = math.MaxUint32
+		f.Flag |= mysql.BLOB_FLAG
+	default:
+		f.Type = mysql.MYSQL_TYPE_VAR_STRING
+		f.Charset = charsetUTF8
+		f.Flag = 0
+		f.ColumnLength = maxVarchar
+	}
+	c.field = f
+	return c
+}
+
+// coerce - Convert a value returned by the driver to the column type, nil is NULL.
+func (c *resultColumn) coerce(v interface{}) (interface{}, error) {
+
+	if v == nil {
+		return nil, nil
+	}
+	if s, ok := v.(string); ok && c.typ != shared.String && c.typ != shared.JSON {
+		v = strings.TrimSpace(s)
+	}
+
+	val := value.NewValue(v)
+	switch c.typ {
+	case shared.Integer:
+		if n, ok := value.ValueToInt64(val); ok {
+			return n, nil
+		}
+	case shared.Float:
+		if n, ok := value.ValueToFloat64(val); ok {
+			return n, nil
+		}
+	case shared.Boolean:
+		if b, ok := value.ValueToBool(val); ok {
+			return b, nil
+		}
+	case shared.Date, shared.DateTime:
+		if t, ok := value.ValueToTime(val); ok {
+			return t, nil
+		}
+	case shared.JSON:
+		switch x := v.(type) {
+		case string, []byte:
+			return x, nil
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %v", c.field.Name, err)
+		}
+		return b, nil
+	default:
+		switch x := v.(type) {
+		case string, []byte:
+			return x, nil
+		case time.Time:
+			return x.Format(time.RFC3339Nano), nil
+		}
+		return fmt.Sprintf("%v", v), nil
+	}
+	return nil, fmt.Errorf("column %s: cannot convert '%v' to %s", c.field.Name, v, c.typ)
+}
+
+// fallback - The text of a value that cannot be coerced to the column type, NULL for the binary protocol.
+func (c *resultColumn) fallback(v interface{}, binary bool) interface{} {
+
+	if binary {
+		return nil
+	}
+	switch x := v.(type) {
+	case string, []byte:
+		return x
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// appendText - Encode a (non NULL) coerced value for the text protocol.
+func (c *resultColumn) appendText(row []byte, v interface{}) []byte {
+
+	var b []byte
+	switch x := v.(type) {
+	case int64:
+		b = strconv.AppendInt(nil, x, 10)
+	case float64:
+		prec := -1
+		if c.field.Decimal < notFixedDec {
+			prec = int(c.field.Decimal)
+		}
+		b = strconv.AppendFloat(nil, x, 'f', prec, 64)
+	case bool:
+		b = []byte("0")
+		if x {
+			b = []byte("1")
+		}
+	case time.Time:
+		b = []byte(x.Format(c.timeLayout()))
+	case []byte:
+		b = x
+	case string:
+		b = []byte(x)
+	}
+	return append(row, mysql.PutLengthEncodedString(b)...)
+}
+
+// appendBinary - Encode a (non NULL) coerced value for the binary protocol.
+func (c *resultColumn) appendBinary(row []byte, v interface{}) []byte {
+
+	switch x := v.(type) {
+	case int64:
+		return append(row, mysql.Uint64ToBytes(uint64(x))...)
+	case float64:
+		return append(row, mysql.Uint64ToBytes(math.Float64bits(x))...)
+	case bool:
+		if x {
+			return append(row, 1)
+		}
+		return append(row, 0)
+	case time.Time:
+		if c.typ == shared.Date {
+			return appendBinaryTime(row, x, true)
+		}
+		precision := time.Second / time.Duration(math.Pow10(int(c.field.Decimal)))
+		return appendBinaryTime(row, x.Truncate(precision), false)
+	case []byte:
+		return append(row, mysql.PutLengthEncodedString(x)...)
+	case string:
+		return append(row, mysql.PutLengthEncodedString([]byte(x))...)
+	}
+	return row
+}
+
+func (c *resultColumn) timeLayout() string {
+
+	if c.typ == shared.Date {
+		return "2006-01-02"
+	}
+	if c.field.Decimal > 0 {
+		return "2006-01-02 15:04:05." + strings.Repeat("0", int(c.field.Decimal))
+	}
+	return "2006-01-02 15:04:05"
+}
+
+// appendBinaryTime - Binary protocol DATE and DATETIME values are a length followed by as many parts as needed.
+func appendBinaryTime(row []byte, t time.Time, dateOnly bool) []byte {
+
+	if t.IsZero() {
+		return append(row, 0)
+	}
+	b := make([]byte, 0, 12)
+	b = append(b, 0, byte(t.Year()), byte(t.Year()>>8), byte(t.Month()), byte(t.Day()))
+	if !dateOnly {
+		b = append(b, byte(t.Hour()), byte(t.Minute()), byte(t.Second()))
+		if micros := t.Nanosecond() / 1000; micros > 0 {
+			b = append(b, mysql.Uint32ToBytes(uint32(micros))...)
+		}
+	}
+	b[0] = byte(len(b) - 1)
+	return append(row, b...)
+}
+
+// resultColumns - Describe the columns of a query.
+func resultColumns(rows *sql.Rows) ([]*resultColumn, error) {
+
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+	cols := make([]*resultColumn, len(types))
+	for i, ct := range types {
+		cols[i] = newResultColumn(ct)
+	}
+	return cols, nil
+}
+
+// encodeRow - Encode a row, binary rows start with a header byte and a NULL bitmap offset by 2 bits.
+func encodeRow(cols []*resultColumn, vals []interface{}, binary bool) mysql.RowData {
+
+	var row []byte
+	var nullBitmap []byte
+	if binary {
+		nullBitmap = make([]byte, (len(cols)+7+2)>>3)
+		row = append(row, 0)
+		row = append(row, nullBitmap...)
+	}
+	for i, c := range cols {
+		v, err := c.coerce(vals[i])
+		if err != nil {
+			u.Warnf("%v, returning it as text", err)
+			v = c.fallback(vals[i], binary)
+		}
+		switch {
+		case v == nil && binary:
+			nullBitmap[(i+2)>>3] |= 1 << (uint(i+2) % 8)
+		case v == nil:
+			row = append(row, 0xfb)
+		case binary:
+			row = c.appendBinary(row, v)
+		default:
+			row = c.appendText(row, v)
+		}
+	}
+	if binary {
+		copy(row[1:], nullBitmap)
+	}
+	return row
+}
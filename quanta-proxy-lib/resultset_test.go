@@ -0,0 +1,121 @@
+package proxy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/disney/quanta/shared"
+	"github.com/siddontang/go-mysql/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoerce(t *testing.T) {
+
+	ts := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		typ      shared.DataType
+		in       interface{}
+		expected interface{}
+		fails    bool
+	}{
+		{shared.Integer, nil, nil, false},
+		{shared.Integer, "42", int64(42), false},
+		{shared.Integer, "  7 ", int64(7), false},
+		{shared.Integer, int64(5), int64(5), false},
+		{shared.Integer, "abc", nil, true},
+		{shared.Integer, "NULL", nil, true},
+		{shared.Float, "1.5", 1.5, false},
+		{shared.Float, int64(2), 2.0, false},
+		{shared.Boolean, "true", true, false},
+		{shared.Boolean, false, false, false},
+		{shared.String, "NULL", "NULL", false},
+		{shared.String, "  padded ", "  padded ", false},
+		{shared.String, int64(12), "12", false},
+		{shared.String, ts, "2020-01-02T03:04:05Z", false},
+		{shared.JSON, `{"a":1}`, `{"a":1}`, false},
+		{shared.JSON, map[string]int{"a": 1}, []byte(`{"a":1}`), false},
+		{shared.JSON, nil, nil, false},
+	}
+	for _, tc := range tests {
+		c := newColumn("col", tc.typ)
+		v, err := c.coerce(tc.in)
+		if tc.fails {
+			assert.Error(t, err, "%s %v", tc.typ, tc.in)
+			continue
+		}
+		require.NoError(t, err, "%s %v", tc.typ, tc.in)
+		assert.Equal(t, tc.expected, v, "%s %v", tc.typ, tc.in)
+	}
+
+	v, err := newColumn("col", shared.DateTime).coerce("2020-01-02 03:04:05")
+	require.NoError(t, err)
+	require.IsType(t, time.Time{}, v)
+	assert.Equal(t, "2020-01-02 03:04:05", v.(time.Time).Format("2006-01-02 15:04:05"))
+}
+
+func TestEncodeRow(t *testing.T) {
+
+	intCol := newColumn("i", shared.Integer)
+	strCol := newColumn("s", shared.String)
+	lenEnc := func(s string) []byte { return mysql.PutLengthEncodedString([]byte(s)) }
+	join := func(parts ...[]byte) []byte {
+		var b []byte
+		for _, p := range parts {
+			b = append(b, p...)
+		}
+		return b
+	}
+
+	tests := []struct {
+		name     string
+		cols     []*resultColumn
+		vals     []interface{}
+		binary   bool
+		expected []byte
+	}{
+		{"text", []*resultColumn{intCol, strCol, intCol}, []interface{}{"1", "NULL", nil}, false,
+			join(lenEnc("1"), lenEnc("NULL"), []byte{0xfb})},
+		{"text fallback", []*resultColumn{intCol}, []interface{}{"abc"}, false, lenEnc("abc")},
+		{"binary", []*resultColumn{intCol, strCol}, []interface{}{int64(3), nil}, true,
+			join([]byte{0, 0x08}, mysql.Uint64ToBytes(3))},
+		{"binary fallback", []*resultColumn{intCol}, []interface{}{"abc"}, true, []byte{0, 0x04}},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, mysql.RowData(tc.expected), encodeRow(tc.cols, tc.vals, tc.binary), tc.name)
+	}
+
+	// NULLs beyond the first byte of the bitmap, bit positions are offset by 2.
+	cols := make([]*resultColumn, 10)
+	vals := make([]interface{}, 10)
+	for i := range cols {
+		cols[i] = intCol
+		vals[i] = int64(i)
+	}
+	vals[0], vals[6], vals[9] = nil, nil, nil
+	row := encodeRow(cols, vals, true)
+	require.Len(t, row, 1+2+7*8)
+	assert.Equal(t, []byte{0, 0x04, 0x09}, []byte(row[:3]))
+	assert.Equal(t, mysql.Uint64ToBytes(1), []byte(row[3:11]))
+	assert.Equal(t, mysql.Uint64ToBytes(8), []byte(row[len(row)-8:]))
+}
+
+func TestAppendBinaryTime(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		t        time.Time
+		dateOnly bool
+		expected []byte
+	}{
+		{"zero", time.Time{}, false, []byte{0}},
+		{"date", time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC), true, []byte{4, 0xe5, 0x07, 3, 4}},
+		{"datetime", time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC), false,
+			[]byte{7, 0xe5, 0x07, 3, 4, 5, 6, 7}},
+		{"micros", time.Date(2021, 3, 4, 5, 6, 7, 1500, time.UTC), false,
+			[]byte{11, 0xe5, 0x07, 3, 4, 5, 6, 7, 1, 0, 0, 0}},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, appendBinaryTime(nil, tc.t, tc.dateOnly), tc.name)
+	}
+}
@@ -0,0 +1,226 @@
+package proxy
+
+//
+// Streaming result sets.
+//
+// The MySQL server library writes the result set returned by a handler in one piece.  Instead rows are written to
+// the client as they are read from the query pipeline so that memory use is bounded by the channel and buffer sizes.
+// A slow client blocks the writes, which blocks reading rows, which in turn blocks the projection (backpressure).
+//
+// The library still writes a response for the handler's return value, it is discarded by the streamConn installed
+// for the duration of the command.
+//
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/disney/quanta/qlbridge/datasource"
+	"github.com/disney/quanta/qlbridge/expr"
+	"github.com/disney/quanta/qlbridge/rel"
+	"github.com/disney/quanta/qlbridge/value"
+	"github.com/disney/quanta/qlbridge/vm"
+	"github.com/siddontang/go-mysql/mysql"
+	"github.com/siddontang/go-mysql/server"
+)
+
+// Session variables for result set limits (SET max_result_rows = 100000).
+const (
+	MaxResultRowsKey  = "max_result_rows"
+	MaxResultBytesKey = "max_result_bytes"
+)
+
+// streamChunkSize - Rows are written to the client in chunks of this many bytes.
+const streamChunkSize = 64 * 1024
+
+var (
+	// MaxResultRows - Default maximum rows of a result set, zero is unlimited.
+	MaxResultRows int64
+	// MaxResultBytes - Default maximum size of a result set in bytes, zero is unlimited.
+	MaxResultBytes int64
+)
+
+// resultLimits - Maximum rows and bytes of a result set, zero is unlimited.
+type resultLimits struct {
+	maxRows  int64
+	maxBytes int64
+}
+
+// streamConn - Buffers the packets of a streamed result set and discards writes once it is complete.
+type streamConn struct {
+	net.Conn
+	w       *bufio.Writer
+	discard bool
+}
+
+func (c *streamConn) Write(b []byte) (int, error) {
+
+	if c.discard {
+		return len(b), nil
+	}
+	return c.w.Write(b)
+}
+
+// endStream - Restore the client connection after a streamed result set, called after each command.
+func endStream(conn *server.Conn) {
+
+	if conn == nil || conn.Conn == nil {
+		return
+	}
+	if sc, ok := conn.Conn.Conn.(*streamConn); ok {
+		conn.Conn.Conn = sc.Conn
+	}
+}
+
+// streamResultset - Write the rows of a query to the client as they are read.  An error is returned (and
+// nothing written) only if the columns cannot be described, later errors including exceeded limits and
+// interrupted queries terminate the result set with an error packet.
+func streamResultset(ctx context.Context, conn *server.Conn, rows *sql.Rows, binary bool,
+	limits resultLimits) error {
+
+	cols, err := resultColumns(rows)
+	if err != nil {
+		return err
+	}
+
+	sc := &streamConn{Conn: conn.Conn.Conn, w: bufio.NewWriterSize(conn.Conn.Conn, streamChunkSize)}
+	conn.Conn.Conn = sc
+	defer func() {
+		sc.w.Flush()
+		sc.discard = true
+	}()
+
+	if err = writeResultHeader(conn, cols); err != nil {
+		return err
+	}
+
+	readCols := make([]interface{}, len(cols))
+	vals := make([]interface{}, len(cols))
+	for i := range vals {
+		readCols[i] = &vals[i]
+	}
+	var count, size int64
+	data := make([]byte, 4, 1024)
+	for rows.Next() {
+		if err = rows.Scan(readCols...); err != nil {
+			return writeResultError(conn, mysql.NewError(mysql.ER_UNKNOWN_ERROR, err.Error()))
+		}
+		row := encodeRow(cols, vals, binary)
+		count++
+		size += int64(len(row))
+		if limits.maxRows > 0 && count > limits.maxRows {
+			return writeResultError(conn, mysql.NewError(mysql.ER_TOO_BIG_SELECT,
+				fmt.Sprintf("result exceeds %s (%d rows), add a LIMIT or raise it with SET %s",
+					MaxResultRowsKey, limits.maxRows, MaxResultRowsKey)))
+		}
+		if limits.maxBytes > 0 && size > limits.maxBytes {
+			return writeResultError(conn, mysql.NewError(mysql.ER_TOO_BIG_SELECT,
+				fmt.Sprintf("result exceeds %s (%d bytes), add a LIMIT or raise it with SET %s",
+					MaxResultBytesKey, limits.maxBytes, MaxResultBytesKey)))
+		}
+		data = append(data[:4], row...)
+		if err = conn.WritePacket(data); err != nil {
+			return err
+		}
+	}
+	if err = rows.Err(); err != nil {
+		if e, ok := interrupted(ctx, err).(*mysql.MyError); ok {
+			return writeResultError(conn, e)
+		}
+		return writeResultError(conn, mysql.NewError(mysql.ER_UNKNOWN_ERROR, err.Error()))
+	}
+	return writeEOF(conn)
+}
+
+// writeResultHeader - Write the column count, column definitions and the EOF that precedes the rows.
+func writeResultHeader(conn *server.Conn, cols []*resultColumn) error {
+
+	data := make([]byte, 4, 1024)
+	data = append(data, mysql.PutLengthEncodedInt(uint64(len(cols)))...)
+	if err := conn.WritePacket(data); err != nil {
+		return err
+	}
+	for _, c := range cols {
+		data = append(data[:4], c.field.Dump()...)
+		if err := conn.WritePacket(data); err != nil {
+			return err
+		}
+	}
+	return writeEOF(conn)
+}
+
+func writeEOF(conn *server.Conn) error {
+
+	var status uint16
+	if conn.IsAutoCommit() {
+		status = mysql.SERVER_STATUS_AUTOCOMMIT
+	}
+	data := make([]byte, 4, 9)
+	data = append(data, mysql.EOF_HEADER, 0, 0, byte(status), byte(status>>8))
+	return conn.WritePacket(data)
+}
+
+// writeResultError - Terminate a result set with an error packet.
+func writeResultError(conn *server.Conn, e *mysql.MyError) error {
+
+	data := make([]byte, 4, 16+len(e.Message))
+	data = append(data, mysql.ERR_HEADER, byte(e.Code), byte(e.Code>>8), '#')
+	data = append(data, e.State...)
+	data = append(data, e.Message...)
+	return conn.WritePacket(data)
+}
+
+// setSessionLimits - Apply SET max_result_rows / max_result_bytes / max_execution_time to the session.
+func (h *ProxyHandler) setSessionLimits(query string) error {
+
+	stmt, err := rel.ParseSql(query)
+	if err != nil {
+		return nil // Left to the query engine to report
+	}
+	cmd, ok := stmt.(*rel.SqlCommand)
+	if !ok {
+		return nil
+	}
+	for _, col := range cmd.Columns {
+		name := strings.ToLower(strings.TrimPrefix(col.Name, "@@"))
+		if name != MaxResultRowsKey && name != MaxResultBytesKey && name != MaxExecutionTimeKey {
+			continue
+		}
+		limit, err := setValueInt(col)
+		if err != nil {
+			return fmt.Errorf("%s - %v", name, err)
+		}
+		switch name {
+		case MaxResultRowsKey:
+			h.limits.maxRows = limit
+		case MaxResultBytesKey:
+			h.limits.maxBytes = limit
+		default:
+			h.maxExecutionTime = limit
+		}
+	}
+	return nil
+}
+
+// setValueInt - Evaluate the value of a SET column as a non negative integer.
+func setValueInt(col *rel.CommandColumn) (int64, error) {
+
+	bn, ok := col.Expr.(*expr.BinaryNode)
+	if !ok || len(bn.Args) != 2 {
+		return 0, fmt.Errorf("expected name = value")
+	}
+	val, ok := vm.Eval(datasource.NewContextSimple(), bn.Args[1])
+	if !ok {
+		return 0, fmt.Errorf("cannot evaluate %s", bn.Args[1])
+	}
+	n, ok := value.ValueToInt64(val)
+	if !ok || n < 0 {
+		return 0, fmt.Errorf("value must be a non negative integer, not %s", strconv.Quote(val.ToString()))
+	}
+	return n, nil
+}
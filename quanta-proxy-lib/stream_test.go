@@ -0,0 +1,109 @@
+package proxy
+
+import (
+	"database/sql"
+	"net"
+	"regexp"
+	"testing"
+
+	"github.com/disney/quanta/qlbridge/testutil"
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/siddontang/go-mysql/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Serve the mock CSV tables through the proxy handler.
+func startTestProxy(t *testing.T) *sql.DB {
+
+	testutil.Setup()
+	SetupCounters()
+	reWhitespace = regexp.MustCompile(`[\s\p{Zs}]{2,}`)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				db, _ := sql.Open("qlbridge", "mockcsv")
+				h := &ProxyHandler{db: db, stmts: make(map[interface{}]*sql.Stmt), authProvider: NewAuthProvider()}
+				h.authProvider.GetCredential("root")
+				sconn, err := server.NewConn(c, "root", "", h)
+				if err != nil {
+					return
+				}
+				h.register(sconn, c)
+				defer h.Close()
+				for {
+					err := sconn.HandleCommand()
+					endStream(sconn)
+					if err != nil {
+						return
+					}
+				}
+			}()
+		}
+	}()
+
+	client, err := sql.Open("mysql", "root:@tcp("+l.Addr().String()+")/mockcsv")
+	require.NoError(t, err)
+	client.SetMaxOpenConns(1)
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestStreamResultset(t *testing.T) {
+
+	client := startTestProxy(t)
+
+	rows, err := client.Query("select user_id, referral_count, reg_date FROM users")
+	require.NoError(t, err)
+	types, err := rows.ColumnTypes()
+	require.NoError(t, err)
+	assert.Equal(t, "VARCHAR", types[0].DatabaseTypeName())
+	assert.Equal(t, "BIGINT", types[1].DatabaseTypeName())
+	assert.Equal(t, "DATETIME", types[2].DatabaseTypeName())
+	count := 0
+	for rows.Next() {
+		var id, regDate string
+		var referrals int64
+		require.NoError(t, rows.Scan(&id, &referrals, &regDate))
+		count++
+	}
+	assert.NoError(t, rows.Err())
+	assert.Equal(t, 3, count)
+	rows.Close()
+
+	// Binary protocol
+	stmt, err := client.Prepare("select user_id, referral_count FROM users WHERE user_id = '9Ip1aKbeZe2njCDM'")
+	require.NoError(t, err)
+	var id string
+	var referrals int64
+	require.NoError(t, stmt.QueryRow().Scan(&id, &referrals))
+	assert.Equal(t, int64(82), referrals)
+	stmt.Close()
+
+	// Exceeding the session limit terminates the result with an error, the connection remains usable.
+	_, err = client.Exec("set max_result_rows = 2")
+	require.NoError(t, err)
+	rows, err = client.Query("select user_id FROM users")
+	require.NoError(t, err)
+	count = 0
+	for rows.Next() {
+		count++
+	}
+	assert.Equal(t, 2, count)
+	assert.ErrorContains(t, rows.Err(), "max_result_rows")
+	rows.Close()
+	_, err = client.Exec("set max_result_rows = -1")
+	assert.Error(t, err)
+	_, err = client.Exec("set max_result_rows = 0")
+	require.NoError(t, err)
+	require.NoError(t, client.QueryRow("select count(*) FROM users").Scan(&count))
+	assert.Equal(t, 3, count)
+}
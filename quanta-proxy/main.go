@@ -59,6 +59,9 @@ func main() {
 	consul := app.Flag("consul-endpoint", "Consul agent address/port").Default("127.0.0.1:8500").String()
 	poolSize := app.Flag("session-pool-size", "Session pool size").Int()
 	pprof := app.Flag("pprof", "Start the pprof server").Default("false").String()
+	maxResultRows := app.Flag("max-result-rows", "Default maximum rows of a result set (0 = unlimited)").Default("0").Int64()
+	maxResultBytes := app.Flag("max-result-bytes", "Default maximum size of a result set in bytes (0 = unlimited)").Default("0").Int64()
+	maxExecTime := app.Flag("max-execution-time", "Default query timeout in milliseconds (0 = unlimited)").Default("0").Int64()
 
 	kingpin.MustParse(app.Parse(os.Args[1:]))
 
@@ -88,6 +91,10 @@ func main() {
 		u.Error(errx)
 		os.Exit(1)
 	}
+	if errx = shared.RegisterViewChangeListener(consulConfig, proxy.SchemaChangeListener); errx != nil {
+		u.Error(errx)
+		os.Exit(1)
+	}
 
 	if publicKeyURL != nil && len(*publicKeyURL) != 0 {
 		proxy.PublicKeySet = make([]*jwk.Set, 0)
@@ -117,6 +124,10 @@ func main() {
 		log.Printf("Session Pool Size = %d", proxy.SessionPoolSize)
 	}
 
+	proxy.MaxResultRows = *maxResultRows
+	proxy.MaxResultBytes = *maxResultBytes
+	proxy.MaxExecutionTime = *maxExecTime
+
 	// load all of our built-in functions
 	builtins.LoadAllBuiltins()
 	sink.LoadAll()      // Register output sinks
@@ -0,0 +1,177 @@
+package rbac
+
+//
+// Parsing and execution of the SQL GRANT, REVOKE and SHOW GRANTS statements.
+//
+// GRANT <role> ON <database> TO <user>
+// GRANT SystemAdmin TO <user>
+// GRANT <privilege>[, <privilege> ...] ON [TABLE] [<database>.]<table> [(<column>, ...)] TO <user>
+// REVOKE ... FROM <user>
+// SHOW GRANTS [FOR <user>]
+//
+// Privileges are either permission names (i.e. WriteDatabase) or the SQL equivalents SELECT, INSERT,
+// UPDATE, DELETE, CREATE, ALTER, EXPORT and ALL.
+//
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	grantRegex = regexp.MustCompile(`(?is)^\s*(GRANT|REVOKE)\s+(.+?)(?:\s+ON\s+(?:TABLE\s+|DATABASE\s+)?` +
+		`([^\s(]+)(?:\s*\(([^)]*)\))?)?\s+(TO|FROM)\s+(\S+?)\s*;?\s*$`)
+	showGrantsRegex = regexp.MustCompile(`(?is)^\s*SHOW\s+GRANTS(?:\s+FOR\s+(\S+?))?\s*;?\s*$`)
+)
+
+// GrantStatement - Parsed GRANT, REVOKE or SHOW GRANTS statement.
+type GrantStatement struct {
+	Show        bool
+	Revoke      bool
+	Role        Role
+	Permissions []Permission
+	Database    string
+	Table       string
+	Columns     []string
+	UserID      string
+}
+
+// IsGrantStatement - Returns true if the statement is a GRANT, REVOKE or SHOW GRANTS.
+func IsGrantStatement(sql string) bool {
+
+	fields := strings.Fields(strings.ToUpper(sql))
+	if len(fields) == 0 {
+		return false
+	}
+	switch fields[0] {
+	case "GRANT", "REVOKE":
+		return true
+	case "SHOW":
+		return len(fields) > 1 && strings.TrimSuffix(fields[1], ";") == "GRANTS"
+	}
+	return false
+}
+
+// ParseGrantStatement - Parse a GRANT, REVOKE or SHOW GRANTS statement.  Tables that are not
+// qualified with a database name default to defaultDB.
+func ParseGrantStatement(sql, defaultDB string) (*GrantStatement, error) {
+
+	if m := showGrantsRegex.FindStringSubmatch(sql); m != nil {
+		return &GrantStatement{Show: true, UserID: unquote(m[1])}, nil
+	}
+
+	m := grantRegex.FindStringSubmatch(sql)
+	if m == nil {
+		return nil, fmt.Errorf("cannot parse grant statement [%s]", sql)
+	}
+	stmt := &GrantStatement{Revoke: strings.EqualFold(m[1], "REVOKE"), UserID: unquote(m[6])}
+	if stmt.Revoke != strings.EqualFold(m[5], "FROM") {
+		return nil, fmt.Errorf("expecting GRANT ... TO or REVOKE ... FROM [%s]", sql)
+	}
+	target := unquote(m[3])
+
+	// Role assignment
+	privileges := strings.Split(m[2], ",")
+	if len(privileges) == 1 {
+		if role := roleFromSQL(privileges[0]); role != NoRole {
+			if m[4] != "" || strings.Contains(target, ".") {
+				return nil, fmt.Errorf("roles are granted on a database not a table [%s]", sql)
+			}
+			if target == "" && role != SystemAdmin {
+				return nil, fmt.Errorf("database must be specified for role %s", role.String())
+			}
+			stmt.Role = role
+			stmt.Database = target
+			return stmt, nil
+		}
+	}
+
+	// Table permissions
+	stmt.Permissions = make([]Permission, len(privileges))
+	for i, v := range privileges {
+		if stmt.Permissions[i] = PermissionFromSQL(v); stmt.Permissions[i] == NoPermission {
+			return nil, fmt.Errorf("unknown privilege '%s'", strings.TrimSpace(v))
+		}
+	}
+	if target == "" {
+		return nil, fmt.Errorf("table must be specified [%s]", sql)
+	}
+	stmt.Database = defaultDB
+	stmt.Table = target
+	if i := strings.LastIndex(target, "."); i >= 0 {
+		stmt.Database = unquote(target[:i])
+		stmt.Table = unquote(target[i+1:])
+	}
+	if m[4] != "" {
+		for _, col := range strings.Split(m[4], ",") {
+			if col = unquote(col); col != "" {
+				stmt.Columns = append(stmt.Columns, col)
+			}
+		}
+	}
+	return stmt, nil
+}
+
+// ExecuteGrantStatement - Execute a parsed grant statement.  Returns the grants for SHOW GRANTS.
+func (c *AuthContext) ExecuteGrantStatement(stmt *GrantStatement) ([]string, error) {
+
+	if stmt.Show {
+		return c.ShowGrants(stmt.UserID)
+	}
+	if stmt.Role != NoRole {
+		if stmt.Revoke {
+			return nil, c.RevokeRole(stmt.UserID, stmt.Database)
+		}
+		return nil, c.GrantRole(stmt.Role, stmt.UserID, stmt.Database, false)
+	}
+	for _, perm := range stmt.Permissions {
+		var err error
+		if stmt.Revoke {
+			err = c.RevokeTable(perm, stmt.UserID, stmt.Database, stmt.Table, stmt.Columns)
+		} else {
+			err = c.GrantTable(perm, stmt.UserID, stmt.Database, stmt.Table, stmt.Columns)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// PermissionFromSQL - Construct a Permission from a SQL privilege or a permission name.
+func PermissionFromSQL(privilege string) Permission {
+
+	privilege = strings.TrimSpace(privilege)
+	switch strings.ToUpper(privilege) {
+	case "SELECT":
+		return ViewDatabase
+	case "INSERT", "UPDATE", "DELETE", "WRITE":
+		return WriteDatabase
+	case "CREATE", "ALTER", "DROP", "ALL", "ALL PRIVILEGES":
+		return CreateOrAlterTable
+	case "EXPORT":
+		return ExportData
+	}
+	for p := CreateSession; p <= CreateOrAlterView; p++ {
+		if strings.EqualFold(privilege, p.String()) {
+			return p
+		}
+	}
+	return NoPermission
+}
+
+func roleFromSQL(role string) Role {
+
+	role = strings.TrimSpace(role)
+	for r := DomainUser; r <= SystemAdmin; r++ {
+		if strings.EqualFold(role, r.String()) {
+			return r
+		}
+	}
+	return NoRole
+}
+
+func unquote(s string) string {
+	return strings.Trim(strings.TrimSpace(s), "'\"`")
+}
@@ -3,6 +3,7 @@ package rbac
 import (
 	"fmt"
 	"reflect"
+	"strings"
 
 	u "github.com/araddon/gou"
 	"github.com/disney/quanta/shared"
@@ -107,6 +108,182 @@ func (c *AuthContext) IsAuthorized(perm Permission, database string) (bool, erro
 	return perm <= authRole.MaxPermission(), nil
 }
 
+// IsAuthorizedForTable - Check user permissions for a table and optionally a set of columns.  The
+// database role is checked first, then any table level grants.  A grant that is restricted to a set
+// of columns only authorizes operations that reference those columns.
+func (c *AuthContext) IsAuthorizedForTable(perm Permission, database, table string, columns []string) (bool, error) {
+
+	grantee, err := load(c.Store, c.UserID)
+	if err != nil {
+		return false, fmt.Errorf("Error in IsAuthorizedForTable(load) [%v]", err)
+	}
+	if grantee == nil {
+		return false, fmt.Errorf("Unknown user %s", c.UserID)
+	}
+
+	if grantee.IsSystemAdmin || perm <= grantee.getRole(database).MaxPermission() {
+		return true, nil
+	}
+	if grantee.hasTableGrant(perm, database, table, columns) {
+		return true, nil
+	}
+
+	err = fmt.Errorf("Attempting %s, user %s is not authorized for table %s.%s", perm.String(), c.UserID,
+		database, table)
+	if len(columns) > 0 {
+		err = fmt.Errorf("Attempting %s, user %s is not authorized for table %s.%s columns %v", perm.String(),
+			c.UserID, database, table, columns)
+	}
+	u.Error(err)
+	return false, err
+}
+
+// RevokeRole - Remove a user's role assignment for a database.  If database is empty then the
+// SystemAdmin role is revoked.
+func (c *AuthContext) RevokeRole(userID, database string) error {
+
+	if userID == "" {
+		return fmt.Errorf("Grantee must be specified")
+	}
+	if c.UserID == userID {
+		return fmt.Errorf("Cannot revoke roles from self")
+	}
+	grantor, grantee, err := c.loadGrantorAndGrantee(userID, "RevokeRole")
+	if err != nil {
+		return err
+	}
+
+	role := grantee.getRole(database)
+	if database == "" {
+		role = NoRole
+		if grantee.IsSystemAdmin {
+			role = SystemAdmin
+		}
+	}
+	if role == NoRole {
+		return fmt.Errorf("Error in RevokeRole [User %s has no role for database %s]", userID, database)
+	}
+	if role > grantor.getRole(database) {
+		return fmt.Errorf("Error in RevokeRole [Cannot revoke a role above grantor's level]")
+	}
+
+	grantee.setRole(NoRole, database)
+	return grantee.save(c.Store)
+}
+
+// GrantTable - Grant a permission for a table.  If columns are provided then the grant is
+// restricted to those columns, otherwise it applies to the entire table.
+func (c *AuthContext) GrantTable(perm Permission, userID, database, table string, columns []string) error {
+
+	if err := c.checkTableGrant(perm, userID, database, table); err != nil {
+		return err
+	}
+	grantor, grantee, err := c.loadGrantorAndGrantee(userID, "GrantTable")
+	if err != nil {
+		return err
+	}
+	if err := grantor.checkTableGrantor(perm, database); err != nil {
+		return fmt.Errorf("Error in GrantTable [%v]", err)
+	}
+
+	grantee.grantTable(perm, database, table, columns)
+	return grantee.save(c.Store)
+}
+
+// RevokeTable - Revoke a table permission (or a subset of its columns).
+func (c *AuthContext) RevokeTable(perm Permission, userID, database, table string, columns []string) error {
+
+	if err := c.checkTableGrant(perm, userID, database, table); err != nil {
+		return err
+	}
+	grantor, grantee, err := c.loadGrantorAndGrantee(userID, "RevokeTable")
+	if err != nil {
+		return err
+	}
+	if err := grantor.checkTableGrantor(perm, database); err != nil {
+		return fmt.Errorf("Error in RevokeTable [%v]", err)
+	}
+
+	if err := grantee.revokeTable(perm, database, table, columns); err != nil {
+		return fmt.Errorf("Error in RevokeTable [%v]", err)
+	}
+	return grantee.save(c.Store)
+}
+
+// ShowGrants - Return the grants for a user as GRANT statements.  Users can always view their own
+// grants, otherwise only grants for databases where the caller is a DomainAdmin are shown.
+func (c *AuthContext) ShowGrants(userID string) ([]string, error) {
+
+	if userID == "" {
+		userID = c.UserID
+	}
+	caller, grantee, err := c.loadGrantorAndGrantee(userID, "ShowGrants")
+	if err != nil {
+		return nil, err
+	}
+	visible := func(database string) bool {
+		return c.UserID == userID || caller.getRole(database) >= DomainAdmin
+	}
+
+	grants := make([]string, 0)
+	if grantee.IsSystemAdmin && visible("") {
+		grants = append(grants, fmt.Sprintf("GRANT %s TO %s", SystemAdmin.String(), userID))
+	}
+	for _, dbRole := range grantee.DBRoles {
+		if visible(dbRole.Database) {
+			grants = append(grants, fmt.Sprintf("GRANT %s ON %s TO %s", dbRole.Role, dbRole.Database, userID))
+		}
+	}
+	for _, g := range grantee.TableGrants {
+		if !visible(g.Database) {
+			continue
+		}
+		cols := ""
+		if len(g.Columns) > 0 {
+			cols = " (" + strings.Join(g.Columns, ", ") + ")"
+		}
+		grants = append(grants, fmt.Sprintf("GRANT %s ON %s.%s%s TO %s", g.Permission, g.Database, g.Table,
+			cols, userID))
+	}
+	return grants, nil
+}
+
+func (c *AuthContext) checkTableGrant(perm Permission, userID, database, table string) error {
+
+	if perm == NoPermission {
+		return fmt.Errorf("Permission must be specified")
+	}
+	if database == "" || table == "" {
+		return fmt.Errorf("Database and table must be specified")
+	}
+	if userID == "" {
+		return fmt.Errorf("Grantee must be specified")
+	}
+	if c.UserID == userID {
+		return fmt.Errorf("Cannot grant permissions to self")
+	}
+	return nil
+}
+
+func (c *AuthContext) loadGrantorAndGrantee(userID, caller string) (*User, *User, error) {
+
+	grantor, err := load(c.Store, c.UserID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error in grantor %s(load) [%v]", caller, err)
+	}
+	grantee, err := load(c.Store, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Error in grantee %s(load) [%v]", caller, err)
+	}
+	if grantor == nil {
+		return nil, nil, fmt.Errorf("Error in %s(load) [User %s not found]", caller, c.UserID)
+	}
+	if grantee == nil {
+		return nil, nil, fmt.Errorf("Error in %s(load) [User %s not found]", caller, userID)
+	}
+	return grantor, grantee, nil
+}
+
 // Role - User roles
 type Role int
 
@@ -236,11 +413,20 @@ type DbRole struct {
 	Role     string `yaml:"role"`
 }
 
+// TableGrant - Permission granted for a specific table, optionally restricted to a set of columns.
+type TableGrant struct {
+	Database   string   `yaml:"database"`
+	Table      string   `yaml:"table"`
+	Permission string   `yaml:"permission"`
+	Columns    []string `yaml:"columns,omitempty"`
+}
+
 // User to Role mapping container
 type User struct {
-	UserID        string   `yaml:"id"`
-	IsSystemAdmin bool     `yaml:"isSystemAdmin"`
-	DBRoles       []DbRole `yaml:"dbRoles"`
+	UserID        string       `yaml:"id"`
+	IsSystemAdmin bool         `yaml:"isSystemAdmin"`
+	DBRoles       []DbRole     `yaml:"dbRoles"`
+	TableGrants   []TableGrant `yaml:"tableGrants,omitempty"`
 }
 
 func load(store *shared.KVStore, userID string) (*User, error) {
@@ -279,8 +465,16 @@ func (user *User) setRole(role Role, database string) {
 		user.IsSystemAdmin = true
 		return
 	}
+	if role == NoRole && database == "" {
+		user.IsSystemAdmin = false
+		return
+	}
 	for i, dbRole := range user.DBRoles {
 		if dbRole.Database == database {
+			if role == NoRole {
+				user.DBRoles = append(user.DBRoles[:i], user.DBRoles[i+1:]...)
+				return
+			}
 			user.DBRoles[i].Role = role.String()
 			return
 		}
@@ -289,6 +483,108 @@ func (user *User) setRole(role Role, database string) {
 	return
 }
 
+// hasTableGrant - Do the table grants cover the permission (and columns)?  Grants are hierarchical
+// in the same way as roles, i.e. WriteDatabase implies ViewDatabase.
+func (user *User) hasTableGrant(perm Permission, database, table string, columns []string) bool {
+
+	covered := make(map[string]struct{})
+	for _, g := range user.TableGrants {
+		if g.Database != database || g.Table != table || PermissionFromString(g.Permission) < perm {
+			continue
+		}
+		if len(g.Columns) == 0 {
+			return true
+		}
+		for _, col := range g.Columns {
+			covered[col] = struct{}{}
+		}
+	}
+	if len(columns) == 0 {
+		return false
+	}
+	for _, col := range columns {
+		if _, ok := covered[col]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (user *User) grantTable(perm Permission, database, table string, columns []string) {
+
+	for i, g := range user.TableGrants {
+		if g.Database != database || g.Table != table || g.Permission != perm.String() {
+			continue
+		}
+		if len(columns) == 0 || len(g.Columns) == 0 {
+			user.TableGrants[i].Columns = nil
+			return
+		}
+		for _, col := range columns {
+			found := false
+			for _, x := range g.Columns {
+				if x == col {
+					found = true
+					break
+				}
+			}
+			if !found {
+				user.TableGrants[i].Columns = append(user.TableGrants[i].Columns, col)
+			}
+		}
+		return
+	}
+	user.TableGrants = append(user.TableGrants, TableGrant{Database: database, Table: table,
+		Permission: perm.String(), Columns: columns})
+}
+
+func (user *User) revokeTable(perm Permission, database, table string, columns []string) error {
+
+	for i, g := range user.TableGrants {
+		if g.Database != database || g.Table != table || g.Permission != perm.String() {
+			continue
+		}
+		if len(columns) > 0 {
+			if len(g.Columns) == 0 {
+				return fmt.Errorf("Cannot revoke columns from a grant for the entire table")
+			}
+			remaining := make([]string, 0)
+			for _, x := range g.Columns {
+				revoked := false
+				for _, col := range columns {
+					if x == col {
+						revoked = true
+						break
+					}
+				}
+				if !revoked {
+					remaining = append(remaining, x)
+				}
+			}
+			if len(remaining) > 0 {
+				user.TableGrants[i].Columns = remaining
+				return nil
+			}
+		}
+		user.TableGrants = append(user.TableGrants[:i], user.TableGrants[i+1:]...)
+		return nil
+	}
+	return fmt.Errorf("User %s has no %s grant for table %s.%s", user.UserID, perm.String(), database, table)
+}
+
+// checkTableGrantor - A grantor must be a DomainAdmin and hold the permission being granted.
+func (user *User) checkTableGrantor(perm Permission, database string) error {
+
+	role := user.getRole(database)
+	if role < DomainAdmin {
+		return fmt.Errorf("Grantor must be a DomainAdmin for database %s", database)
+	}
+	if perm > role.MaxPermission() {
+		return fmt.Errorf("Cannot grant a permission above grantor's level")
+	}
+	return nil
+}
+
 func (user *User) save(store *shared.KVStore) error {
 
 	b, err := yaml.Marshal(&user)
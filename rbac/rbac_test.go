@@ -142,3 +142,87 @@ func (suite *RBACTestSuite) TestPermSuccess() {
 	assert.NoError(suite.T(), err)
 	assert.True(suite.T(), ok)
 }
+
+func (suite *RBACTestSuite) TestTableGrants() {
+
+	ctx, err := NewAuthContext(suite.client, "USER002", false)
+	assert.NoError(suite.T(), err)
+	ok, _ := ctx.IsAuthorizedForTable(WriteDatabase, "quanta", "orders", []string{"amount"})
+	assert.False(suite.T(), ok)
+
+	// DomainUser cannot grant table permissions
+	err = ctx.GrantTable(WriteDatabase, "USER001", "quanta", "orders", nil)
+	assert.EqualError(suite.T(), err, "Error in GrantTable [Grantor must be a DomainAdmin for database quanta]")
+
+	admin, err := NewAuthContext(suite.client, "USER001", false)
+	assert.NoError(suite.T(), err)
+	err = admin.GrantTable(WriteDatabase, "USER002", "quanta", "orders", []string{"amount", "status"})
+	assert.NoError(suite.T(), err)
+
+	ok, err = ctx.IsAuthorizedForTable(WriteDatabase, "quanta", "orders", []string{"amount"})
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), ok)
+	ok, _ = ctx.IsAuthorizedForTable(WriteDatabase, "quanta", "orders", []string{"amount", "price"})
+	assert.False(suite.T(), ok)
+	ok, _ = ctx.IsAuthorizedForTable(WriteDatabase, "quanta", "orders", nil)
+	assert.False(suite.T(), ok)
+	ok, _ = ctx.IsAuthorizedForTable(ExportData, "quanta", "orders", []string{"amount"})
+	assert.False(suite.T(), ok)
+
+	grants, err := ctx.ShowGrants("")
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), grants, "GRANT WriteDatabase ON quanta.orders (amount, status) TO USER002")
+
+	err = admin.RevokeTable(WriteDatabase, "USER002", "quanta", "orders", []string{"amount"})
+	assert.NoError(suite.T(), err)
+	ok, _ = ctx.IsAuthorizedForTable(WriteDatabase, "quanta", "orders", []string{"amount"})
+	assert.False(suite.T(), ok)
+	ok, _ = ctx.IsAuthorizedForTable(WriteDatabase, "quanta", "orders", []string{"status"})
+	assert.True(suite.T(), ok)
+
+	err = admin.RevokeTable(WriteDatabase, "USER002", "quanta", "orders", nil)
+	assert.NoError(suite.T(), err)
+	ok, _ = ctx.IsAuthorizedForTable(WriteDatabase, "quanta", "orders", []string{"status"})
+	assert.False(suite.T(), ok)
+}
+
+func TestParseGrantStatement(t *testing.T) {
+
+	stmt, err := ParseGrantStatement("GRANT select, UPDATE ON TABLE orders (amount, `status`) TO 'USER002'", "quanta")
+	assert.NoError(t, err)
+	assert.False(t, stmt.Revoke)
+	assert.Equal(t, []Permission{ViewDatabase, WriteDatabase}, stmt.Permissions)
+	assert.Equal(t, "quanta", stmt.Database)
+	assert.Equal(t, "orders", stmt.Table)
+	assert.Equal(t, []string{"amount", "status"}, stmt.Columns)
+	assert.Equal(t, "USER002", stmt.UserID)
+
+	stmt, err = ParseGrantStatement("REVOKE DomainAdmin ON quanta FROM USER002;", "quanta")
+	assert.NoError(t, err)
+	assert.True(t, stmt.Revoke)
+	assert.Equal(t, DomainAdmin, stmt.Role)
+	assert.Equal(t, "quanta", stmt.Database)
+
+	stmt, err = ParseGrantStatement("grant SystemAdmin to USER003", "quanta")
+	assert.NoError(t, err)
+	assert.Equal(t, SystemAdmin, stmt.Role)
+	assert.Equal(t, "", stmt.Database)
+
+	stmt, err = ParseGrantStatement("SHOW GRANTS FOR USER002", "quanta")
+	assert.NoError(t, err)
+	assert.True(t, stmt.Show)
+	assert.Equal(t, "USER002", stmt.UserID)
+
+	stmt, err = ParseGrantStatement("GRANT ALL PRIVILEGES ON sales.orders TO USER002", "quanta")
+	assert.NoError(t, err)
+	assert.Equal(t, []Permission{CreateOrAlterTable}, stmt.Permissions)
+	assert.Equal(t, "sales", stmt.Database)
+
+	_, err = ParseGrantStatement("GRANT JUNK ON orders TO USER002", "quanta")
+	assert.EqualError(t, err, "unknown privilege 'JUNK'")
+	_, err = ParseGrantStatement("GRANT SELECT ON orders FROM USER002", "quanta")
+	assert.Error(t, err)
+
+	assert.True(t, IsGrantStatement("show grants"))
+	assert.False(t, IsGrantStatement("show tables"))
+}
@@ -48,9 +48,14 @@ var (
 // setBitThreads - Used to identify when incoming API SetBatch calls have fallen to zero triggering writes.
 // writeSignal - Channel used by setBitThreads to initiate write operations to persist cache items.
 // tableCache - Schema metadata cache (essentially same YAML file used by loader).
+// wal - Write-ahead log of mutations not yet persisted (nil if persistence is disabled).
+// cold - Archived partitions, loaded on demand by time range queries.
+// drain - Progress of the node decommission.
+// repair - Progress of the anti-entropy repair between replicas.
 type BitmapIndex struct {
 	*Node
 	memoryLimitMb   int
+	coldLimitMb     int
 	bitmapCache     map[string]map[string]map[uint64]map[int64]*StandardBitmap
 	bitmapCacheLock sync.RWMutex
 	bsiCache        map[string]map[string]map[int64]*BSIBitmap
@@ -75,6 +80,10 @@ type BitmapIndex struct {
 	saveBSIECnt     atomic.Uint64
 	saveBSITCnt     atomic.Uint64
 	saveBSITime     atomic.Uint64
+	wal             *WAL
+	cold            *ColdStore
+	drain           decommission
+	repair          repair
 }
 
 type WorkerThread struct {
@@ -143,6 +152,12 @@ func NewBitmapIndex(node *Node, memoryLimitMb int) *BitmapIndex {
 	return e
 }
 
+// SetColdCacheLimit - Set the memory budget for archived partitions loaded by queries.  Must be
+// called before Init.
+func (m *BitmapIndex) SetColdCacheLimit(limitMb int) {
+	m.coldLimitMb = limitMb
+}
+
 func (m *BitmapIndex) GetBitmapCache() map[string]map[string]map[uint64]map[int64]*StandardBitmap {
 	return m.bitmapCache
 }
@@ -187,6 +202,19 @@ func (m *BitmapIndex) Init() error {
 		return fmt.Errorf("cannot initialize bitmap server error: %v", err)
 	}
 
+	// Replay mutations that were not persisted before the last shutdown
+	if m.ServicePort != 0 {
+		if err := m.replayWAL(); err != nil {
+			return fmt.Errorf("cannot initialize bitmap server error: %v", err)
+		}
+	}
+
+	// Catalog archived partitions so that they remain queryable
+	m.cold = NewColdStore(m.dataDir+sep+"archive", m.coldLimitMb)
+	if err := m.cold.Scan(); err != nil {
+		u.Errorf("%v", err)
+	}
+
 	if m.memoryLimitMb > 0 {
 		u.Infof("Starting data expiration thread - expiration after %d Mb limit.", m.memoryLimitMb)
 	} else {
@@ -205,10 +233,7 @@ func (m *BitmapIndex) Init() error {
 				return
 			case forceSync := <-m.writeSignal:
 				// fmt.Println(m.Node.hashKey, "had writeSignal, checkPersist*Cache(", forceSync, ")")
-				// go
-				m.checkPersistBitmapCache(forceSync)
-				// go
-				m.checkPersistBSICache(forceSync)
+				m.persistCaches(forceSync)
 				// fmt.Println(m.Node.hashKey, "had writeSignal DONE")
 			}
 		}
@@ -220,7 +245,11 @@ func (m *BitmapIndex) Init() error {
 // Shutdown - Shut down and clean up.
 func (m *BitmapIndex) Shutdown() {
 	u.Warnf("Shutting down bitmap server.")
-	// TODO:  Anything to do here?
+	if m.wal != nil {
+		if err := m.wal.Close(); err != nil {
+			u.Errorf("error closing WAL - %v", err)
+		}
+	}
 }
 
 // JoinCluster - Join the cluster
@@ -241,6 +270,9 @@ func (m *BitmapIndex) BatchMutate(stream pb.BitmapIndex_BatchMutateServer) error
 	for {
 		kv, err := stream.Recv()
 		if err == io.EOF {
+			if err := m.syncWAL(); err != nil {
+				return err
+			}
 			return stream.SendAndClose(&empty.Empty{})
 		}
 		if err != nil {
@@ -276,18 +308,146 @@ func (m *BitmapIndex) BatchMutate(stream pb.BitmapIndex_BatchMutateServer) error
 			isBSI, kv.IsClear, false)
 
 		if kv.Sync {
-			if frag.IsBSI {
-				m.updateBSICache(frag)
-			} else {
-				m.updateBitmapCache(frag)
+			err := m.logMutation(&walRecord{kind: walFragment, frag: frag}, func() error {
+				if frag.IsBSI {
+					m.updateBSICache(frag)
+				} else {
+					m.updateBitmapCache(frag)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
 			}
-			return nil
+			return m.syncWAL()
+		}
+		if err := m.queueFragment(frag); err != nil {
+			return fmt.Errorf("BatchMutate: %v", err)
+		}
+	}
+}
+
+// logMutation - Append a mutation to the WAL and then apply it.  Sealing of the active WAL segment
+// waits until the mutation has been applied.
+func (m *BitmapIndex) logMutation(rec *walRecord, apply func() error) error {
+
+	if m.wal != nil {
+		m.wal.Begin()
+		defer m.wal.End()
+		if err := m.wal.Append(rec); err != nil {
+			return err
 		}
+	}
+	return apply()
+}
+
+// queueFragment - Log a fragment to the WAL and add it to the fragment queue.
+func (m *BitmapIndex) queueFragment(frag *BitmapFragment) error {
+
+	return m.logMutation(&walRecord{kind: walFragment, frag: frag}, func() error {
 		select {
 		case m.fragQueue <- frag:
-			// fmt.Println(m.hashKey, "svr BatchMutate sent to fragQueue", frag.FieldName, frag.RowIDOrBits, frag.Time.Format(timeFmt), uintptr(unsafe.Pointer(m)))
+			return nil
 		default:
-			return fmt.Errorf("BatchMutate: fragment queue is full")
+			return fmt.Errorf("fragment queue is full")
+		}
+	})
+}
+
+// syncWAL - Make logged mutations durable before they are acknowledged.
+func (m *BitmapIndex) syncWAL() error {
+
+	if m.wal == nil {
+		return nil
+	}
+	return m.wal.Sync()
+}
+
+// replayWAL - Open the WAL and apply the mutations it contains.  Must be called after the bitmap
+// files are read.  The segments are removed after the next successful persist.
+func (m *BitmapIndex) replayWAL() error {
+
+	wal, err := OpenWAL(m.dataDir + sep + "wal")
+	if err != nil {
+		return err
+	}
+	segments := wal.Sealed()
+	if len(segments) > 0 {
+		// Data read from the bitmap files must be in the cache first.
+		for len(m.fragQueue) > 0 {
+			time.Sleep(100 * time.Millisecond)
+		}
+		if err := m.flush(); err != nil {
+			return err
+		}
+	}
+	for i, path := range segments {
+		n, complete, err := replayWALSegment(path, m.applyWALRecord)
+		if err != nil {
+			return fmt.Errorf("WAL replay of %s failed - %v", path, err)
+		}
+		// Later segments cannot be applied without the mutations lost from a damaged segment.
+		if !complete && i < len(segments)-1 {
+			return fmt.Errorf("WAL replay of %s failed - segment is damaged after %d records and is followed by %d more",
+				path, n, len(segments)-1-i)
+		}
+		u.Infof("Replayed %d WAL records from %s", n, path)
+	}
+	m.wal = wal
+	return nil
+}
+
+// applyWALRecord - Apply a mutation during WAL replay.
+func (m *BitmapIndex) applyWALRecord(rec *walRecord) error {
+
+	switch rec.kind {
+	case walFragment:
+		frag := rec.frag
+		if _, err := m.getFieldConfig(frag.IndexName, frag.FieldName); err != nil {
+			return nil // Table or attribute was dropped
+		}
+		if frag.IsBSI {
+			m.updateBSICache(frag)
+		} else {
+			m.updateBitmapCache(frag)
+		}
+	case walBulkClear:
+		foundSet := roaring64.NewBitmap()
+		if err := foundSet.UnmarshalBinary(rec.foundSet); err != nil {
+			return err
+		}
+		m.clearAll(rec.index, rec.start, rec.end, foundSet)
+	case walTruncate:
+		m.Truncate(rec.index)
+	case walDropField:
+		m.clearField(rec.index, rec.field)
+	}
+	return nil
+}
+
+// persistCaches - Write dirty cache items to disk.  WAL segments sealed beforehand are removed once
+// everything has been persisted.
+func (m *BitmapIndex) persistCaches(forceSync bool) {
+
+	var sealed []string
+	if m.wal != nil {
+		var err error
+		if sealed, err = m.wal.Rotate(); err != nil {
+			u.Errorf("WAL rotate failed - %v", err)
+		}
+		// Everything in the sealed segments must be applied to the cache before it is persisted.
+		if len(sealed) > 0 {
+			if err := m.flush(); err != nil {
+				u.Errorf("persistCaches flush failed - %v", err)
+				sealed = nil
+			}
+		}
+	}
+	bitmapOK := m.checkPersistBitmapCache(forceSync)
+	bsiOK := m.checkPersistBSICache(forceSync)
+	if len(sealed) > 0 && bitmapOK && bsiOK {
+		if err := m.wal.Remove(sealed); err != nil {
+			u.Errorf("WAL segment removal failed - %v", err)
 		}
 	}
 }
@@ -538,13 +698,14 @@ func (m *BitmapIndex) partitionProcessLoop() {
 
 		select {
 		case p := <-m.partitionQueue:
-			m.executeOperation(p)
-			m.purgePartition(p.Partition)
+			m.processPartition(p)
 			runtime.GC()
 		case <-time.After(time.Hour):
 			state, _, _ := m.GetClusterState()
 			if m.State == Active && state == shared.Green {
 				m.cleanupStrandedShards()
+				m.enforceRetention()
+				m.scheduleRepair()
 			}
 		}
 	}
@@ -569,10 +730,7 @@ func (m *BitmapIndex) verifyNode() {
 			m.State = Active
 			u.Debugf("verifyNode Setting node state to Active for %s", m.hashKey)
 			// we need to 'touch' the health so everyone knows we are active atw
-			consul := m.Consul
-			valStr := fmt.Sprintf("%s_%d", m.hashKey, time.Now().UnixMilli())
-			pair := &api.KVPair{Key: "AnyNodeStatusChangeTime", Value: []byte(valStr)}
-			consul.KV().Put(pair, nil)
+			m.publishState()
 			break
 		}
 		time.Sleep(shared.SyncRetryInterval)
@@ -582,6 +740,14 @@ func (m *BitmapIndex) verifyNode() {
 	u.Debug("verifyNode done ", m.hashKey)
 }
 
+// publishState - Touch AnyNodeStatusChangeTime so that peers and clients refresh the node status.
+func (m *BitmapIndex) publishState() {
+
+	valStr := fmt.Sprintf("%s_%d", m.hashKey, time.Now().UnixMilli())
+	pair := &api.KVPair{Key: "AnyNodeStatusChangeTime", Value: []byte(valStr)}
+	m.Consul.KV().Put(pair, nil)
+}
+
 // Updates to the standard bitmap field cache
 func (m *BitmapIndex) updateBitmapCache(f *BitmapFragment) {
 
@@ -784,6 +950,20 @@ func (m *BitmapIndex) updateBSICache(f *BitmapFragment) {
 	}
 }
 
+// logTruncate - Truncate the in-memory data cache for a given index and record it in the WAL so that
+// earlier mutations are not resurrected by a replay.
+func (m *BitmapIndex) logTruncate(index string) error {
+
+	err := m.logMutation(&walRecord{kind: walTruncate, index: index}, func() error {
+		m.Truncate(index)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return m.syncWAL()
+}
+
 // Truncate - Truncate the in-memory data cache for a given index
 func (m *BitmapIndex) Truncate(index string) {
 
@@ -807,6 +987,66 @@ func (m *BitmapIndex) Truncate(index string) {
 	}
 }
 
+// dropArchive - Remove the archived partitions of a table.
+func (m *BitmapIndex) dropArchive(index string) {
+
+	if m.cold == nil {
+		return
+	}
+	if err := m.cold.Drop(index); err != nil {
+		u.Errorf("error removing archived partitions for table %s - %v", index, err)
+	}
+}
+
+// dropField - Purge the bitmaps, backing strings and archived partitions of a dropped field.  The drop is
+// recorded in the WAL so that a replay does not resurrect earlier mutations if the field is added again.
+func (m *BitmapIndex) dropField(index, field string) {
+
+	err := m.logMutation(&walRecord{kind: walDropField, index: index, field: field}, func() error {
+		m.clearField(index, field)
+		return nil
+	})
+	if err != nil {
+		u.Errorf("error logging drop of field %s.%s - %v", index, field, err)
+		m.clearField(index, field)
+	} else if err := m.syncWAL(); err != nil {
+		u.Errorf("error logging drop of field %s.%s - %v", index, field, err)
+	}
+
+	if err := os.RemoveAll(m.dataDir + sep + "bitmap" + sep + index + sep + field); err != nil {
+		u.Errorf("error dropping field %s.%s directory - %v", index, field, err)
+	}
+	if m.cold != nil {
+		if err := m.cold.DropField(index, field); err != nil {
+			u.Errorf("error removing archived partitions for field %s.%s - %v", index, field, err)
+		}
+	}
+	if localKV, ok := m.Node.GetNodeService("KVStore").(*KVStore); ok {
+		for _, prefix := range []string{index + sep + field + sep, index + sep + field + ".StringEnum"} {
+			req := &pb.DeleteIndicesWithPrefixRequest{Prefix: prefix}
+			if _, err := localKV.DeleteIndicesWithPrefix(context.Background(), req); err != nil {
+				u.Errorf("error dropping field %s.%s indices - %v", index, field, err)
+			}
+		}
+	}
+	u.Infof("Field %s.%s dropped.", index, field)
+}
+
+// clearField - Remove a field from the in-memory data caches.
+func (m *BitmapIndex) clearField(index, field string) {
+
+	m.bitmapCacheLock.Lock()
+	if fm, ok := m.bitmapCache[index]; ok {
+		delete(fm, field)
+	}
+	m.bitmapCacheLock.Unlock()
+	m.bsiCacheLock.Lock()
+	if fm, ok := m.bsiCache[index]; ok {
+		delete(fm, field)
+	}
+	m.bsiCacheLock.Unlock()
+}
+
 func (m *BitmapIndex) cleanupStrandedShards() {
 
 	m.cleanupLock.RLock()
@@ -879,7 +1119,7 @@ func (m *BitmapIndex) cleanupOp(p *Partition) error {
 		hashKey = fmt.Sprintf("%s/%s/%s", p.Index, p.Field, p.Time.Format(timeFmt))
 	}
 
-	nodeKeys := m.HashTable.GetN(m.Replicas, hashKey)
+	nodeKeys := m.GetNodesForKey(hashKey)
 
 	// fmt.Println("cleanupOp ", m.hashKey, " hashKey ", hashKey, " nodeKeys ", nodeKeys)
 
@@ -948,20 +1188,28 @@ func (m *BitmapIndex) iterateBSICache(op func(p *Partition) error) {
 // calcMemOp - Calculate memory usage per bitmap
 func (m *BitmapIndex) calcMemOp(p *Partition) error {
 
+	m.memoryUsed += partitionBytes(p)
+	return nil
+}
+
+// partitionBytes - Serialized size of a partition.
+func partitionBytes(p *Partition) int {
+
+	size := 0
 	if p.RowIDOrBits < 0 {
 		bsi := p.Shard.(*BSIBitmap)
 		if b, err := bsi.MarshalBinary(); err == nil {
 			for _, x := range b {
-				m.memoryUsed += len(x)
+				size += len(x)
 			}
 		}
 	} else {
 		bitmap := p.Shard.(*StandardBitmap)
 		if b, err := bitmap.Bits.MarshalBinary(); err == nil {
-			m.memoryUsed += len(b)
+			size += len(b)
 		}
 	}
-	return nil
+	return size
 }
 
 // calculateMemoryUsage - Calculate memory usage
@@ -1004,11 +1252,12 @@ func (m *BitmapIndex) truncateCaches(index string) {
 	}
 }
 
-// Iterate standard bitmap cache looking for potential writes (dirty data)
-func (m *BitmapIndex) checkPersistBitmapCache(forceSync bool) {
+// Iterate standard bitmap cache looking for potential writes (dirty data).  Returns false if any
+// write failed.
+func (m *BitmapIndex) checkPersistBitmapCache(forceSync bool) bool {
 
 	if m.ServicePort == 0 {
-		return // test mode, persistence disabled
+		return true // test mode, persistence disabled
 	}
 
 	m.bitmapCacheLock.RLock()
@@ -1016,6 +1265,7 @@ func (m *BitmapIndex) checkPersistBitmapCache(forceSync bool) {
 
 	bitmapCount := 0
 	var writeCount uint64
+	ok := true
 	start := time.Now()
 	for indexName, index := range m.bitmapCache {
 		for fieldName, field := range index {
@@ -1028,6 +1278,7 @@ func (m *BitmapIndex) checkPersistBitmapCache(forceSync bool) {
 							time.Unix(0, t)); err != nil {
 							u.Errorf("saveCompleteBitmap failed! - %v", err)
 							bitmap.Lock.Unlock()
+							ok = false
 							continue
 						}
 						writeCount++
@@ -1051,13 +1302,14 @@ func (m *BitmapIndex) checkPersistBitmapCache(forceSync bool) {
 			u.Debugf("Persist [edge triggered] %d files done in %v", writeCount, elapsed)
 		}
 	}
+	return ok
 }
 
-// Iterate BSI cache looking for potential writes (dirty data)
-func (m *BitmapIndex) checkPersistBSICache(forceSync bool) {
+// Iterate BSI cache looking for potential writes (dirty data).  Returns false if any write failed.
+func (m *BitmapIndex) checkPersistBSICache(forceSync bool) bool {
 
 	if m.ServicePort == 0 {
-		return // test mode persistence disabled
+		return true // test mode persistence disabled
 	}
 
 	m.bsiCacheLock.RLock()
@@ -1076,7 +1328,7 @@ func (m *BitmapIndex) checkPersistBSICache(forceSync bool) {
 						time.Unix(0, t)); err != nil {
 						u.Errorf("saveCompleteBSI failed! - %v", err)
 						bsi.Lock.Unlock()
-						return
+						return false
 					}
 					writeCount++
 					bsi.PersistTime = time.Now()
@@ -1098,6 +1350,7 @@ func (m *BitmapIndex) checkPersistBSICache(forceSync bool) {
 			u.Debugf("Persist BSI [edge triggered] %d files done in %v", writeCount, elapsed)
 		}
 	}
+	return true
 }
 
 // BulkClear - Batch "delete".
@@ -1111,8 +1364,16 @@ func (m *BitmapIndex) BulkClear(ctx context.Context, req *pb.BulkClearRequest) (
 	if err := foundSet.UnmarshalBinary(req.FoundSet); err != nil {
 		return &empty.Empty{}, err
 	}
-	m.clearAll(req.Index, int64(req.FromTime), int64(req.ToTime), foundSet)
-	return &empty.Empty{}, nil
+	rec := &walRecord{kind: walBulkClear, index: req.Index, start: int64(req.FromTime),
+		end: int64(req.ToTime), foundSet: req.FoundSet}
+	err := m.logMutation(rec, func() error {
+		m.clearAll(req.Index, int64(req.FromTime), int64(req.ToTime), foundSet)
+		return nil
+	})
+	if err != nil {
+		return &empty.Empty{}, err
+	}
+	return &empty.Empty{}, m.syncWAL()
 
 }
 
@@ -1159,13 +1420,10 @@ func (m *BitmapIndex) Update(ctx context.Context, req *pb.UpdateRequest) (*empty
 		ba[0] = buf
 		frag = newBitmapFragment(req.Index, req.Field, req.RowIdOrValue, ts, ba, isBSI, false, true)
 	}
-	select {
-	case m.fragQueue <- frag:
-	default:
-		// Fragment queue is full
-		return &empty.Empty{}, fmt.Errorf("Update: fragment queue is full")
+	if err := m.queueFragment(frag); err != nil {
+		return &empty.Empty{}, fmt.Errorf("Update: %v", err)
 	}
-	return &empty.Empty{}, nil
+	return &empty.Empty{}, m.syncWAL()
 }
 
 // Flush will first wait until everything currently in the queue is processed, maybe more.
@@ -1349,15 +1607,26 @@ func (m *BitmapIndex) TableOperation(ctx context.Context, req *pb.TableOperation
 			os.Exit(1)
 		} else {
 			m.tableCacheLock.Lock()
-			defer m.tableCacheLock.Unlock()
+			var dropped []string
+			if existing, ok := m.tableCache[req.Table]; ok {
+				dropped = existing.DroppedAttributes(table)
+			}
 			m.tableCache[req.Table] = table
+			m.tableCacheLock.Unlock()
 			u.Infof("%s schema for table re-loaded and initialized %s", m.hashKey, req.Table)
+			for _, field := range dropped {
+				m.dropField(req.Table, field)
+			}
 		}
 	case pb.TableOperationRequest_DROP:
+		// The WAL is written before taking the table lock, mutations in flight may hold the WAL gate.
+		if err := m.logTruncate(req.Table); err != nil {
+			return &empty.Empty{}, err
+		}
 		m.tableCacheLock.Lock()
 		defer m.tableCacheLock.Unlock()
 		delete(m.tableCache, req.Table)
-		m.Truncate(req.Table)
+		m.dropArchive(req.Table)
 		tableDir := m.dataDir + sep + "bitmap" + sep + req.Table
 		if err := os.RemoveAll(tableDir); err != nil {
 			u.Infof("error dropping table %s directory - %v", req.Table, err)
@@ -1365,9 +1634,12 @@ func (m *BitmapIndex) TableOperation(ctx context.Context, req *pb.TableOperation
 			u.Infof("Table %s dropped.", req.Table)
 		}
 	case pb.TableOperationRequest_TRUNCATE:
+		if err := m.logTruncate(req.Table); err != nil {
+			return &empty.Empty{}, err
+		}
 		m.tableCacheLock.Lock()
 		defer m.tableCacheLock.Unlock()
-		m.Truncate(req.Table)
+		m.dropArchive(req.Table)
 		tableDir := m.dataDir + sep + "bitmap" + sep + req.Table
 		if err := os.RemoveAll(tableDir); err != nil {
 			u.Errorf("error truncating table %s directory - %v", req.Table, err)
@@ -120,10 +120,21 @@ func (m *BitmapIndex) executeOperation(aop *PartitionOperation) error {
 	oldPath := m.generateBitmapFilePath(aop.Partition, false)
 	newPath := m.generateBitmapFilePath(aop.Partition, true)
 	aop.newPath = newPath
+	if aop.RowIDOrBits >= 0 {
+		// Standard bitmap partitions are files within the row directory
+		oldPath = oldPath + sep + aop.Time.Format(timeFmt)
+		newPath = newPath + sep + aop.Time.Format(timeFmt)
+		if _, err := os.Stat(oldPath); os.IsNotExist(err) {
+			return nil // Not persisted
+		}
+	}
 
 	if err := filepath.Walk(oldPath, aop.perform); err != nil {
 		return err
 	}
+	if !aop.RemoveOnly && m.cold != nil {
+		m.cold.Add(aop.Index, aop.Field, aop.RowIDOrBits, aop.Time, newPath)
+	}
 	if aop.RowIDOrBits >= 0 {
 		return nil
 	}
@@ -468,6 +479,15 @@ func (m *BitmapIndex) readBitmapFiles(fragQueue chan *BitmapFragment) error {
 	return nil
 }
 
+// processPartition - Archive or remove a partition and purge it from cache.
+func (m *BitmapIndex) processPartition(aop *PartitionOperation) {
+
+	if err := m.executeOperation(aop); err != nil {
+		u.Errorf("partition operation failed for %s/%s/%d - %v", aop.Index, aop.Field, aop.RowIDOrBits, err)
+	}
+	m.purgePartition(aop.Partition)
+}
+
 // Purge a partition from cache
 func (m *BitmapIndex) purgePartition(aop *Partition) {
 
@@ -55,6 +55,10 @@ func (m *BitmapIndex) Query(ctx context.Context, query *pb.BitmapQuery) (*pb.Que
 
 	dataMap := make(map[string]*roaring64.Bitmap)
 	samples := make([]*shared.RowBitmap, 0)
+	reads := &coldReads{replica: int(query.Replica)}
+	if query.ShardChecksums {
+		reads.shards = make(map[string]*pb.ShardChecksum)
+	}
 
 	/*
 	 *  Iterate over query predicates to see if there are any null checks or situations where there is no
@@ -91,7 +95,7 @@ func (m *BitmapIndex) Query(ctx context.Context, query *pb.BitmapQuery) (*pb.Que
 				return nil, fmt.Errorf("timeRangeExistence GetPK info failed for %s - %v", v.Index, err)
 			}
 			var errx error
-			ei, errx = m.timeRangeExistence(v.Index, pka[0].FieldName, fromTime, toTime)
+			ei, errx = m.timeRangeExistence(v.Index, pka[0].FieldName, fromTime, toTime, reads)
 			if errx != nil {
 				return nil, fmt.Errorf("timeRangeExistence failed for %s - %v", v.Index, errx)
 			}
@@ -99,8 +103,13 @@ func (m *BitmapIndex) Query(ctx context.Context, query *pb.BitmapQuery) (*pb.Que
 		}
 	}
 
-	// Main query flow loop
+	// Main query flow loop, abandoned if the client cancels the query.
+	fragmentStats := make(map[string]*shared.FragmentStats)
 	for _, v := range query.Query {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		fragmentStart := time.Now()
 		var bm *roaring64.Bitmap
 		var err error
 		if v.NullCheck {
@@ -111,13 +120,13 @@ func (m *BitmapIndex) Query(ctx context.Context, query *pb.BitmapQuery) (*pb.Que
 			}
 		}
 		if v.NullCheck && m.isBSI(v.Index, v.Field) {
-			bm, err = m.timeRangeExistence(v.Index, v.Field, fromTime, toTime)
+			bm, err = m.timeRangeExistence(v.Index, v.Field, fromTime, toTime, reads)
 			if err != nil {
 				return nil, fmt.Errorf("timeRangeExistence failed for %s - %v", v.Index, err)
 			}
 		} else if v.BsiOp > 0 {
 			start := time.Now()
-			bsi, err := m.timeRangeBSI(v.Index, v.Field, fromTime, toTime, nil, false)
+			bsi, err := m.timeRangeBSI(v.Index, v.Field, fromTime, toTime, nil, false, reads)
 			if err != nil {
 				return nil, err
 			}
@@ -149,7 +158,10 @@ func (m *BitmapIndex) Query(ctx context.Context, query *pb.BitmapQuery) (*pb.Que
 				var x *roaring64.Bitmap
 				exist := make([]*roaring64.Bitmap, 0)
 				for _, row := range m.listAllRowIDs(v.Index, v.Field) {
-					if x, err = m.timeRange(v.Index, v.Field, row, fromTime, toTime, nil, false); err != nil {
+					if err = ctx.Err(); err != nil {
+						return nil, err
+					}
+					if x, err = m.timeRange(v.Index, v.Field, row, fromTime, toTime, nil, false, reads); err != nil {
 						return nil, err
 					}
 					if x.GetCardinality() == 0 {
@@ -165,7 +177,7 @@ func (m *BitmapIndex) Query(ctx context.Context, query *pb.BitmapQuery) (*pb.Que
 					bm = roaring64.ParOr(0, exist...)
 				}
 			} else {
-				if bm, err = m.timeRange(v.Index, v.Field, v.RowID, fromTime, toTime, nil, false); err != nil {
+				if bm, err = m.timeRange(v.Index, v.Field, v.RowID, fromTime, toTime, nil, false, reads); err != nil {
 					return nil, err
 				}
 			}
@@ -178,6 +190,10 @@ func (m *BitmapIndex) Query(ctx context.Context, query *pb.BitmapQuery) (*pb.Que
 		} else {
 			dataMap[v.Id] = roaring64.NewBitmap()
 		}
+		if query.Analyze {
+			fragmentStats[v.Id] = &shared.FragmentStats{Node: m.GetNodeID(),
+				Cardinality: dataMap[v.Id].GetCardinality(), Elapsed: time.Since(fragmentStart)}
+		}
 	}
 
 	start := time.Now()
@@ -188,6 +204,16 @@ func (m *BitmapIndex) Query(ctx context.Context, query *pb.BitmapQuery) (*pb.Que
 	if ge, ok := globalExistence[ir.Index]; ok {
 		ir.AddExistence(ge)
 	}
+	for id, v := range fragmentStats {
+		ir.AddFragmentStats(id, v)
+	}
+	for _, v := range reads.shards {
+		ir.AddShard(v)
+	}
+	ir.ColdPartitions = reads.get()
+	if ir.ColdPartitions > 0 {
+		u.Infof("Query read %d archived partitions", ir.ColdPartitions)
+	}
 	elapsed := time.Since(start)
 	u.Debugf("Reduce and finalize response elapsed time %v", elapsed)
 
@@ -205,12 +231,10 @@ func truncateTime(tr time.Time, tq string) time.Time {
 	return time.Unix(0, rts)
 }
 
-// Walk the time range and assemble a union of all bitmap fields.
+// Walk the time range and assemble a union of all bitmap fields.  Archived partitions in the time
+// range are included and counted in reads (if not nil).  They are loaded before the cache lock is taken.
 func (m *BitmapIndex) timeRange(index, field string, rowID uint64, fromTime,
-	toTime time.Time, foundSet *roaring64.Bitmap, negate bool) (*roaring64.Bitmap, error) {
-
-	m.bitmapCacheLock.RLock()
-	defer m.bitmapCacheLock.RUnlock()
+	toTime time.Time, foundSet *roaring64.Bitmap, negate bool, reads *coldReads) (*roaring64.Bitmap, error) {
 
 	attr, err := m.getFieldConfig(index, field)
 	if err != nil {
@@ -219,30 +243,45 @@ func (m *BitmapIndex) timeRange(index, field string, rowID uint64, fromTime,
 	tq := attr.TimeQuantumType
 	fromTime = truncateTime(fromTime, tq)
 	toTime = truncateTime(toTime, tq)
+	cold, err := m.coldBitmaps(index, field, rowID, tq, fromTime, toTime, reads)
+	if err != nil {
+		return nil, err
+	}
+
+	m.bitmapCacheLock.RLock()
+	defer m.bitmapCacheLock.RUnlock()
+
 	result := roaring64.NewBitmap()
 	yr, mn, da := fromTime.Date()
 	lookupTime := time.Date(yr, mn, da, 0, 0, 0, 0, time.UTC)
 	a := make([]*roaring64.Bitmap, 0)
 
+	// Apply the found set (if any) and add to the union
+	selectBits := func(bits *roaring64.Bitmap) {
+		if foundSet == nil {
+			a = append(a, bits)
+			return
+		}
+		b := bits.Clone()
+		if negate {
+			b.AndNot(foundSet)
+		} else {
+			b.And(foundSet)
+		}
+		if b.GetCardinality() > 0 {
+			a = append(a, b)
+		}
+	}
+
 	if tq == "" { // No time quantum
 		hashKey := fmt.Sprintf("%s/%s/%d/%s", index, field, rowID, lookupTime.Format(timeFmt))
-		if !m.Member(hashKey) {
+		if !m.readMember(hashKey, reads) {
 			return result, nil
 		}
 		if bm, ok := m.bitmapCache[index][field][rowID][0]; ok {
-			if foundSet != nil {
-				b := bm.Bits.Clone()
-				if negate {
-					b.AndNot(foundSet)
-				} else {
-					b.And(foundSet)
-				}
-				a = append(a, b)
-			} else {
-				a = append(a, bm.Bits)
-			}
+			selectBits(bm.Bits)
+			reads.addBitmap(index, field, rowID, 0, bm)
 			u.Debugf("timeRange No Quantum selecting %s", hashKey)
-			result = roaring64.ParOr(0, a...)
 		}
 	} else {
 		if rm, ok := m.bitmapCache[index][field][rowID]; ok {
@@ -252,27 +291,19 @@ func (m *BitmapIndex) timeRange(index, field string, rowID uint64, fromTime,
 					continue
 				}
 				hashKey := fmt.Sprintf("%s/%s/%d/%s", index, field, rowID, time.Unix(0, ts).Format(timeFmt))
-				if !m.Member(hashKey) {
+				if !m.readMember(hashKey, reads) {
 					continue
 				}
-				if foundSet != nil {
-					b := bitmap.Bits.Clone()
-					if negate {
-						b.AndNot(foundSet)
-					} else {
-						b.And(foundSet)
-					}
-					if b.GetCardinality() == 0 {
-						continue
-					}
-					a = append(a, b)
-					u.Debugf("timeRange %s selecting %s", tq, hashKey)
-				} else {
-					a = append(a, bitmap.Bits)
-					u.Debugf("timeRange %s selecting %s", tq, hashKey)
-				}
+				selectBits(bitmap.Bits)
+				reads.addBitmap(index, field, rowID, ts, bitmap)
+				u.Debugf("timeRange %s selecting %s", tq, hashKey)
 			}
 		}
+	}
+	for _, bits := range cold {
+		selectBits(bits)
+	}
+	if len(a) > 0 {
 		result = roaring64.ParOr(0, a...)
 	}
 	return result, nil
@@ -286,15 +317,21 @@ func (m *BitmapIndex) listAllRowIDs(index, field string) []uint64 {
 	for k := range m.bitmapCache[index][field] {
 		rowIDs = append(rowIDs, k)
 	}
+	if m.cold != nil {
+		for _, k := range m.cold.RowIDs(index, field) {
+			if _, found := m.bitmapCache[index][field][k]; !found {
+				rowIDs = append(rowIDs, k)
+			}
+		}
+	}
 	return rowIDs
 }
 
-// Walk the time range and assemble a union of all BSI fields.
+// Walk the time range and assemble a union of all BSI fields.  Archived partitions in the time
+// range are included and counted in reads (if not nil).  They are loaded before the cache lock is taken.
+// Where a partition was written to after it was archived, in memory values take precedence.
 func (m *BitmapIndex) timeRangeBSI(index, field string, fromTime, toTime time.Time,
-	foundSet *roaring64.Bitmap, negate bool) (*BSIBitmap, error) {
-
-	m.bsiCacheLock.RLock()
-	defer m.bsiCacheLock.RUnlock()
+	foundSet *roaring64.Bitmap, negate bool, reads *coldReads) (*BSIBitmap, error) {
 
 	attr, err := m.getFieldConfig(index, field)
 	if err != nil {
@@ -303,32 +340,48 @@ func (m *BitmapIndex) timeRangeBSI(index, field string, fromTime, toTime time.Ti
 	tq := attr.TimeQuantumType
 	fromTime = truncateTime(fromTime, tq)
 	toTime = truncateTime(toTime, tq)
+	cold, err := m.coldBSIs(index, field, tq, fromTime, toTime, reads)
+	if err != nil {
+		return nil, err
+	}
+
+	m.bsiCacheLock.RLock()
+	defer m.bsiCacheLock.RUnlock()
+
 	result := m.newBSIBitmap(index, field)
 	yr, mn, da := fromTime.Date()
 	lookupTime := time.Date(yr, mn, da, 0, 0, 0, 0, time.UTC)
 	a := make([]*roaring64.BSI, 0)
 
+	// Apply the found set (if any) and add to the union
+	selectBSI := func(bsi *roaring64.BSI) {
+		if foundSet == nil {
+			if bsi.GetCardinality() > 0 {
+				a = append(a, bsi)
+			}
+			return
+		}
+		var x *roaring64.BSI
+		if negate {
+			x = bsi.NewBSIRetainSet(roaring64.AndNot(bsi.GetExistenceBitmap(), foundSet))
+		} else {
+			x = bsi.NewBSIRetainSet(foundSet)
+		}
+		if x.GetCardinality() > 0 {
+			a = append(a, x)
+		}
+	}
+
 	if tq == "" { // No time quantum
 		// Verify that the data shard is primary here, skip if not.
 		hashKey := fmt.Sprintf("%s/%s/%s", index, field, lookupTime.Format(timeFmt))
-		if !m.Member(hashKey) {
+		if !m.readMember(hashKey, reads) {
 			return result, nil
 		}
 		if bm, ok := m.bsiCache[index][field][0]; ok {
-			if foundSet != nil {
-				if negate {
-					a = append(a, bm.BSI.NewBSIRetainSet(roaring64.AndNot(bm.BSI.GetExistenceBitmap(), foundSet)))
-				} else {
-					a = append(a, bm.BSI.NewBSIRetainSet(foundSet))
-				}
-			} else {
-				a = append(a, bm.BSI)
-			}
+			selectBSI(bm.BSI)
+			reads.addBSI(index, field, 0, bm)
 			u.Debugf("timeRangeBSI No Quantum selecting %s", hashKey)
-			//result.BSI.ParOr(0, a...)
-			for _, v := range a {
-				result.BSI.ParOr(0, v)
-			}
 		}
 	} else {
 		if tm, ok := m.bsiCache[index][field]; ok {
@@ -338,43 +391,40 @@ func (m *BitmapIndex) timeRangeBSI(index, field string, fromTime, toTime time.Ti
 					continue
 				}
 				hashKey := fmt.Sprintf("%s/%s/%s", index, field, time.Unix(0, ts).Format(timeFmt))
-				if !m.Member(hashKey) {
+				if !m.readMember(hashKey, reads) {
 					continue
 				}
-				if foundSet != nil {
-					var x *roaring64.BSI
-					if negate {
-						x = bsi.BSI.NewBSIRetainSet(roaring64.AndNot(bsi.BSI.GetExistenceBitmap(), foundSet))
-					} else {
-						x = bsi.BSI.NewBSIRetainSet(foundSet)
-					}
-					if x.GetCardinality() == 0 {
-						continue
-					}
-					a = append(a, x)
-					u.Debugf("timeRangeBSI %s selecting %s", tq, hashKey)
-				} else {
-					if bsi.BSI.GetCardinality() == 0 {
-						continue
-					}
-					a = append(a, bsi.BSI)
-					u.Debugf("timeRangeBSI %s selecting %s", tq, hashKey)
-				}
+				selectBSI(bsi.BSI)
+				reads.addBSI(index, field, ts, bsi)
+				u.Debugf("timeRangeBSI %s selecting %s", tq, hashKey)
 			}
 		}
-		//result.BSI.ParOr(0, a...)
-		for _, v := range a {
-			result.BSI.ParOr(0, v)
+	}
+	for ts, bsi := range cold {
+		if hot, ok := m.bsiCache[index][field][ts]; ok {
+			bsi = bsi.NewBSIRetainSet(roaring64.AndNot(bsi.GetExistenceBitmap(), hot.GetExistenceBitmap()))
 		}
+		selectBSI(bsi)
+	}
+	//result.BSI.ParOr(0, a...)
+	for _, v := range a {
+		result.BSI.ParOr(0, v)
 	}
 	return result, nil
 }
 
-// Walk the time range and assemble a union of all BSI esistence
-func (m *BitmapIndex) timeRangeExistence(index, field string, fromTime, toTime time.Time) (*roaring64.Bitmap, error) {
+// readMember - Returns true if this node answers reads of a shard for a request.
+func (m *BitmapIndex) readMember(key string, reads *coldReads) bool {
 
-	m.bsiCacheLock.RLock()
-	defer m.bsiCacheLock.RUnlock()
+	if reads == nil {
+		return m.Member(key)
+	}
+	return m.MemberReplica(key, reads.replica)
+}
+
+// Walk the time range and assemble a union of all BSI esistence (including archived partitions)
+func (m *BitmapIndex) timeRangeExistence(index, field string, fromTime, toTime time.Time,
+	reads *coldReads) (*roaring64.Bitmap, error) {
 
 	attr, err := m.getFieldConfig(index, field)
 	if err != nil {
@@ -383,17 +433,26 @@ func (m *BitmapIndex) timeRangeExistence(index, field string, fromTime, toTime t
 	tq := attr.TimeQuantumType
 	fromTime = truncateTime(fromTime, tq)
 	toTime = truncateTime(toTime, tq)
+	cold, err := m.coldBSIs(index, field, tq, fromTime, toTime, reads)
+	if err != nil {
+		return nil, err
+	}
+
+	m.bsiCacheLock.RLock()
+	defer m.bsiCacheLock.RUnlock()
+
 	results := make([]*roaring64.Bitmap, 0)
 	yr, mn, da := fromTime.Date()
 	lookupTime := time.Date(yr, mn, da, 0, 0, 0, 0, time.UTC)
 	if tq == "" { // No time quantum
 		// Verify that the data shard is primary here, skip if not.
 		hashKey := fmt.Sprintf("%s/%s/%s", index, field, lookupTime.Format(timeFmt))
-		if !m.Member(hashKey) {
+		if !m.readMember(hashKey, reads) {
 			return roaring64.ParOr(0, results...), nil
 		}
 		if bm, ok := m.bsiCache[index][field][0]; ok {
 			results = append(results, bm.BSI.GetExistenceBitmap())
+			reads.addBSI(index, field, 0, bm)
 		}
 		u.Debugf("timeRangeExistence No Quantum selecting %s", hashKey)
 	} else {
@@ -404,14 +463,18 @@ func (m *BitmapIndex) timeRangeExistence(index, field string, fromTime, toTime t
 					continue
 				}
 				hashKey := fmt.Sprintf("%s/%s/%s", index, field, time.Unix(0, ts).Format(timeFmt))
-				if !m.Member(hashKey) {
+				if !m.readMember(hashKey, reads) {
 					continue
 				}
 				u.Debugf("timeRangeExistence %s selecting %s", tq, hashKey)
 				results = append(results, bm.BSI.GetExistenceBitmap())
+				reads.addBSI(index, field, ts, bm)
 			}
 		}
 	}
+	for _, bsi := range cold {
+		results = append(results, bsi.GetExistenceBitmap())
+	}
 	return roaring64.ParOr(0, results...), nil
 }
 
@@ -448,13 +511,17 @@ func (m *BitmapIndex) Join(ctx context.Context, req *pb.JoinRequest) (*pb.JoinRe
 		filterSets[i] = filterSet
 	}
 
+	reads := &coldReads{}
 	bsiArray := make([]*BSIBitmap, len(req.FkFields))
 	minCardValue := uint64(1<<64 - 1)
 	minCardIndex := 0
 	for i, v := range req.FkFields {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		start := time.Now()
 		//bsi, err := m.timeRangeBSI(req.DriverIndex, v, fromTime, toTime, foundSet, req.Negate)
-		bsi, err := m.timeRangeBSI(req.DriverIndex, v, fromTime, toTime, foundSet, false)
+		bsi, err := m.timeRangeBSI(req.DriverIndex, v, fromTime, toTime, foundSet, false, reads)
 		if err != nil {
 			err2 := fmt.Errorf("cannot find FK BSI for %s %s - %v", req.DriverIndex, v, err)
 			return nil, err2
@@ -470,6 +537,9 @@ func (m *BitmapIndex) Join(ctx context.Context, req *pb.JoinRequest) (*pb.JoinRe
 	}
 
 	// Process the final FK relation with TransposeWithCounts
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	start := time.Now()
 	transposeBsi := bsiArray[minCardIndex]
 	jr := transposeBsi.TransposeWithCounts(0, transposeBsi.GetExistenceBitmap(), filterSets[minCardIndex])
@@ -480,7 +550,10 @@ func (m *BitmapIndex) Join(ctx context.Context, req *pb.JoinRequest) (*pb.JoinRe
 	if err != nil {
 		return nil, err
 	}
-	return &pb.JoinResponse{Results: data}, nil
+	if reads.get() > 0 {
+		u.Infof("Join read %d archived partitions", reads.get())
+	}
+	return &pb.JoinResponse{Results: data, ColdPartitions: int32(reads.get())}, nil
 }
 
 // Projection - Retrieve bitmaps to be included in a result set projection.
@@ -507,8 +580,12 @@ func (m *BitmapIndex) Projection(ctx context.Context, req *pb.ProjectionRequest)
 	bsiResults := make([]*pb.BSIResult, 0)
 
 	start := time.Now()
+	reads := &coldReads{}
 	var err2 error
 	for _, v := range req.Fields {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		attr, err := m.getFieldConfig(req.Index, v)
 		if err != nil {
 			return nil, err
@@ -516,7 +593,11 @@ func (m *BitmapIndex) Projection(ctx context.Context, req *pb.ProjectionRequest)
 		if _, ok := m.bitmapCache[req.Index][v]; ok {
 			var x *roaring64.Bitmap
 			for _, row := range m.listAllRowIDs(req.Index, v) {
-				if x, err2 = m.timeRange(req.Index, v, row, fromTime, toTime, foundSet, req.Negate); err2 != nil {
+				if err2 = ctx.Err(); err2 != nil {
+					return nil, err2
+				}
+				if x, err2 = m.timeRange(req.Index, v, row, fromTime, toTime, foundSet, req.Negate,
+					reads); err2 != nil {
 					return nil, err2
 				}
 				if x.GetCardinality() == 0 {
@@ -532,7 +613,7 @@ func (m *BitmapIndex) Projection(ctx context.Context, req *pb.ProjectionRequest)
 		if _, ok := m.bsiCache[req.Index][v]; ok {
 			var bsi *BSIBitmap
 			//if bsi, err2 = m.timeRangeBSI(req.Index, v, fromTime, toTime, foundSet, req.Negate); err2 != nil {
-			if bsi, err2 = m.timeRangeBSI(req.Index, v, fromTime, toTime, foundSet, false); err2 != nil {
+			if bsi, err2 = m.timeRangeBSI(req.Index, v, fromTime, toTime, foundSet, false, reads); err2 != nil {
 				return nil, fmt.Errorf("Error ranging projection BSI for %s %s - %v", req.Index, v, err2)
 			}
 			if bsi.GetCardinality() == 0 {
@@ -553,5 +634,314 @@ func (m *BitmapIndex) Projection(ctx context.Context, req *pb.ProjectionRequest)
 	}
 	elapsed := time.Since(start)
 	u.Debugf("Projection retrieval elapsed time %v", elapsed)
-	return &pb.ProjectionResponse{BitmapResults: bitmapResults, BsiResults: bsiResults}, nil
+	if reads.get() > 0 {
+		u.Infof("Projection read %d archived partitions", reads.get())
+	}
+	return &pb.ProjectionResponse{BitmapResults: bitmapResults, BsiResults: bsiResults,
+		ColdPartitions: int32(reads.get())}, nil
+}
+
+// GroupBy - Compute partial aggregates for each group set.  The client computes the group sets by
+// intersecting the query results with the row bitmaps of the GROUP BY fields (via the Projection API).
+// Each node then aggregates the BSI partitions that it owns.  Results are returned per partition so
+// that the client can discard duplicates returned by replicas.
+func (m *BitmapIndex) GroupBy(ctx context.Context, req *pb.GroupByRequest) (*pb.GroupByResponse, error) {
+
+	u.Debugf("GroupBy started for %v - %v", req.Index, req.Fields)
+
+	fromTime := time.Unix(0, req.FromTime)
+	toTime := time.Unix(0, req.ToTime)
+
+	if req.Index == "" {
+		return nil, fmt.Errorf("index not specified for group by criteria")
+	}
+	if req.GroupSets == nil || len(req.GroupSets) == 0 {
+		return nil, fmt.Errorf("group sets not specified for group by criteria")
+	}
+
+	groupSets := make([]*roaring64.Bitmap, len(req.GroupSets))
+	for i, gsData := range req.GroupSets {
+		groupSet := roaring64.NewBitmap()
+		if err := groupSet.UnmarshalBinary(gsData); err != nil {
+			return nil, err
+		}
+		groupSets[i] = groupSet
+	}
+
+	start := time.Now()
+	results := make([]*pb.GroupByResult, 0)
+	for _, v := range req.Fields {
+		partitions, err := m.partitionsBSI(req.Index, v, fromTime, toTime)
+		if err != nil {
+			return nil, fmt.Errorf("Error ranging group by BSI for %s %s - %v", req.Index, v, err)
+		}
+		for ts, bsi := range partitions {
+			ebm := bsi.GetExistenceBitmap()
+			for i, groupSet := range groupSets {
+				fs := roaring64.And(ebm, groupSet)
+				if fs.GetCardinality() == 0 {
+					continue
+				}
+				r := &pb.GroupByResult{Group: uint32(i), Field: v, Partition: ts}
+				r.Sum, r.Count = bsi.Sum(fs)
+				r.Min = bsi.MinMax(0, roaring64.MIN, fs)
+				r.Max = bsi.MinMax(0, roaring64.MAX, fs)
+				results = append(results, r)
+			}
+		}
+	}
+	elapsed := time.Since(start)
+	u.Debugf("GroupBy elapsed time %v", elapsed)
+	return &pb.GroupByResponse{Results: results}, nil
+}
+
+// TopK - Return the column IDs within the found set that have the k largest (or smallest) values
+// of a BSI field.  Each partition contributes at most k results which are reduced client side.
+func (m *BitmapIndex) TopK(ctx context.Context, req *pb.TopKRequest) (*pb.TopKResponse, error) {
+
+	u.Debugf("TopK started for %v - %v", req.Index, req.Field)
+
+	fromTime := time.Unix(0, req.FromTime)
+	toTime := time.Unix(0, req.ToTime)
+
+	if req.Index == "" {
+		return nil, fmt.Errorf("index not specified for top k criteria")
+	}
+	if req.Field == "" {
+		return nil, fmt.Errorf("field not specified for top k criteria")
+	}
+	if req.K == 0 {
+		return &pb.TopKResponse{Results: []*pb.TopKResult{}}, nil
+	}
+	foundSet := roaring64.NewBitmap()
+	if err := foundSet.UnmarshalBinary(req.FoundSet); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	partitions, err := m.partitionsBSI(req.Index, req.Field, fromTime, toTime)
+	if err != nil {
+		return nil, fmt.Errorf("Error ranging top k BSI for %s %s - %v", req.Index, req.Field, err)
+	}
+	results := make([]*pb.TopKResult, 0)
+	for _, bsi := range partitions {
+		r, err := topK(bsi, foundSet, int(req.K), req.Descending)
+		if err != nil {
+			return nil, fmt.Errorf("Error computing top k for %s %s - %v", req.Index, req.Field, err)
+		}
+		results = append(results, r...)
+	}
+	elapsed := time.Since(start)
+	u.Debugf("TopK elapsed time %v", elapsed)
+	return &pb.TopKResponse{Results: results}, nil
+}
+
+// topK - Bit sliced top K selection.  Slices are visited from most to least significant, at each step
+// the candidates having the preferred bit value are retained if they don't exceed k.  For negative
+// values (64 bit two's complement) the sign slice is visited first with the preference reversed.
+// Ties at the boundary are broken arbitrarily.
+func topK(bsi *roaring64.BSI, foundSet *roaring64.Bitmap, k int, desc bool) ([]*pb.TopKResult, error) {
+
+	candidates := roaring64.And(bsi.GetExistenceBitmap(), foundSet)
+	if candidates.GetCardinality() == 0 {
+		return []*pb.TopKResult{}, nil
+	}
+
+	// Only the slices of the candidate columns are needed.
+	data, err := bsi.NewBSIRetainSet(candidates).MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	slices := make([]*roaring64.Bitmap, len(data)-1)
+	for i := range slices {
+		slices[i] = roaring64.NewBitmap()
+		if len(data[i+1]) == 0 {
+			continue
+		}
+		if err := slices[i].UnmarshalBinary(data[i+1]); err != nil {
+			return nil, err
+		}
+	}
+
+	winners := roaring64.NewBitmap()
+	for i := len(slices) - 1; i >= 0 && candidates.GetCardinality() > 0; i-- {
+		preferSet := desc
+		if i == 63 {
+			preferSet = !desc // sign bit
+		}
+		var preferred *roaring64.Bitmap
+		if preferSet {
+			preferred = roaring64.And(candidates, slices[i])
+		} else {
+			preferred = roaring64.AndNot(candidates, slices[i])
+		}
+		n := winners.GetCardinality() + preferred.GetCardinality()
+		if n > uint64(k) {
+			candidates = preferred
+			continue
+		}
+		winners.Or(preferred)
+		if n == uint64(k) {
+			candidates = roaring64.NewBitmap()
+			break
+		}
+		candidates.AndNot(preferred)
+	}
+
+	// Whatever remains are ties, fill from them.
+	iter := candidates.Iterator()
+	for winners.GetCardinality() < uint64(k) && iter.HasNext() {
+		winners.Add(iter.Next())
+	}
+
+	results := make([]*pb.TopKResult, 0, winners.GetCardinality())
+	iter = winners.Iterator()
+	for iter.HasNext() {
+		columnID := iter.Next()
+		value, _ := bsi.GetValue(columnID)
+		results = append(results, &pb.TopKResult{ColumnId: columnID, Value: value})
+	}
+	return results, nil
+}
+
+// CountDistinct - Return the distinct values of each requested field within the found set.  For standard
+// bitmap fields these are the row IDs that intersect the found set.  For BSI fields they are the values
+// themselves, or a HyperLogLog sketch of the values if a precision is specified.  Both forms can be
+// merged client side without regard to duplicates returned by replicas.  Results are in request order.
+func (m *BitmapIndex) CountDistinct(ctx context.Context, req *pb.CountDistinctRequest) (*pb.CountDistinctResponse, error) {
+
+	u.Debugf("CountDistinct started for %v - %v", req.Index, req.Fields)
+
+	fromTime := time.Unix(0, req.FromTime)
+	toTime := time.Unix(0, req.ToTime)
+
+	if req.Index == "" {
+		return nil, fmt.Errorf("index not specified for count distinct criteria")
+	}
+	if len(req.Fields) == 0 {
+		return nil, fmt.Errorf("fields not specified for count distinct criteria")
+	}
+	foundSet := roaring64.NewBitmap()
+	if err := foundSet.UnmarshalBinary(req.FoundSet); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	resp := &pb.CountDistinctResponse{Results: make([]*pb.CountDistinctResult, len(req.Fields))}
+	for i, f := range req.Fields {
+		if f.Field == "" {
+			return nil, fmt.Errorf("field not specified for count distinct criteria")
+		}
+		r, err := m.countDistinct(req.Index, f.Field, int(f.Precision), fromTime, toTime, foundSet)
+		if err != nil {
+			return nil, err
+		}
+		resp.Results[i] = r
+	}
+	u.Debugf("CountDistinct elapsed time %v", time.Since(start))
+	return resp, nil
+}
+
+// countDistinct - Distinct values of a single field within the found set.
+func (m *BitmapIndex) countDistinct(index, field string, precision int, fromTime, toTime time.Time,
+	foundSet *roaring64.Bitmap) (*pb.CountDistinctResult, error) {
+
+	attr, err := m.getFieldConfig(index, field)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &pb.CountDistinctResult{}
+	values := roaring64.NewBitmap()
+	if !attr.IsBSI() {
+		for _, row := range m.listAllRowIDs(index, field) {
+			x, err := m.timeRange(index, field, row, fromTime, toTime, foundSet, false, nil)
+			if err != nil {
+				return nil, err
+			}
+			if x.GetCardinality() > 0 {
+				values.Add(row)
+			}
+		}
+		if result.Values, err = values.MarshalBinary(); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	var sketch *shared.HyperLogLog
+	if precision > 0 {
+		if sketch, err = shared.NewHyperLogLog(precision); err != nil {
+			return nil, err
+		}
+	}
+	partitions, err := m.partitionsBSI(index, field, fromTime, toTime)
+	if err != nil {
+		return nil, fmt.Errorf("Error ranging count distinct BSI for %s %s - %v", index, field, err)
+	}
+	for _, bsi := range partitions {
+		fs := roaring64.And(bsi.GetExistenceBitmap(), foundSet)
+		if fs.GetCardinality() == 0 {
+			continue
+		}
+		if sketch == nil {
+			values.Or(bsi.IntersectAndTranspose(0, fs))
+			continue
+		}
+		iter := fs.Iterator()
+		for iter.HasNext() {
+			if v, ok := bsi.GetValue(iter.Next()); ok {
+				sketch.Add(uint64(v))
+			}
+		}
+	}
+	if sketch != nil {
+		result.Sketch, err = sketch.MarshalBinary()
+	} else {
+		result.Values, err = values.MarshalBinary()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Return the BSI partitions within the time range that this node owns keyed by partition timestamp.
+func (m *BitmapIndex) partitionsBSI(index, field string, fromTime, toTime time.Time) (map[int64]*roaring64.BSI, error) {
+
+	m.bsiCacheLock.RLock()
+	defer m.bsiCacheLock.RUnlock()
+
+	attr, err := m.getFieldConfig(index, field)
+	if err != nil {
+		return nil, err
+	}
+	tq := attr.TimeQuantumType
+	fromTime = truncateTime(fromTime, tq)
+	toTime = truncateTime(toTime, tq)
+	results := make(map[int64]*roaring64.BSI)
+	yr, mn, da := fromTime.Date()
+	lookupTime := time.Date(yr, mn, da, 0, 0, 0, 0, time.UTC)
+	if tq == "" { // No time quantum
+		hashKey := fmt.Sprintf("%s/%s/%s", index, field, lookupTime.Format(timeFmt))
+		if !m.Member(hashKey) {
+			return results, nil
+		}
+		if bm, ok := m.bsiCache[index][field][0]; ok {
+			results[0] = bm.BSI.Clone()
+		}
+		return results, nil
+	}
+	for ts, bm := range m.bsiCache[index][field] {
+		rts := truncateTime(time.Unix(0, ts).UTC(), tq).UnixNano()
+		if rts < fromTime.UnixNano() || rts > toTime.UnixNano() {
+			continue
+		}
+		hashKey := fmt.Sprintf("%s/%s/%s", index, field, time.Unix(0, ts).Format(timeFmt))
+		if !m.Member(hashKey) {
+			continue
+		}
+		results[ts] = bm.BSI.Clone()
+	}
+	return results, nil
 }
@@ -0,0 +1,58 @@
+package server
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/RoaringBitmap/roaring/roaring64"
+	pb "github.com/disney/quanta/grpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopK(t *testing.T) {
+
+	values := map[uint64]int64{1: 50, 2: -7, 3: 1000, 4: 3, 5: 50, 6: -100, 7: 0, 8: 999}
+	bsi := roaring64.NewDefaultBSI()
+	for k, v := range values {
+		bsi.SetValue(k, v)
+	}
+	foundSet := roaring64.BitmapOf(1, 2, 3, 4, 5, 6, 7, 9)
+
+	sorted := func(r []*pb.TopKResult) []int64 {
+		vals := make([]int64, len(r))
+		for i, v := range r {
+			assert.Equal(t, values[v.ColumnId], v.Value)
+			vals[i] = v.Value
+		}
+		sort.Slice(vals, func(i, j int) bool { return vals[i] < vals[j] })
+		return vals
+	}
+
+	r, err := topK(bsi, foundSet, 3, true)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{50, 50, 1000}, sorted(r))
+
+	r, err = topK(bsi, foundSet, 2, false)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{-100, -7}, sorted(r))
+
+	// Ties at the boundary.
+	r, err = topK(bsi, foundSet, 2, true)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{50, 1000}, sorted(r))
+
+	// More than the found set.
+	r, err = topK(bsi, foundSet, 20, false)
+	require.NoError(t, err)
+	assert.Len(t, r, 7)
+
+	// No negative values, fewer slices.
+	bsi = roaring64.NewDefaultBSI()
+	for i := uint64(1); i <= 100; i++ {
+		bsi.SetValue(i, int64(i%10))
+	}
+	r, err = topK(bsi, roaring64.BitmapOf(5, 15, 19, 28, 31), 2, true)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uint64{19, 28}, []uint64{r[0].ColumnId, r[1].ColumnId})
+}
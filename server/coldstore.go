@@ -0,0 +1,512 @@
+package server
+
+//
+// This file contains the cold (archived) partition store for the bitmap server.
+//
+// When the memory limit is exceeded the oldest partitions are moved to the archive directory and
+// purged from the caches.  Archived partitions remain queryable.  They are read from disk on demand
+// when a time range touches them and retained in an LRU cache that has its own memory budget.
+// Cached partitions are never mutated, an evicted partition is simply dropped.
+//
+
+import (
+	"container/list"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/RoaringBitmap/roaring/roaring64"
+	u "github.com/araddon/gou"
+	pb "github.com/disney/quanta/grpc"
+	"github.com/disney/quanta/shared"
+)
+
+// ColdStore - Catalog and LRU cache of archived partitions.
+//
+// catalog - Archived partitions keyed by index, field, row ID (-1 for BSI) and time.
+// limit - Memory budget for loaded partitions in bytes.
+type ColdStore struct {
+	dir       string
+	limit     int64
+	lock      sync.Mutex
+	catalog   map[string]map[string]map[int64]map[int64]*coldPartition
+	lru       *list.List
+	used      int64
+	loads     atomic.Uint64
+	hits      atomic.Uint64
+	evictions atomic.Uint64
+}
+
+// coldPartition - An archived partition, bits or bsi is set while it is cached.
+type coldPartition struct {
+	path string // File path for standard bitmaps, directory path for BSI
+	bits *roaring64.Bitmap
+	bsi  *roaring64.BSI
+	size int64
+	elem *list.Element
+}
+
+// coldReads - Count of archived partitions read while processing a request and the replica that the request
+// reads from.  If shards is not nil the checksums of the in memory shards read are collected so that the
+// client can compare replicas.  A nil value is ignored and reads from the primary.
+type coldReads struct {
+	count   int
+	replica int
+	shards  map[string]*pb.ShardChecksum
+}
+
+func (r *coldReads) add() {
+	if r != nil {
+		r.count++
+	}
+}
+
+func (r *coldReads) get() int {
+	if r == nil {
+		return 0
+	}
+	return r.count
+}
+
+// addBitmap - Collect the checksum of a bitmap shard.
+func (r *coldReads) addBitmap(index, field string, rowID uint64, ts int64, bitmap *StandardBitmap) {
+
+	if r == nil || r.shards == nil {
+		return
+	}
+	s := &pb.ShardChecksum{Index: index, Field: field, RowIdOrBits: int64(rowID), Time: ts,
+		Checksum: bitmapChecksum(bitmap.Bits), ModTime: bitmap.ModTime.UnixNano()}
+	r.shards[shared.ShardKey(s)] = s
+}
+
+// addBSI - Collect the checksum of a BSI shard.
+func (r *coldReads) addBSI(index, field string, ts int64, bsi *BSIBitmap) {
+
+	if r == nil || r.shards == nil {
+		return
+	}
+	checksum, err := bsiChecksum(bsi.BSI)
+	if err != nil {
+		u.Errorf("shard checksum failed for %s/%s - %v", index, field, err)
+		return
+	}
+	s := &pb.ShardChecksum{Index: index, Field: field, RowIdOrBits: -1, Time: ts, Checksum: checksum,
+		ModTime: bsi.ModTime.UnixNano()}
+	r.shards[shared.ShardKey(s)] = s
+}
+
+// NewColdStore - Construct a cold store for an archive directory with a cache budget in Mb.
+func NewColdStore(dir string, limitMb int) *ColdStore {
+
+	return &ColdStore{dir: dir, limit: int64(limitMb) * 1024 * 1024, lru: list.New(),
+		catalog: make(map[string]map[string]map[int64]map[int64]*coldPartition)}
+}
+
+// Scan - Catalog the partitions in the archive directory.  Nothing is loaded.
+func (c *ColdStore) Scan() error {
+
+	if _, err := os.Stat(c.dir); os.IsNotExist(err) {
+		return nil
+	}
+	count := 0
+	err := filepath.Walk(c.dir,
+		func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				return nil
+			}
+			s := strings.Split(strings.Replace(path, c.dir+sep, "", 1), sep)
+			if len(s) < 4 {
+				return nil
+			}
+			var rowID int64
+			var partPath, tsName string
+			if s[2] == "bsi" {
+				rowID = -1
+				partPath = filepath.Dir(path)
+				tsName = s[len(s)-2]
+			} else {
+				var err error
+				if rowID, err = strconv.ParseInt(s[2], 10, 64); err != nil {
+					return nil // Backing strings and key indices are not partitions
+				}
+				partPath = path
+				tsName = s[len(s)-1]
+			}
+			ts := time.Unix(0, 0)
+			if tsName != "default" {
+				if ts, err = time.Parse(timeFmt, tsName); err != nil {
+					u.Warnf("ignoring archived file %s - %v", path, err)
+					return nil
+				}
+			}
+			c.lock.Lock()
+			if _, found := c.lookup(s[0], s[1], rowID, ts.UnixNano()); !found {
+				c.add(s[0], s[1], rowID, ts.UnixNano(), partPath)
+				count++
+			}
+			c.lock.Unlock()
+			return nil
+		})
+	if err != nil {
+		return fmt.Errorf("cannot scan archive directory %s - %v", c.dir, err)
+	}
+	u.Infof("Cold store cataloged %d archived partitions.", count)
+	return nil
+}
+
+// Add - Catalog a partition that was just archived.  Replaces (and evicts) a prior version.
+func (c *ColdStore) Add(index, field string, rowIDOrBits int64, ts time.Time, path string) {
+
+	if rowIDOrBits < 0 {
+		rowIDOrBits = -1
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if p, found := c.lookup(index, field, rowIDOrBits, ts.UnixNano()); found {
+		c.evict(p)
+	}
+	c.add(index, field, rowIDOrBits, ts.UnixNano(), path)
+}
+
+func (c *ColdStore) add(index, field string, rowID, ts int64, path string) {
+
+	if _, ok := c.catalog[index]; !ok {
+		c.catalog[index] = make(map[string]map[int64]map[int64]*coldPartition)
+	}
+	if _, ok := c.catalog[index][field]; !ok {
+		c.catalog[index][field] = make(map[int64]map[int64]*coldPartition)
+	}
+	if _, ok := c.catalog[index][field][rowID]; !ok {
+		c.catalog[index][field][rowID] = make(map[int64]*coldPartition)
+	}
+	c.catalog[index][field][rowID][ts] = &coldPartition{path: path}
+}
+
+func (c *ColdStore) lookup(index, field string, rowID, ts int64) (*coldPartition, bool) {
+	p, ok := c.catalog[index][field][rowID][ts]
+	return p, ok
+}
+
+// Drop - Remove all archived partitions for a table.
+func (c *ColdStore) Drop(index string) error {
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for _, fm := range c.catalog[index] {
+		for _, rm := range fm {
+			for _, p := range rm {
+				c.evict(p)
+			}
+		}
+	}
+	delete(c.catalog, index)
+	return os.RemoveAll(c.dir + sep + index)
+}
+
+// DropField - Remove all archived partitions of a field.
+func (c *ColdStore) DropField(index, field string) error {
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if fm, ok := c.catalog[index]; ok {
+		for _, p := range fm[field] {
+			for _, cp := range p {
+				c.evict(cp)
+			}
+		}
+		delete(fm, field)
+	}
+	return os.RemoveAll(c.dir + sep + index + sep + field)
+}
+
+// archivedPartition - Catalog entry for an archived partition.
+type archivedPartition struct {
+	Index       string
+	Field       string
+	RowIDOrBits int64 // -1 for BSI
+	Time        int64
+	Path        string
+}
+
+// Partitions - Archived partitions of a table that satisfy a filter.
+func (c *ColdStore) Partitions(index string, filter func(field string, rowID, ts int64) bool) []*archivedPartition {
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	results := make([]*archivedPartition, 0)
+	for field, fm := range c.catalog[index] {
+		for rowID, rm := range fm {
+			for ts, p := range rm {
+				if filter(field, rowID, ts) {
+					results = append(results, &archivedPartition{Index: index, Field: field, RowIDOrBits: rowID,
+						Time: ts, Path: p.path})
+				}
+			}
+		}
+	}
+	return results
+}
+
+// Count - Number of archived partitions.
+func (c *ColdStore) Count() int {
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	count := 0
+	for _, fm := range c.catalog {
+		for _, rm := range fm {
+			for _, tm := range rm {
+				count += len(tm)
+			}
+		}
+	}
+	return count
+}
+
+// Remove - Delete an archived partition from disk and from the catalog.
+func (c *ColdStore) Remove(ap *archivedPartition) error {
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if p, found := c.lookup(ap.Index, ap.Field, ap.RowIDOrBits, ap.Time); found {
+		c.evict(p)
+		delete(c.catalog[ap.Index][ap.Field][ap.RowIDOrBits], ap.Time)
+	}
+	return os.RemoveAll(ap.Path)
+}
+
+// Times - Archived partition times for a bitmap row (or -1 for BSI) that satisfy a filter.
+func (c *ColdStore) Times(index, field string, rowID int64, filter func(ts int64) bool) []int64 {
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	times := make([]int64, 0)
+	for ts := range c.catalog[index][field][rowID] {
+		if filter(ts) {
+			times = append(times, ts)
+		}
+	}
+	return times
+}
+
+// RowIDs - Row IDs of archived standard bitmap partitions for a field.
+func (c *ColdStore) RowIDs(index, field string) []uint64 {
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	rowIDs := make([]uint64, 0)
+	for k := range c.catalog[index][field] {
+		if k >= 0 {
+			rowIDs = append(rowIDs, uint64(k))
+		}
+	}
+	return rowIDs
+}
+
+// Bitmap - Get an archived standard bitmap partition, loading it if it is not cached.
+func (c *ColdStore) Bitmap(index, field string, rowID uint64, ts int64) (*roaring64.Bitmap, error) {
+
+	p, err := c.load(index, field, int64(rowID), ts, func(path string) (*coldPartition, error) {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		bm := roaring64.NewBitmap()
+		if err := bm.UnmarshalBinary(data); err != nil {
+			return nil, err
+		}
+		return &coldPartition{bits: bm, size: int64(len(data))}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return p.bits, nil
+}
+
+// BSI - Get an archived BSI partition, loading it if it is not cached.
+func (c *ColdStore) BSI(index, field string, ts int64, create func() *roaring64.BSI) (*roaring64.BSI, error) {
+
+	p, err := c.load(index, field, -1, ts, func(path string) (*coldPartition, error) {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		data := make([][]byte, 65)
+		var size int64
+		for _, e := range entries {
+			i := 0
+			if e.Name() != "EBM" {
+				if i, err = strconv.Atoi(e.Name()); err != nil || i < 1 || i >= len(data) {
+					continue
+				}
+			}
+			if data[i], err = os.ReadFile(path + sep + e.Name()); err != nil {
+				return nil, err
+			}
+			size += int64(len(data[i]))
+		}
+		bsi := create()
+		if err := bsi.UnmarshalBinary(data); err != nil {
+			return nil, err
+		}
+		return &coldPartition{bsi: bsi, size: size}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return p.bsi, nil
+}
+
+// load - Return a cached partition or read it with the reader function.  Reads are performed
+// without holding the lock.
+func (c *ColdStore) load(index, field string, rowID, ts int64,
+	reader func(path string) (*coldPartition, error)) (*coldPartition, error) {
+
+	c.lock.Lock()
+	p, found := c.lookup(index, field, rowID, ts)
+	if !found {
+		c.lock.Unlock()
+		return nil, fmt.Errorf("archived partition %s/%s/%d/%s not found", index, field, rowID,
+			time.Unix(0, ts).Format(timeFmt))
+	}
+	if p.elem != nil {
+		c.lru.MoveToFront(p.elem)
+		loaded := &coldPartition{bits: p.bits, bsi: p.bsi}
+		c.lock.Unlock()
+		c.hits.Add(1)
+		return loaded, nil
+	}
+	path := p.path
+	c.lock.Unlock()
+
+	loaded, err := reader(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read archived partition %s - %v", path, err)
+	}
+	c.loads.Add(1)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if p, found = c.lookup(index, field, rowID, ts); !found || p.path != path {
+		return loaded, nil // Dropped or replaced while reading
+	}
+	if p.elem != nil { // Loaded concurrently
+		c.lru.MoveToFront(p.elem)
+		return &coldPartition{bits: p.bits, bsi: p.bsi}, nil
+	}
+	if loaded.size > c.limit {
+		return loaded, nil // Too large to retain
+	}
+	p.bits, p.bsi, p.size = loaded.bits, loaded.bsi, loaded.size
+	p.elem = c.lru.PushFront(p)
+	c.used += p.size
+	for c.used > c.limit {
+		c.evict(c.lru.Back().Value.(*coldPartition))
+	}
+	return loaded, nil
+}
+
+// evict - Drop a partition from the cache.  Caller must hold the lock.
+func (c *ColdStore) evict(p *coldPartition) {
+
+	if p.elem == nil {
+		return
+	}
+	c.lru.Remove(p.elem)
+	c.used -= p.size
+	p.bits, p.bsi, p.size, p.elem = nil, nil, 0, nil
+	c.evictions.Add(1)
+}
+
+// Stats - Cache statistics.
+func (c *ColdStore) Stats() (used int64, loads, hits, evictions uint64) {
+
+	c.lock.Lock()
+	used = c.used
+	c.lock.Unlock()
+	return used, c.loads.Load(), c.hits.Load(), c.evictions.Load()
+}
+
+// coldBitmaps - Archived standard bitmaps for a row within a time range.  Partitions that are not
+// owned by this node are skipped.
+func (m *BitmapIndex) coldBitmaps(index, field string, rowID uint64, tq string, fromTime,
+	toTime time.Time, reads *coldReads) ([]*roaring64.Bitmap, error) {
+
+	if m.cold == nil {
+		return nil, nil
+	}
+	results := make([]*roaring64.Bitmap, 0)
+	for _, ts := range m.cold.Times(index, field, int64(rowID), coldFilter(tq, fromTime, toTime)) {
+		hashKey := fmt.Sprintf("%s/%s/%d/%s", index, field, rowID, coldKeyTime(tq, fromTime, ts))
+		if !m.readMember(hashKey, reads) {
+			continue
+		}
+		bm, err := m.cold.Bitmap(index, field, rowID, ts)
+		if err != nil {
+			return nil, err
+		}
+		u.Debugf("timeRange %s selecting archived %s", tq, hashKey)
+		reads.add()
+		results = append(results, bm)
+	}
+	return results, nil
+}
+
+// coldBSIs - Archived BSI partitions within a time range keyed by time.  Partitions that are not
+// owned by this node are skipped.
+func (m *BitmapIndex) coldBSIs(index, field string, tq string, fromTime, toTime time.Time,
+	reads *coldReads) (map[int64]*roaring64.BSI, error) {
+
+	if m.cold == nil {
+		return nil, nil
+	}
+	results := make(map[int64]*roaring64.BSI)
+	for _, ts := range m.cold.Times(index, field, -1, coldFilter(tq, fromTime, toTime)) {
+		hashKey := fmt.Sprintf("%s/%s/%s", index, field, coldKeyTime(tq, fromTime, ts))
+		if !m.readMember(hashKey, reads) {
+			continue
+		}
+		bsi, err := m.cold.BSI(index, field, ts, func() *roaring64.BSI {
+			return m.newBSIBitmap(index, field).BSI
+		})
+		if err != nil {
+			return nil, err
+		}
+		u.Debugf("timeRangeBSI %s selecting archived %s", tq, hashKey)
+		reads.add()
+		results[ts] = bsi
+	}
+	return results, nil
+}
+
+// coldKeyTime - Time component of the shard key of an archived partition.  Fields without a time quantum
+// are keyed by the day of the start of the time range, the same as the in memory partition.
+func coldKeyTime(tq string, fromTime time.Time, ts int64) string {
+
+	if tq == "" {
+		yr, mn, da := fromTime.Date()
+		return time.Date(yr, mn, da, 0, 0, 0, 0, time.UTC).Format(timeFmt)
+	}
+	return time.Unix(0, ts).Format(timeFmt)
+}
+
+// coldFilter - Select partition times within a (truncated) time range.  Fields without a time
+// quantum only have the default partition.
+func coldFilter(tq string, fromTime, toTime time.Time) func(ts int64) bool {
+
+	return func(ts int64) bool {
+		if tq == "" {
+			return ts == 0
+		}
+		rts := truncateTime(time.Unix(0, ts).UTC(), tq).UnixNano()
+		return rts >= fromTime.UnixNano() && rts <= toTime.UnixNano()
+	}
+}
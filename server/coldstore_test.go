@@ -0,0 +1,93 @@
+package server
+
+import (
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/RoaringBitmap/roaring/roaring64"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestColdStore(t *testing.T) {
+
+	dir := t.TempDir()
+	ts := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	// Archived standard bitmap partition
+	rowDir := dir + sep + "orders" + sep + "status" + sep + "3"
+	require.NoError(t, os.MkdirAll(rowDir, 0755))
+	bm := roaring64.BitmapOf(1, 5, 9)
+	data, err := bm.MarshalBinary()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(rowDir+sep+ts.Format(timeFmt), data, 0644))
+
+	// Archived BSI partition
+	bsiDir := dir + sep + "orders" + sep + "amount" + sep + "bsi" + sep + ts.Format(timeFmt)
+	require.NoError(t, os.MkdirAll(bsiDir, 0755))
+	bsi := roaring64.NewDefaultBSI()
+	bsi.SetValue(1, 100)
+	bsi.SetValue(5, 7)
+	bsiData, err := bsi.MarshalBinary()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(bsiDir+sep+"EBM", bsiData[0], 0644))
+	bsiSize := len(bsiData[0])
+	for i := 1; i < len(bsiData); i++ {
+		require.NoError(t, os.WriteFile(bsiDir+sep+strconv.Itoa(i), bsiData[i], 0644))
+		bsiSize += len(bsiData[i])
+	}
+
+	// Backing strings are not partitions
+	stringsDir := dir + sep + "orders" + sep + "amount" + sep + "strings" + sep + ts.Format(timeFmt)
+	require.NoError(t, os.MkdirAll(stringsDir, 0755))
+	require.NoError(t, os.WriteFile(stringsDir+sep+"data", []byte("x"), 0644))
+
+	c := NewColdStore(dir, 0)
+	require.NoError(t, c.Scan())
+	assert.Equal(t, 2, c.Count())
+	assert.Equal(t, []uint64{3}, c.RowIDs("orders", "status"))
+	inRange := coldFilter("YMD", ts, ts)
+	assert.Equal(t, []int64{ts.UnixNano()}, c.Times("orders", "status", 3, inRange))
+	assert.Equal(t, []int64{ts.UnixNano()}, c.Times("orders", "amount", -1, inRange))
+	assert.Empty(t, c.Times("orders", "amount", -1, coldFilter("YMD", ts.AddDate(0, 0, 1), ts.AddDate(0, 0, 2))))
+
+	// A zero budget loads partitions without retaining them
+	x, err := c.Bitmap("orders", "status", 3, ts.UnixNano())
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 5, 9}, x.ToArray())
+	used, loads, hits, _ := c.Stats()
+	assert.Equal(t, int64(0), used)
+	assert.Equal(t, uint64(1), loads)
+	assert.Equal(t, uint64(0), hits)
+
+	y, err := c.BSI("orders", "amount", ts.UnixNano(), roaring64.NewDefaultBSI)
+	require.NoError(t, err)
+	v, ok := y.GetValue(1)
+	assert.True(t, ok)
+	assert.Equal(t, int64(100), v)
+	assert.Equal(t, uint64(2), y.GetCardinality())
+
+	// With a budget partitions are cached until the budget is exceeded
+	c.limit = int64(len(data)) + 1
+	_, err = c.Bitmap("orders", "status", 3, ts.UnixNano())
+	require.NoError(t, err)
+	_, err = c.Bitmap("orders", "status", 3, ts.UnixNano())
+	require.NoError(t, err)
+	used, _, hits, _ = c.Stats()
+	assert.Equal(t, int64(len(data)), used)
+	assert.Equal(t, uint64(1), hits)
+
+	c.limit = int64(max(len(data), bsiSize))
+	_, err = c.BSI("orders", "amount", ts.UnixNano(), roaring64.NewDefaultBSI)
+	require.NoError(t, err)
+	used, _, _, evictions := c.Stats()
+	assert.LessOrEqual(t, used, c.limit)
+	assert.Equal(t, uint64(1), evictions)
+
+	require.NoError(t, c.Drop("orders"))
+	assert.Empty(t, c.RowIDs("orders", "status"))
+	_, err = os.Stat(dir + sep + "orders")
+	assert.True(t, os.IsNotExist(err))
+}
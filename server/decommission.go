@@ -0,0 +1,332 @@
+package server
+
+//
+// This file contains the node decommission process.
+//
+// A decommissioned node is placed in the Draining state.  Clients then route writes for its keys to the
+// remaining owners and their rendezvous successors as well as to the draining node.  Queries are answered by
+// a remaining owner, or by the draining node if it is the only owner, since a successor only has the data
+// once the shard is pushed.  Every shard held by the node is pushed to the owners it will have once the node
+// leaves, using the same diff mechanism as the node join synchronization.  Pushed shards are recorded in a
+// progress file so that an interrupted decommission can resume.  Shards modified after they were recorded are
+// pushed again.  Archived partitions are queryable but cannot be moved, so a node that holds any cannot be
+// decommissioned.
+//
+// The admin tool shuts the node down (which deregisters it from Consul) once all shards are pushed.
+//
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/RoaringBitmap/roaring/roaring64"
+	u "github.com/araddon/gou"
+	pb "github.com/disney/quanta/grpc"
+	"github.com/disney/quanta/shared"
+)
+
+// decommission - Progress of the decommission process.
+type decommission struct {
+	sync.Mutex
+	running  bool
+	complete bool
+	shards   uint64
+	pushed   uint64
+	skipped  uint64
+	err      error
+}
+
+// drainShard - A shard to be pushed to its successors.
+type drainShard struct {
+	index   string
+	field   string
+	rowID   uint64
+	ts      int64
+	isBSI   bool
+	modTime time.Time
+}
+
+// key - Shard key as used for rendezvous hashing.
+func (s *drainShard) key() string {
+
+	if s.isBSI {
+		return fmt.Sprintf("%s/%s/%s", s.index, s.field, time.Unix(0, s.ts).Format(timeFmt))
+	}
+	return fmt.Sprintf("%s/%s/%d/%s", s.index, s.field, s.rowID, time.Unix(0, s.ts).Format(timeFmt))
+}
+
+// Decommission - Start decommissioning this node if requested and report progress.  A failed decommission
+// is restarted by another start request, the node remains in the Draining state until it is shut down.
+func (m *BitmapIndex) Decommission(ctx context.Context, req *pb.DecommissionRequest) (*pb.DecommissionResponse, error) {
+
+	m.drain.Lock()
+	defer m.drain.Unlock()
+
+	if req.Start && !m.drain.running && !m.drain.complete {
+		if m.State != Active && m.State != Draining {
+			return nil, fmt.Errorf("cannot decommission %s in state %s", m.hashKey, m.State)
+		}
+		if replicas := m.MaxReplicas(); len(m.GetNodeMap())-1 < replicas {
+			return nil, fmt.Errorf("cannot decommission %s, %d remaining nodes are required for %d replicas",
+				m.hashKey, replicas, replicas)
+		}
+		if m.cold != nil {
+			if count := m.cold.Count(); count > 0 {
+				return nil, fmt.Errorf("cannot decommission %s, it holds %d archived partitions", m.hashKey, count)
+			}
+		}
+		m.drain.running = true
+		m.drain.err = nil
+		m.drain.shards, m.drain.pushed, m.drain.skipped = 0, 0, 0
+		m.State = Draining
+		m.publishState()
+		u.Warnf("Setting node state to Draining %s", m.hashKey)
+		go m.drainShards()
+	}
+
+	response := &pb.DecommissionResponse{NodeState: m.State.String(), Shards: m.drain.shards,
+		Pushed: m.drain.pushed, Skipped: m.drain.skipped, Complete: m.drain.complete}
+	if m.drain.err != nil {
+		response.Error = m.drain.err.Error()
+	}
+	return response, nil
+}
+
+// drainShards - Decommission worker thread.
+func (m *BitmapIndex) drainShards() {
+
+	err := m.pushShards()
+
+	m.drain.Lock()
+	defer m.drain.Unlock()
+	m.drain.running = false
+	m.drain.complete = err == nil
+	m.drain.err = err
+	if err != nil {
+		u.Errorf("%s decommission failed - %v", m.hashKey, err)
+		return
+	}
+	os.Remove(m.drainProgressPath())
+	u.Warnf("%s decommission complete, %d shards pushed, %d skipped", m.hashKey, m.drain.pushed, m.drain.skipped)
+}
+
+// pushShards - Push all shards to their successors.
+func (m *BitmapIndex) pushShards() error {
+
+	path := m.drainProgressPath()
+	done, err := readDrainProgress(path)
+	if err != nil {
+		return err
+	}
+	progress, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("cannot open progress file %s - %v", path, err)
+	}
+	defer progress.Close()
+
+	shards := m.drainList()
+	m.drain.Lock()
+	m.drain.shards = uint64(len(shards))
+	m.drain.Unlock()
+
+	peerClient := m.Conn.GetService("BitmapIndex").(*shared.BitmapIndex)
+	peerKV := m.Conn.GetService("KVStore").(*shared.KVStore)
+	enums := make(map[string]struct{})
+	for _, s := range shards {
+		key := s.key()
+		attr, err := m.getFieldConfig(s.index, s.field)
+		if t, found := done[key]; err != nil || (found && t == s.modTime.UnixNano()) {
+			// Already pushed and unchanged, or the field was dropped.
+			m.drain.Lock()
+			m.drain.skipped++
+			m.drain.Unlock()
+			continue
+		}
+		for _, nodeID := range m.GetSuccessorsForKey(key, m.hashKey) {
+			ci, err := m.GetClientIndexForNodeID(nodeID)
+			if err != nil {
+				return err
+			}
+			if err := m.pushShard(peerClient, peerKV, ci, s, attr, enums); err != nil {
+				return fmt.Errorf("cannot push %s to %s - %v", key, nodeID, err)
+			}
+		}
+		if _, err := fmt.Fprintf(progress, "%s %d\n", key, s.modTime.UnixNano()); err != nil {
+			return fmt.Errorf("cannot write progress file %s - %v", path, err)
+		}
+		m.drain.Lock()
+		m.drain.pushed++
+		m.drain.Unlock()
+	}
+	return nil
+}
+
+// drainProgressPath - Progress file of the current decommission, removed once it completes.
+func (m *BitmapIndex) drainProgressPath() string {
+	return m.dataDir + sep + "decommission.progress"
+}
+
+// readDrainProgress - Shard keys and modification times of shards pushed by a previous run.
+func readDrainProgress(path string) (map[string]int64, error) {
+
+	done := make(map[string]int64)
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return done, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot open progress file %s - %v", path, err)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		i := strings.LastIndex(line, " ")
+		if i < 0 {
+			continue
+		}
+		t, err := strconv.ParseInt(line[i+1:], 10, 64)
+		if err != nil {
+			continue // Partial write
+		}
+		done[line[:i]] = t
+	}
+	return done, scanner.Err()
+}
+
+// drainList - All shards held by this node, BSI shards first so that PK/SK indices follow their anchor.
+func (m *BitmapIndex) drainList() []*drainShard {
+
+	shards := make([]*drainShard, 0)
+	m.iterateBSICache(func(p *Partition) error {
+		shards = append(shards, &drainShard{index: p.Index, field: p.Field, ts: p.Time.UnixNano(), isBSI: true,
+			modTime: p.Shard.(*BSIBitmap).ModTime})
+		return nil
+	})
+	m.iterateBitmapCache(func(p *Partition) error {
+		shards = append(shards, &drainShard{index: p.Index, field: p.Field, rowID: uint64(p.RowIDOrBits),
+			ts: p.Time.UnixNano(), modTime: p.Shard.(*StandardBitmap).ModTime})
+		return nil
+	})
+	return shards
+}
+
+// pushShard - Push the differences for a shard to a successor node.
+func (m *BitmapIndex) pushShard(peerClient *shared.BitmapIndex, peerKV *shared.KVStore, ci int, s *drainShard,
+	attr *shared.BasicAttribute, enums map[string]struct{}) error {
+
+	newNode := peerClient.Client(ci)
+	newKVClient := peerKV.Client(ci)
+	cx, cancel := context.WithTimeout(context.Background(), shared.Deadline)
+	defer cancel()
+
+	if !s.isBSI {
+		// StringEnum metadata is pushed once per field and node.
+		if attr.MappingStrategy == "StringEnum" {
+			enumKey := fmt.Sprintf("%s/%s/%d", s.index, s.field, ci)
+			if _, found := enums[enumKey]; !found {
+				if err := m.syncEnumMetadata(peerKV, newKVClient, s.index, s.field); err != nil {
+					return err
+				}
+				enums[enumKey] = struct{}{}
+			}
+		}
+		m.bitmapCacheLock.RLock()
+		bitmap := m.bitmapCache[s.index][s.field][s.rowID][s.ts]
+		m.bitmapCacheLock.RUnlock()
+		if bitmap == nil {
+			return nil // purged since the list was built
+		}
+		bitmap.Lock.RLock()
+		req := &pb.SyncStatusRequest{Index: s.index, Field: s.field, RowId: s.rowID, Time: s.ts, SendData: true,
+			Cardinality: bitmap.Bits.GetCardinality(), ModTime: bitmap.ModTime.UnixNano()}
+		bitmap.Lock.RUnlock() // never hold a lock while making a grpc call
+		res, err := newNode.SyncStatus(cx, req)
+		if err != nil {
+			return err
+		}
+		if res.Ok {
+			return nil
+		}
+		resBm := roaring64.NewBitmap()
+		if len(res.Data) == 1 && res.Cardinality > 0 {
+			if err := resBm.UnmarshalBinary(res.Data[0]); err != nil {
+				return fmt.Errorf("deserialize sync reponse - UnmarshalBinary error - %v", err)
+			}
+		}
+		bitmap.Lock.RLock()
+		pushDiff := roaring64.AndNot(bitmap.Bits, resBm)
+		bitmap.Lock.RUnlock()
+		if pushDiff.GetCardinality() == 0 {
+			return nil
+		}
+		return m.pushBitmapDiff(peerClient, newNode, s.index, s.field, s.rowID, s.ts, pushDiff)
+	}
+
+	m.bsiCacheLock.RLock()
+	bsi := m.bsiCache[s.index][s.field][s.ts]
+	m.bsiCacheLock.RUnlock()
+	if bsi == nil {
+		return nil // purged since the list was built
+	}
+	bsi.Lock.RLock()
+	sum, card := bsi.Sum(bsi.GetExistenceBitmap())
+	req := &pb.SyncStatusRequest{Index: s.index, Field: s.field, Time: s.ts, SendData: true, Cardinality: card,
+		BSIChecksum: sum, ModTime: bsi.ModTime.UnixNano()}
+	bsi.Lock.RUnlock()
+	res, err := newNode.SyncStatus(cx, req)
+	if err != nil {
+		return err
+	}
+	if !res.Ok {
+		resBsi := roaring64.NewBSI(int64(attr.MaxValue), int64(attr.MinValue))
+		if res.Cardinality > 0 {
+			if err := resBsi.UnmarshalBinary(res.Data); err != nil {
+				return fmt.Errorf("deserialize sync reponse - BSI UnmarshalBinary error - %v", err)
+			}
+		}
+		bsi.Lock.RLock()
+		pushDiff := roaring64.AndNot(bsi.GetExistenceBitmap(), resBsi.GetExistenceBitmap())
+		pushBSI := bsi.NewBSIRetainSet(pushDiff)
+		bsi.Lock.RUnlock()
+		if pushDiff.GetCardinality() > 0 {
+			if err := m.pushBSIDiff(peerClient, newNode, s.index, s.field, s.ts, pushBSI); err != nil {
+				return err
+			}
+			if attr.MappingStrategy == "StringHashBSI" {
+				if err := m.syncStringBackingStore(peerKV, newKVClient, s.index, s.field, s.ts, pushDiff,
+					nil); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	// PK/SK indices are partitioned by the time of the primary key anchor attribute.
+	table := attr.Parent
+	pka, err := table.GetPrimaryKeyInfo()
+	if err != nil || len(pka) == 0 || pka[0].FieldName != s.field {
+		return nil
+	}
+	key := s.key()
+	if table.PrimaryKey != "" {
+		if err := m.indexKVPush(peerKV, newKVClient, fmt.Sprintf("%s%s%s.PK", key, sep, table.PrimaryKey)); err != nil {
+			return err
+		}
+	}
+	if table.SecondaryKeys == "" {
+		return nil
+	}
+	for _, v := range strings.Split(table.SecondaryKeys, ",") {
+		if err := m.indexKVPush(peerKV, newKVClient, fmt.Sprintf("%s%s%s.SK", key, sep, v)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -53,6 +53,8 @@ const (
 	Active
 	// Stopped - Node was stopped gracefully.
 	Stopped
+	// Draining - Node is being decommissioned, its shards are pushed to their new owners.
+	Draining
 )
 
 // String - Returns a string representation of StateType
@@ -69,6 +71,8 @@ func (st StateType) String() string {
 		return "Active"
 	case Stopped:
 		return "Stopped"
+	case Draining:
+		return "Draining"
 	}
 	return ""
 }
@@ -269,7 +273,17 @@ func (n *Node) Member(key string) bool {
 	if n.consul == nil {
 		return true // for testing
 	}
-	return n.HashTable.Get(key) == n.hashKey
+	return n.GetPrimaryForKey(key) == n.hashKey
+}
+
+// MemberReplica - Returns true if this node is the n-th active owner of a key (replica 0 is the primary).  If
+// the key has fewer active owners the last of them is selected.
+func (n *Node) MemberReplica(key string, replica int) bool {
+
+	if replica == 0 || n.consul == nil {
+		return n.Member(key)
+	}
+	return n.GetReplicaForKey(key, replica) == n.hashKey
 }
 
 // Leave removes the Node from the distributed hash table by de-registering it
@@ -392,7 +406,7 @@ var (
 
 	pState = promauto.NewGauge(prometheus.GaugeOpts{
 		Name: "node_state",
-		Help: "The State of the node [Starting = 0, Joining = 1, Syncing = 2, Active = 3]",
+		Help: "The State of the node [Starting = 0, Joining = 1, Syncing = 2, Active = 3, Stopped = 4, Draining = 5]",
 	})
 
 	pKVStoreCloserLatency = promauto.NewGauge(prometheus.GaugeOpts{
@@ -0,0 +1,552 @@
+package server
+
+//
+// This file contains the anti-entropy repair process.
+//
+// Replicas can drift apart when writes to one of them fail.  Each node periodically compares the shards it
+// shares with every active peer.  Shard checksums are grouped into buckets by key hash (a two level Merkle
+// tree) so that only the bucket hashes are exchanged while the replicas agree.  For mismatched buckets the
+// shard checksums are exchanged and every differing shard is repaired the same way as during node join
+// synchronization:  the replicas are merged by union, bits and columns missing on the peer are pushed and those
+// missing locally are merged.  Nothing is cleared, as there is no clear log to tell a cleared bit from a missed
+// write.  Shards missing on a peer are pushed, shards missing locally are pushed by the peer when it runs its
+// own pass.
+//
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/RoaringBitmap/roaring/roaring64"
+	u "github.com/araddon/gou"
+	pb "github.com/disney/quanta/grpc"
+	"github.com/disney/quanta/shared"
+)
+
+const (
+	repairBuckets  = 256
+	repairInterval = 6 * time.Hour
+)
+
+// repair - Progress of the current (or last) repair pass.
+type repair struct {
+	sync.Mutex
+	running    bool
+	lastRun    time.Time
+	shards     uint64
+	mismatched uint64
+	pushed     uint64
+	merged     uint64
+	err        error
+}
+
+// Repair - Start a repair pass with all active peers if requested and report progress.  If an index is
+// specified only the shards of that table are compared.  If shards are specified only those are repaired.
+func (m *BitmapIndex) Repair(ctx context.Context, req *pb.RepairRequest) (*pb.RepairResponse, error) {
+
+	m.repair.Lock()
+	defer m.repair.Unlock()
+
+	started := false
+	if req.Start && !m.repair.running {
+		if m.State != Active {
+			return nil, fmt.Errorf("cannot repair %s in state %s", m.hashKey, m.State)
+		}
+		m.startRepair(req.Index, repairFilter(req.Shards))
+		started = true
+	}
+
+	response := &pb.RepairResponse{Running: m.repair.running, Shards: m.repair.shards,
+		Mismatched: m.repair.mismatched, Pushed: m.repair.pushed, Merged: m.repair.merged, Started: started}
+	if m.repair.err != nil {
+		response.Error = m.repair.err.Error()
+	}
+	return response, nil
+}
+
+// RepairChecksums - Checksums of the shards that this node shares with the requesting node.  If no buckets are
+// requested the bucket hashes are returned, otherwise the shard checksums within the requested buckets.
+func (m *BitmapIndex) RepairChecksums(ctx context.Context, req *pb.RepairChecksumRequest) (*pb.RepairChecksumResponse, error) {
+
+	if req.NodeId == "" {
+		return nil, fmt.Errorf("node ID not specified for repair checksums")
+	}
+	shards := m.repairShards(req.NodeId, req.Index)
+	response := &pb.RepairChecksumResponse{}
+	if len(req.Buckets) == 0 {
+		response.BucketHashes = bucketHashes(shards)
+		return response, nil
+	}
+	buckets := make(map[uint32]struct{}, len(req.Buckets))
+	for _, b := range req.Buckets {
+		buckets[b] = struct{}{}
+	}
+	response.Shards = make([]*pb.ShardChecksum, 0)
+	for _, s := range shards {
+		if _, found := buckets[repairBucket(shared.ShardKey(s))]; found {
+			response.Shards = append(response.Shards, s)
+		}
+	}
+	return response, nil
+}
+
+// repairFilter - Keys of the shards to be repaired, nil if all shards are compared.
+func repairFilter(shards []*pb.ShardChecksum) map[string]struct{} {
+
+	if len(shards) == 0 {
+		return nil
+	}
+	keys := make(map[string]struct{}, len(shards))
+	for _, s := range shards {
+		keys[shared.ShardKey(s)] = struct{}{}
+	}
+	return keys
+}
+
+// startRepair - Start a repair pass in the background.  Caller must hold the repair lock.
+func (m *BitmapIndex) startRepair(index string, keys map[string]struct{}) {
+
+	m.repair.running = true
+	m.repair.err = nil
+	m.repair.shards, m.repair.mismatched, m.repair.pushed, m.repair.merged = 0, 0, 0, 0
+	go m.runRepair(index, keys)
+}
+
+// scheduleRepair - Start a repair pass if none was run within the repair interval.
+func (m *BitmapIndex) scheduleRepair() {
+
+	m.repair.Lock()
+	defer m.repair.Unlock()
+	if !m.repair.running && time.Since(m.repair.lastRun) >= repairInterval {
+		m.startRepair("", nil)
+	}
+}
+
+// runRepair - Repair worker thread.
+func (m *BitmapIndex) runRepair(index string, keys map[string]struct{}) {
+
+	err := m.repairPeers(index, keys)
+
+	m.repair.Lock()
+	defer m.repair.Unlock()
+	m.repair.running = false
+	m.repair.lastRun = time.Now()
+	m.repair.err = err
+	if err != nil {
+		u.Errorf("%s repair failed - %v", m.hashKey, err)
+		return
+	}
+	u.Infof("%s repair complete, %d shards compared, %d mismatched, %d pushed, %d merged", m.hashKey,
+		m.repair.shards, m.repair.mismatched, m.repair.pushed, m.repair.merged)
+}
+
+// repairPeers - Repair the shards shared with each active peer.  A failed peer does not stop the pass, the
+// first error is returned.
+func (m *BitmapIndex) repairPeers(index string, keys map[string]struct{}) error {
+
+	var firstErr error
+	for nodeID := range m.GetNodeMap() {
+		if nodeID == m.hashKey {
+			continue
+		}
+		status, err := m.GetNodeStatusForID(nodeID)
+		if err != nil || status.NodeState != "Active" {
+			continue
+		}
+		if err := m.repairPeer(nodeID, index, keys); err != nil {
+			u.Errorf("%s repair with %s failed - %v", m.hashKey, nodeID, err)
+			if firstErr == nil {
+				firstErr = fmt.Errorf("repair with %s failed - %v", nodeID, err)
+			}
+		}
+	}
+	return firstErr
+}
+
+// repairPeer - Compare the shards shared with a peer and repair the differences.  If shard keys are given only
+// those shards are compared, without exchanging the bucket hashes first.
+func (m *BitmapIndex) repairPeer(nodeID, index string, keys map[string]struct{}) error {
+
+	ci, err := m.GetClientIndexForNodeID(nodeID)
+	if err != nil {
+		return err
+	}
+	peerClient := m.Conn.GetService("BitmapIndex").(*shared.BitmapIndex)
+	peerKV := m.Conn.GetService("KVStore").(*shared.KVStore)
+	client := peerClient.Client(ci)
+
+	local := m.repairShards(nodeID, index)
+	if keys != nil {
+		local = filterShards(local, keys)
+		if len(local) == 0 {
+			return nil
+		}
+	}
+	m.repair.Lock()
+	m.repair.shards += uint64(len(local))
+	m.repair.Unlock()
+
+	cx, cancel := context.WithTimeout(context.Background(), shared.SyncDeadline)
+	defer cancel()
+	req := &pb.RepairChecksumRequest{NodeId: m.hashKey, Index: index}
+	if keys != nil {
+		req.Buckets = shardBuckets(local)
+	} else {
+		res, err := client.RepairChecksums(cx, req)
+		if err != nil {
+			return fmt.Errorf("%v.RepairChecksums(_) = _, %v", client, err)
+		}
+		localHashes := bucketHashes(local)
+		if len(res.BucketHashes) != len(localHashes) {
+			return fmt.Errorf("bucket count mismatch, local = %d, remote = %d", len(localHashes),
+				len(res.BucketHashes))
+		}
+		for i, h := range localHashes {
+			if res.BucketHashes[i] != h {
+				req.Buckets = append(req.Buckets, uint32(i))
+			}
+		}
+		if len(req.Buckets) == 0 {
+			return nil
+		}
+	}
+	res, err := client.RepairChecksums(cx, req)
+	if err != nil {
+		return fmt.Errorf("%v.RepairChecksums(_) = _, %v", client, err)
+	}
+	if keys != nil {
+		res.Shards = filterShards(res.Shards, keys)
+	}
+	remote := make(map[string]*pb.ShardChecksum, len(res.Shards))
+	for _, s := range res.Shards {
+		remote[shared.ShardKey(s)] = s
+	}
+
+	buckets := make(map[uint32]struct{}, len(req.Buckets))
+	for _, b := range req.Buckets {
+		buckets[b] = struct{}{}
+	}
+	for _, s := range local {
+		key := shared.ShardKey(s)
+		if _, found := buckets[repairBucket(key)]; !found {
+			continue
+		}
+		r := remote[key]
+		delete(remote, key)
+		if r != nil && r.Checksum == s.Checksum {
+			continue
+		}
+		m.repair.Lock()
+		m.repair.mismatched++
+		m.repair.Unlock()
+		if err := m.repairShard(peerClient, peerKV, ci, s, r); err != nil {
+			return fmt.Errorf("cannot repair %s - %v", key, err)
+		}
+	}
+	// Shards that exist only on the peer are pushed by the peer itself.
+	m.repair.Lock()
+	m.repair.mismatched += uint64(len(remote))
+	m.repair.Unlock()
+	return nil
+}
+
+// repairShard - Exchange the differences of a shard with a peer.  The remote shard is nil if it is missing.
+// Replicas are merged by union:  bits and columns missing on one replica are copied from the other one.
+// Nothing is cleared, there is no record of clears that would tell a cleared bit from a missed write.
+func (m *BitmapIndex) repairShard(peerClient *shared.BitmapIndex, peerKV *shared.KVStore, ci int,
+	s, r *pb.ShardChecksum) error {
+
+	attr, err := m.getFieldConfig(s.Index, s.Field)
+	if err != nil {
+		return nil // field was dropped
+	}
+	newNode := peerClient.Client(ci)
+	cx, cancel := context.WithTimeout(context.Background(), shared.Deadline)
+	defer cancel()
+
+	// A zero modification time forces the peer to return its data.
+	req := &pb.SyncStatusRequest{Index: s.Index, Field: s.Field, Time: s.Time, SendData: true}
+
+	if s.RowIdOrBits >= 0 {
+		rowID := uint64(s.RowIdOrBits)
+		resBm := roaring64.NewBitmap()
+		if r != nil {
+			req.RowId = rowID
+			res, err := newNode.SyncStatus(cx, req)
+			if err != nil {
+				return err
+			}
+			if len(res.Data) == 1 && res.Cardinality > 0 {
+				if err := resBm.UnmarshalBinary(res.Data[0]); err != nil {
+					return fmt.Errorf("deserialize sync reponse - UnmarshalBinary error - %v", err)
+				}
+			}
+		}
+		m.bitmapCacheLock.RLock()
+		bitmap := m.bitmapCache[s.Index][s.Field][rowID][s.Time]
+		m.bitmapCacheLock.RUnlock()
+		if bitmap == nil {
+			return nil // purged since the checksums were computed
+		}
+		bitmap.Lock.RLock()
+		pushDiff, pullDiff := repairDiffs(bitmap.Bits, resBm)
+		bitmap.Lock.RUnlock()
+		if pushDiff.GetCardinality() > 0 {
+			if err := m.pushBitmapDiff(peerClient, newNode, s.Index, s.Field, rowID, s.Time, pushDiff); err != nil {
+				return err
+			}
+			m.countRepair(true)
+		}
+		if pullDiff.GetCardinality() > 0 {
+			if err := m.mergeBitmapDiff(s.Index, s.Field, rowID, s.Time, pullDiff); err != nil {
+				return err
+			}
+			m.countRepair(false)
+		}
+		return nil
+	}
+
+	resBsi := roaring64.NewBSI(int64(attr.MaxValue), int64(attr.MinValue))
+	if r != nil {
+		res, err := newNode.SyncStatus(cx, req)
+		if err != nil {
+			return err
+		}
+		if res.Cardinality > 0 {
+			if err := resBsi.UnmarshalBinary(res.Data); err != nil {
+				return fmt.Errorf("deserialize sync reponse - BSI UnmarshalBinary error - %v", err)
+			}
+		}
+	}
+	m.bsiCacheLock.RLock()
+	bsi := m.bsiCache[s.Index][s.Field][s.Time]
+	m.bsiCacheLock.RUnlock()
+	if bsi == nil {
+		return nil // purged since the checksums were computed
+	}
+
+	// Columns with different values on the two replicas are left as they are.
+	bsi.Lock.RLock()
+	pushDiff, pullDiff := repairDiffs(bsi.GetExistenceBitmap(), resBsi.GetExistenceBitmap())
+	pushBSI := bsi.NewBSIRetainSet(pushDiff)
+	bsi.Lock.RUnlock()
+	if pushDiff.GetCardinality() > 0 {
+		if err := m.pushBSIDiff(peerClient, newNode, s.Index, s.Field, s.Time, pushBSI); err != nil {
+			return err
+		}
+		m.countRepair(true)
+	}
+	if pullDiff.GetCardinality() > 0 {
+		if err := m.mergeBSIDiff(s.Index, s.Field, s.Time, resBsi.NewBSIRetainSet(pullDiff)); err != nil {
+			return err
+		}
+		m.countRepair(false)
+	}
+	if attr.MappingStrategy == "StringHashBSI" && (pushDiff.GetCardinality() > 0 || pullDiff.GetCardinality() > 0) {
+		return m.syncStringBackingStore(peerKV, peerKV.Client(ci), s.Index, s.Field, s.Time, pushDiff, pullDiff)
+	}
+	return nil
+}
+
+// repairDiffs - Bits missing on the peer that are pushed and bits missing locally that are merged.
+func repairDiffs(local, remote *roaring64.Bitmap) (pushDiff, pullDiff *roaring64.Bitmap) {
+	return roaring64.AndNot(local, remote), roaring64.AndNot(remote, local)
+}
+
+// countRepair - Count a pushed or merged shard difference.
+func (m *BitmapIndex) countRepair(pushed bool) {
+
+	m.repair.Lock()
+	defer m.repair.Unlock()
+	if pushed {
+		m.repair.pushed++
+	} else {
+		m.repair.merged++
+	}
+}
+
+// repairShards - Checksums of the shards that are owned by both this node and the given node.  BSI shards have
+// a RowIdOrBits value of -1.  The shards are collected under the cache locks and hashed after they are released.
+func (m *BitmapIndex) repairShards(nodeID, index string) []*pb.ShardChecksum {
+
+	owned := func(s *pb.ShardChecksum) bool {
+		var self, peer bool
+		for _, n := range m.GetNodesForKey(shared.ShardKey(s)) {
+			self = self || n == m.hashKey
+			peer = peer || n == nodeID
+		}
+		return self && peer
+	}
+
+	bsis := make(map[*pb.ShardChecksum]*BSIBitmap)
+	m.bsiCacheLock.RLock()
+	for indexName, fm := range m.bsiCache {
+		if index != "" && indexName != index {
+			continue
+		}
+		for fieldName, tm := range fm {
+			for ts, bsi := range tm {
+				s := &pb.ShardChecksum{Index: indexName, Field: fieldName, RowIdOrBits: -1, Time: ts}
+				if owned(s) {
+					bsis[s] = bsi
+				}
+			}
+		}
+	}
+	m.bsiCacheLock.RUnlock()
+
+	bitmaps := make(map[*pb.ShardChecksum]*StandardBitmap)
+	m.bitmapCacheLock.RLock()
+	for indexName, fm := range m.bitmapCache {
+		if index != "" && indexName != index {
+			continue
+		}
+		for fieldName, rm := range fm {
+			for rowID, tm := range rm {
+				for ts, bitmap := range tm {
+					s := &pb.ShardChecksum{Index: indexName, Field: fieldName, RowIdOrBits: int64(rowID), Time: ts}
+					if owned(s) {
+						bitmaps[s] = bitmap
+					}
+				}
+			}
+		}
+	}
+	m.bitmapCacheLock.RUnlock()
+
+	shards := make([]*pb.ShardChecksum, 0, len(bsis)+len(bitmaps))
+	for s, bsi := range bsis {
+		bsi.Lock.RLock()
+		checksum, err := bsiChecksum(bsi.BSI)
+		s.ModTime = bsi.ModTime.UnixNano()
+		bsi.Lock.RUnlock()
+		if err != nil {
+			u.Errorf("repair checksum failed for %s/%s - %v", s.Index, s.Field, err)
+			continue
+		}
+		s.Checksum = checksum
+		shards = append(shards, s)
+	}
+	for s, bitmap := range bitmaps {
+		bitmap.Lock.RLock()
+		s.Checksum = bitmapChecksum(bitmap.Bits)
+		s.ModTime = bitmap.ModTime.UnixNano()
+		bitmap.Lock.RUnlock()
+		shards = append(shards, s)
+	}
+	return shards
+}
+
+// repairBucket - Checksum bucket of a shard key.
+func repairBucket(key string) uint32 {
+
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return h.Sum32() % repairBuckets
+}
+
+// filterShards - Shards with the given keys.
+func filterShards(shards []*pb.ShardChecksum, keys map[string]struct{}) []*pb.ShardChecksum {
+
+	filtered := make([]*pb.ShardChecksum, 0, len(keys))
+	for _, s := range shards {
+		if _, found := keys[shared.ShardKey(s)]; found {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered
+}
+
+// shardBuckets - Sorted checksum buckets of the given shards.
+func shardBuckets(shards []*pb.ShardChecksum) []uint32 {
+
+	set := make(map[uint32]struct{}, len(shards))
+	for _, s := range shards {
+		set[repairBucket(shared.ShardKey(s))] = struct{}{}
+	}
+	buckets := make([]uint32, 0, len(set))
+	for b := range set {
+		buckets = append(buckets, b)
+	}
+	sort.Slice(buckets, func(a, b int) bool { return buckets[a] < buckets[b] })
+	return buckets
+}
+
+// bucketHashes - Hash of the shard keys and checksums within each bucket, zero for empty buckets.
+func bucketHashes(shards []*pb.ShardChecksum) []uint64 {
+
+	buckets := make([][]*pb.ShardChecksum, repairBuckets)
+	for _, s := range shards {
+		b := repairBucket(shared.ShardKey(s))
+		buckets[b] = append(buckets[b], s)
+	}
+	hashes := make([]uint64, repairBuckets)
+	buf := make([]byte, 8)
+	for i, bucket := range buckets {
+		if len(bucket) == 0 {
+			continue
+		}
+		sort.Slice(bucket, func(a, b int) bool { return shared.ShardKey(bucket[a]) < shared.ShardKey(bucket[b]) })
+		h := fnv.New64a()
+		for _, s := range bucket {
+			h.Write([]byte(shared.ShardKey(s)))
+			binary.LittleEndian.PutUint64(buf, s.Checksum)
+			h.Write(buf)
+		}
+		hashes[i] = h.Sum64()
+	}
+	return hashes
+}
+
+// bitmapChecksum - Hash of the values of a bitmap.  Unlike the roaring checksum it does not depend on the
+// container layout, which can differ between replicas holding the same values.
+func bitmapChecksum(bm *roaring64.Bitmap) uint64 {
+
+	h := fnv.New64a()
+	hashValues(h, bm)
+	return h.Sum64()
+}
+
+// bsiChecksum - Hash of the values of the existence bitmap and the non empty bit slices of a BSI.
+func bsiChecksum(bsi *roaring64.BSI) (uint64, error) {
+
+	data, err := bsi.MarshalBinary()
+	if err != nil {
+		return 0, err
+	}
+	h := fnv.New64a()
+	buf := make([]byte, 8)
+	for i, b := range data {
+		bm := roaring64.NewBitmap()
+		if err := bm.UnmarshalBinary(b); err != nil {
+			return 0, err
+		}
+		if bm.IsEmpty() {
+			continue
+		}
+		binary.LittleEndian.PutUint64(buf, uint64(i))
+		h.Write(buf)
+		hashValues(h, bm)
+	}
+	return h.Sum64(), nil
+}
+
+// hashValues - Write the values of a bitmap to a hash.
+func hashValues(h hash.Hash64, bm *roaring64.Bitmap) {
+
+	values := make([]uint64, 4096)
+	buf := make([]byte, 8*len(values))
+	it := bm.ManyIterator()
+	for n := it.NextMany(values); n > 0; n = it.NextMany(values) {
+		for i, v := range values[:n] {
+			binary.LittleEndian.PutUint64(buf[i*8:], v)
+		}
+		h.Write(buf[:n*8])
+	}
+}
@@ -0,0 +1,202 @@
+package server
+
+//
+// This file contains per-table retention (TTL) enforcement for time partitioned tables.
+//
+// Partitions that are entirely older than a table's retention period are archived (the default) or
+// deleted by the partition worker thread.  The operations are run inline, the worker thread is the only
+// consumer of the partition queue.  Deletion also removes partitions that were previously archived along
+// with their backing string and primary key stores.
+//
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	u "github.com/araddon/gou"
+	pb "github.com/disney/quanta/grpc"
+)
+
+// retentionPolicy - Partitions with a time before the cutoff are expired.
+type retentionPolicy struct {
+	cutoff time.Time
+	remove bool
+}
+
+// retentionPolicies - Cutoff times for tables with retention enabled.
+func (m *BitmapIndex) retentionPolicies(now time.Time) map[string]*retentionPolicy {
+
+	m.tableCacheLock.RLock()
+	defer m.tableCacheLock.RUnlock()
+	policies := make(map[string]*retentionPolicy)
+	for name, table := range m.tableCache {
+		cutoff, err := table.RetentionCutoff(now)
+		if err != nil {
+			u.Errorf("retention policy for %s - %v", name, err)
+			continue
+		}
+		if cutoff.IsZero() {
+			continue
+		}
+		policies[name] = &retentionPolicy{cutoff: cutoff, remove: table.IsRetentionDelete()}
+	}
+	return policies
+}
+
+// isExpired - Partition is entirely older than the retention period.  Partitions of fields that do
+// not have a time quantum never expire.
+func (r *retentionPolicy) isExpired(tq string, ts int64) bool {
+	return tq != "" && ts < r.cutoff.UnixNano()
+}
+
+// enforceRetention - Archive or remove expired partitions.
+func (m *BitmapIndex) enforceRetention() {
+
+	policies := m.retentionPolicies(time.Now())
+	if len(policies) == 0 {
+		return
+	}
+	expired := make([]*Partition, 0)
+	op := func(p *Partition) error {
+		if r, ok := policies[p.Index]; ok && r.isExpired(p.TQType, p.Time.UnixNano()) {
+			expired = append(expired, p)
+		}
+		return nil
+	}
+	m.iterateBSICache(op)
+	m.iterateBitmapCache(op)
+
+	for _, p := range expired {
+		if po := m.NewPartitionOperation(p, policies[p.Index].remove); po != nil {
+			m.processPartition(po)
+		}
+	}
+	if len(expired) > 0 {
+		u.Infof("Retention: %d expired partitions processed.", len(expired))
+		runtime.GC()
+	}
+
+	// Hard deletes also apply to archived partitions
+	if m.cold == nil {
+		return
+	}
+	for index, r := range policies {
+		if !r.remove {
+			continue
+		}
+		for _, ap := range m.expiredArchive(index, r) {
+			if err := m.removeArchived(ap); err != nil {
+				u.Errorf("Retention: cannot remove archived partition %s - %v", ap.Path, err)
+			}
+		}
+	}
+}
+
+// expiredArchive - Archived partitions of a table that are older than the retention period.
+func (m *BitmapIndex) expiredArchive(index string, r *retentionPolicy) []*archivedPartition {
+
+	return m.cold.Partitions(index, func(field string, rowID, ts int64) bool {
+		attr, err := m.getFieldConfig(index, field)
+		return err == nil && r.isExpired(attr.TimeQuantumType, ts)
+	})
+}
+
+// removeArchived - Delete an archived partition including any backing string or key store.
+func (m *BitmapIndex) removeArchived(ap *archivedPartition) error {
+
+	if err := m.cold.Remove(ap); err != nil {
+		return err
+	}
+	if ap.RowIDOrBits >= 0 {
+		return nil
+	}
+	attr, err := m.getFieldConfig(ap.Index, ap.Field)
+	if err != nil {
+		return err
+	}
+	p := &Partition{Index: ap.Index, Field: ap.Field, RowIDOrBits: ap.RowIDOrBits, Time: time.Unix(0, ap.Time),
+		TQType: attr.TimeQuantumType}
+	po := m.NewPartitionOperation(p, true)
+	if po == nil {
+		return fmt.Errorf("cannot resolve partition %s/%s", ap.Index, ap.Field)
+	}
+	if po.HasStrings {
+		path, _ := m.generateStringsFilePath(po, true)
+		return os.RemoveAll(path)
+	}
+	if po.IsPK {
+		path, _ := m.generateIndexFilePath(po, true, 0)
+		return os.RemoveAll(path)
+	}
+	return nil
+}
+
+// PartitionInfo - List the partitions (including archived) of a table with a time before the
+// request time.  All partitions are listed if the time is zero.
+func (m *BitmapIndex) PartitionInfo(ctx context.Context, req *pb.PartitionInfoRequest) (*pb.PartitionInfoResponse, error) {
+
+	if req.Index == "" {
+		return nil, fmt.Errorf("index not specified for partition info")
+	}
+	before := req.Time
+	if before == 0 {
+		before = math.MaxInt64
+	}
+	response := &pb.PartitionInfoResponse{PartitionInfo: make([]*pb.PartitionInfoResult, 0)}
+	op := func(p *Partition) error {
+		if p.Index != req.Index || p.Time.UnixNano() >= before {
+			return nil
+		}
+		var modTime time.Time
+		if p.RowIDOrBits < 0 {
+			modTime = p.Shard.(*BSIBitmap).ModTime
+		} else {
+			modTime = p.Shard.(*StandardBitmap).ModTime
+		}
+		response.PartitionInfo = append(response.PartitionInfo, &pb.PartitionInfoResult{Index: p.Index,
+			Field: p.Field, RowIdOrBits: p.RowIDOrBits, Time: p.Time.UnixNano(), TqType: p.TQType,
+			Bytes: uint64(partitionBytes(p)), ModTime: modTime.UnixNano()})
+		return nil
+	}
+	m.iterateBSICache(op)
+	m.iterateBitmapCache(op)
+
+	if m.cold == nil {
+		return response, nil
+	}
+	archived := m.cold.Partitions(req.Index, func(field string, rowID, ts int64) bool {
+		return ts < before
+	})
+	for _, ap := range archived {
+		var tq string
+		if attr, err := m.getFieldConfig(ap.Index, ap.Field); err == nil {
+			tq = attr.TimeQuantumType
+		}
+		size, modTime := pathInfo(ap.Path)
+		response.PartitionInfo = append(response.PartitionInfo, &pb.PartitionInfoResult{Index: ap.Index,
+			Field: ap.Field, RowIdOrBits: ap.RowIDOrBits, Time: ap.Time, TqType: tq, Bytes: uint64(size),
+			ModTime: modTime.UnixNano(), Archived: true})
+	}
+	return response, nil
+}
+
+// pathInfo - Total size and latest modification time of a file or directory.
+func pathInfo(path string) (size int64, modTime time.Time) {
+
+	filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return nil
+		}
+		size += info.Size()
+		if info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+		return nil
+	})
+	return
+}
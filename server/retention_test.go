@@ -0,0 +1,46 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/RoaringBitmap/roaring/roaring64"
+	"github.com/disney/quanta/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnforceRetention(t *testing.T) {
+
+	table, err := shared.LoadSchema("../shared/testdata/config", "cityzip", nil)
+	require.NoError(t, err)
+	table.TimeQuantumType = "YMD"
+	table.Retention = "30d"
+
+	// More expired partitions than the partition queue holds, nothing reads from the queue.
+	now := time.Now().UTC()
+	tm := make(map[int64]*StandardBitmap)
+	for i := 0; i < 5; i++ {
+		ts := now.AddDate(0, 0, -60-i).Truncate(24 * time.Hour).UnixNano()
+		tm[ts] = &StandardBitmap{Bits: roaring64.BitmapOf(1), TQType: "YMD"}
+	}
+	current := now.Truncate(24 * time.Hour).UnixNano()
+	tm[current] = &StandardBitmap{Bits: roaring64.BitmapOf(1), TQType: "YMD"}
+	m := &BitmapIndex{Node: &Node{dataDir: t.TempDir()}, partitionQueue: make(chan *PartitionOperation, 1),
+		tableCache:  map[string]*shared.BasicTable{"cityzip": table},
+		bitmapCache: map[string]map[string]map[uint64]map[int64]*StandardBitmap{"cityzip": {"state": {3: tm}}}}
+
+	done := make(chan struct{})
+	go func() {
+		m.enforceRetention()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("enforceRetention blocked on the partition queue")
+	}
+	assert.Len(t, m.bitmapCache["cityzip"]["state"][3], 1)
+	assert.Contains(t, m.bitmapCache["cityzip"]["state"][3], current)
+	assert.Empty(t, m.partitionQueue)
+}
@@ -1,23 +1,38 @@
 package server
 
+//
+// Server side string search service.  Indexed strings are stored by hash in search.dat and an
+// inverted index of terms to roaring bitmaps of string hashes is maintained in terms.dat.  Each batch
+// stores only the hashes it added to a term as a delta (term, 0x00, sequence), the deltas of a term
+// are merged into its base bitmap (keyed by the term) once maxTermDeltas have accumulated.
+//
+
 import (
 	"bytes"
+	"context"
+	"crypto/sha512"
 	"encoding/binary"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/RoaringBitmap/roaring/roaring64"
 	"github.com/akrylysov/pogreb"
 	u "github.com/araddon/gou"
 	"github.com/aviddiviner/go-murmur"
 	"github.com/bbalet/stopwords"
 	pb "github.com/disney/quanta/grpc"
+	"github.com/disney/quanta/shared"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/golang/protobuf/ptypes/wrappers"
-	"github.com/steakknife/bloomfilter"
 	"golang.org/x/text/unicode/norm"
-	"hash"
-	"hash/fnv"
-	"io"
-	"regexp"
-	"time"
 )
 
 var (
@@ -25,25 +40,38 @@ var (
 	_ NodeService = (*StringSearch)(nil)
 )
 
-const (
-	maxElements = 100
-	probCollide = 0.0000001
-)
-
 var (
 	wordSegmenter = regexp.MustCompile(`[\pL\p{Mc}\p{Mn}\p{Nd}-_']+`)
 )
 
+const (
+	maxTermDeltas = 16
+)
+
 // StringSearch service state.
+//
+// store - Original string values keyed by hash.
+// termStore - Persistent inverted index (term -> bitmap of string hashes).
+// terms - In memory copy of the inverted index.
+// termList - Sorted list of terms for prefix searches.
+// termDeltas - Sequence numbers of the persisted deltas of each term.
+// termSeq - Last delta sequence number.
 type StringSearch struct {
 	*Node
-	store *pogreb.DB
+	store      *pogreb.DB
+	termStore  *pogreb.DB
+	terms      map[string]*roaring64.Bitmap
+	termList   []string
+	termDeltas map[string][]uint64
+	termSeq    uint64
+	termLock   sync.RWMutex
 }
 
 // NewStringSearch - Construct server side state for search service.
 func NewStringSearch(node *Node) *StringSearch {
 
-	e := &StringSearch{Node: node}
+	e := &StringSearch{Node: node, terms: make(map[string]*roaring64.Bitmap),
+		termDeltas: make(map[string][]uint64)}
 	pb.RegisterStringSearchServer(node.server, e)
 	return e
 }
@@ -57,25 +85,79 @@ func (m *StringSearch) Init() error {
 	}
 	m.store = db
 
-	u.Info("Pre-warming  string search cache.")
+	tdb, err := pogreb.Open(m.dataDir+"/index/"+"terms.dat", nil)
+	if err != nil {
+		return fmt.Errorf("cannot initialize string search service: %v", err)
+	}
+	m.termStore = tdb
+
+	u.Info("Loading string search term index.")
 	start := time.Now()
-	count := 0
-	it := db.Items()
+	if err := m.loadTerms(); err != nil {
+		return fmt.Errorf("cannot initialize string search service: %v", err)
+	}
+
+	count := db.Count()
+	elapsed := time.Since(start)
+	u.Infof("Term index initialization complete %d terms, %d strings loaded in %s.\n", len(m.terms),
+		count, elapsed)
+	if len(m.terms) == 0 && count > 0 {
+		u.Warnf("String search term index is empty, searchable fields must be re-indexed.")
+	}
+	return nil
+}
+
+// loadTerms - Load the term index, the deltas of each term are merged with its base bitmap.
+func (m *StringSearch) loadTerms() error {
+
+	it := m.termStore.Items()
 	for {
-		_, _, err := it.Next()
+		key, val, err := it.Next()
 		if err != nil {
 			if err != pogreb.ErrIterationDone {
-				return fmt.Errorf("cannot initialize string search service: %v", err)
+				return err
 			}
 			break
 		}
-		count++
+		term := string(key)
+		if i := bytes.IndexByte(key, 0); i >= 0 {
+			if len(key) != i+9 {
+				return fmt.Errorf("term %q - invalid delta key", term)
+			}
+			term = string(key[:i])
+			seq := binary.BigEndian.Uint64(key[i+1:])
+			m.termDeltas[term] = append(m.termDeltas[term], seq)
+			if seq > m.termSeq {
+				m.termSeq = seq
+			}
+		}
+		bm := roaring64.NewBitmap()
+		if err := bm.UnmarshalBinary(val); err != nil {
+			return fmt.Errorf("term %s - %v", term, err)
+		}
+		if existing, found := m.terms[term]; found {
+			existing.Or(bm)
+		} else {
+			m.terms[term] = bm
+		}
 	}
-	elapsed := time.Since(start)
-	u.Infof("Cache initialization complete %d items loaded in %s.\n", count, elapsed)
+	m.termList = make([]string, 0, len(m.terms))
+	for k := range m.terms {
+		m.termList = append(m.termList, k)
+	}
+	sort.Strings(m.termList)
 	return nil
 }
 
+// termDeltaKey - Key of a term delta.
+func termDeltaKey(term string, seq uint64) []byte {
+
+	key := make([]byte, len(term)+9)
+	copy(key, term)
+	binary.BigEndian.PutUint64(key[len(term)+1:], seq)
+	return key
+}
+
 // Shutdown search service.
 func (m *StringSearch) Shutdown() {
 
@@ -83,6 +165,10 @@ func (m *StringSearch) Shutdown() {
 		m.store.Sync()
 		m.store.Close()
 	}
+	if m.termStore != nil {
+		m.termStore.Sync()
+		m.termStore.Close()
+	}
 }
 
 // JoinCluster - Join the cluster
@@ -92,11 +178,28 @@ func (m *StringSearch) JoinCluster() {
 // BatchIndex - Insert a new batch of searchable strings.
 func (m *StringSearch) BatchIndex(stream pb.StringSearch_BatchIndexServer) error {
 
+	dirty := make(map[string]*roaring64.Bitmap)
+	newTerms := make([]string, 0)
+	err := m.batchIndex(stream, dirty, &newTerms)
+
+	// Persist the term index even if the batch failed part way through.
+	if err2 := m.saveTerms(dirty, newTerms); err2 != nil && err == nil {
+		err = err2
+	}
+	m.store.Sync()
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(&empty.Empty{})
+}
+
+func (m *StringSearch) batchIndex(stream pb.StringSearch_BatchIndexServer, dirty map[string]*roaring64.Bitmap,
+	newTerms *[]string) error {
+
 	for {
 		sv, err := stream.Recv()
 		if err == io.EOF {
-			m.store.Sync()
-			return stream.SendAndClose(&empty.Empty{})
+			return nil
 		}
 		if err != nil {
 			return err
@@ -111,72 +214,185 @@ func (m *StringSearch) BatchIndex(stream pb.StringSearch_BatchIndexServer) error
 		key := make([]byte, 8)
 		binary.LittleEndian.PutUint64(key, hashVal)
 
-		if found, err := m.store.Has(key); err != nil {
+		// Skip strings that are already indexed.  Legacy (bloom filter) values are replaced.
+		if val, err := m.store.Get(key); err != nil {
 			return err
-		} else if found {
+		} else if string(val) == str {
 			continue
 		}
 
-		// Construct bloom filter sans stopwords
-		bloomFilter, err := constructBloomFilter(str)
-		if err != nil {
+		if err := m.store.Put(key, []byte(str)); err != nil {
 			return err
 		}
+		m.addTerms(hashVal, str, dirty, newTerms)
+	}
+}
+
+// addTerms - Add a string hash to the bitmaps of each of its terms.  The hashes added to each term are
+// collected in dirty.
+func (m *StringSearch) addTerms(hashVal uint64, str string, dirty map[string]*roaring64.Bitmap,
+	newTerms *[]string) {
+
+	m.termLock.Lock()
+	defer m.termLock.Unlock()
+
+	for _, t := range parseTerms(str) {
+		term := string(t)
+		bm, found := m.terms[term]
+		if !found {
+			bm = roaring64.NewBitmap()
+			m.terms[term] = bm
+			*newTerms = append(*newTerms, term)
+		}
+		bm.Add(hashVal)
+		delta, found := dirty[term]
+		if !found {
+			delta = roaring64.NewBitmap()
+			dirty[term] = delta
+		}
+		delta.Add(hashVal)
+	}
+}
+
+// saveTerms - Persist the deltas of modified terms and merge new terms into the sorted term list.
+func (m *StringSearch) saveTerms(dirty map[string]*roaring64.Bitmap, newTerms []string) error {
+
+	m.termLock.Lock()
+	defer m.termLock.Unlock()
+
+	if len(newTerms) > 0 {
+		sort.Strings(newTerms)
+		merged := make([]string, 0, len(m.termList)+len(newTerms))
+		i, j := 0, 0
+		for i < len(m.termList) && j < len(newTerms) {
+			if m.termList[i] < newTerms[j] {
+				merged = append(merged, m.termList[i])
+				i++
+			} else {
+				merged = append(merged, newTerms[j])
+				j++
+			}
+		}
+		merged = append(merged, m.termList[i:]...)
+		merged = append(merged, newTerms[j:]...)
+		m.termList = merged
+	}
 
-		bfBuf, err := bloomFilter.MarshalBinary()
+	for term, delta := range dirty {
+		if err := m.saveTerm(term, delta); err != nil {
+			return fmt.Errorf("saveTerms: term %s - %v", term, err)
+		}
+	}
+	if len(dirty) > 0 {
+		return m.termStore.Sync()
+	}
+	return nil
+}
+
+// saveTerm - Persist the hashes added to a term.  New terms and terms with maxTermDeltas deltas are written in
+// full, replacing their deltas.  Caller must hold termLock.
+func (m *StringSearch) saveTerm(term string, delta *roaring64.Bitmap) error {
+
+	bm := m.terms[term]
+	seqs := m.termDeltas[term]
+	if len(seqs) < maxTermDeltas && delta.GetCardinality() < bm.GetCardinality() {
+		buf, err := delta.MarshalBinary()
 		if err != nil {
 			return err
 		}
+		m.termSeq++
+		if err := m.termStore.Put(termDeltaKey(term, m.termSeq), buf); err != nil {
+			return err
+		}
+		m.termDeltas[term] = append(seqs, m.termSeq)
+		return nil
+	}
 
-		if err := m.store.Put(key, bfBuf); err != nil {
+	// The base is written before the deltas are removed, merging a delta twice is harmless.
+	buf, err := bm.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	if err := m.termStore.Put([]byte(term), buf); err != nil {
+		return err
+	}
+	for _, seq := range seqs {
+		if err := m.termStore.Delete(termDeltaKey(term, seq)); err != nil {
 			return err
 		}
 	}
+	delete(m.termDeltas, term)
+	return nil
 }
 
 // Search - Execute a text search.
+//
+// Terms are implicitly "and"ed together, the keyword OR separates alternatives.  Double quoted
+// terms must appear as a phrase and a term ending with '%' or '*' is matched as a prefix.
 func (m *StringSearch) Search(searchStr *wrappers.StringValue, stream pb.StringSearch_SearchServer) error {
 
 	search := searchStr.GetValue()
 	if searchStr == nil || search == "" {
 		return fmt.Errorf("Search string must not be empty")
 	}
-	terms := parseTerms(search)
 
-	hashedTerms := make([]hash.Hash64, len(terms))
+	clauses := parseQuery(search)
+	if len(clauses) == 0 {
+		// Nothing but wildcards and stopwords, everything matches.
+		return m.sendAll(stream)
+	}
 
-	for i, v := range terms {
-		hasher := fnv.New64a()
-		hasher.Write(v)
-		hashedTerms[i] = hasher
+	// Resolve the candidates for each clause from the term index.
+	candidates := make([]*roaring64.Bitmap, len(clauses))
+	m.termLock.RLock()
+	for i, c := range clauses {
+		candidates[i] = m.lookupClause(c)
 	}
+	m.termLock.RUnlock()
 
-	bloomFilter, err := bloomfilter.NewOptimal(maxElements, probCollide)
-	if err != nil {
-		return err
+	results := roaring64.NewBitmap()
+	for i, c := range clauses {
+		if len(c.phrases) == 0 {
+			results.Or(candidates[i])
+			continue
+		}
+		// Phrases are verified against the original string.
+		candidates[i].AndNot(results)
+		it := candidates[i].Iterator()
+		for it.HasNext() {
+			hashVal := it.Next()
+			ok, err := m.verifyPhrases(hashVal, c.phrases)
+			if err != nil {
+				return err
+			}
+			if ok {
+				results.Add(hashVal)
+			}
+		}
 	}
 
+	// return the hashes of the original string values
+	it := results.Iterator()
+	for it.HasNext() {
+		if err := stream.Send(&wrappers.UInt64Value{Value: it.Next()}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sendAll - Return the hashes of all indexed strings.
+func (m *StringSearch) sendAll(stream pb.StringSearch_SearchServer) error {
+
 	it := m.store.Items()
-Top:
 	for {
-		stringHash, val, err := it.Next()
+		stringHash, _, err := it.Next()
 		if err != nil {
 			if err != pogreb.ErrIterationDone {
 				return err
 			}
 			break
 		}
-
-		bloomFilter.UnmarshalBinary(val)
-
-		// Perform "and" comparison. Item will be selected if all terms are contained.
-		for _, v := range hashedTerms {
-			if !bloomFilter.Contains(v) {
-				continue Top
-			}
-		}
-
-		// return the hash of the original string value
 		v := binary.LittleEndian.Uint64(stringHash[:8])
 		if err := stream.Send(&wrappers.UInt64Value{Value: v}); err != nil {
 			return err
@@ -185,6 +401,185 @@ Top:
 	return nil
 }
 
+// lookupClause - Intersect the bitmaps of all terms in a clause.  Caller must hold termLock.
+func (m *StringSearch) lookupClause(c *searchClause) *roaring64.Bitmap {
+
+	terms := append([]searchTerm{}, c.terms...)
+	for _, p := range c.phrases {
+		terms = append(terms, p...)
+	}
+
+	var result *roaring64.Bitmap
+	for _, t := range terms {
+		bm := m.lookupTerm(t)
+		if result == nil {
+			result = bm.Clone()
+		} else {
+			result.And(bm)
+		}
+		if result.IsEmpty() {
+			break
+		}
+	}
+	if result == nil {
+		result = roaring64.NewBitmap()
+	}
+	return result
+}
+
+// lookupTerm - Get the bitmap for a term or the union of all terms matching a prefix.
+// Caller must hold termLock.
+func (m *StringSearch) lookupTerm(t searchTerm) *roaring64.Bitmap {
+
+	if !t.prefix {
+		if bm, found := m.terms[t.text]; found {
+			return bm
+		}
+		return roaring64.NewBitmap()
+	}
+
+	bms := make([]*roaring64.Bitmap, 0)
+	for i := sort.SearchStrings(m.termList, t.text); i < len(m.termList); i++ {
+		if !strings.HasPrefix(m.termList[i], t.text) {
+			break
+		}
+		bms = append(bms, m.terms[m.termList[i]])
+	}
+	return roaring64.FastOr(bms...)
+}
+
+// verifyPhrases - Check that the original string contains all phrases.
+func (m *StringSearch) verifyPhrases(hashVal uint64, phrases [][]searchTerm) (bool, error) {
+
+	key := make([]byte, 8)
+	binary.LittleEndian.PutUint64(key, hashVal)
+	val, err := m.store.Get(key)
+	if err != nil {
+		return false, err
+	}
+	if isLegacyValue(val) {
+		u.Debugf("verifyPhrases: skipping legacy bloom filter value for hash %d, table must be re-indexed", hashVal)
+		return false, nil
+	}
+	words := parseTerms(string(val))
+	for _, p := range phrases {
+		if !matchPhrase(words, p) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// isLegacyValue - Values indexed before the term index are stored as marshalled bloom filters, these consist of
+// k, n and m followed by k keys, (m+63)/64 words of bits and a SHA-384 hash of the preceding bytes.
+func isLegacyValue(val []byte) bool {
+
+	const header, trailer = 24, sha512.Size384
+	if len(val) < header+trailer {
+		return false
+	}
+	k := binary.LittleEndian.Uint64(val[0:8])
+	m := binary.LittleEndian.Uint64(val[16:24])
+	if k > uint64(len(val)) || m > uint64(len(val))*8 {
+		return false
+	}
+	size := header + 8*int(k) + 8*int((m+63)/64)
+	if len(val) != size+trailer {
+		return false
+	}
+	hash := sha512.Sum384(val[:size])
+	return bytes.Equal(hash[:], val[size:])
+}
+
+// reindexKey - Cluster key of a local string store path (table/field/strings/[YYYYMMDD/]timestamp) as
+// derived by the client when the strings are written.
+func reindexKey(index string) string {
+
+	s := strings.Split(index, sep)
+	if len(s) == 5 {
+		return fmt.Sprintf("%s/%s/%s", s[0], s[1], s[4]) // YMDH partitions
+	}
+	return strings.Join(s[:len(s)-1], "/")
+}
+
+// Reindex - Resubmit the locally stored values of a table's searchable fields for indexing.  All replicas
+// resubmit their values (indexing is idempotent) but only the values of stores for which this node is the
+// primary are counted.
+func (m *StringSearch) Reindex(ctx context.Context, table *wrappers.StringValue) (*wrappers.UInt64Value, error) {
+
+	tableName := table.GetValue()
+	if table == nil || tableName == "" {
+		return nil, fmt.Errorf("table name must not be empty")
+	}
+	tbl, err := shared.LoadSchema("", tableName, m.consul)
+	if err != nil {
+		return nil, fmt.Errorf("Reindex: %v", err)
+	}
+
+	localKV := m.Node.GetNodeService("KVStore").(*KVStore)
+	searchClient := m.Conn.GetService("StringSearch").(*shared.StringSearch)
+
+	start := time.Now()
+	var count uint64
+	for _, attr := range tbl.Attributes {
+		if !attr.Searchable {
+			continue
+		}
+		// Backing strings are stored by time partition under table/field/strings
+		iPath := m.dataDir + sep + "index" + sep
+		fPath := iPath + tableName + sep + attr.FieldName + sep + "strings"
+		if _, err := os.Stat(fPath); os.IsNotExist(err) {
+			continue
+		}
+		stores := make([]string, 0)
+		err := filepath.Walk(fPath,
+			func(path string, info os.FileInfo, err error) error {
+				if err != nil {
+					return err
+				}
+				if !strings.HasSuffix(path, "/00000.psg") {
+					return nil
+				}
+				stores = append(stores, strings.TrimPrefix(filepath.Dir(path), iPath))
+				return nil
+			})
+		if err != nil {
+			return nil, fmt.Errorf("Reindex: %v", err)
+		}
+		for _, index := range stores {
+			primary := m.Member(reindexKey(index))
+			db, err := localKV.getStore(index)
+			if err != nil {
+				return nil, fmt.Errorf("Reindex: %v", err)
+			}
+			it := db.Items()
+			for {
+				_, val, err := it.Next()
+				if err != nil {
+					if err != pogreb.ErrIterationDone {
+						return nil, fmt.Errorf("Reindex: %s - %v", index, err)
+					}
+					break
+				}
+				if len(val) == 0 {
+					continue
+				}
+				if err := searchClient.Index(string(val)); err != nil {
+					return nil, fmt.Errorf("Reindex: %v", err)
+				}
+				if primary {
+					count++
+				}
+			}
+		}
+	}
+	if err := searchClient.Flush(); err != nil {
+		return nil, fmt.Errorf("Reindex: %v", err)
+	}
+	u.Infof("Reindex of table %s complete, %d values submitted in %s.", tableName, count, time.Since(start))
+	return &wrappers.UInt64Value{Value: count}, nil
+}
+
 func parseTerms(content string) [][]byte {
 
 	cleanStr := stopwords.CleanString(content, "en", true)
@@ -193,21 +588,91 @@ func parseTerms(content string) [][]byte {
 	return wordSegmenter.FindAll(c, -1)
 }
 
-func constructBloomFilter(content string) (*bloomfilter.Filter, error) {
+// searchTerm - A single search term, optionally matched as a prefix.
+type searchTerm struct {
+	text   string
+	prefix bool
+}
 
-	words := parseTerms(content)
+// searchClause - Terms and phrases that must all be present.
+type searchClause struct {
+	terms   []searchTerm
+	phrases [][]searchTerm
+}
 
-	// Construct bloom filter sans stopwords
-	//bloomFilter, err := bloomfilter.NewOptimal(uint64(len(words)), probCollide)
-	bloomFilter, err := bloomfilter.NewOptimal(uint64(maxElements), probCollide)
-	if err != nil {
-		return nil, err
+// parseQuery - Parse a search string into a list of clauses that are "or"ed together.
+func parseQuery(query string) []*searchClause {
+
+	clauses := []*searchClause{{}}
+	for {
+		query = strings.TrimLeftFunc(query, unicode.IsSpace)
+		if query == "" {
+			break
+		}
+		clause := clauses[len(clauses)-1]
+		if query[0] == '"' {
+			phrase := query[1:]
+			query = ""
+			if end := strings.IndexByte(phrase, '"'); end >= 0 {
+				phrase, query = phrase[:end], phrase[end+1:]
+			}
+			if terms := parseSearchTerms(phrase); len(terms) > 0 {
+				clause.phrases = append(clause.phrases, terms)
+			}
+			continue
+		}
+		token := query
+		query = ""
+		if end := strings.IndexFunc(token, unicode.IsSpace); end >= 0 {
+			token, query = token[:end], token[end:]
+		}
+		switch token {
+		case "OR":
+			if len(clause.terms) > 0 || len(clause.phrases) > 0 {
+				clauses = append(clauses, &searchClause{})
+			}
+			continue
+		case "AND":
+			continue
+		}
+		clause.terms = append(clause.terms, parseSearchTerms(token)...)
 	}
 
-	for _, v := range words {
-		hasher := fnv.New64a()
-		hasher.Write(v)
-		bloomFilter.Add(hasher)
+	result := make([]*searchClause, 0, len(clauses))
+	for _, c := range clauses {
+		if len(c.terms) > 0 || len(c.phrases) > 0 {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// parseSearchTerms - Extract terms from a token or phrase.  A trailing wildcard makes the last term a prefix.
+func parseSearchTerms(s string) []searchTerm {
+
+	words := parseTerms(s)
+	terms := make([]searchTerm, len(words))
+	for i, w := range words {
+		terms[i] = searchTerm{text: string(w)}
+	}
+	if len(terms) > 0 && (strings.HasSuffix(s, "%") || strings.HasSuffix(s, "*")) {
+		terms[len(terms)-1].prefix = true
+	}
+	return terms
+}
+
+// matchPhrase - Return true if the phrase terms appear consecutively in words.
+func matchPhrase(words [][]byte, phrase []searchTerm) bool {
+
+Top:
+	for i := 0; i+len(phrase) <= len(words); i++ {
+		for j, t := range phrase {
+			w := string(words[i+j])
+			if t.prefix && !strings.HasPrefix(w, t.text) || !t.prefix && w != t.text {
+				continue Top
+			}
+		}
+		return true
 	}
-	return bloomFilter, nil
+	return false
 }
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"crypto/sha512"
+	"encoding/binary"
+	"testing"
+
+	"github.com/RoaringBitmap/roaring/roaring64"
+	"github.com/akrylysov/pogreb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchParseQuery(t *testing.T) {
+
+	clauses := parseQuery(`%quick brown% "lazy dog" OR fox*`)
+	require.Len(t, clauses, 2)
+	assert.Equal(t, []searchTerm{{text: "quick"}, {text: "brown", prefix: true}}, clauses[0].terms)
+	require.Len(t, clauses[0].phrases, 1)
+	assert.Equal(t, []searchTerm{{text: "lazy"}, {text: "dog"}}, clauses[0].phrases[0])
+	assert.Equal(t, []searchTerm{{text: "fox", prefix: true}}, clauses[1].terms)
+
+	// Wildcards and stopwords only.
+	assert.Empty(t, parseQuery("% the %"))
+}
+
+func TestSearchMatchPhrase(t *testing.T) {
+
+	words := parseTerms("The quick brown fox jumps over the lazy dog")
+	assert.True(t, matchPhrase(words, []searchTerm{{text: "brown"}, {text: "fox"}}))
+	assert.True(t, matchPhrase(words, []searchTerm{{text: "lazy"}, {text: "do", prefix: true}}))
+	assert.False(t, matchPhrase(words, []searchTerm{{text: "fox"}, {text: "brown"}}))
+	assert.False(t, matchPhrase(words, []searchTerm{{text: "dog"}, {text: "lazy"}}))
+}
+
+func TestSearchLegacyValue(t *testing.T) {
+
+	// Marshalled bloom filter with k = 2, n = 3 and m = 128.
+	val := make([]byte, 0)
+	for _, v := range []uint64{2, 3, 128, 11, 12, 0xff00, 0x00ff} {
+		val = binary.LittleEndian.AppendUint64(val, v)
+	}
+	hash := sha512.Sum384(val)
+	val = append(val, hash[:]...)
+	assert.True(t, isLegacyValue(val))
+
+	val[30] ^= 1
+	assert.False(t, isLegacyValue(val))
+	assert.False(t, isLegacyValue([]byte("The quick brown fox jumps over the lazy dog")))
+}
+
+func TestSearchReindexKey(t *testing.T) {
+
+	assert.Equal(t, "orders/notes/strings", reindexKey("orders/notes/strings/2024-03-15T00"))
+	assert.Equal(t, "orders/notes/2024-03-15T13", reindexKey("orders/notes/strings/20240315/2024-03-15T13"))
+}
+
+func TestSearchSaveTerms(t *testing.T) {
+
+	dir := t.TempDir()
+	db, err := pogreb.Open(dir+"/terms.dat", nil)
+	require.NoError(t, err)
+	m := &StringSearch{termStore: db, terms: make(map[string]*roaring64.Bitmap),
+		termDeltas: make(map[string][]uint64)}
+
+	// The first batch writes the new term in full, later batches only their deltas.
+	for i := uint64(1); i <= maxTermDeltas+3; i++ {
+		dirty := make(map[string]*roaring64.Bitmap)
+		newTerms := make([]string, 0)
+		m.addTerms(i, "quick fox", dirty, &newTerms)
+		require.NoError(t, m.saveTerms(dirty, newTerms))
+		if i == 1 {
+			assert.Empty(t, m.termDeltas["quick"])
+		}
+	}
+	// After maxTermDeltas deltas the term is written in full again.
+	assert.Len(t, m.termDeltas["quick"], 1)
+	assert.Len(t, m.termDeltas["fox"], 1)
+	assert.Equal(t, uint32(4), db.Count())
+	require.NoError(t, db.Close())
+
+	db, err = pogreb.Open(dir+"/terms.dat", nil)
+	require.NoError(t, err)
+	defer db.Close()
+	loaded := &StringSearch{termStore: db, terms: make(map[string]*roaring64.Bitmap),
+		termDeltas: make(map[string][]uint64)}
+	require.NoError(t, loaded.loadTerms())
+	assert.Equal(t, []string{"fox", "quick"}, loaded.termList)
+	assert.Equal(t, uint64(maxTermDeltas+3), loaded.terms["quick"].GetCardinality())
+	assert.True(t, loaded.terms["fox"].Equals(m.terms["fox"]))
+	assert.Equal(t, m.termDeltas, loaded.termDeltas)
+	assert.Equal(t, m.termSeq, loaded.termSeq)
+}
@@ -0,0 +1,444 @@
+package server
+
+//
+// This file contains the write-ahead log (WAL) for the bitmap server.
+//
+// Mutations accepted by BatchMutate, Update and BulkClear are appended to the active WAL segment
+// before they are acknowledged.  When the caches are persisted the active segment is sealed and
+// sealed segments are removed once everything they contain has been written to the bitmap files.
+// On startup, any remaining segments are replayed after the bitmap files are read.  Truncated tables
+// and dropped fields are logged as well so that a replay does not resurrect the mutations before them.
+//
+// Each record is framed as [payload length uint32][CRC32 of payload uint32][payload].  A short
+// read or checksum mismatch marks a torn write, replay of that segment stops at the last good record.
+// Only the last segment can end with a torn write, replay fails if an earlier segment is damaged.
+//
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	u "github.com/araddon/gou"
+)
+
+const (
+	walSuffix       = ".wal"
+	walHeaderSize   = 8
+	walMaxRecordLen = 1 << 30
+)
+
+// WAL record types.
+const (
+	walFragment = byte(iota + 1)
+	walBulkClear
+	walTruncate
+	walDropField
+)
+
+// WAL - Segmented append-only write-ahead log.
+//
+// gate - Held shared while a mutation is logged and queued, held exclusively to seal the segment.
+// This guarantees that everything in a sealed segment is in the fragment queue.
+type WAL struct {
+	dir    string
+	gate   sync.RWMutex
+	lock   sync.Mutex
+	file   *os.File
+	seq    uint64
+	size   int64
+	sealed []string
+}
+
+// walRecord - Decoded WAL entry.
+type walRecord struct {
+	kind     byte
+	frag     *BitmapFragment
+	index    string
+	field    string
+	start    int64
+	end      int64
+	foundSet []byte
+}
+
+// OpenWAL - Open the WAL in a given directory.  Existing segments are sealed, they are returned
+// by Sealed() for replay.
+func OpenWAL(dir string) (*WAL, error) {
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("cannot create WAL directory %s - %v", dir, err)
+	}
+	w := &WAL{dir: dir}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), walSuffix) {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(e.Name(), walSuffix), 10, 64)
+		if err != nil {
+			u.Warnf("ignoring unexpected WAL file %s", e.Name())
+			continue
+		}
+		if seq > w.seq {
+			w.seq = seq
+		}
+		w.sealed = append(w.sealed, filepath.Join(dir, e.Name()))
+	}
+	sort.Strings(w.sealed)
+	if err := w.openSegment(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// openSegment - Create the next active segment.  Caller must hold the lock (or be the constructor).
+func (w *WAL) openSegment() error {
+
+	w.seq++
+	path := filepath.Join(w.dir, fmt.Sprintf("%020d%s", w.seq, walSuffix))
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND|os.O_EXCL, 0644)
+	if err != nil {
+		return fmt.Errorf("cannot create WAL segment %s - %v", path, err)
+	}
+	w.file = f
+	w.size = 0
+	return nil
+}
+
+// Begin - Must be called before a mutation is logged, End must be called once it is queued.
+func (w *WAL) Begin() {
+	w.gate.RLock()
+}
+
+// End - Mutation has been queued.
+func (w *WAL) End() {
+	w.gate.RUnlock()
+}
+
+// Append - Write a record to the active segment.  The record is not durable until Sync is called.
+func (w *WAL) Append(rec *walRecord) error {
+
+	payload := rec.encode()
+	buf := make([]byte, walHeaderSize+len(payload))
+	binary.LittleEndian.PutUint32(buf[0:], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(buf[4:], crc32.ChecksumIEEE(payload))
+	copy(buf[walHeaderSize:], payload)
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.file == nil {
+		return fmt.Errorf("WAL is closed")
+	}
+	n, err := w.file.Write(buf)
+	w.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("WAL append failed - %v", err)
+	}
+	return nil
+}
+
+// Sync - Flush the active segment to stable storage.
+func (w *WAL) Sync() error {
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.file == nil {
+		return fmt.Errorf("WAL is closed")
+	}
+	return w.file.Sync()
+}
+
+// Rotate - Seal the active segment (if it contains anything) and start a new one.  Returns all
+// sealed segments, these can be removed once the caches are persisted.
+func (w *WAL) Rotate() ([]string, error) {
+
+	w.gate.Lock()
+	defer w.gate.Unlock()
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.file != nil && w.size > 0 {
+		if err := w.file.Sync(); err != nil {
+			return nil, err
+		}
+		if err := w.file.Close(); err != nil {
+			return nil, err
+		}
+		w.sealed = append(w.sealed, w.file.Name())
+		if err := w.openSegment(); err != nil {
+			w.file = nil
+			return nil, err
+		}
+	}
+	sealed := make([]string, len(w.sealed))
+	copy(sealed, w.sealed)
+	return sealed, nil
+}
+
+// Sealed - Returns the sealed segments in the order they were written.
+func (w *WAL) Sealed() []string {
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	sealed := make([]string, len(w.sealed))
+	copy(sealed, w.sealed)
+	return sealed
+}
+
+// Remove - Delete sealed segments after a successful persist.
+func (w *WAL) Remove(segments []string) error {
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	remove := make(map[string]struct{}, len(segments))
+	for _, v := range segments {
+		remove[v] = struct{}{}
+	}
+	remaining := make([]string, 0)
+	var err error
+	for _, v := range w.sealed {
+		if _, found := remove[v]; !found {
+			remaining = append(remaining, v)
+			continue
+		}
+		if errx := os.Remove(v); errx != nil && !os.IsNotExist(errx) {
+			err = errx
+			remaining = append(remaining, v)
+		}
+	}
+	w.sealed = remaining
+	return err
+}
+
+// Close - Sync and close the active segment.
+func (w *WAL) Close() error {
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Sync()
+	if errx := w.file.Close(); err == nil {
+		err = errx
+	}
+	w.file = nil
+	return err
+}
+
+// replayWALSegment - Read the records in a segment and pass them to the apply function.  Returns
+// the number of records read and whether the segment was read to the end.  A torn or corrupted record
+// ends the replay of the segment without an error.
+func replayWALSegment(path string, apply func(rec *walRecord) error) (int, bool, error) {
+
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, false, err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	header := make([]byte, walHeaderSize)
+	count := 0
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			if err == io.ErrUnexpectedEOF {
+				u.Warnf("WAL segment %s has a torn record header after %d records", path, count)
+				return count, false, nil
+			} else if err != io.EOF {
+				return count, false, err
+			}
+			return count, true, nil
+		}
+		size := binary.LittleEndian.Uint32(header[0:])
+		sum := binary.LittleEndian.Uint32(header[4:])
+		if size > walMaxRecordLen {
+			u.Warnf("WAL segment %s has an invalid record length after %d records", path, count)
+			return count, false, nil
+		}
+		payload := make([]byte, size)
+		if _, err := io.ReadFull(r, payload); err != nil {
+			if err == io.ErrUnexpectedEOF || err == io.EOF {
+				u.Warnf("WAL segment %s has a torn record after %d records", path, count)
+				return count, false, nil
+			}
+			return count, false, err
+		}
+		if crc32.ChecksumIEEE(payload) != sum {
+			u.Warnf("WAL segment %s has a checksum mismatch after %d records", path, count)
+			return count, false, nil
+		}
+		rec, err := decodeWALRecord(payload)
+		if err != nil {
+			u.Warnf("WAL segment %s has an undecodable record after %d records - %v", path, count, err)
+			return count, false, nil
+		}
+		if err := apply(rec); err != nil {
+			return count, false, err
+		}
+		count++
+	}
+}
+
+// encode - Serialize a record payload.
+func (rec *walRecord) encode() []byte {
+
+	var buf []byte
+	buf = append(buf, rec.kind)
+	switch rec.kind {
+	case walFragment:
+		f := rec.frag
+		buf = appendWALString(buf, f.IndexName)
+		buf = appendWALString(buf, f.FieldName)
+		buf = binary.LittleEndian.AppendUint64(buf, uint64(f.RowIDOrBits))
+		buf = binary.LittleEndian.AppendUint64(buf, uint64(f.Time.UnixNano()))
+		var flags byte
+		if f.IsBSI {
+			flags |= 1
+		}
+		if f.IsClear {
+			flags |= 2
+		}
+		if f.IsUpdate {
+			flags |= 4
+		}
+		buf = append(buf, flags)
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(f.BitData)))
+		for _, v := range f.BitData {
+			buf = appendWALBytes(buf, v)
+		}
+	case walBulkClear:
+		buf = appendWALString(buf, rec.index)
+		buf = binary.LittleEndian.AppendUint64(buf, uint64(rec.start))
+		buf = binary.LittleEndian.AppendUint64(buf, uint64(rec.end))
+		buf = appendWALBytes(buf, rec.foundSet)
+	case walTruncate:
+		buf = appendWALString(buf, rec.index)
+	case walDropField:
+		buf = appendWALString(buf, rec.index)
+		buf = appendWALString(buf, rec.field)
+	}
+	return buf
+}
+
+// decodeWALRecord - Deserialize a record payload.
+func decodeWALRecord(payload []byte) (*walRecord, error) {
+
+	d := &walDecoder{buf: payload}
+	rec := &walRecord{kind: d.byte()}
+	switch rec.kind {
+	case walFragment:
+		index := d.string()
+		field := d.string()
+		rowIDOrBits := int64(d.uint64())
+		ts := time.Unix(0, int64(d.uint64()))
+		flags := d.byte()
+		n := d.uint32()
+		if d.err == nil && int(n) > len(d.buf) {
+			return nil, fmt.Errorf("invalid bit data count %d", n)
+		}
+		data := make([][]byte, n)
+		for i := range data {
+			data[i] = d.bytes()
+		}
+		rec.frag = newBitmapFragment(index, field, rowIDOrBits, ts, data, flags&1 != 0, flags&2 != 0,
+			flags&4 != 0)
+	case walBulkClear:
+		rec.index = d.string()
+		rec.start = int64(d.uint64())
+		rec.end = int64(d.uint64())
+		rec.foundSet = d.bytes()
+	case walTruncate:
+		rec.index = d.string()
+	case walDropField:
+		rec.index = d.string()
+		rec.field = d.string()
+	default:
+		return nil, fmt.Errorf("unknown record type %d", rec.kind)
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	return rec, nil
+}
+
+func appendWALString(buf []byte, s string) []byte {
+	return appendWALBytes(buf, []byte(s))
+}
+
+func appendWALBytes(buf, b []byte) []byte {
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(b)))
+	return append(buf, b...)
+}
+
+// walDecoder - Sequential reader over a record payload, the first error sticks.
+type walDecoder struct {
+	buf []byte
+	err error
+}
+
+func (d *walDecoder) next(n int) []byte {
+
+	if d.err != nil {
+		return nil
+	}
+	if n < 0 || n > len(d.buf) {
+		d.err = fmt.Errorf("record truncated")
+		return nil
+	}
+	b := d.buf[:n]
+	d.buf = d.buf[n:]
+	return b
+}
+
+func (d *walDecoder) byte() byte {
+	if b := d.next(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+func (d *walDecoder) uint32() uint32 {
+	if b := d.next(4); b != nil {
+		return binary.LittleEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (d *walDecoder) uint64() uint64 {
+	if b := d.next(8); b != nil {
+		return binary.LittleEndian.Uint64(b)
+	}
+	return 0
+}
+
+func (d *walDecoder) bytes() []byte {
+	n := d.uint32()
+	if d.err != nil {
+		return nil
+	}
+	b := d.next(int(n))
+	if b == nil {
+		return nil
+	}
+	out := make([]byte, len(b))
+	copy(out, b)
+	return out
+}
+
+func (d *walDecoder) string() string {
+	return string(d.bytes())
+}
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWALReplay(t *testing.T) {
+
+	dir := t.TempDir()
+	wal, err := OpenWAL(dir)
+	require.NoError(t, err)
+	assert.Empty(t, wal.Sealed())
+
+	ts := time.Unix(0, 1700000000000000000)
+	frag := newBitmapFragment("cities", "name", -12, ts, [][]byte{{1, 2}, {}, {3}}, true, false, true)
+	require.NoError(t, wal.Append(&walRecord{kind: walFragment, frag: frag}))
+	require.NoError(t, wal.Append(&walRecord{kind: walBulkClear, index: "cities", start: 1, end: 2,
+		foundSet: []byte{9, 9}}))
+	require.NoError(t, wal.Append(&walRecord{kind: walTruncate, index: "cities"}))
+	require.NoError(t, wal.Append(&walRecord{kind: walDropField, index: "cities", field: "name"}))
+	require.NoError(t, wal.Close())
+
+	// Reopening seals the existing segment.
+	wal, err = OpenWAL(dir)
+	require.NoError(t, err)
+	sealed := wal.Sealed()
+	require.Len(t, sealed, 1)
+
+	var recs []*walRecord
+	apply := func(rec *walRecord) error {
+		recs = append(recs, rec)
+		return nil
+	}
+	n, complete, err := replayWALSegment(sealed[0], apply)
+	require.NoError(t, err)
+	assert.True(t, complete)
+	assert.Equal(t, 4, n)
+	require.Len(t, recs, 4)
+	assert.Equal(t, "cities", recs[0].frag.IndexName)
+	assert.Equal(t, "name", recs[0].frag.FieldName)
+	assert.Equal(t, int64(-12), recs[0].frag.RowIDOrBits)
+	assert.Equal(t, ts.UnixNano(), recs[0].frag.Time.UnixNano())
+	assert.Equal(t, [][]byte{{1, 2}, {}, {3}}, recs[0].frag.BitData)
+	assert.True(t, recs[0].frag.IsBSI)
+	assert.False(t, recs[0].frag.IsClear)
+	assert.True(t, recs[0].frag.IsUpdate)
+	assert.Equal(t, walBulkClear, recs[1].kind)
+	assert.Equal(t, []byte{9, 9}, recs[1].foundSet)
+	assert.Equal(t, int64(2), recs[1].end)
+	assert.Equal(t, walTruncate, recs[2].kind)
+	assert.Equal(t, walDropField, recs[3].kind)
+	assert.Equal(t, "cities", recs[3].index)
+	assert.Equal(t, "name", recs[3].field)
+
+	// Torn write at the end of the segment.
+	data, err := os.ReadFile(sealed[0])
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(sealed[0], data[:len(data)-3], 0644))
+	recs = nil
+	n, complete, err = replayWALSegment(sealed[0], apply)
+	require.NoError(t, err)
+	assert.False(t, complete)
+	assert.Equal(t, 3, n)
+
+	// Checksum mismatch in the first record.
+	data[walHeaderSize] ^= 0xff
+	require.NoError(t, os.WriteFile(sealed[0], data, 0644))
+	n, complete, err = replayWALSegment(sealed[0], apply)
+	require.NoError(t, err)
+	assert.False(t, complete)
+	assert.Equal(t, 0, n)
+
+	// Segments are only sealed if they contain records.
+	sealed, err = wal.Rotate()
+	require.NoError(t, err)
+	assert.Len(t, sealed, 1)
+	require.NoError(t, wal.Append(&walRecord{kind: walTruncate, index: "cities"}))
+	sealed, err = wal.Rotate()
+	require.NoError(t, err)
+	assert.Len(t, sealed, 2)
+	require.NoError(t, wal.Remove(sealed))
+	assert.Empty(t, wal.Sealed())
+	for _, v := range sealed {
+		_, err := os.Stat(v)
+		assert.True(t, os.IsNotExist(err))
+	}
+	require.NoError(t, wal.Close())
+}
@@ -0,0 +1,513 @@
+package shared
+
+// Online schema evolution (ALTER TABLE).
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v2"
+)
+
+// AlterOp - ALTER TABLE column operations.
+type AlterOp int
+
+// Constant defines for ALTER TABLE column operations.
+const (
+	AddColumn = AlterOp(iota)
+	DropColumn
+	ModifyColumn
+)
+
+// String - Return string representation of AlterOp.
+func (op AlterOp) String() string {
+
+	switch op {
+	case AddColumn:
+		return "ADD"
+	case DropColumn:
+		return "DROP"
+	case ModifyColumn:
+		return "MODIFY"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// AlterAction - A column change within an ALTER TABLE statement.
+type AlterAction struct {
+	Op      AlterOp
+	Column  string
+	Type    string            // Quanta data type (optional for MODIFY)
+	Size    int               // From the SQL type, i.e. VARCHAR(20)
+	Scale   int               // From the SQL type, i.e. DECIMAL(10,2)
+	Options map[string]string // Attribute settings keyed by schema.yaml name, i.e. mappingStrategy
+}
+
+// AlterTableStatement - Parsed ALTER TABLE statement.
+type AlterTableStatement struct {
+	Table   string
+	Actions []*AlterAction
+}
+
+// attributeOptions - Lower case option names to schema.yaml attribute names.
+var attributeOptions = func() map[string]string {
+
+	opts := make(map[string]string)
+	typ := reflect.TypeOf(BasicAttribute{})
+	for i := 0; i < typ.NumField(); i++ {
+		name := strings.Split(typ.Field(i).Tag.Get("yaml"), ",")[0]
+		switch name {
+		case "", "-", "fieldName", "type", "values", "configuration":
+			continue
+		}
+		opts[strings.ToLower(name)] = name
+	}
+	return opts
+}()
+
+// ParseAlterTable - Parse an ALTER TABLE statement.  Column options are specified as key=value pairs
+// using the names in schema.yaml.  Mapper configuration is specified as configuration.<key>=value.
+//
+//	ALTER TABLE orders ADD COLUMN discount DECIMAL(10,2) desc='Discount amount'
+//	ALTER TABLE orders MODIFY COLUMN region configuration.delim=','
+//	ALTER TABLE orders DROP COLUMN legacy_code
+func ParseAlterTable(sql string) (*AlterTableStatement, error) {
+
+	words, err := splitDDL(sql)
+	if err != nil {
+		return nil, err
+	}
+	if len(words) < 4 || !strings.EqualFold(words[0], "ALTER") || !strings.EqualFold(words[1], "TABLE") {
+		return nil, fmt.Errorf("expecting ALTER TABLE <table> [ADD|DROP|MODIFY] [COLUMN] <column> [%s]", sql)
+	}
+	stmt := &AlterTableStatement{Table: unquoteDDL(words[2]), Actions: make([]*AlterAction, 0)}
+	if i := strings.LastIndex(words[2], "."); i >= 0 {
+		stmt.Table = unquoteDDL(words[2][i+1:])
+	}
+
+	words = words[3:]
+	for len(words) > 0 {
+		// Each action ends at a comma
+		end := len(words)
+		for i, w := range words {
+			if w == "," {
+				end = i
+				break
+			}
+		}
+		action, err := parseAlterAction(words[:end])
+		if err != nil {
+			return nil, err
+		}
+		stmt.Actions = append(stmt.Actions, action)
+		if end == len(words) {
+			break
+		}
+		words = words[end+1:]
+	}
+	return stmt, nil
+}
+
+func parseAlterAction(words []string) (*AlterAction, error) {
+
+	if len(words) == 0 {
+		return nil, fmt.Errorf("missing ALTER TABLE action")
+	}
+	var op AlterOp
+	switch strings.ToUpper(words[0]) {
+	case "ADD":
+		op = AddColumn
+	case "DROP":
+		op = DropColumn
+	case "MODIFY":
+		op = ModifyColumn
+	default:
+		return nil, fmt.Errorf("unsupported ALTER TABLE action '%s', expecting ADD, DROP or MODIFY", words[0])
+	}
+	words = words[1:]
+	if len(words) > 0 && strings.EqualFold(words[0], "COLUMN") {
+		words = words[1:]
+	}
+	return parseColumn(op, words)
+}
+
+// parseColumn - Parse a column definition, <column> [<type>] [option=value ...]
+func parseColumn(op AlterOp, words []string) (*AlterAction, error) {
+
+	if len(words) == 0 {
+		return nil, fmt.Errorf("column name expected for %s", op)
+	}
+	action := &AlterAction{Op: op, Options: make(map[string]string)}
+	action.Column = unquoteDDL(words[0])
+	words = words[1:]
+	if action.Op == DropColumn {
+		if len(words) > 0 {
+			return nil, fmt.Errorf("unexpected '%s' after DROP COLUMN %s", words[0], action.Column)
+		}
+		return action, nil
+	}
+
+	if len(words) > 0 && !strings.Contains(words[0], "=") {
+		var err error
+		if action.Type, action.Size, action.Scale, err = sqlType(words[0]); err != nil {
+			return nil, err
+		}
+		words = words[1:]
+	}
+	if action.Op == AddColumn && action.Type == "" {
+		return nil, fmt.Errorf("data type expected for ADD COLUMN %s", action.Column)
+	}
+	for _, w := range words {
+		kv := strings.SplitN(w, "=", 2)
+		if len(kv) != 2 || kv[1] == "" {
+			return nil, fmt.Errorf("expecting option=value for column %s, got '%s'", action.Column, w)
+		}
+		key := strings.ToLower(kv[0])
+		if strings.HasPrefix(key, "configuration.") {
+			key = "configuration." + kv[0][len("configuration."):]
+		} else if name, ok := attributeOptions[key]; ok {
+			key = name
+		} else {
+			return nil, fmt.Errorf("unknown option '%s' for column %s", kv[0], action.Column)
+		}
+		action.Options[key] = kv[1]
+	}
+	return action, nil
+}
+
+// sqlType - Map a SQL data type (or Quanta type name) to a Quanta data type.
+func sqlType(s string) (typ string, size, scale int, err error) {
+
+	name, args := s, make([]int, 0)
+	if i := strings.Index(s, "("); i >= 0 {
+		if !strings.HasSuffix(s, ")") {
+			return "", 0, 0, fmt.Errorf("invalid data type '%s'", s)
+		}
+		name = s[:i]
+		for _, a := range strings.Split(s[i+1:len(s)-1], ",") {
+			n, err := strconv.Atoi(strings.TrimSpace(a))
+			if err != nil {
+				return "", 0, 0, fmt.Errorf("invalid data type '%s'", s)
+			}
+			args = append(args, n)
+		}
+	}
+	switch strings.ToLower(name) {
+	case "varchar", "char", "text", "string":
+		typ = String.String()
+		if len(args) > 0 {
+			size = args[0]
+		}
+	case "int", "integer", "bigint", "smallint", "tinyint", "mediumint":
+		typ = Integer.String()
+	case "float", "double", "real":
+		typ = Float.String()
+	case "decimal", "numeric":
+		typ = Float.String()
+		if len(args) > 1 {
+			scale = args[1]
+		}
+	case "date":
+		typ = Date.String()
+	case "datetime", "timestamp":
+		typ = DateTime.String()
+	case "bool", "boolean":
+		typ = Boolean.String()
+	case "json":
+		typ = JSON.String()
+	default:
+		return "", 0, 0, fmt.Errorf("unsupported data type '%s'", s)
+	}
+	return
+}
+
+// splitDDL - Split a DDL statement into words.  Quoted strings and parenthesized arguments are kept
+// intact, commas are separate words and "key = value" is joined as "key=value".
+func splitDDL(s string) ([]string, error) {
+
+	words := make([]string, 0)
+	var b strings.Builder
+	var quote rune
+	depth := 0
+	flush := func() {
+		if b.Len() > 0 {
+			words = append(words, b.String())
+			b.Reset()
+		}
+	}
+	for _, r := range s {
+		switch {
+		case quote != 0:
+			b.WriteRune(r)
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+			b.WriteRune(r)
+		case r == '(':
+			depth++
+			b.WriteRune(r)
+		case r == ')':
+			if depth--; depth < 0 {
+				return nil, fmt.Errorf("unbalanced parentheses [%s]", s)
+			}
+			b.WriteRune(r)
+		case depth > 0:
+			if !unicode.IsSpace(r) {
+				b.WriteRune(r)
+			}
+		case r == ',':
+			flush()
+			words = append(words, ",")
+		case r == ';':
+			flush()
+		case unicode.IsSpace(r):
+			flush()
+		default:
+			b.WriteRune(r)
+		}
+	}
+	if quote != 0 || depth != 0 {
+		return nil, fmt.Errorf("unterminated quote or parentheses [%s]", s)
+	}
+	flush()
+
+	joined := make([]string, 0, len(words))
+	for i := 0; i < len(words); i++ {
+		w := words[i]
+		for i+1 < len(words) && (strings.HasSuffix(w, "=") || strings.HasPrefix(words[i+1], "=")) {
+			i++
+			w += words[i]
+		}
+		joined = append(joined, w)
+	}
+	return joined, nil
+}
+
+// unquoteDDL - Remove surrounding quotes or backticks.
+func unquoteDDL(s string) string {
+
+	if len(s) >= 2 && (s[0] == '\'' || s[0] == '"' || s[0] == '`') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// defaultMappingStrategy - Mapping strategy for new columns if not specified.
+func defaultMappingStrategy(typ string) string {
+
+	switch TypeFromString(typ) {
+	case String:
+		return "StringHashBSI"
+	case Integer:
+		return "IntBSI"
+	case Float:
+		return "FloatScaleBSI"
+	case Date:
+		return "SysMillisBSI"
+	case DateTime:
+		return "SysMicroBSI"
+	case Boolean:
+		return "BoolDirect"
+	default:
+		return ""
+	}
+}
+
+// apply - Apply the action's data type and options to an attribute.
+func (a *AlterAction) apply(attr *BasicAttribute) error {
+
+	if a.Type != "" {
+		attr.Type = a.Type
+	}
+	if a.Size > 0 {
+		attr.Size = a.Size
+	}
+	if a.Scale > 0 {
+		attr.Scale = a.Scale
+	}
+	var doc strings.Builder
+	for k, v := range a.Options {
+		if strings.HasPrefix(k, "configuration.") {
+			conf := make(map[string]string, len(attr.MapperConfig)+1)
+			for ck, cv := range attr.MapperConfig {
+				conf[ck] = cv
+			}
+			conf[k[len("configuration."):]] = unquoteDDL(v)
+			attr.MapperConfig = conf
+			continue
+		}
+		if uq := unquoteDDL(v); uq != v {
+			v = strconv.Quote(uq)
+		}
+		doc.WriteString(k + ": " + v + "\n")
+	}
+	if err := yaml.UnmarshalStrict([]byte(doc.String()), attr); err != nil {
+		return fmt.Errorf("invalid option for column %s - %v", a.Column, err)
+	}
+	return nil
+}
+
+// newAttribute - Create an attribute from a column definition.
+func (a *AlterAction) newAttribute() (BasicAttribute, error) {
+
+	attr := BasicAttribute{FieldName: a.Column}
+	if err := a.apply(&attr); err != nil {
+		return attr, err
+	}
+	if TypeFromString(attr.Type) == NotDefined {
+		return attr, fmt.Errorf("unknown type %s for column %s", attr.Type, a.Column)
+	}
+	if attr.MappingStrategy == "" {
+		attr.MappingStrategy = defaultMappingStrategy(attr.Type)
+	}
+	if attr.MappingStrategy == "" {
+		return attr, fmt.Errorf("mappingStrategy must be specified for column %s", a.Column)
+	}
+	return attr, nil
+}
+
+// Alter - Apply ALTER TABLE actions to a copy of this table.  Returns the altered table and a
+// description of the changes.  This table is not modified.
+func (t *BasicTable) Alter(actions []*AlterAction) (*BasicTable, []string, error) {
+
+	b, err := yaml.Marshal(t)
+	if err != nil {
+		return nil, nil, err
+	}
+	altered := &BasicTable{}
+	if err := yaml.Unmarshal(b, altered); err != nil {
+		return nil, nil, err
+	}
+	altered.ConsulClient = t.ConsulClient
+	if err := altered.initialize(); err != nil {
+		return nil, nil, err
+	}
+
+	warnings := make([]string, 0)
+	for _, a := range actions {
+		switch a.Op {
+		case AddColumn:
+			if _, err := altered.GetAttribute(a.Column); err == nil {
+				return nil, nil, fmt.Errorf("column '%s' already exists in %s", a.Column, t.Name)
+			}
+			attr, err := a.newAttribute()
+			if err != nil {
+				return nil, nil, err
+			}
+			if attr.Required {
+				return nil, nil, fmt.Errorf("cannot add required column '%s', existing rows have no value", a.Column)
+			}
+			altered.Attributes = append(altered.Attributes, attr)
+			warnings = append(warnings, fmt.Sprintf("new attribute '%s' %s %s", a.Column, attr.Type,
+				attr.MappingStrategy))
+		case DropColumn:
+			if err := altered.checkDrop(a.Column); err != nil {
+				return nil, nil, err
+			}
+			for i, v := range altered.Attributes {
+				if v.FieldName == a.Column {
+					altered.Attributes = append(altered.Attributes[:i], altered.Attributes[i+1:]...)
+					break
+				}
+			}
+			warnings = append(warnings, fmt.Sprintf("attribute '%s' will be dropped", a.Column))
+		case ModifyColumn:
+			attr, err := altered.GetAttribute(a.Column)
+			if err != nil {
+				return nil, nil, fmt.Errorf("column '%s' does not exist in %s", a.Column, t.Name)
+			}
+			modified := *attr
+			if err := a.apply(&modified); err != nil {
+				return nil, nil, err
+			}
+			if err := checkMappingChange(attr, &modified); err != nil {
+				return nil, nil, fmt.Errorf("incompatible change - %v", err)
+			}
+			// The remaining properties are compared as for a redeployed schema.
+			compared := modified
+			if modified.MappingStrategy != attr.MappingStrategy {
+				compared.MappingStrategy, compared.MinValue, compared.MaxValue = attr.MappingStrategy,
+					attr.MinValue, attr.MaxValue
+				warnings = append(warnings, fmt.Sprintf("attribute '%s' mapping strategy changed existing = '%s', "+
+					"new = '%s'", a.Column, attr.MappingStrategy, modified.MappingStrategy))
+			}
+			equal, attrWarnings, err := attr.Compare(&compared)
+			if err != nil {
+				return nil, nil, fmt.Errorf("incompatible change - %v", err)
+			}
+			if !equal {
+				warnings = append(warnings, attrWarnings...)
+			}
+			*attr = modified
+		}
+		if err := altered.initialize(); err != nil {
+			return nil, nil, err
+		}
+	}
+	return altered, warnings, nil
+}
+
+// checkMappingChange - A mapping strategy can only be changed to one with the same storage type, standard
+// bitmap to standard bitmap or BSI to BSI.  The value range of a BSI must include the existing one, the scale
+// cannot change and hashed or scaled values cannot be reinterpreted so that the stored values keep their meaning.
+func checkMappingChange(existing, modified *BasicAttribute) error {
+
+	if existing.MappingStrategy == modified.MappingStrategy {
+		return nil
+	}
+	if existing.IsBSI() != modified.IsBSI() {
+		return fmt.Errorf("attribute '%s' mapping strategy '%s' cannot be changed to '%s', the storage types differ",
+			existing.FieldName, existing.MappingStrategy, modified.MappingStrategy)
+	}
+	if !existing.IsBSI() {
+		return nil
+	}
+	// Hashed strings and scaled floats cannot be read as other values.
+	for _, v := range []string{"StringHashBSI", "FloatScaleBSI"} {
+		if existing.MappingStrategy == v || modified.MappingStrategy == v {
+			return fmt.Errorf("attribute '%s' mapping strategy '%s' cannot be changed to '%s', the values are "+
+				"not compatible", existing.FieldName, existing.MappingStrategy, modified.MappingStrategy)
+		}
+	}
+	if modified.Scale != existing.Scale {
+		return fmt.Errorf("attribute '%s' scale differs existing = '%d', new = '%d'", existing.FieldName,
+			existing.Scale, modified.Scale)
+	}
+	if modified.MinValue > existing.MinValue || modified.MaxValue < existing.MaxValue {
+		return fmt.Errorf("attribute '%s' value range %d to %d does not include the existing range %d to %d",
+			existing.FieldName, modified.MinValue, modified.MaxValue, existing.MinValue, existing.MaxValue)
+	}
+	return nil
+}
+
+// checkDrop - Verify that a column can be dropped.
+func (t *BasicTable) checkDrop(column string) error {
+
+	attr, err := t.GetAttribute(column)
+	if err != nil || attr.FieldName != column {
+		return fmt.Errorf("column '%s' does not exist in %s", column, t.Name)
+	}
+	keys := strings.Split(t.PrimaryKey, "+")
+	keys = append(keys, strings.Split(t.SecondaryKeys, ",")...)
+	keys = append(keys, t.TimeQuantumField)
+	for _, k := range keys {
+		if strings.TrimSpace(k) == column {
+			return fmt.Errorf("cannot drop key column '%s'", column)
+		}
+	}
+	if attr.ForeignKey != "" {
+		return fmt.Errorf("cannot drop foreign key column '%s'", column)
+	}
+	for _, v := range t.Attributes {
+		if v.DelegationTarget == column {
+			return fmt.Errorf("cannot drop column '%s', it is the delegation target of '%s'", column, v.FieldName)
+		}
+	}
+	return nil
+}
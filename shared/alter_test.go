@@ -0,0 +1,106 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAlterTable(t *testing.T) {
+
+	stmt, err := ParseAlterTable("ALTER TABLE quanta.`cities` ADD COLUMN discount DECIMAL(10, 2) desc = 'A, B', " +
+		"MODIFY timezone configuration.delim=',' , DROP COLUMN density;")
+	require.NoError(t, err)
+	assert.Equal(t, "cities", stmt.Table)
+	require.Len(t, stmt.Actions, 3)
+	assert.Equal(t, AddColumn, stmt.Actions[0].Op)
+	assert.Equal(t, "discount", stmt.Actions[0].Column)
+	assert.Equal(t, "Float", stmt.Actions[0].Type)
+	assert.Equal(t, 2, stmt.Actions[0].Scale)
+	assert.Equal(t, map[string]string{"desc": "'A, B'"}, stmt.Actions[0].Options)
+	assert.Equal(t, ModifyColumn, stmt.Actions[1].Op)
+	assert.Equal(t, map[string]string{"configuration.delim": "','"}, stmt.Actions[1].Options)
+	assert.Equal(t, DropColumn, stmt.Actions[2].Op)
+	assert.Equal(t, "density", stmt.Actions[2].Column)
+
+	for _, sql := range []string{
+		"ALTER TABLE cities RENAME COLUMN a TO b",
+		"ALTER TABLE cities ADD COLUMN x",
+		"ALTER TABLE cities ADD COLUMN x BLOB",
+		"ALTER TABLE cities ADD COLUMN x INT colour=red",
+		"ALTER TABLE cities DROP COLUMN x y",
+		"ALTER TABLE cities ADD COLUMN x VARCHAR(20",
+	} {
+		_, err := ParseAlterTable(sql)
+		assert.Error(t, err, sql)
+	}
+}
+
+func TestAlterTable(t *testing.T) {
+
+	table, err := LoadSchema("./testdata/config2", "cities", nil)
+	require.NoError(t, err)
+
+	stmt, err := ParseAlterTable("ALTER TABLE cities ADD COLUMN zip VARCHAR(10) searchable=true, " +
+		"DROP COLUMN density, MODIFY COLUMN timezone desc='IANA zone'")
+	require.NoError(t, err)
+	altered, warnings, err := table.Alter(stmt.Actions)
+	require.NoError(t, err)
+	assert.Len(t, warnings, 3)
+
+	zip, err := altered.GetAttribute("zip")
+	require.NoError(t, err)
+	assert.Equal(t, "String", zip.Type)
+	assert.Equal(t, "StringHashBSI", zip.MappingStrategy)
+	assert.Equal(t, 10, zip.Size)
+	assert.True(t, zip.Searchable)
+	tz, err := altered.GetAttribute("timezone")
+	require.NoError(t, err)
+	assert.Equal(t, "IANA zone", tz.Desc)
+	assert.Equal(t, "StringEnum", tz.MappingStrategy)
+	assert.Equal(t, []string{"density"}, table.DroppedAttributes(altered))
+
+	// The original table is unchanged
+	_, err = table.GetAttribute("zip")
+	assert.Error(t, err)
+	_, err = table.GetAttribute("density")
+	assert.NoError(t, err)
+
+	_, _, err = table.CompareAllowDrops(altered)
+	assert.NoError(t, err)
+	_, _, err = table.Compare(altered)
+	assert.Error(t, err)
+
+	// Mapping strategy changes that keep the storage type are allowed.
+	stmt, err = ParseAlterTable("ALTER TABLE cities MODIFY COLUMN ranking mappingStrategy=StringEnum, " +
+		"MODIFY COLUMN population mappingStrategy=SysSecBSI maxValue=1000000")
+	require.NoError(t, err)
+	altered, warnings, err = table.Alter(stmt.Actions)
+	require.NoError(t, err)
+	assert.Len(t, warnings, 2)
+	ranking, err := altered.GetAttribute("ranking")
+	require.NoError(t, err)
+	assert.Equal(t, "StringEnum", ranking.MappingStrategy)
+	population, err := altered.GetAttribute("population")
+	require.NoError(t, err)
+	assert.Equal(t, "SysSecBSI", population.MappingStrategy)
+	assert.Equal(t, 1000000, population.MaxValue)
+
+	for _, sql := range []string{
+		"ALTER TABLE cities DROP COLUMN id",
+		"ALTER TABLE cities DROP COLUMN nosuch",
+		"ALTER TABLE cities ADD COLUMN name VARCHAR(20)",
+		"ALTER TABLE cities ADD COLUMN zip VARCHAR(10) required=true",
+		"ALTER TABLE cities MODIFY COLUMN population mappingStrategy=IntDirect",
+		"ALTER TABLE cities MODIFY COLUMN ranking mappingStrategy=IntBSI",
+		"ALTER TABLE cities MODIFY COLUMN population mappingStrategy=SysSecBSI minValue=10 maxValue=20",
+		"ALTER TABLE cities MODIFY COLUMN latitude mappingStrategy=IntBSI",
+		"ALTER TABLE cities MODIFY COLUMN latitude DECIMAL(10,2)",
+	} {
+		stmt, err := ParseAlterTable(sql)
+		require.NoError(t, err, sql)
+		_, _, err = table.Alter(stmt.Actions)
+		assert.Error(t, err, sql)
+	}
+}
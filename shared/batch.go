@@ -86,6 +86,15 @@ func (c *BatchBuffer) Flush() error {
 	return nil
 }
 
+// Discard - Drop the outstanding batch without sending it.
+func (c *BatchBuffer) Discard() {
+
+	c.batchMutex.Lock()
+	defer c.batchMutex.Unlock()
+	c.batchSets, c.batchClears, c.batchValues, c.batchPartitionStr = nil, nil, nil, nil
+	c.batchSetCount, c.batchClearCount, c.batchValueCount, c.batchPartitionStrCount = 0, 0, 0, 0
+}
+
 // IsEmpty - Return true is batch is empty
 func (c *BatchBuffer) IsEmpty() bool {
 
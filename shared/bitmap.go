@@ -8,6 +8,7 @@ package shared
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/RoaringBitmap/roaring/roaring64"
@@ -31,9 +32,12 @@ const (
 //
 // Conn - "base" class wrapper for network connection to servers.
 // client - Array of client API wrappers, one each for every server node.
+// repairTimes - Start times of repairs scheduled by queries keyed by shard.
 type BitmapIndex struct {
 	*Conn
-	client []pb.BitmapIndexClient
+	client      []pb.BitmapIndexClient
+	repairLock  sync.Mutex
+	repairTimes map[string]time.Time
 }
 
 // NewBitmapIndex - Initializer for client side API wrappers.
@@ -43,7 +47,7 @@ func NewBitmapIndex(conn *Conn) *BitmapIndex {
 	for i := 0; i < len(conn.ClientConnections()); i++ {
 		clients[i] = pb.NewBitmapIndexClient(conn.ClientConnections()[i])
 	}
-	c := &BitmapIndex{Conn: conn, client: clients}
+	c := &BitmapIndex{Conn: conn, client: clients, repairTimes: make(map[string]time.Time)}
 	conn.RegisterService(c)
 	return c
 }
@@ -456,6 +460,12 @@ func (c *BitmapIndex) sequencerClient(client pb.BitmapIndexClient, req *pb.Check
 // Projection - Send fields and target set for a given index to cluster for projection processing.
 func (c *BitmapIndex) Projection(index string, fields []string, fromTime, toTime int64,
 	foundSet *roaring64.Bitmap, negate bool) (map[string]*roaring64.BSI, map[string]map[uint64]*roaring64.Bitmap, error) {
+	return c.ProjectionContext(context.Background(), index, fields, fromTime, toTime, foundSet, negate)
+}
+
+// ProjectionContext - Projection processing that can be canceled.
+func (c *BitmapIndex) ProjectionContext(ctx context.Context, index string, fields []string, fromTime, toTime int64,
+	foundSet *roaring64.Bitmap, negate bool) (map[string]*roaring64.BSI, map[string]map[uint64]*roaring64.Bitmap, error) {
 
 	bsiResults := make(map[string][]*roaring64.BSI, 0)
 	bitmapResults := make(map[string]map[uint64][]*roaring64.Bitmap, 0)
@@ -480,7 +490,7 @@ func (c *BitmapIndex) Projection(index string, fields []string, fromTime, toTime
 		client := c.client[n]
 		clientIndex := n
 		eg.Go(func() error {
-			pr, err := c.projectionClient(client, req, clientIndex)
+			pr, err := c.projectionClient(ctx, client, req, clientIndex)
 			if err != nil {
 				return err
 			}
@@ -494,7 +504,9 @@ func (c *BitmapIndex) Projection(index string, fields []string, fromTime, toTime
 	}
 	close(resultChan)
 
+	coldPartitions := 0
 	for rs := range resultChan {
+		coldPartitions += int(rs.GetColdPartitions())
 		for _, v := range rs.GetBsiResults() {
 			bsi, ok := bsiResults[v.Field]
 			if !ok {
@@ -524,6 +536,10 @@ func (c *BitmapIndex) Projection(index string, fields []string, fromTime, toTime
 		}
 	}
 
+	if coldPartitions > 0 {
+		u.Infof("Projection of %s read %d archived partitions", index, coldPartitions)
+	}
+
 	// Aggregate the per node results
 	aggbsiResults := make(map[string]*roaring64.BSI)
 	for k, v := range bsiResults {
@@ -545,10 +561,10 @@ func (c *BitmapIndex) Projection(index string, fields []string, fromTime, toTime
 }
 
 // Send projection processing request to a specific node.
-func (c *BitmapIndex) projectionClient(client pb.BitmapIndexClient, req *pb.ProjectionRequest,
-	clientIndex int) (*pb.ProjectionResponse, error) {
+func (c *BitmapIndex) projectionClient(ctx context.Context, client pb.BitmapIndexClient,
+	req *pb.ProjectionRequest, clientIndex int) (*pb.ProjectionResponse, error) {
 
-	ctx, cancel := context.WithTimeout(context.Background(), Deadline)
+	ctx, cancel := context.WithTimeout(ctx, Deadline)
 	defer cancel()
 
 	result, err := client.Projection(ctx, req)
@@ -46,14 +46,18 @@ type QueryFragment struct {
 	Data      *roaring64.Bitmap
 	Sample    []*RowBitmap
 	Added     bool
+	Stats     *FragmentStats // Collected by EXPLAIN ANALYZE
+	id        string
 }
 
 // BitmapQuery - Top level query state container
 type BitmapQuery struct {
-	FromTime string `yaml:"fromTime"`
-	ToTime   string `yaml:"toTime"`
-	root     *QueryFragment
-	curLevel int
+	FromTime    string           `yaml:"fromTime"`
+	ToTime      string           `yaml:"toTime"`
+	Consistency ConsistencyLevel `yaml:"consistency,omitempty"`
+	Analyze     bool             `yaml:"analyze,omitempty"` // Collect fragment statistics (EXPLAIN ANALYZE)
+	root        *QueryFragment
+	curLevel    int
 }
 
 // NewBitmapQuery - Construct a new query.
@@ -63,11 +67,12 @@ func NewBitmapQuery() *BitmapQuery {
 
 // BitmapQueryResponse - Bitmap query API response container
 type BitmapQueryResponse struct {
-	Success      bool
-	ErrorMessage string
-	Value        int64
-	Count        uint64
-	Results      *roaring64.Bitmap
+	Success        bool
+	ErrorMessage   string
+	Value          int64
+	Count          uint64
+	Results        *roaring64.Bitmap
+	ColdPartitions int // Number of archived partitions read from disk by the nodes
 }
 
 // IntermediateResult - Container for query results returned from individual server nodes.
@@ -77,6 +82,7 @@ type IntermediateResult struct {
 	Index          string
 	SamplePct      float32
 	SampleIsUnion  bool
+	ColdPartitions int
 	unions         []*roaring64.Bitmap
 	intersects     []*roaring64.Bitmap
 	andDifferences []*roaring64.Bitmap
@@ -84,8 +90,11 @@ type IntermediateResult struct {
 	existences     []*roaring64.Bitmap
 	samples        []*RowBitmap
 	joinFkList     []FK
+	fragmentStats  map[string]*FragmentStats
+	shards         map[string]*pb.ShardChecksum // Shards read, collected when replicas are compared
 	union          *roaring64.Bitmap
 	existence      *roaring64.Bitmap
+	groupResults   map[uint32]map[string]map[int64]*pb.GroupByResult
 }
 
 // FK - Foreign key container.
@@ -116,6 +125,9 @@ func NewIntermediateResult(index string) *IntermediateResult {
 	r.orDifferences = make([]*roaring64.Bitmap, 0)
 	r.samples = make([]*RowBitmap, 0)
 	r.joinFkList = make([]FK, 0)
+	r.fragmentStats = make(map[string]*FragmentStats)
+	r.shards = make(map[string]*pb.ShardChecksum)
+	r.groupResults = make(map[uint32]map[string]map[int64]*pb.GroupByResult)
 	return r
 }
 
@@ -191,6 +203,31 @@ func (r *IntermediateResult) GetSamples() []*RowBitmap {
 	return r.samples
 }
 
+// AddShard - Add the checksum of a shard that was read.
+func (r *IntermediateResult) AddShard(s *pb.ShardChecksum) {
+	r.shards[ShardKey(s)] = s
+}
+
+// GetShards - Get the checksums of the shards that were read.
+func (r *IntermediateResult) GetShards() []*pb.ShardChecksum {
+
+	shards := make([]*pb.ShardChecksum, 0, len(r.shards))
+	for _, v := range r.shards {
+		shards = append(shards, v)
+	}
+	return shards
+}
+
+// ShardKey - Key of a shard as used for rendezvous hashing.  BSI shards have a RowIdOrBits value of -1.
+func ShardKey(s *pb.ShardChecksum) string {
+
+	ts := time.Unix(0, s.Time).Format(timeFmt)
+	if s.RowIdOrBits < 0 {
+		return fmt.Sprintf("%s/%s/%s", s.Index, s.Field, ts)
+	}
+	return fmt.Sprintf("%s/%s/%d/%s", s.Index, s.Field, s.RowIdOrBits, ts)
+}
+
 // AddExistence - Add an existence bitmap to the query predicates.
 func (r *IntermediateResult) AddExistence(b *roaring64.Bitmap) {
 	if b == nil {
@@ -224,6 +261,42 @@ func (r *IntermediateResult) GetFKList() []FK {
 	return r.joinFkList
 }
 
+// AddGroupResult - Add a partial GROUP BY aggregate returned from a node.  Partials are keyed by
+// partition so that identical results returned by replicas are only counted once.
+func (r *IntermediateResult) AddGroupResult(gr *pb.GroupByResult) {
+	if gr == nil {
+		panic("Attempt to add nil GroupResult.")
+	}
+	if _, ok := r.groupResults[gr.Group]; !ok {
+		r.groupResults[gr.Group] = make(map[string]map[int64]*pb.GroupByResult)
+	}
+	if _, ok := r.groupResults[gr.Group][gr.Field]; !ok {
+		r.groupResults[gr.Group][gr.Field] = make(map[int64]*pb.GroupByResult)
+	}
+	r.groupResults[gr.Group][gr.Field][gr.Partition] = gr
+}
+
+// GetGroupAggregates - Reduce all partial aggregates for a group, keyed by field name.
+func (r *IntermediateResult) GetGroupAggregates(group uint32) map[string]*GroupAggregate {
+
+	results := make(map[string]*GroupAggregate)
+	for field, partitions := range r.groupResults[group] {
+		agg := &GroupAggregate{}
+		for _, v := range partitions {
+			if agg.Count == 0 || v.Min < agg.Min {
+				agg.Min = v.Min
+			}
+			if agg.Count == 0 || v.Max > agg.Max {
+				agg.Max = v.Max
+			}
+			agg.Sum += v.Sum
+			agg.Count += v.Count
+		}
+		results[field] = agg
+	}
+	return results
+}
+
 // Collapse and finalize all distributive values.
 func (r *IntermediateResult) Collapse() {
 
@@ -292,7 +365,9 @@ func (r *IntermediateResult) MarshalQueryResult() (qr *pb.QueryResult, err error
 
 	return &pb.QueryResult{Unions: unionBuf, Intersects: intersectBuf, Differences: differenceBuf,
 		Samples: sampleRows, SamplePct: r.SamplePct, SampleIsUnion: r.SampleIsUnion,
-		Existences: existenceBuf, AndDifferencesCount: int32(len(r.andDifferences))}, nil
+		Existences: existenceBuf, AndDifferencesCount: int32(len(r.andDifferences)),
+		ColdPartitions: int32(r.ColdPartitions), FragmentStats: r.marshalFragmentStats(),
+		Shards: r.GetShards()}, nil
 }
 
 // UnmarshalAndAdd protobuf into Quanta query response (client side).
@@ -324,6 +399,14 @@ func (r *IntermediateResult) UnmarshalAndAdd(rs *pb.QueryResult) error {
 	}
 	r.SamplePct = rs.GetSamplePct()
 	r.SampleIsUnion = rs.SampleIsUnion
+	r.ColdPartitions += int(rs.GetColdPartitions())
+	for _, v := range rs.GetFragmentStats() {
+		r.AddFragmentStats(v.Id, &FragmentStats{Node: v.Node, Cardinality: v.Cardinality,
+			Elapsed: time.Duration(v.Elapsed)})
+	}
+	for _, v := range rs.GetShards() {
+		r.AddShard(v)
+	}
 
 	for i := 0; i < int(rs.AndDifferencesCount); i++ {
 		bm = roaring64.NewBitmap()
@@ -528,7 +611,7 @@ func (q *BitmapQuery) ToProto() *pb.BitmapQuery {
 	frags := make([]*pb.QueryFragment, 0)
 	q.toProtoFrag(q.root, &frags)
 
-	bq := &pb.BitmapQuery{Query: frags}
+	bq := &pb.BitmapQuery{Query: frags, Analyze: q.Analyze}
 	if fromTime, err := time.Parse(YMDHTimeFmt, q.FromTime); err == nil {
 		bq.FromTime = fromTime.UnixNano()
 	}
@@ -586,7 +669,11 @@ func (q *BitmapQuery) toProtoFrag(n *QueryFragment, fa *[]*pb.QueryFragment) str
 		}
 	}
 
-	id := uuid.New()
+	// The id is kept so that fragment statistics returned by the nodes can be matched to the fragment.
+	if n.id == "" {
+		n.id = uuid.New()
+	}
+	id := n.id
 
 	f := &pb.QueryFragment{Index: n.Index, Field: n.Field, RowID: n.RowID, Id: id, ChildrenIds: childIds,
 		Operation: op, BsiOp: bsiOp, Value: n.Value, Begin: n.Begin, End: n.End, Fk: n.Fk, Values: n.Values,
@@ -706,7 +793,7 @@ func FromProto(q *pb.BitmapQuery, dataMap map[string]*roaring64.Bitmap) *BitmapQ
 		}
 		f := &QueryFragment{Index: v.Index, Field: v.Field, RowID: v.RowID, Value: v.Value, End: v.End,
 			Begin: v.Begin, Fk: v.Fk, Values: v.Values, Operation: op, SamplePct: v.SamplePct, Negate: v.Negate,
-			ORContext: v.OrContext, NullCheck: v.NullCheck}
+			ORContext: v.OrContext, NullCheck: v.NullCheck, id: v.Id}
 		if dataMap != nil {
 			if bm, ok := dataMap[v.Id]; ok {
 				f.Data = bm
@@ -742,7 +829,7 @@ func FromProto(q *pb.BitmapQuery, dataMap map[string]*roaring64.Bitmap) *BitmapQ
 	from := time.Unix(0, q.FromTime).Format(YMDHTimeFmt)
 	to := time.Unix(0, q.ToTime).Format(YMDHTimeFmt)
 
-	return &BitmapQuery{root: root, FromTime: from, ToTime: to}
+	return &BitmapQuery{root: root, FromTime: from, ToTime: to, Analyze: q.Analyze}
 
 }
 
@@ -759,6 +846,7 @@ func (q *BitmapQuery) GroupQueryFragmentsByIndex() map[string]*pb.BitmapQuery {
 			rq = &pb.BitmapQuery{Query: []*pb.QueryFragment{f}}
 			rq.FromTime = query.FromTime
 			rq.ToTime = query.ToTime
+			rq.Analyze = query.Analyze
 			results[f.Index] = rq
 		} else {
 			rq.Query = append(rq.Query, f)
@@ -15,14 +15,18 @@ import (
 	"golang.org/x/sync/errgroup"
 )
 
-// Main processing flow for bitmap queries.  Returns a bitmap.  Processing is parallelized.
-func (c *BitmapIndex) query(query *pb.BitmapQuery) (*roaring64.Bitmap, error) {
+// Main processing flow for bitmap queries.  Returns a bitmap, the number of archived partitions
+// that were read and the fragment statistics if requested (EXPLAIN ANALYZE).  Processing is
+// parallelized.  Consistency levels other than ONE compare the results of several replicas.
+// Canceling ctx aborts the requests sent to the nodes.
+func (c *BitmapIndex) query(ctx context.Context, query *pb.BitmapQuery,
+	level ConsistencyLevel) (*roaring64.Bitmap, int, map[string]*FragmentStats, error) {
 
 	//c.Conn.nodeMapLock.RLock()
 	//defer c.Conn.nodeMapLock.RUnlock()
 
 	if len(query.Query) == 0 {
-		return nil, fmt.Errorf("query must have at least 1 predicate")
+		return nil, 0, nil, fmt.Errorf("query must have at least 1 predicate")
 	}
 
 	// Query should have at least 1 union predicate.  If not, make the first intersect a union.
@@ -66,7 +70,13 @@ func (c *BitmapIndex) query(query *pb.BitmapQuery) (*roaring64.Bitmap, error) {
 		i := k
 		q := v
 		eg.Go(func() error {
-			ir, err := c.queryGroup(i, q)
+			var ir *IntermediateResult
+			var err error
+			if level == ConsistencyOne {
+				ir, err = c.queryGroup(ctx, i, q)
+			} else {
+				ir, err = c.queryReplicas(ctx, i, q, level)
+			}
 			if err != nil {
 				return err
 			}
@@ -76,12 +86,18 @@ func (c *BitmapIndex) query(query *pb.BitmapQuery) (*roaring64.Bitmap, error) {
 	}
 
 	if err := eg.Wait(); err != nil {
-		return nil, err
+		return nil, 0, nil, err
 	}
 	close(resultChan)
 
 	// Merge all group results
+	coldPartitions := 0
+	fragmentStats := make(map[string]*FragmentStats)
 	for rs := range resultChan {
+		coldPartitions += rs.ColdPartitions
+		for id, v := range rs.GetFragmentStats() {
+			addFragmentStats(fragmentStats, id, v)
+		}
 		ir, ok := resultsMap[rs.Index]
 		if !ok {
 			ir = rs
@@ -127,7 +143,7 @@ func (c *BitmapIndex) query(query *pb.BitmapQuery) (*roaring64.Bitmap, error) {
 		}
 		if v.FKCount() == 0 {
 			if driver != "" {
-				return nil, fmt.Errorf("Must specify at least 1 join for index %s", v.Index)
+				return nil, 0, nil, fmt.Errorf("Must specify at least 1 join for index %s", v.Index)
 			}
 			driver = v.Index
 		}
@@ -144,7 +160,7 @@ func (c *BitmapIndex) query(query *pb.BitmapQuery) (*roaring64.Bitmap, error) {
 			// Get the results of the FK table
 			fki, ok := resultsMap[fk.JoinIndex]
 			if !ok {
-				return nil, fmt.Errorf("Can't find FK %s", fk.JoinIndex)
+				return nil, 0, nil, fmt.Errorf("Can't find FK %s", fk.JoinIndex)
 			}
 			// Perform the join transpose using local results
 			r := v.GetFinalUnion()
@@ -152,9 +168,9 @@ func (c *BitmapIndex) query(query *pb.BitmapQuery) (*roaring64.Bitmap, error) {
 				r = roaring64.FastAnd(x, r)
 			}
 			start := time.Now()
-			rs, err := c.Join(v.Index, []string{fk.Field}, query.FromTime, query.ToTime, r, nil, false)
+			rs, err := c.JoinContext(ctx, v.Index, []string{fk.Field}, query.FromTime, query.ToTime, r, nil, false)
 			if err != nil {
-				return nil, err
+				return nil, 0, nil, err
 			}
 			fki.AddIntersect(rs.GetExistenceBitmap())
 			elapsed := time.Since(start)
@@ -194,12 +210,13 @@ func (c *BitmapIndex) query(query *pb.BitmapQuery) (*roaring64.Bitmap, error) {
 		intersects = append(intersects, result)
 		result = roaring64.FastAnd(intersects...)
 	}
-	return result, nil
+	return result, coldPartitions, fragmentStats, nil
 }
 
 // Perform query processing for a group of query predicates (fragments) for a given index.
 // Processing is parallelized across nodes.
-func (c *BitmapIndex) queryGroup(index string, query *pb.BitmapQuery) (*IntermediateResult, error) {
+func (c *BitmapIndex) queryGroup(ctx context.Context, index string,
+	query *pb.BitmapQuery) (*IntermediateResult, error) {
 
 	resultChan := make(chan *pb.QueryResult, 100)
 	var eg errgroup.Group
@@ -219,7 +236,7 @@ func (c *BitmapIndex) queryGroup(index string, query *pb.BitmapQuery) (*Intermed
 		client := n
 		clientIndex := i
 		eg.Go(func() error {
-			qr, err := c.queryClient(client, query, clientIndex)
+			qr, err := c.queryClient(ctx, client, query, clientIndex)
 			if err != nil {
 				return err
 			}
@@ -242,6 +259,13 @@ func (c *BitmapIndex) queryGroup(index string, query *pb.BitmapQuery) (*Intermed
 		}
 
 		ra = append(ra, ir)
+		gr.ColdPartitions += ir.ColdPartitions
+		for id, v := range ir.GetFragmentStats() {
+			gr.AddFragmentStats(id, v)
+		}
+		for _, v := range ir.GetShards() {
+			gr.AddShard(v)
+		}
 		for _, v := range ir.GetSamples() {
 			key := fmt.Sprintf("%s/%d", v.Field, v.RowID)
 			if e, ok := sa[key]; !ok {
@@ -331,6 +355,7 @@ func (c *BitmapIndex) groupQueryFragmentsByIndex(query *pb.BitmapQuery) map[stri
 			rq = &pb.BitmapQuery{Query: []*pb.QueryFragment{f}}
 			rq.FromTime = query.FromTime
 			rq.ToTime = query.ToTime
+			rq.Analyze = query.Analyze
 			results[f.Index] = rq
 		} else {
 			rq.Query = append(rq.Query, f)
@@ -340,7 +365,7 @@ func (c *BitmapIndex) groupQueryFragmentsByIndex(query *pb.BitmapQuery) map[stri
 }
 
 // Execute a query against a single node.
-func (c *BitmapIndex) queryClient(client pb.BitmapIndexClient, q *pb.BitmapQuery,
+func (c *BitmapIndex) queryClient(ctx context.Context, client pb.BitmapIndexClient, q *pb.BitmapQuery,
 	clientIndex int) (*pb.QueryResult, error) {
 
 	/*
@@ -352,7 +377,7 @@ func (c *BitmapIndex) queryClient(client pb.BitmapIndexClient, q *pb.BitmapQuery
 	   u.Debugf("vvv query dump:\n%s\n\n", string(d))
 	*/
 
-	ctx, cancel := context.WithTimeout(context.Background(), Deadline)
+	ctx, cancel := context.WithTimeout(ctx, Deadline)
 	defer cancel()
 
 	result, err := client.Query(ctx, q)
@@ -366,14 +391,24 @@ func (c *BitmapIndex) queryClient(client pb.BitmapIndexClient, q *pb.BitmapQuery
 
 // Query - Entrypoint for count/result queries
 func (c *BitmapIndex) Query(query *BitmapQuery) (*BitmapQueryResponse, error) {
+	return c.QueryContext(context.Background(), query)
+}
+
+// QueryContext - Entrypoint for count/result queries that can be canceled.
+func (c *BitmapIndex) QueryContext(ctx context.Context, query *BitmapQuery) (*BitmapQueryResponse, error) {
 
 	response := &BitmapQueryResponse{}
+	var fragmentStats map[string]*FragmentStats
 	var err error
-	if response.Results, err = c.query(query.ToProto()); err != nil {
+	if response.Results, response.ColdPartitions, fragmentStats, err = c.query(ctx, query.ToProto(),
+		query.Consistency); err != nil {
 		response.ErrorMessage = fmt.Sprintf("%v", err)
 	} else {
 		response.Count = response.Results.GetCardinality()
 		response.Success = true
+		if query.Analyze {
+			query.setFragmentStats(fragmentStats)
+		}
 	}
 
 	return response, err
@@ -382,7 +417,7 @@ func (c *BitmapIndex) Query(query *BitmapQuery) (*BitmapQueryResponse, error) {
 // ResultsQuery - Entrypoint for queries where result is returned as a list of column IDs
 func (c *BitmapIndex) ResultsQuery(query *pb.BitmapQuery, limit uint64) ([]uint64, error) {
 
-	result, err := c.query(query)
+	result, _, _, err := c.query(context.Background(), query, ConsistencyOne)
 	if err != nil {
 		return []uint64{}, err
 	}
@@ -401,6 +436,12 @@ func (c *BitmapIndex) ResultsQuery(query *pb.BitmapQuery, limit uint64) ([]uint6
 // Resulting bitmap is then intersected with final query results.
 func (c *BitmapIndex) Join(driverIndex string, fklist []string, fromTime, toTime int64,
 	foundSet *roaring64.Bitmap, filterSets []*roaring64.Bitmap, negate bool) (*roaring64.BSI, error) {
+	return c.JoinContext(context.Background(), driverIndex, fklist, fromTime, toTime, foundSet, filterSets, negate)
+}
+
+// JoinContext - Join processing that can be canceled.
+func (c *BitmapIndex) JoinContext(ctx context.Context, driverIndex string, fklist []string, fromTime, toTime int64,
+	foundSet *roaring64.Bitmap, filterSets []*roaring64.Bitmap, negate bool) (*roaring64.BSI, error) {
 
 	foundData, err := foundSet.MarshalBinary()
 	if err != nil {
@@ -427,7 +468,7 @@ func (c *BitmapIndex) Join(driverIndex string, fklist []string, fromTime, toTime
 		client := n
 		clientIndex := i
 		eg.Go(func() error {
-			jr, err := c.joinClient(client, req, clientIndex)
+			jr, err := c.joinClient(ctx, client, req, clientIndex)
 			if err != nil {
 				return err
 			}
@@ -442,7 +483,9 @@ func (c *BitmapIndex) Join(driverIndex string, fklist []string, fromTime, toTime
 	close(resultChan)
 
 	results := make([]*roaring64.BSI, 0)
+	coldPartitions := 0
 	for rs := range resultChan {
+		coldPartitions += int(rs.GetColdPartitions())
 		bsi := roaring64.NewDefaultBSI()
 		if rs.Results != nil {
 			if err := bsi.UnmarshalBinary(rs.Results); err != nil {
@@ -454,6 +497,10 @@ func (c *BitmapIndex) Join(driverIndex string, fklist []string, fromTime, toTime
 		}
 	}
 
+	if coldPartitions > 0 {
+		u.Infof("Join read %d archived partitions", coldPartitions)
+	}
+
 	ret := roaring64.NewDefaultBSI()
 	for _, v := range results {
 		ret.Add(v)
@@ -463,10 +510,10 @@ func (c *BitmapIndex) Join(driverIndex string, fklist []string, fromTime, toTime
 }
 
 // Send join processing request to a specific node.
-func (c *BitmapIndex) joinClient(client pb.BitmapIndexClient, req *pb.JoinRequest,
+func (c *BitmapIndex) joinClient(ctx context.Context, client pb.BitmapIndexClient, req *pb.JoinRequest,
 	clientIndex int) (*pb.JoinResponse, error) {
 
-	ctx, cancel := context.WithTimeout(context.Background(), Deadline)
+	ctx, cancel := context.WithTimeout(ctx, Deadline)
 	defer cancel()
 
 	result, err := client.Join(ctx, req)
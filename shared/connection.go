@@ -65,6 +65,8 @@ type Conn struct {
 	activeCount        int                          // Number of active nodes.
 	IsLocalCluster     bool                         // Is this a local cluster? For debugging.
 	owner              string                       // for debugging
+	tableReplicas      map[string]int               // Replication factor overrides by table.
+	tableReplicasLock  sync.RWMutex                 // Lock for tableReplicas.
 }
 
 // NewDefaultConnection - Configure a connection with default values.
@@ -258,7 +260,12 @@ func (m *Conn) Connect(consul *api.Client) (err error) {
 
 		m.GetAllPeerStatus()
 
+		lastIndex, err := m.updateTableReplicas(0)
+		if err != nil {
+			return fmt.Errorf("node: can't fetch table replicas: %s", err)
+		}
 		go m.poll()
+		go m.pollTableReplicas(lastIndex)
 	} else {
 		// ServicePort is 0
 		m.HashTable = rendezvous.New([]string{"test"})
@@ -346,6 +353,13 @@ func (m *Conn) CreateNodeConnections(largeBuffer bool) (nodeConns []*grpc.Client
 */
 func (m *Conn) SelectNodes(key interface{}, op OpType) ([]int, error) {
 
+	return m.SelectNodesForTable(tableFromKey(ToString(key)), key, op)
+}
+
+// SelectNodesForTable - Select nodes for a key using the replication factor of a table.  Used where keys do not
+// begin with the table name, i.e. KVStore keys.
+func (m *Conn) SelectNodesForTable(table string, key interface{}, op OpType) ([]int, error) {
+
 	m.nodeMapLock.RLock()
 	defer m.nodeMapLock.RUnlock()
 
@@ -360,12 +374,19 @@ func (m *Conn) SelectNodes(key interface{}, op OpType) ([]int, error) {
 	}
 
 	all := (op == AllActive || op == Admin || op == WriteIntentAll || op == ReadIntentAll)
-	replicas := m.Replicas
+	replicas := m.GetReplicas(table)
 	if all && len(m.nodeMap) > 0 {
 		replicas = len(m.nodeMap)
 	}
 
-	nodeKeys := m.HashTable.GetN(replicas, ToString(key))
+	var nodeKeys []string
+	if all {
+		nodeKeys = m.HashTable.GetN(replicas, ToString(key))
+	} else if op == ReadIntent {
+		nodeKeys = m.readN(replicas, ToString(key))
+	} else {
+		nodeKeys = m.writeN(replicas, ToString(key))
+	}
 	indices := make([]int, 0)
 
 	// u.Info("SelectNodes GetN", ToString(key), nodeKeys)
@@ -378,10 +399,10 @@ func (m *Conn) SelectNodes(key interface{}, op OpType) ([]int, error) {
 			return nil, fmt.Errorf("SelectNodes: assert fail for key [%v], node %v not found in nodeStatusMap",
 				ToString(key), v)
 		}
-		if status.NodeState != "Active" && status.NodeState != "Syncing" && !all {
+		if status.NodeState != "Active" && status.NodeState != "Syncing" && status.NodeState != "Draining" && !all {
 			continue
 		}
-		if status.NodeState != "Active" && (op == ReadIntent || op == ReadIntentAll) {
+		if status.NodeState != "Active" && status.NodeState != "Draining" && (op == ReadIntent || op == ReadIntentAll) {
 			continue // Don't read from a node in Syncing state.
 		}
 		if j, ok := m.nodeMap[v]; ok {
@@ -691,7 +712,7 @@ func (m *Conn) CheckNodeForKey(key, nodeID string) (bool, int) {
 
 	// u.Debug("CheckNodeForKey GetN", m.Replicas, key, nodeID)
 
-	nodeKeys := m.HashTable.GetN(m.Replicas, key)
+	nodeKeys := m.getN(m.GetReplicas(tableFromKey(key)), key)
 
 	for i, v := range nodeKeys {
 		if v != nodeID {
@@ -711,6 +732,123 @@ func (m *Conn) CheckNodeForKey(key, nodeID string) (bool, int) {
 	return false, 0
 }
 
+// getN - Rendezvous owners of a key.  Draining nodes are replaced by their successors so that traffic
+// reaches the new owners while a node is decommissioned.  Caller must hold nodeMapLock.
+func (m *Conn) getN(replicas int, key string) []string {
+
+	draining := 0
+	for _, status := range m.nodeStatusMap {
+		if status.NodeState == "Draining" {
+			draining++
+		}
+	}
+	if draining == 0 {
+		return m.HashTable.GetN(replicas, key)
+	}
+	nodeKeys := make([]string, 0, replicas)
+	for _, v := range m.HashTable.GetN(replicas+draining, key) {
+		if status, found := m.nodeStatusMap[v]; found && status.NodeState == "Draining" {
+			continue
+		}
+		nodeKeys = append(nodeKeys, v)
+		if len(nodeKeys) == replicas {
+			break
+		}
+	}
+	return nodeKeys
+}
+
+// readN - Owners of a key in query order.  Draining nodes are ordered last, so queries are answered by a
+// remaining owner.  If the draining node is the only owner (a single replica) it answers queries until it
+// leaves the cluster, by then all of its shards have been pushed to the successors.  Caller must hold nodeMapLock.
+func (m *Conn) readN(replicas int, key string) []string {
+
+	nodeKeys := m.HashTable.GetN(replicas, key)
+	draining := make([]string, 0)
+	readKeys := make([]string, 0, len(nodeKeys))
+	for _, v := range nodeKeys {
+		if status, found := m.nodeStatusMap[v]; found && status.NodeState == "Draining" {
+			draining = append(draining, v)
+			continue
+		}
+		readKeys = append(readKeys, v)
+	}
+	return append(readKeys, draining...)
+}
+
+// writeN - Nodes that receive writes for a key.  The owners returned by getN plus any draining owner, which
+// must stay current because it may still be answering queries for the key (see readN).  Caller must hold
+// nodeMapLock.
+func (m *Conn) writeN(replicas int, key string) []string {
+
+	nodeKeys := m.getN(replicas, key)
+	for _, v := range m.HashTable.GetN(replicas, key) {
+		if status, found := m.nodeStatusMap[v]; found && status.NodeState == "Draining" {
+			nodeKeys = append(nodeKeys, v)
+		}
+	}
+	return nodeKeys
+}
+
+// GetNodesForKey - Nodes that own a key, draining nodes are replaced by their successors.
+func (m *Conn) GetNodesForKey(key string) []string {
+
+	m.nodeMapLock.RLock()
+	defer m.nodeMapLock.RUnlock()
+	return m.getN(m.GetReplicas(tableFromKey(key)), key)
+}
+
+// GetPrimaryForKey - Node that answers queries for a key.  A draining node answers only if it is the sole owner.
+func (m *Conn) GetPrimaryForKey(key string) string {
+
+	m.nodeMapLock.RLock()
+	defer m.nodeMapLock.RUnlock()
+	if nodeKeys := m.readN(m.GetReplicas(tableFromKey(key)), key); len(nodeKeys) > 0 {
+		return nodeKeys[0]
+	}
+	return ""
+}
+
+// GetReplicaForKey - Node that answers reads of a key for the n-th replica (replica 0 is the primary).  If the
+// key has fewer active owners the last of them is selected.  Returns an empty string if there are none.
+func (m *Conn) GetReplicaForKey(key string, replica int) string {
+
+	if replica == 0 {
+		return m.GetPrimaryForKey(key)
+	}
+	owners := make([]string, 0)
+	for _, id := range m.GetNodesForKey(key) {
+		if status, err := m.GetNodeStatusForID(id); err == nil && status.NodeState == "Active" {
+			owners = append(owners, id)
+		}
+	}
+	if len(owners) == 0 {
+		return ""
+	}
+	if replica >= len(owners) {
+		replica = len(owners) - 1
+	}
+	return owners[replica]
+}
+
+// GetSuccessorsForKey - Nodes that own a key once the given node has left the cluster.
+func (m *Conn) GetSuccessorsForKey(key, nodeID string) []string {
+
+	m.nodeMapLock.RLock()
+	defer m.nodeMapLock.RUnlock()
+
+	ids := make([]string, 0, len(m.ids))
+	for _, id := range m.ids {
+		if id != nodeID {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return []string{}
+	}
+	return rendezvous.New(ids).GetN(m.GetReplicas(tableFromKey(key)), key)
+}
+
 // SendMemberLeft - Notify listening service of MemberLeft event.
 func (m *Conn) SendMemberLeft(nodeID string, index int) {
 
@@ -830,7 +968,7 @@ func (m *Conn) GetClusterState() (status ClusterState, activeCount, clusterSizeT
 		status = Green
 		return
 	}
-	if m.activeCount >= m.clusterSizeTarget-(m.Replicas-1) {
+	if m.activeCount >= m.clusterSizeTarget-(m.minReplicas()-1) {
 		status = Orange
 		return
 	}
@@ -856,7 +994,8 @@ func (m *Conn) GetAllPeerStatus() {
 		// u.Debug("GetAllPeerStatus getNodeStatusForIndex", m.owner, k, v, status.NodeState)
 		if err == nil {
 			nodeStatusMap[k] = status
-			if status.NodeState == "Active" {
+			// Draining nodes still hold data until they are decommissioned.
+			if status.NodeState == "Active" || status.NodeState == "Draining" {
 				activeCount++
 			}
 		} else {
@@ -0,0 +1,114 @@
+package shared
+
+import (
+	"fmt"
+	"testing"
+
+	pb "github.com/disney/quanta/grpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stvp/rendezvous"
+)
+
+func TestDrainingNodeRouting(t *testing.T) {
+
+	ids := []string{"node-1", "node-2", "node-3", "node-4"}
+	m := &Conn{Replicas: 2, HashTable: rendezvous.New(ids), ids: ids,
+		nodeStatusMap: make(map[string]*pb.StatusMessage)}
+	for _, id := range ids {
+		m.nodeStatusMap[id] = &pb.StatusMessage{NodeState: "Active"}
+	}
+	m.nodeStatusMap["node-2"].NodeState = "Draining"
+
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("cities/population/2023-01-%02dT00", i)
+		owners := m.getN(2, key)
+		assert.Len(t, owners, 2)
+		assert.NotContains(t, owners, "node-2")
+		// Once node-2 is removed its successors are the owners.
+		assert.ElementsMatch(t, rendezvous.New([]string{"node-1", "node-3", "node-4"}).GetN(2, key), owners)
+		assert.ElementsMatch(t, owners, m.GetSuccessorsForKey(key, "node-2"))
+		// Stranded shard cleanup keeps the shards pushed to the successors.
+		assert.Equal(t, owners, m.GetNodesForKey(key))
+		found, _ := m.CheckNodeForKey(key, "node-2")
+		assert.False(t, found)
+		// Queries are answered by the highest ranked owner that already holds the shard.
+		current := m.HashTable.GetN(2, key)
+		if current[0] == "node-2" {
+			assert.Equal(t, current[1], m.GetPrimaryForKey(key))
+		} else {
+			assert.Equal(t, current[0], m.GetPrimaryForKey(key))
+		}
+	}
+}
+
+func TestDrainingSingleReplica(t *testing.T) {
+
+	ids := []string{"node-1", "node-2", "node-3"}
+	m := &Conn{Replicas: 1, ServicePort: 4000, HashTable: rendezvous.New(ids), ids: ids,
+		nodeMap: make(map[string]int), nodeStatusMap: make(map[string]*pb.StatusMessage)}
+	for i, id := range ids {
+		m.nodeMap[id] = i
+		m.nodeStatusMap[id] = &pb.StatusMessage{NodeState: "Active"}
+	}
+	m.nodeStatusMap["node-2"].NodeState = "Draining"
+
+	tested := 0
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("cities/population/2023-01-%02dT00", i)
+		if m.HashTable.Get(key) != "node-2" {
+			continue
+		}
+		tested++
+		successor := m.GetSuccessorsForKey(key, "node-2")[0]
+		// The successor may not have received the shard yet, node-2 answers until it leaves.
+		assert.Equal(t, "node-2", m.GetPrimaryForKey(key))
+		indices, err := m.SelectNodes(key, ReadIntent)
+		assert.NoError(t, err)
+		assert.Equal(t, []int{1}, indices)
+		// Writes reach both the successor and node-2.
+		indices, err = m.SelectNodes(key, WriteIntent)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []int{m.nodeMap[successor], 1}, indices)
+		// The successor keeps the pushed shard.
+		assert.Equal(t, []string{successor}, m.GetNodesForKey(key))
+	}
+	assert.Greater(t, tested, 0)
+}
+
+func TestTableReplicas(t *testing.T) {
+
+	ids := []string{"node-1", "node-2", "node-3", "node-4"}
+	m := &Conn{Replicas: 2, ServicePort: 4000, HashTable: rendezvous.New(ids), ids: ids,
+		nodeMap: make(map[string]int), nodeStatusMap: make(map[string]*pb.StatusMessage),
+		tableReplicas: map[string]int{"lookup": 3}}
+	for i, id := range ids {
+		m.nodeMap[id] = i
+		m.nodeStatusMap[id] = &pb.StatusMessage{NodeState: "Active"}
+	}
+
+	assert.Equal(t, 3, m.GetReplicas("lookup"))
+	assert.Equal(t, 2, m.GetReplicas("events"))
+	assert.Equal(t, 3, m.MaxReplicas())
+	assert.Equal(t, 2, m.minReplicas())
+
+	indices, err := m.SelectNodes("lookup/code/2023-01-01T00", WriteIntent)
+	assert.NoError(t, err)
+	assert.Len(t, indices, 3)
+	indices, err = m.SelectNodes("events/code/2023-01-01T00", WriteIntent)
+	assert.NoError(t, err)
+	assert.Len(t, indices, 2)
+	indices, err = m.SelectNodesForTable("lookup", "some key", WriteIntent)
+	assert.NoError(t, err)
+	assert.Len(t, indices, 3)
+	indices, err = m.SelectNodes("lookup/code/2023-01-01T00", ReadIntent)
+	assert.NoError(t, err)
+	assert.Len(t, indices, 1)
+
+	third := m.HashTable.GetN(3, "lookup/code/2023-01-01T00")[2]
+	found, replica := m.CheckNodeForKey("lookup/code/2023-01-01T00", third)
+	assert.True(t, found)
+	assert.Equal(t, 3, replica)
+	third = m.HashTable.GetN(3, "events/code/2023-01-01T00")[2]
+	found, _ = m.CheckNodeForKey("events/code/2023-01-01T00", third)
+	assert.False(t, found)
+}
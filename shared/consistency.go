@@ -0,0 +1,236 @@
+package shared
+
+//
+// Read consistency levels for bitmap queries.
+//
+// By default every shard is read from its primary replica only.  With QUORUM or ALL the query is run once per
+// replica, each node answering for the shards where it is the n-th active owner.  The nodes return the
+// checksums of the shards that were read.  Shards that differ between replicas are repaired by the node holding
+// the most recently modified version, and the result of the replica that holds the most recent versions is
+// returned.
+//
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	u "github.com/araddon/gou"
+	pb "github.com/disney/quanta/grpc"
+	"golang.org/x/sync/errgroup"
+)
+
+// ReadConsistencyKey - Session variable that holds the read consistency level (SET read_consistency = 'QUORUM').
+const ReadConsistencyKey = "read_consistency"
+
+// readRepairInterval - Minimum time between repairs of the same shard scheduled by queries.
+const readRepairInterval = 10 * time.Minute
+
+// ConsistencyLevel - Number of replicas that are read and compared by a query.
+type ConsistencyLevel int
+
+const (
+	// ConsistencyOne - Read from the primary replica only.
+	ConsistencyOne = ConsistencyLevel(iota)
+	// ConsistencyQuorum - Read from a majority of replicas.
+	ConsistencyQuorum
+	// ConsistencyAll - Read from all replicas.
+	ConsistencyAll
+)
+
+// String - Return string representation of ConsistencyLevel
+func (l ConsistencyLevel) String() string {
+
+	switch l {
+	case ConsistencyOne:
+		return "ONE"
+	case ConsistencyQuorum:
+		return "QUORUM"
+	case ConsistencyAll:
+		return "ALL"
+	default:
+		return "Unknown"
+	}
+}
+
+// ParseConsistencyLevel - Parse a consistency level name (ONE, QUORUM or ALL), an empty name is ONE.
+func ParseConsistencyLevel(name string) (ConsistencyLevel, error) {
+
+	switch strings.ToUpper(strings.TrimSpace(name)) {
+	case "", "ONE":
+		return ConsistencyOne, nil
+	case "QUORUM":
+		return ConsistencyQuorum, nil
+	case "ALL":
+		return ConsistencyAll, nil
+	default:
+		return ConsistencyOne, fmt.Errorf("unknown consistency level '%s', must be ONE, QUORUM or ALL", name)
+	}
+}
+
+// Replicas - Number of replicas that are read for a replication factor.
+func (l ConsistencyLevel) Replicas(replicas int) int {
+
+	switch l {
+	case ConsistencyQuorum:
+		return replicas/2 + 1
+	case ConsistencyAll:
+		return replicas
+	default:
+		return 1
+	}
+}
+
+// queryReplicas - Run a query group against several replicas and compare the results.
+func (c *BitmapIndex) queryReplicas(ctx context.Context, index string, query *pb.BitmapQuery,
+	level ConsistencyLevel) (*IntermediateResult, error) {
+
+	if level == ConsistencyAll {
+		if state, _, _ := c.GetClusterState(); state != Green {
+			return nil, fmt.Errorf("consistency level %s requires all nodes to be active, cluster state is %s",
+				level, state)
+		}
+	}
+	results := make([]*IntermediateResult, level.Replicas(c.GetReplicas(index)))
+	var eg errgroup.Group
+	for i := range results {
+		r := i
+		q := &pb.BitmapQuery{Query: query.Query, FromTime: query.FromTime, ToTime: query.ToTime, Replica: int32(r),
+			Analyze: query.Analyze, ShardChecksums: true}
+		eg.Go(func() error {
+			ir, err := c.queryGroup(ctx, index, q)
+			if err != nil {
+				return err
+			}
+			results[r] = ir
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+
+	diverged := divergedShards(results)
+	if len(diverged) == 0 {
+		return results[0], nil
+	}
+	latest := latestReplicas(results, diverged)
+	u.Warnf("Replicas of %s returned %d different shards, scheduling repair", index, len(diverged))
+	c.scheduleReadRepair(index, results, diverged, latest)
+	return results[selectReplica(latest)], nil
+}
+
+// divergedShards - Sorted keys of the shards that are missing from or have a different checksum in any of the
+// replica results.
+func divergedShards(results []*IntermediateResult) []string {
+
+	keys := make(map[string]struct{})
+	for _, ir := range results {
+		for key, s := range ir.shards {
+			for _, other := range results {
+				if o, found := other.shards[key]; !found || o.Checksum != s.Checksum {
+					keys[key] = struct{}{}
+					break
+				}
+			}
+		}
+	}
+	diverged := make([]string, 0, len(keys))
+	for key := range keys {
+		diverged = append(diverged, key)
+	}
+	sort.Strings(diverged)
+	return diverged
+}
+
+// latestReplicas - Replica holding the most recently modified version of each diverged shard.  Ties go to the
+// lower replica number.
+func latestReplicas(results []*IntermediateResult, diverged []string) []int {
+
+	latest := make([]int, len(diverged))
+	for i, key := range diverged {
+		var modTime int64
+		for r, ir := range results {
+			if s, found := ir.shards[key]; found && s.ModTime > modTime {
+				latest[i], modTime = r, s.ModTime
+			}
+		}
+	}
+	return latest
+}
+
+// selectReplica - Replica that holds the most recent version of the most diverged shards.  Ties go to the lower
+// replica number.
+func selectReplica(latest []int) int {
+
+	counts := make(map[int]int)
+	selected := 0
+	for _, r := range latest {
+		counts[r]++
+		if counts[r] > counts[selected] || (counts[r] == counts[selected] && r < selected) {
+			selected = r
+		}
+	}
+	return selected
+}
+
+// scheduleReadRepair - Ask the nodes that hold the most recent version of the diverged shards to repair them,
+// unless a repair of the same shard was started recently.  A node that is already running a repair does not
+// start another one, the shards are then requested again by a later read.
+func (c *BitmapIndex) scheduleReadRepair(index string, results []*IntermediateResult, diverged []string,
+	latest []int) {
+
+	repairs := make(map[int][]*pb.ShardChecksum)
+	c.repairLock.Lock()
+	for key, t := range c.repairTimes {
+		if time.Since(t) >= readRepairInterval {
+			delete(c.repairTimes, key)
+		}
+	}
+	for i, key := range diverged {
+		if _, found := c.repairTimes[key]; found {
+			continue
+		}
+		shard := results[latest[i]].shards[key]
+		nodeID := c.GetReplicaForKey(key, latest[i])
+		if shard == nil || nodeID == "" {
+			continue
+		}
+		ci, err := c.GetClientIndexForNodeID(nodeID)
+		if err != nil {
+			continue
+		}
+		repairs[ci] = append(repairs[ci], shard)
+	}
+	c.repairLock.Unlock()
+
+	for i, shards := range repairs {
+		client := c.client[i]
+		req := &pb.RepairRequest{Start: true, Index: index, Shards: shards}
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), Deadline)
+			defer cancel()
+			res, err := client.Repair(ctx, req)
+			if err != nil {
+				u.Errorf("cannot start repair of %s - %v", index, err)
+				return
+			}
+			if res.Started {
+				c.markRepaired(req.Shards)
+			}
+		}()
+	}
+}
+
+// markRepaired - Record the time that a repair of the given shards was started.
+func (c *BitmapIndex) markRepaired(shards []*pb.ShardChecksum) {
+
+	c.repairLock.Lock()
+	defer c.repairLock.Unlock()
+	now := time.Now()
+	for _, s := range shards {
+		c.repairTimes[ShardKey(s)] = now
+	}
+}
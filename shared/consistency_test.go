@@ -0,0 +1,62 @@
+package shared
+
+import (
+	"testing"
+
+	"time"
+
+	pb "github.com/disney/quanta/grpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsistencyLevel(t *testing.T) {
+
+	level, err := ParseConsistencyLevel("")
+	require.NoError(t, err)
+	assert.Equal(t, ConsistencyOne, level)
+	level, err = ParseConsistencyLevel(" quorum")
+	require.NoError(t, err)
+	assert.Equal(t, ConsistencyQuorum, level)
+	level, err = ParseConsistencyLevel("ALL")
+	require.NoError(t, err)
+	assert.Equal(t, "ALL", level.String())
+	_, err = ParseConsistencyLevel("SOME")
+	assert.Error(t, err)
+
+	assert.Equal(t, 1, ConsistencyOne.Replicas(3))
+	assert.Equal(t, 2, ConsistencyQuorum.Replicas(3))
+	assert.Equal(t, 2, ConsistencyQuorum.Replicas(2))
+	assert.Equal(t, 3, ConsistencyAll.Replicas(3))
+
+	shard := func(rowID, checksum, modTime int64) *pb.ShardChecksum {
+		return &pb.ShardChecksum{Index: "orders", Field: "status", RowIdOrBits: rowID, Checksum: uint64(checksum),
+			ModTime: modTime}
+	}
+	a := NewIntermediateResult("orders")
+	a.AddShard(shard(1, 10, 100))
+	a.AddShard(shard(2, 20, 300))
+	a.AddShard(shard(-1, 30, 100))
+	b := NewIntermediateResult("orders")
+	b.AddShard(shard(1, 10, 100))
+	b.AddShard(shard(2, 21, 200))
+	b.AddShard(shard(-1, 31, 200))
+	b.AddShard(shard(3, 40, 100))
+	results := []*IntermediateResult{a, b}
+	assert.Empty(t, divergedShards(results[:1]))
+
+	diverged := divergedShards(results)
+	assert.Equal(t, []string{ShardKey(shard(-1, 0, 0)), ShardKey(shard(2, 0, 0)), ShardKey(shard(3, 0, 0))},
+		diverged)
+	latest := latestReplicas(results, diverged)
+	assert.Equal(t, []int{1, 0, 1}, latest)
+	assert.Equal(t, 1, selectReplica(latest))
+	assert.Equal(t, 0, selectReplica([]int{1, 0}))
+	assert.Equal(t, "orders/status/"+time.Unix(0, 0).Format(timeFmt), ShardKey(shard(-1, 0, 0)))
+
+	// Only repairs that were started are recorded.
+	c := &BitmapIndex{repairTimes: make(map[string]time.Time)}
+	c.markRepaired([]*pb.ShardChecksum{shard(2, 0, 0)})
+	assert.Len(t, c.repairTimes, 1)
+	assert.Contains(t, c.repairTimes, ShardKey(shard(2, 0, 0)))
+}
@@ -0,0 +1,139 @@
+package shared
+
+//
+// Client side COUNT(DISTINCT) processing.  Each node returns either the set of distinct values (or row
+// IDs) or a HyperLogLog sketch of them, these are merged here.
+//
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RoaringBitmap/roaring/roaring64"
+	pb "github.com/disney/quanta/grpc"
+	"golang.org/x/sync/errgroup"
+)
+
+// DistinctField - A field of a CountDistinct request.  If Precision is zero the count is exact, otherwise
+// BSI fields are estimated using a HyperLogLog sketch with 2^Precision registers.
+type DistinctField struct {
+	Field     string
+	Precision int
+}
+
+// CountDistinct - Count the distinct values of each field within the found set.  All of the fields are
+// counted in one request to each node, counts are returned in the order of the fields.
+func (c *BitmapIndex) CountDistinct(index string, fields []DistinctField, fromTime, toTime int64,
+	foundSet *roaring64.Bitmap) ([]uint64, error) {
+
+	counts := make([]uint64, len(fields))
+	req := &pb.CountDistinctRequest{Index: index, FromTime: fromTime, ToTime: toTime,
+		Fields: make([]*pb.CountDistinctField, len(fields))}
+	for i, f := range fields {
+		if f.Precision != 0 && (f.Precision < MinHLLPrecision || f.Precision > MaxHLLPrecision) {
+			return nil, fmt.Errorf("CountDistinct: precision must be between %d and %d", MinHLLPrecision,
+				MaxHLLPrecision)
+		}
+		req.Fields[i] = &pb.CountDistinctField{Field: f.Field, Precision: uint32(f.Precision)}
+	}
+	if len(fields) == 0 || foundSet == nil || foundSet.GetCardinality() == 0 {
+		return counts, nil
+	}
+
+	var err error
+	if req.FoundSet, err = foundSet.MarshalBinary(); err != nil {
+		return nil, err
+	}
+
+	resultChan := make(chan *pb.CountDistinctResponse, 100)
+	var eg errgroup.Group
+
+	// Send the same count distinct request to each readable node.
+	indices, err2 := c.SelectNodes(index, ReadIntentAll)
+	if err2 != nil {
+		return nil, fmt.Errorf("CountDistinct: %v", err2)
+	}
+	for _, n := range indices {
+		client := c.client[n]
+		clientIndex := n
+		eg.Go(func() error {
+			cr, err := c.countDistinctClient(client, req, clientIndex)
+			if err != nil {
+				return err
+			}
+			if len(cr.GetResults()) != len(fields) {
+				return fmt.Errorf("CountDistinct: expected %d results, node = %s returned %d", len(fields),
+					c.ClientConnections()[clientIndex].Target(), len(cr.GetResults()))
+			}
+			resultChan <- cr
+			return nil
+		})
+	}
+
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	close(resultChan)
+
+	results := make([][]*pb.CountDistinctResult, len(fields))
+	for rs := range resultChan {
+		for i, r := range rs.GetResults() {
+			results[i] = append(results[i], r)
+		}
+	}
+	for i := range fields {
+		if counts[i], err = mergeCountDistinct(results[i]); err != nil {
+			return nil, err
+		}
+	}
+	return counts, nil
+}
+
+// mergeCountDistinct - Union the value sets and merge the sketches of a field.
+func mergeCountDistinct(results []*pb.CountDistinctResult) (uint64, error) {
+
+	values := roaring64.NewBitmap()
+	var sketch *HyperLogLog
+	for _, rs := range results {
+		if len(rs.GetSketch()) > 0 {
+			s := &HyperLogLog{}
+			if err := s.UnmarshalBinary(rs.GetSketch()); err != nil {
+				return 0, err
+			}
+			if sketch == nil {
+				sketch = s
+			} else if err := sketch.Merge(s); err != nil {
+				return 0, err
+			}
+		}
+		if len(rs.GetValues()) > 0 {
+			bm := roaring64.NewBitmap()
+			if err := bm.UnmarshalBinary(rs.GetValues()); err != nil {
+				return 0, err
+			}
+			values.Or(bm)
+		}
+	}
+	if sketch == nil {
+		return values.GetCardinality(), nil
+	}
+	iter := values.Iterator()
+	for iter.HasNext() {
+		sketch.Add(iter.Next())
+	}
+	return sketch.Estimate(), nil
+}
+
+func (c *BitmapIndex) countDistinctClient(client pb.BitmapIndexClient, req *pb.CountDistinctRequest,
+	clientIndex int) (*pb.CountDistinctResponse, error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), Deadline)
+	defer cancel()
+
+	result, err := client.CountDistinct(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("%v.CountDistinct(_) = _, %v, node = %s", client, err,
+			c.ClientConnections()[clientIndex].Target())
+	}
+	return result, nil
+}
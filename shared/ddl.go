@@ -0,0 +1,397 @@
+package shared
+
+// Table DDL (CREATE, DROP and TRUNCATE) shared by the admin tool and the proxy.
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/consul/api"
+	"gopkg.in/yaml.v2"
+)
+
+// ConsumerFlushWait - Time given to consumers to flush and restart after a table was dropped or truncated.
+const ConsumerFlushWait = 5 * time.Second
+
+// tableOptions - Lower case option names to schema.yaml table setting names.
+var tableOptions = func() map[string]string {
+
+	opts := make(map[string]string)
+	typ := reflect.TypeOf(BasicTable{})
+	for i := 0; i < typ.NumField(); i++ {
+		name := strings.Split(typ.Field(i).Tag.Get("yaml"), ",")[0]
+		switch name {
+		case "", "-", "tableName", "attributes", "isViewOf":
+			continue
+		}
+		opts[strings.ToLower(name)] = name
+	}
+	return opts
+}()
+
+// ParseCreateTable - Parse a CREATE TABLE statement into a table.  Column definitions are the same as
+// for ALTER TABLE ADD COLUMN.  "PRIMARY KEY" may follow a column definition or be specified as a
+// separate PRIMARY KEY (<columns>) clause.  Table settings follow the column list as key=value pairs
+// using the names in schema.yaml.
+//
+//	CREATE TABLE orders (
+//	    order_date DATETIME,
+//	    order_id VARCHAR(20) NOT NULL,
+//	    customer_id INT foreignKey=customers,
+//	    status VARCHAR(10) mappingStrategy=StringEnum,
+//	    PRIMARY KEY (order_date, order_id)
+//	) timeQuantumType=YMD retention=90d
+func ParseCreateTable(sql string) (table *BasicTable, ifNotExists bool, err error) {
+
+	open := indexUnquoted(sql, '(', 0)
+	if open < 0 {
+		return nil, false, fmt.Errorf("expecting CREATE TABLE <table> (<columns>) [%s]", sql)
+	}
+	header, err := splitDDL(sql[:open])
+	if err != nil {
+		return nil, false, err
+	}
+	if len(header) > 3 && strings.EqualFold(strings.Join(header[2:len(header)-1], " "), "IF NOT EXISTS") {
+		ifNotExists = true
+		header = append(header[:2], header[len(header)-1])
+	}
+	if len(header) != 3 || !strings.EqualFold(header[0], "CREATE") || !strings.EqualFold(header[1], "TABLE") {
+		return nil, false, fmt.Errorf("expecting CREATE TABLE [IF NOT EXISTS] <table> (<columns>) [%s]", sql)
+	}
+	closing := indexUnquoted(sql, ')', open)
+	if closing < 0 {
+		return nil, false, fmt.Errorf("unbalanced parentheses [%s]", sql)
+	}
+	table = &BasicTable{Name: unquoteDDL(header[2]), Attributes: make([]BasicAttribute, 0)}
+	if i := strings.LastIndex(header[2], "."); i >= 0 {
+		table.Name = unquoteDDL(header[2][i+1:])
+	}
+
+	columns, err := splitDDL(sql[open+1 : closing])
+	if err != nil {
+		return nil, false, err
+	}
+	pk := make([]string, 0)
+	for len(columns) > 0 {
+		end := len(columns)
+		for i, w := range columns {
+			if w == "," {
+				end = i
+				break
+			}
+		}
+		def := columns[:end]
+		if end < len(columns) {
+			columns = columns[end+1:]
+		} else {
+			columns = nil
+		}
+		if len(def) > 2 && strings.EqualFold(def[0], "PRIMARY") && strings.EqualFold(def[1], "KEY") {
+			list := strings.TrimSuffix(strings.TrimPrefix(def[2], "("), ")")
+			for _, k := range strings.Split(list, ",") {
+				pk = append(pk, unquoteDDL(k))
+			}
+			continue
+		}
+		words := make([]string, 0, len(def))
+		required := false
+		for i := 0; i < len(def); i++ {
+			switch {
+			case i+1 < len(def) && strings.EqualFold(def[i], "PRIMARY") && strings.EqualFold(def[i+1], "KEY"):
+				pk = append(pk, unquoteDDL(def[0]))
+				i++
+			case i+1 < len(def) && strings.EqualFold(def[i], "NOT") && strings.EqualFold(def[i+1], "NULL"):
+				required = true
+				i++
+			case strings.EqualFold(def[i], "NULL"):
+			default:
+				words = append(words, def[i])
+			}
+		}
+		action, err := parseColumn(AddColumn, words)
+		if err != nil {
+			return nil, false, err
+		}
+		if action.Type == "" {
+			return nil, false, fmt.Errorf("data type expected for column %s", action.Column)
+		}
+		attr, err := action.newAttribute()
+		if err != nil {
+			return nil, false, err
+		}
+		attr.Required = attr.Required || required
+		table.Attributes = append(table.Attributes, attr)
+	}
+	if len(pk) > 0 {
+		table.PrimaryKey = strings.Join(pk, "+")
+	}
+
+	options, err := splitDDL(sql[closing+1:])
+	if err != nil {
+		return nil, false, err
+	}
+	var doc strings.Builder
+	for _, w := range options {
+		kv := strings.SplitN(w, "=", 2)
+		if len(kv) != 2 || kv[1] == "" {
+			return nil, false, fmt.Errorf("expecting option=value for table %s, got '%s'", table.Name, w)
+		}
+		name, ok := tableOptions[strings.ToLower(kv[0])]
+		if !ok {
+			return nil, false, fmt.Errorf("unknown option '%s' for table %s", kv[0], table.Name)
+		}
+		doc.WriteString(name + ": " + strconv.Quote(unquoteDDL(kv[1])) + "\n")
+	}
+	if err := yaml.UnmarshalStrict([]byte(doc.String()), table); err != nil {
+		return nil, false, fmt.Errorf("invalid option for table %s - %v", table.Name, err)
+	}
+	if err := table.initialize(); err != nil {
+		return nil, false, err
+	}
+	return table, ifNotExists, nil
+}
+
+// indexUnquoted - Index of the first occurrence of a rune outside of quotes, starting at offset.  For
+// a closing parenthesis the offset is the position of the opening parenthesis and nesting is honored.
+func indexUnquoted(s string, r rune, offset int) int {
+
+	var quote rune
+	depth := 0
+	for i, c := range s[offset:] {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case r == ')' && c == '(':
+			depth++
+		case c == r:
+			if r != ')' {
+				return offset + i
+			}
+			if depth--; depth == 0 {
+				return offset + i
+			}
+		}
+	}
+	return -1
+}
+
+// ParseDropTable - Parse DROP TABLE [IF EXISTS] <table> and TRUNCATE [TABLE] <table> [FORCE] statements.
+// Returns the statement keyword (DROP or TRUNCATE) and the table name.  FORCE truncates a table that is
+// referenced by a foreign key.
+func ParseDropTable(sql string) (op, table string, ifExists, force bool, err error) {
+
+	words, err := splitDDL(sql)
+	if err != nil {
+		return "", "", false, false, err
+	}
+	if len(words) > 0 {
+		op = strings.ToUpper(words[0])
+		words = words[1:]
+	}
+	if len(words) > 0 && strings.EqualFold(words[0], "TABLE") {
+		words = words[1:]
+	} else if op == "DROP" {
+		return "", "", false, false, fmt.Errorf("expecting DROP TABLE [IF EXISTS] <table> [%s]", sql)
+	}
+	if op == "DROP" && len(words) > 2 && strings.EqualFold(words[0], "IF") && strings.EqualFold(words[1], "EXISTS") {
+		ifExists = true
+		words = words[2:]
+	}
+	if op == "TRUNCATE" && len(words) == 2 && strings.EqualFold(words[1], "FORCE") {
+		force = true
+		words = words[:1]
+	}
+	if (op != "DROP" && op != "TRUNCATE") || len(words) != 1 {
+		return "", "", false, false, fmt.Errorf(
+			"expecting DROP TABLE [IF EXISTS] <table> or TRUNCATE [TABLE] <table> [FORCE] [%s]", sql)
+	}
+	table = unquoteDDL(words[0])
+	if i := strings.LastIndex(words[0], "."); i >= 0 {
+		table = unquoteDDL(words[0][i+1:])
+	}
+	return op, table, ifExists, force, nil
+}
+
+// CheckAttributeTypes - Verify that all attributes have a valid data type.
+func (t *BasicTable) CheckAttributeTypes() error {
+
+	for _, v := range t.Attributes {
+		if TypeFromString(v.Type) == NotDefined && v.MappingStrategy != "ChildRelation" {
+			return fmt.Errorf("unknown type %s for field %s", v.Type, v.FieldName)
+		}
+	}
+	return nil
+}
+
+// CreateTable - Deploy a new table.
+func CreateTable(consul *api.Client, services *BitmapIndex, table *BasicTable) error {
+
+	if err := table.CheckAttributeTypes(); err != nil {
+		return err
+	}
+	if ok, _ := TableExists(consul, table.Name); ok {
+		return fmt.Errorf("table %s already exists", table.Name)
+	}
+	// Simulate create table where parent of FK does not exist
+	ok, err := CheckParentRelation(consul, table)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("cannot create table due to missing parent FK constraint dependency")
+	}
+	return DeployTable(consul, services, table)
+}
+
+// DeployTable - Replace a table schema in Consul, verify it and notify the nodes to reload it.
+func DeployTable(consul *api.Client, services *BitmapIndex, table *BasicTable) error {
+
+	lock, err := Lock(consul, "admin-tool", "admin-tool")
+	if err != nil {
+		return err
+	}
+	defer Unlock(consul, lock)
+	return deployTable(consul, services, table)
+}
+
+// UpdateTable - Load a table schema from Consul, change it and deploy the result.  The admin lock is held
+// from the load through the deploy so that concurrent schema changes are not lost.  Nothing is deployed if
+// change returns a nil table.
+func UpdateTable(consul *api.Client, services *BitmapIndex, tableName string,
+	change func(table *BasicTable) (*BasicTable, error)) error {
+
+	lock, err := Lock(consul, "admin-tool", "admin-tool")
+	if err != nil {
+		return err
+	}
+	defer Unlock(consul, lock)
+
+	table, err := LoadSchema("", tableName, consul)
+	if err != nil {
+		return fmt.Errorf("cannot load table %s - %v", tableName, err)
+	}
+	changed, err := change(table)
+	if err != nil || changed == nil {
+		return err
+	}
+	return deployTable(consul, services, changed)
+}
+
+// deployTable - Deploy a table schema.  Caller must hold the admin lock.
+func deployTable(consul *api.Client, services *BitmapIndex, table *BasicTable) error {
+
+	if table.Replicas > 0 {
+		size, err := GetClusterSizeTarget(consul)
+		if err != nil {
+			return err
+		}
+		if size > 0 && table.Replicas > size {
+			return fmt.Errorf("table %s has %d replicas but the cluster size target is %d", table.Name,
+				table.Replicas, size)
+		}
+	}
+	if err := DeleteTable(consul, table.Name); err != nil {
+		return fmt.Errorf("DeleteTable error %v", err)
+	}
+	if err := UpdateModTimeForTable(consul, table.Name); err != nil {
+		return fmt.Errorf("updateModTimeForTable  error %v", err)
+	}
+	if err := MarshalConsul(table, consul); err != nil {
+		return fmt.Errorf("Error marshalling table %v", err)
+	}
+
+	// Verify table persistence.  Read schema back from Consul and compare.
+	table2, err := LoadSchema("", table.Name, consul)
+	if err != nil {
+		return fmt.Errorf("Error loading schema from consul %v", err)
+	}
+	ok, warnings, err := table2.Compare(table)
+	if err != nil {
+		return fmt.Errorf("error comparing deployed table %v", err)
+	}
+	if !ok {
+		return fmt.Errorf("differences detected with deployed table %v - %v", table.Name, warnings)
+	}
+	return services.TableOperation(table.Name, "deploy")
+}
+
+// CheckForChildDependencies - Verify that a table exists and is not the parent of a foreign key.
+func CheckForChildDependencies(consul *api.Client, tableName, operation string) error {
+
+	ok, err := TableExists(consul, tableName)
+	if err != nil {
+		return fmt.Errorf("tableExists error %v", err)
+	}
+	if !ok {
+		return fmt.Errorf("table %s doesn't exist", tableName)
+	}
+	dependencies, err := CheckChildRelation(consul, tableName)
+	if err != nil {
+		return fmt.Errorf("checkChildRelation  error %v", err)
+	}
+	if len(dependencies) > 0 {
+		return fmt.Errorf("cannot %s table with dependencies %v", operation, dependencies)
+	}
+	return nil
+}
+
+// DropTable - Remove a table from Consul and purge its data from all nodes.  Consumers are given flushWait to
+// flush and restart before the data is purged.
+func DropTable(consul *api.Client, services *BitmapIndex, kvStore *KVStore, tableName string,
+	flushWait time.Duration) error {
+
+	if err := CheckForViewDependencies(consul, tableName); err != nil {
+		return err
+	}
+	lock, err := Lock(consul, "admin-tool", "admin-tool")
+	if err != nil {
+		return err
+	}
+	defer Unlock(consul, lock)
+
+	if err := DeleteTable(consul, tableName); err != nil {
+		return fmt.Errorf("DeleteTable error %v", err)
+	}
+	time.Sleep(flushWait)
+	return purgeTable(services, kvStore, tableName, "drop", false)
+}
+
+// TruncateTable - Purge the data of a table from all nodes.  StringEnum values are retained unless
+// dropEnums is true.  Consumers are given flushWait to flush and restart before the data is purged.
+func TruncateTable(consul *api.Client, services *BitmapIndex, kvStore *KVStore, tableName string,
+	dropEnums bool, flushWait time.Duration) error {
+
+	lock, err := Lock(consul, "admin-tool", "admin-tool")
+	if err != nil {
+		return err
+	}
+	defer Unlock(consul, lock)
+
+	if err := UpdateModTimeForTable(consul, tableName); err != nil {
+		return fmt.Errorf("updateModTimeForTable  error %v", err)
+	}
+	time.Sleep(flushWait)
+	return purgeTable(services, kvStore, tableName, "truncate", dropEnums)
+}
+
+func purgeTable(services *BitmapIndex, kvStore *KVStore, tableName, operation string, dropEnums bool) error {
+
+	if err := services.TableOperation(tableName, operation); err != nil {
+		return fmt.Errorf("TableOperation error %v", err)
+	}
+	retainEnums := true
+	if dropEnums || operation == "drop" {
+		retainEnums = false
+	}
+	if err := kvStore.DeleteIndicesWithPrefix(tableName, retainEnums); err != nil {
+		return fmt.Errorf("DeleteIndicesWithPrefix error %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCreateTable(t *testing.T) {
+
+	table, ifNotExists, err := ParseCreateTable("CREATE TABLE IF NOT EXISTS quanta.`orders` (\n" +
+		"    order_date DATETIME NOT NULL,\n" +
+		"    order_id VARCHAR(20) NOT NULL,\n" +
+		"    customer_id INT foreignKey=customers,\n" +
+		"    status VARCHAR(10) mappingStrategy=StringEnum desc='Open, closed',\n" +
+		"    total DECIMAL(12, 2) NULL,\n" +
+		"    PRIMARY KEY (order_date, order_id)\n" +
+		") timeQuantumType=YMD retention=90d retentionAction=delete")
+	require.NoError(t, err)
+	assert.True(t, ifNotExists)
+	assert.Equal(t, "orders", table.Name)
+	assert.Equal(t, "order_date+order_id", table.PrimaryKey)
+	assert.Equal(t, "YMD", table.TimeQuantumType)
+	assert.Equal(t, "delete", table.RetentionAction)
+	assert.Equal(t, "90d", table.Retention)
+	require.Len(t, table.Attributes, 5)
+
+	id, err := table.GetAttribute("order_id")
+	require.NoError(t, err)
+	assert.True(t, id.Required)
+	assert.Equal(t, 20, id.Size)
+	cust, err := table.GetAttribute("customer_id")
+	require.NoError(t, err)
+	assert.Equal(t, "customers", cust.ForeignKey)
+	status, err := table.GetAttribute("status")
+	require.NoError(t, err)
+	assert.Equal(t, "StringEnum", status.MappingStrategy)
+	assert.Equal(t, "Open, closed", status.Desc)
+	total, err := table.GetAttribute("total")
+	require.NoError(t, err)
+	assert.False(t, total.Required)
+	assert.Equal(t, 2, total.Scale)
+	assert.NoError(t, table.CheckAttributeTypes())
+
+	table, _, err = ParseCreateTable("create table t (id INT PRIMARY KEY, name VARCHAR(5))")
+	require.NoError(t, err)
+	assert.Equal(t, "id", table.PrimaryKey)
+
+	for _, sql := range []string{
+		"CREATE TABLE t",
+		"CREATE TABLE t (id INT",
+		"CREATE VIEW t (id INT)",
+		"CREATE TABLE t (id)",
+		"CREATE TABLE t (id INT) colour=red",
+		"CREATE TABLE t (id INT) tableName=x",
+		"CREATE TABLE t (id INT, PRIMARY KEY (nope))",
+		"CREATE TABLE t (id INT) timeQuantumType=YMD",
+	} {
+		_, _, err := ParseCreateTable(sql)
+		assert.Error(t, err, sql)
+	}
+}
+
+func TestParseDropTable(t *testing.T) {
+
+	op, table, ifExists, force, err := ParseDropTable("DROP TABLE IF EXISTS quanta.`orders`")
+	require.NoError(t, err)
+	assert.Equal(t, "DROP", op)
+	assert.Equal(t, "orders", table)
+	assert.True(t, ifExists)
+	assert.False(t, force)
+
+	op, table, ifExists, force, err = ParseDropTable("truncate orders;")
+	require.NoError(t, err)
+	assert.Equal(t, "TRUNCATE", op)
+	assert.Equal(t, "orders", table)
+	assert.False(t, ifExists)
+	assert.False(t, force)
+
+	op, table, _, force, err = ParseDropTable("TRUNCATE TABLE orders force")
+	require.NoError(t, err)
+	assert.Equal(t, "TRUNCATE", op)
+	assert.Equal(t, "orders", table)
+	assert.True(t, force)
+
+	for _, sql := range []string{"DROP orders", "DROP TABLE", "DROP TABLE a b", "TRUNCATE TABLE IF EXISTS a",
+		"DROP TABLE a FORCE"} {
+		_, _, _, _, err := ParseDropTable(sql)
+		assert.Error(t, err, sql)
+	}
+}
@@ -0,0 +1,177 @@
+package shared
+
+//
+// Query plans for EXPLAIN.
+//
+// The fragment DAG of a bitmap query is flattened into plan steps.  For EXPLAIN ANALYZE the nodes return the
+// cardinality and evaluation time of each fragment along with the query results.
+//
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	pb "github.com/disney/quanta/grpc"
+)
+
+// FragmentStats - Execution statistics of a query fragment collected by EXPLAIN ANALYZE.
+type FragmentStats struct {
+	Node        string           // Node that evaluated the fragment, empty for the merged statistics
+	Cardinality uint64           // Sum of the node cardinalities
+	Elapsed     time.Duration    // Slowest node
+	Nodes       []*FragmentStats // Statistics of each node
+}
+
+// PlanStep - A node of a query plan shown by EXPLAIN.  Steps are numbered from 1, Parent is 0 for top level steps.
+type PlanStep struct {
+	ID        int
+	Parent    int
+	Table     string
+	Operation string
+	Predicate string
+	Node      string         // Set for the per node steps of a fragment
+	Stats     *FragmentStats // EXPLAIN ANALYZE only
+}
+
+// AddFragmentStats - Merge the statistics of a query fragment returned by a node.
+func (r *IntermediateResult) AddFragmentStats(id string, s *FragmentStats) {
+	addFragmentStats(r.fragmentStats, id, s)
+}
+
+// GetFragmentStats - Get fragment statistics keyed by fragment id.
+func (r *IntermediateResult) GetFragmentStats() map[string]*FragmentStats {
+	return r.fragmentStats
+}
+
+func (r *IntermediateResult) marshalFragmentStats() []*pb.FragmentStats {
+
+	if len(r.fragmentStats) == 0 {
+		return nil
+	}
+	stats := make([]*pb.FragmentStats, 0, len(r.fragmentStats))
+	for id, v := range r.fragmentStats {
+		if len(v.Nodes) == 0 {
+			stats = append(stats, &pb.FragmentStats{Id: id, Cardinality: v.Cardinality, Elapsed: int64(v.Elapsed)})
+			continue
+		}
+		for _, n := range v.Nodes {
+			stats = append(stats, &pb.FragmentStats{Id: id, Cardinality: n.Cardinality, Elapsed: int64(n.Elapsed),
+				Node: n.Node})
+		}
+	}
+	return stats
+}
+
+// addFragmentStats - Nodes evaluate their shards in parallel so cardinalities are summed and the slowest time kept.
+// The statistics of each node are retained for the per node plan steps.
+func addFragmentStats(m map[string]*FragmentStats, id string, s *FragmentStats) {
+
+	v, ok := m[id]
+	if !ok {
+		v = &FragmentStats{}
+		m[id] = v
+	}
+	v.Cardinality += s.Cardinality
+	if s.Elapsed > v.Elapsed {
+		v.Elapsed = s.Elapsed
+	}
+	if s.Node != "" {
+		v.Nodes = append(v.Nodes, &FragmentStats{Node: s.Node, Cardinality: s.Cardinality, Elapsed: s.Elapsed})
+	}
+	v.Nodes = append(v.Nodes, s.Nodes...)
+}
+
+// setFragmentStats - Attach the statistics returned by the nodes to the fragments of the query.
+func (q *BitmapQuery) setFragmentStats(stats map[string]*FragmentStats) {
+
+	if q.root == nil {
+		return
+	}
+	q.Visit(func(f *QueryFragment) error {
+		f.Stats = stats[f.id]
+		return nil
+	})
+}
+
+// Explain - Flatten the fragment DAG into plan steps in depth first order.  Step IDs start after lastID and
+// top level fragments are attached to the parent step.  With EXPLAIN ANALYZE each fragment is followed by a
+// step per node.
+func (q *BitmapQuery) Explain(lastID, parent int) []*PlanStep {
+
+	steps := make([]*PlanStep, 0)
+	if q.root != nil {
+		q.explainFrag(q.root, &lastID, parent, &steps)
+	}
+	return steps
+}
+
+func (q *BitmapQuery) explainFrag(n *QueryFragment, lastID *int, parent int, steps *[]*PlanStep) {
+
+	*lastID++
+	step := &PlanStep{ID: *lastID, Parent: parent, Table: n.Index, Operation: n.Operation,
+		Predicate: n.Describe(), Stats: n.Stats}
+	if step.Operation == "" {
+		step.Operation = "INTERSECT"
+	}
+	step.Operation = strings.TrimPrefix(step.Operation, "LIKE_")
+	if n.ORContext {
+		step.Operation += " (OR)"
+	}
+	*steps = append(*steps, step)
+	if n.Stats != nil {
+		nodes := make([]*FragmentStats, len(n.Stats.Nodes))
+		copy(nodes, n.Stats.Nodes)
+		sort.Slice(nodes, func(i, j int) bool { return nodes[i].Node < nodes[j].Node })
+		for _, v := range nodes {
+			*lastID++
+			*steps = append(*steps, &PlanStep{ID: *lastID, Parent: step.ID, Table: n.Index, Operation: "NODE",
+				Node: v.Node, Stats: v})
+		}
+	}
+	for _, f := range n.children {
+		q.explainFrag(f, lastID, step.ID, steps)
+	}
+}
+
+// Describe - Human readable form of a fragment predicate.
+func (f *QueryFragment) Describe() string {
+
+	switch {
+	case f.Operation == "INNER_JOIN" || f.Operation == "OUTER_JOIN":
+		return fmt.Sprintf("%s -> %s", f.Field, f.Fk)
+	case f.NullCheck && f.Negate:
+		return fmt.Sprintf("%s IS NOT NULL", f.Field)
+	case f.NullCheck:
+		return fmt.Sprintf("%s IS NULL", f.Field)
+	case f.Search != "":
+		// LIKE predicates are rewritten to a BSI batch equals of the string hashes matched by the search.
+		if f.BSIOp == "BATCH_EQ" {
+			return fmt.Sprintf("%s LIKE '%s' (search -> BSI BATCH_EQ of %d values)", f.Field, f.Search,
+				len(f.Values))
+		}
+		return fmt.Sprintf("%s LIKE '%s' (search -> BSI BATCH_EQ)", f.Field, f.Search)
+	case f.SamplePct > 0:
+		return fmt.Sprintf("%s SAMPLE %g%%", f.Field, f.SamplePct)
+	}
+	switch f.BSIOp {
+	case "":
+		return fmt.Sprintf("%s row %d", f.Field, f.RowID)
+	case "LT":
+		return fmt.Sprintf("%s BSI < %d", f.Field, f.Value)
+	case "LE":
+		return fmt.Sprintf("%s BSI <= %d", f.Field, f.Value)
+	case "EQ":
+		return fmt.Sprintf("%s BSI = %d", f.Field, f.Value)
+	case "GE":
+		return fmt.Sprintf("%s BSI >= %d", f.Field, f.Value)
+	case "GT":
+		return fmt.Sprintf("%s BSI > %d", f.Field, f.Value)
+	case "RANGE":
+		return fmt.Sprintf("%s BSI BETWEEN %d AND %d", f.Field, f.Begin, f.End)
+	case "BATCH_EQ":
+		return fmt.Sprintf("%s BSI BATCH_EQ of %d values", f.Field, len(f.Values))
+	}
+	return fmt.Sprintf("%s BSI %s %d", f.Field, f.BSIOp, f.Value)
+}
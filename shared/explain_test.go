@@ -0,0 +1,73 @@
+package shared
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplain(t *testing.T) {
+
+	q := NewBitmapQuery()
+	f := q.NewQueryFragment()
+	f.SetBitmapPredicate("orders", "status", 3)
+	f.Operation = "INTERSECT"
+	q.AddFragment(f)
+	f = q.NewQueryFragment()
+	f.SetBSIRangePredicate("orders", "amount", 10, 20)
+	f.Operation = "UNION"
+	q.AddFragment(f)
+	f = q.NewQueryFragment()
+	f.Index, f.Field, f.Search, f.Operation = "orders", "name", "abc%", "LIKE_INTERSECT"
+	q.AddFragment(f)
+	f = q.NewQueryFragment()
+	f.SetNullPredicate("orders", "region")
+	f.Operation = "DIFFERENCE"
+	q.AddFragment(f)
+
+	steps := q.Explain(1, 1)
+	require.Len(t, steps, 4)
+	assert.Equal(t, &PlanStep{ID: 2, Parent: 1, Table: "orders", Operation: "INTERSECT",
+		Predicate: "status row 3"}, steps[0])
+	assert.Equal(t, 2, steps[1].Parent)
+	assert.Equal(t, "amount BSI BETWEEN 10 AND 20", steps[1].Predicate)
+	assert.Equal(t, "INTERSECT", steps[2].Operation)
+	assert.Equal(t, "name LIKE 'abc%' (search -> BSI BATCH_EQ)", steps[2].Predicate)
+	assert.Equal(t, "region IS NULL", steps[3].Predicate)
+	assert.Equal(t, 5, steps[3].ID)
+
+	// Statistics returned by the nodes are matched to the fragments by id.
+	pq := q.ToProto()
+	stats := make(map[string]*FragmentStats)
+	addFragmentStats(stats, pq.Query[0].Id, &FragmentStats{Node: "node2", Cardinality: 5, Elapsed: time.Millisecond})
+	addFragmentStats(stats, pq.Query[0].Id, &FragmentStats{Node: "node1", Cardinality: 7,
+		Elapsed: 2 * time.Millisecond})
+	q.setFragmentStats(stats)
+	assert.Equal(t, pq.Query[0].Id, q.ToProto().Query[0].Id)
+	steps = q.Explain(0, 0)
+	require.Len(t, steps, 6)
+	require.NotNil(t, steps[1].Stats)
+	assert.Equal(t, uint64(12), steps[1].Stats.Cardinality)
+	assert.Equal(t, 2*time.Millisecond, steps[1].Stats.Elapsed)
+
+	// Each node is shown as a child step of the fragment.
+	assert.Equal(t, &PlanStep{ID: 3, Parent: 2, Table: "orders", Operation: "NODE", Node: "node1",
+		Stats: &FragmentStats{Node: "node1", Cardinality: 7, Elapsed: 2 * time.Millisecond}}, steps[2])
+	assert.Equal(t, "node2", steps[3].Node)
+	assert.Equal(t, uint64(5), steps[3].Stats.Cardinality)
+	assert.Equal(t, 1, steps[4].Parent)
+	assert.Equal(t, 5, steps[4].ID)
+
+	// Node statistics survive the round trip from the nodes to the client.
+	ir := NewIntermediateResult("orders")
+	ir.fragmentStats = stats
+	ir2 := NewIntermediateResult("orders")
+	for _, v := range ir.marshalFragmentStats() {
+		ir2.AddFragmentStats(v.Id, &FragmentStats{Node: v.Node, Cardinality: v.Cardinality,
+			Elapsed: time.Duration(v.Elapsed)})
+	}
+	require.Len(t, ir2.GetFragmentStats()[pq.Query[0].Id].Nodes, 2)
+	assert.Equal(t, uint64(12), ir2.GetFragmentStats()[pq.Query[0].Id].Cardinality)
+}
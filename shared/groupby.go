@@ -0,0 +1,195 @@
+package shared
+
+//
+// Client side GROUP BY processing.  Group sets are assembled by intersecting the query results with
+// the row bitmaps of the GROUP BY fields.  The group sets are then sent to each node where the BSI
+// aggregates are computed and the partial results are reduced here.
+//
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/RoaringBitmap/roaring/roaring64"
+	pb "github.com/disney/quanta/grpc"
+	"golang.org/x/sync/errgroup"
+)
+
+// Group - Container for a single GROUP BY result.
+//
+// RowIDs - Row ID of each GROUP BY field (in the same order as the fields were specified).
+// Nulls - True if the corresponding GROUP BY field has no value for this group.
+// Bits - Column IDs that are members of this group.
+// Aggregates - Aggregated values of BSI fields keyed by field name.
+type Group struct {
+	RowIDs     []uint64
+	Nulls      []bool
+	Bits       *roaring64.Bitmap
+	Aggregates map[string]*GroupAggregate
+}
+
+// Count - Number of rows in the group.
+func (g *Group) Count() uint64 {
+	return g.Bits.GetCardinality()
+}
+
+// GroupAggregate - Reduced aggregate values for a BSI field within a group.
+type GroupAggregate struct {
+	Sum   int64
+	Count uint64
+	Min   int64
+	Max   int64
+}
+
+// Avg - Average value (unscaled).
+func (a *GroupAggregate) Avg() float64 {
+	if a.Count == 0 {
+		return 0
+	}
+	return float64(a.Sum) / float64(a.Count)
+}
+
+// GroupBy - Group the found set by the row IDs of one or more standard bitmap fields.  If any BSI
+// aggregate fields are provided then sum, count, min and max are computed for each group.
+func (c *BitmapIndex) GroupBy(index string, groupFields, aggFields []string, fromTime, toTime int64,
+	foundSet *roaring64.Bitmap) ([]*Group, error) {
+
+	if len(groupFields) == 0 {
+		return nil, fmt.Errorf("GroupBy: one or more group fields must be specified")
+	}
+
+	_, bitmapResults, err := c.Projection(index, groupFields, fromTime, toTime, foundSet, false)
+	if err != nil {
+		return nil, fmt.Errorf("GroupBy: %v", err)
+	}
+
+	groups := []*Group{{RowIDs: []uint64{}, Nulls: []bool{}, Bits: foundSet}}
+	for _, f := range groupFields {
+		rows := bitmapResults[f]
+		rowIDs := make([]uint64, 0, len(rows))
+		for k := range rows {
+			rowIDs = append(rowIDs, k)
+		}
+		sort.Slice(rowIDs, func(i, j int) bool { return rowIDs[i] < rowIDs[j] })
+		newGroups := make([]*Group, 0)
+		for _, g := range groups {
+			remainder := g.Bits.Clone()
+			for _, rowID := range rowIDs {
+				bm := roaring64.And(g.Bits, rows[rowID])
+				if bm.GetCardinality() == 0 {
+					continue
+				}
+				remainder.AndNot(bm)
+				newGroups = append(newGroups, &Group{RowIDs: append(append([]uint64{}, g.RowIDs...), rowID),
+					Nulls: append(append([]bool{}, g.Nulls...), false), Bits: bm})
+			}
+			if remainder.GetCardinality() > 0 {
+				newGroups = append(newGroups, &Group{RowIDs: append(append([]uint64{}, g.RowIDs...), 0),
+					Nulls: append(append([]bool{}, g.Nulls...), true), Bits: remainder})
+			}
+		}
+		groups = newGroups
+	}
+
+	if len(aggFields) == 0 || len(groups) == 0 {
+		return groups, nil
+	}
+
+	groupSets := make([]*roaring64.Bitmap, len(groups))
+	for i, g := range groups {
+		groupSets[i] = g.Bits
+	}
+	aggs, err := c.aggregateGroups(index, aggFields, fromTime, toTime, groupSets)
+	if err != nil {
+		return nil, fmt.Errorf("GroupBy: %v", err)
+	}
+	for i, g := range groups {
+		g.Aggregates = aggs[i]
+	}
+	return groups, nil
+}
+
+// Aggregate - Compute sum, count, min and max for one or more BSI fields over the found set in a
+// single request to each node.  Results are keyed by field name, fields with no values are omitted.
+func (c *BitmapIndex) Aggregate(index string, aggFields []string, fromTime, toTime int64,
+	foundSet *roaring64.Bitmap) (map[string]*GroupAggregate, error) {
+
+	if len(aggFields) == 0 || foundSet == nil || foundSet.GetCardinality() == 0 {
+		return make(map[string]*GroupAggregate), nil
+	}
+	aggs, err := c.aggregateGroups(index, aggFields, fromTime, toTime, []*roaring64.Bitmap{foundSet})
+	if err != nil {
+		return nil, fmt.Errorf("Aggregate: %v", err)
+	}
+	return aggs[0], nil
+}
+
+// aggregateGroups - Send the group sets to each node and reduce the partial aggregates.
+func (c *BitmapIndex) aggregateGroups(index string, aggFields []string, fromTime, toTime int64,
+	groupSets []*roaring64.Bitmap) ([]map[string]*GroupAggregate, error) {
+
+	req := &pb.GroupByRequest{Index: index, Fields: aggFields, FromTime: fromTime, ToTime: toTime}
+	req.GroupSets = make([][]byte, len(groupSets))
+	var err error
+	for i, gs := range groupSets {
+		if req.GroupSets[i], err = gs.MarshalBinary(); err != nil {
+			return nil, err
+		}
+	}
+
+	resultChan := make(chan *pb.GroupByResponse, 100)
+	var eg errgroup.Group
+
+	// Send the same group by request to each readable node.
+	indices, err2 := c.SelectNodes(index, ReadIntentAll)
+	if err2 != nil {
+		return nil, err2
+	}
+	for _, n := range indices {
+		client := c.client[n]
+		clientIndex := n
+		eg.Go(func() error {
+			gr, err := c.groupByClient(client, req, clientIndex)
+			if err != nil {
+				return err
+			}
+			resultChan <- gr
+			return nil
+		})
+	}
+
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	close(resultChan)
+
+	ir := NewIntermediateResult(index)
+	for rs := range resultChan {
+		for _, v := range rs.GetResults() {
+			if int(v.Group) >= len(groupSets) {
+				return nil, fmt.Errorf("group %d out of range", v.Group)
+			}
+			ir.AddGroupResult(v)
+		}
+	}
+	results := make([]map[string]*GroupAggregate, len(groupSets))
+	for i := range groupSets {
+		results[i] = ir.GetGroupAggregates(uint32(i))
+	}
+	return results, nil
+}
+
+func (c *BitmapIndex) groupByClient(client pb.BitmapIndexClient, req *pb.GroupByRequest,
+	clientIndex int) (*pb.GroupByResponse, error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), Deadline)
+	defer cancel()
+
+	result, err := client.GroupBy(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("%v.GroupBy(_) = _, %v, node = %s", client, err,
+			c.ClientConnections()[clientIndex].Target())
+	}
+	return result, nil
+}
@@ -0,0 +1,35 @@
+package shared
+
+import (
+	"testing"
+
+	pb "github.com/disney/quanta/grpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupAggregateReduce(t *testing.T) {
+
+	ir := NewIntermediateResult("test")
+
+	// Two partitions, the first one is returned twice (by a replica).
+	ir.AddGroupResult(&pb.GroupByResult{Group: 0, Field: "amount", Partition: 1, Sum: 30, Count: 3, Min: 5, Max: 15})
+	ir.AddGroupResult(&pb.GroupByResult{Group: 0, Field: "amount", Partition: 1, Sum: 30, Count: 3, Min: 5, Max: 15})
+	ir.AddGroupResult(&pb.GroupByResult{Group: 0, Field: "amount", Partition: 2, Sum: 10, Count: 1, Min: 10, Max: 10})
+	ir.AddGroupResult(&pb.GroupByResult{Group: 1, Field: "amount", Partition: 1, Sum: -4, Count: 2, Min: -3, Max: -1})
+
+	aggs := ir.GetGroupAggregates(0)
+	require.Contains(t, aggs, "amount")
+	assert.Equal(t, int64(40), aggs["amount"].Sum)
+	assert.Equal(t, uint64(4), aggs["amount"].Count)
+	assert.Equal(t, int64(5), aggs["amount"].Min)
+	assert.Equal(t, int64(15), aggs["amount"].Max)
+	assert.Equal(t, float64(10), aggs["amount"].Avg())
+
+	aggs = ir.GetGroupAggregates(1)
+	require.Contains(t, aggs, "amount")
+	assert.Equal(t, int64(-3), aggs["amount"].Min)
+	assert.Equal(t, int64(-1), aggs["amount"].Max)
+
+	assert.Empty(t, ir.GetGroupAggregates(2))
+}
@@ -0,0 +1,130 @@
+package shared
+
+//
+// HyperLogLog sketch for approximate distinct counts.  Sketches created with the same precision can be
+// merged so that each node can sketch its own data and the client combines them.
+//
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+const (
+	// MinHLLPrecision - Smallest supported sketch precision (16 registers).
+	MinHLLPrecision = 4
+	// MaxHLLPrecision - Largest supported sketch precision (65536 registers).
+	MaxHLLPrecision = 16
+	// DefaultHLLPrecision - Default precision, standard error is approximately 0.8%.
+	DefaultHLLPrecision = 14
+)
+
+// HyperLogLog - Sketch state.
+type HyperLogLog struct {
+	precision uint8
+	registers []uint8
+}
+
+// NewHyperLogLog - Construct a new sketch having 2^precision registers.
+func NewHyperLogLog(precision int) (*HyperLogLog, error) {
+
+	if precision < MinHLLPrecision || precision > MaxHLLPrecision {
+		return nil, fmt.Errorf("HLL precision must be between %d and %d", MinHLLPrecision, MaxHLLPrecision)
+	}
+	return &HyperLogLog{precision: uint8(precision), registers: make([]uint8, 1<<uint(precision))}, nil
+}
+
+// Precision - Returns the precision of the sketch.
+func (h *HyperLogLog) Precision() int {
+	return int(h.precision)
+}
+
+// Add - Add a value to the sketch.
+func (h *HyperLogLog) Add(value uint64) {
+
+	x := mix64(value)
+	idx := x >> (64 - h.precision)
+	w := x<<h.precision | 1<<(h.precision-1)
+	rank := uint8(bits.LeadingZeros64(w)) + 1
+	if rank > h.registers[idx] {
+		h.registers[idx] = rank
+	}
+}
+
+// Merge - Combine another sketch into this one.
+func (h *HyperLogLog) Merge(other *HyperLogLog) error {
+
+	if other.precision != h.precision {
+		return fmt.Errorf("cannot merge HLL sketches with precision %d and %d", h.precision, other.precision)
+	}
+	for i, v := range other.registers {
+		if v > h.registers[i] {
+			h.registers[i] = v
+		}
+	}
+	return nil
+}
+
+// Estimate - Returns the estimated number of distinct values added to the sketch.
+func (h *HyperLogLog) Estimate() uint64 {
+
+	m := float64(len(h.registers))
+	var sum float64
+	zeros := 0
+	for _, v := range h.registers {
+		sum += 1 / float64(uint64(1)<<v)
+		if v == 0 {
+			zeros++
+		}
+	}
+	var alpha float64
+	switch len(h.registers) {
+	case 16:
+		alpha = 0.673
+	case 32:
+		alpha = 0.697
+	case 64:
+		alpha = 0.709
+	default:
+		alpha = 0.7213 / (1 + 1.079/m)
+	}
+	est := alpha * m * m / sum
+	if est <= 2.5*m && zeros > 0 {
+		// Small range correction (linear counting)
+		est = m * math.Log(m/float64(zeros))
+	}
+	return uint64(est + 0.5)
+}
+
+// MarshalBinary - Serialize the sketch, the precision is implied by the length.
+func (h *HyperLogLog) MarshalBinary() ([]byte, error) {
+
+	data := make([]byte, len(h.registers))
+	copy(data, h.registers)
+	return data, nil
+}
+
+// UnmarshalBinary - Deserialize a sketch.
+func (h *HyperLogLog) UnmarshalBinary(data []byte) error {
+
+	precision := bits.Len(uint(len(data))) - 1
+	if len(data) != 1<<uint(precision) || precision < MinHLLPrecision || precision > MaxHLLPrecision {
+		return fmt.Errorf("invalid HLL sketch length %d", len(data))
+	}
+	h.precision = uint8(precision)
+	h.registers = make([]uint8, len(data))
+	copy(h.registers, data)
+	return nil
+}
+
+// mix64 - Finalizer from MurmurHash3, values are often sequential so they must be scrambled.
+func mix64(x uint64) uint64 {
+
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
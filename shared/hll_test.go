@@ -0,0 +1,63 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/RoaringBitmap/roaring/roaring64"
+	pb "github.com/disney/quanta/grpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHyperLogLog(t *testing.T) {
+
+	_, err := NewHyperLogLog(MaxHLLPrecision + 1)
+	assert.Error(t, err)
+
+	// Two overlapping halves.
+	a, err := NewHyperLogLog(DefaultHLLPrecision)
+	require.NoError(t, err)
+	b, err := NewHyperLogLog(DefaultHLLPrecision)
+	require.NoError(t, err)
+	for i := uint64(0); i < 60000; i++ {
+		a.Add(i)
+	}
+	for i := uint64(40000); i < 100000; i++ {
+		b.Add(i)
+	}
+	require.NoError(t, a.Merge(b))
+	assert.InEpsilon(t, 100000, float64(a.Estimate()), 0.03)
+
+	data, err := a.MarshalBinary()
+	require.NoError(t, err)
+	c := &HyperLogLog{}
+	require.NoError(t, c.UnmarshalBinary(data))
+	assert.Equal(t, DefaultHLLPrecision, c.Precision())
+	assert.Equal(t, a.Estimate(), c.Estimate())
+
+	// Small cardinalities are exact enough.
+	d, _ := NewHyperLogLog(MinHLLPrecision)
+	for i := 0; i < 10; i++ {
+		d.Add(7)
+	}
+	assert.Equal(t, uint64(1), d.Estimate())
+	assert.Error(t, d.Merge(a))
+}
+
+func TestMergeCountDistinct(t *testing.T) {
+
+	// Replicas return overlapping value sets.
+	r1, _ := roaring64.BitmapOf(1, 2, 3).MarshalBinary()
+	r2, _ := roaring64.BitmapOf(3, 4).MarshalBinary()
+	n, err := mergeCountDistinct([]*pb.CountDistinctResult{{Values: r1}, {Values: r2}, {Values: r1}})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), n)
+
+	s, _ := NewHyperLogLog(8)
+	s.Add(100)
+	s.Add(200)
+	sk, _ := s.MarshalBinary()
+	n, err = mergeCountDistinct([]*pb.CountDistinctResult{{Sketch: sk}, {Sketch: sk}})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), n)
+}
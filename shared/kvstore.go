@@ -74,7 +74,7 @@ func (c *KVStore) Put(indexPath string, k interface{}, v interface{}, pathIsKey
 	if pathIsKey {
 		key, indexPath = checkAdjustKeyAndPath(indexPath)
 	}
-	indices, err := c.SelectNodes(key, WriteIntent)
+	indices, err := c.SelectNodesForTable(tableFromKey(indexPath), key, WriteIntent)
 	if err != nil {
 		return fmt.Errorf("Put: %v", err)
 	}
@@ -92,14 +92,15 @@ func (c *KVStore) Put(indexPath string, k interface{}, v interface{}, pathIsKey
 	return nil
 }
 
-func (c *KVStore) splitBatch(batch map[interface{}]interface{}, op OpType) []map[interface{}]interface{} {
+func (c *KVStore) splitBatch(indexPath string, batch map[interface{}]interface{},
+	op OpType) []map[interface{}]interface{} {
 
 	batches := make([]map[interface{}]interface{}, len(c.client))
 	for i := range batches {
 		batches[i] = make(map[interface{}]interface{}, 0)
 	}
 	for k, v := range batch {
-		indices, err := c.SelectNodes(ToString(k), op)
+		indices, err := c.SelectNodesForTable(tableFromKey(indexPath), ToString(k), op)
 		if err != nil {
 			u.Errorf("splitBatch: %v", err)
 			continue
@@ -121,7 +122,7 @@ func (c *KVStore) BatchPut(indexPath string, batch map[interface{}]interface{},
 	if pathIsKey {
 		var key string
 		key, indexPath = checkAdjustKeyAndPath(indexPath)
-		indices, err := c.SelectNodes(key, WriteIntent)
+		indices, err := c.SelectNodesForTable(tableFromKey(indexPath), key, WriteIntent)
 		if err != nil {
 			return fmt.Errorf("BatchPut: %v", err)
 		}
@@ -129,7 +130,7 @@ func (c *KVStore) BatchPut(indexPath string, batch map[interface{}]interface{},
 			batches[i] = batch
 		}
 	} else {
-		batches = c.splitBatch(batch, WriteIntent)
+		batches = c.splitBatch(indexPath, batch, WriteIntent)
 	}
 
 	// TODO: This should use errgroup
@@ -193,7 +194,7 @@ func (c *KVStore) Lookup(indexPath string, k interface{}, valueType reflect.Kind
 		key, indexPath = checkAdjustKeyAndPath(indexPath)
 	}
 
-	indices, err := c.SelectNodes(key, ReadIntent)
+	indices, err := c.SelectNodesForTable(tableFromKey(indexPath), key, ReadIntent)
 	if err != nil {
 		return nil, fmt.Errorf("Lookup: %v", err)
 	}
@@ -221,7 +222,7 @@ func (c *KVStore) BatchLookup(indexPath string, batch map[interface{}]interface{
 	if pathIsKey {
 		var key string
 		key, indexPath = checkAdjustKeyAndPath(indexPath)
-		indices, err := c.SelectNodes(key, ReadIntent)
+		indices, err := c.SelectNodesForTable(tableFromKey(indexPath), key, ReadIntent)
 		if err != nil {
 			return nil, fmt.Errorf("BatchLookup: %v", err)
 		}
@@ -232,7 +233,7 @@ func (c *KVStore) BatchLookup(indexPath string, batch map[interface{}]interface{
 	}
 
 	// We dont want to iterate over replicas for lookups so count is 1, first replica is primary
-	batches := c.splitBatch(batch, ReadIntent)
+	batches := c.splitBatch(indexPath, batch, ReadIntent)
 
 	// TODO: use errorgroup
 	results := make(map[interface{}]interface{}, 0)
@@ -0,0 +1,127 @@
+package shared
+
+//
+// Per table replication.  Tables may override the cluster wide replication factor (Conn.Replicas) with the
+// "replicas" table setting.  Shard keys and KV index paths begin with the table name, which is used to look up
+// the replication factor when placing data.
+//
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	u "github.com/araddon/gou"
+	"github.com/hashicorp/consul/api"
+)
+
+// tableFromKey - The table name is the first element of a shard key or index path.
+func tableFromKey(key string) string {
+
+	if i := strings.Index(key, "/"); i >= 0 {
+		return key[:i]
+	}
+	return key
+}
+
+// GetReplicas - Replication factor for a table.
+func (m *Conn) GetReplicas(table string) int {
+
+	m.tableReplicasLock.RLock()
+	defer m.tableReplicasLock.RUnlock()
+	if n, ok := m.tableReplicas[table]; ok && n > 0 {
+		return n
+	}
+	return m.Replicas
+}
+
+// MaxReplicas - Highest replication factor of all tables.
+func (m *Conn) MaxReplicas() int {
+
+	m.tableReplicasLock.RLock()
+	defer m.tableReplicasLock.RUnlock()
+	max := m.Replicas
+	for _, n := range m.tableReplicas {
+		if n > max {
+			max = n
+		}
+	}
+	return max
+}
+
+// minReplicas - Lowest replication factor of all tables.
+func (m *Conn) minReplicas() int {
+
+	m.tableReplicasLock.RLock()
+	defer m.tableReplicasLock.RUnlock()
+	min := m.Replicas
+	for _, n := range m.tableReplicas {
+		if n > 0 && n < min {
+			min = n
+		}
+	}
+	return min
+}
+
+// updateTableReplicas - Load table replication factors from Consul.  If waitIndex is set the call blocks until
+// the schema changes.  Tables that are missing (i.e. while being re-deployed) retain their last known value.
+func (m *Conn) updateTableReplicas(waitIndex uint64) (uint64, error) {
+
+	keys, meta, err := m.Consul.KV().Keys("schema/", "", &api.QueryOptions{WaitIndex: waitIndex})
+	if err != nil {
+		return waitIndex, err
+	}
+	tables := make(map[string]struct{})
+	replicas := make(map[string]int)
+	for _, k := range keys {
+		s := strings.Split(k, "/")
+		if len(s) < 3 {
+			continue
+		}
+		tables[s[1]] = struct{}{}
+		if len(s) != 3 || s[2] != "replicas" {
+			continue
+		}
+		pair, _, err := m.Consul.KV().Get(k, nil)
+		if err != nil {
+			return waitIndex, err
+		}
+		if pair == nil {
+			continue
+		}
+		n, err := strconv.Atoi(string(pair.Value))
+		if err != nil {
+			u.Errorf("invalid replicas for table %s - %v", s[1], err)
+			continue
+		}
+		replicas[s[1]] = n
+	}
+
+	m.tableReplicasLock.Lock()
+	defer m.tableReplicasLock.Unlock()
+	for table, n := range m.tableReplicas {
+		if _, found := tables[table]; !found {
+			replicas[table] = n
+		}
+	}
+	m.tableReplicas = replicas
+	return meta.LastIndex, nil
+}
+
+// pollTableReplicas - Track changes to table replication factors.
+func (m *Conn) pollTableReplicas(lastIndex uint64) {
+
+	for {
+		select {
+		case <-m.Stop:
+			return
+		case <-time.After(m.pollWait):
+			index, err := m.updateTableReplicas(lastIndex) // long poll
+			if err != nil {
+				u.Errorf("[client %s] table replicas error: %s", m.ServiceName, err)
+				continue
+			}
+			lastIndex = index
+		}
+	}
+}
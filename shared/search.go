@@ -108,9 +108,9 @@ func (c *StringSearch) splitStringBatch(batch map[string]struct{}, replicas int)
 // Indexing algorithm:
 //  1. Break a string into words and cast list to lower case.
 //  2. Discard the stem words.
-//  3. Create a bloom filter with the remaining key words.
-//  4. Store the bloom filter in a distributed hash (pogreb is the backing store).
+//  3. Store the original string in a distributed hash (pogreb is the backing store).
 //     The key is a murmur32 hash of the original string.
+//  4. Add the hash to the inverted index bitmap of each remaining key word.
 func (c *StringSearch) Index(str string) error {
 
 	c.batchMutex.Lock()
@@ -182,11 +182,14 @@ func (c *StringSearch) batchIndex(client pb.StringSearchClient, batch map[string
 }
 
 // Search - Process a string containing search terms:
-// 1) Construct a bloom filter for the search terms similar to the Index API (above).
-// 2) Execute the remaining steps across all cluster nodes.
-// 3) On each node, iterate over the local keys looking for bloom filter matches.
+// 1) Execute the remaining steps across all cluster nodes.
+// 2) On each node, parse the search terms similar to the Index API (above).
+// 3) On each node, combine the inverted index bitmaps for the terms (phrases are verified).
 // 4) On each node, return the hash codes for the matching items.
 // 5) Merge and return the results as a set of unique hash codes.
+//
+// Terms are "and"ed together unless separated by OR, "quoted terms" must match as a phrase
+// and a term ending with '%' or '*' matches as a prefix.
 func (c *StringSearch) Search(searchTerms string) (map[uint64]struct{}, error) {
 
 	results := make(map[uint64]struct{}, 0)
@@ -253,3 +256,37 @@ func (c *StringSearch) search(client pb.StringSearchClient, searchTerms string)
 
 	return batch, nil
 }
+
+// Reindex - Rebuild the search index for the searchable fields of a table.  Each node resubmits
+// the string values that it stores locally.  Returns the number of values submitted, replicas are counted once.
+func (c *StringSearch) Reindex(table string) (uint64, error) {
+
+	indices, err := c.SelectNodes(table, ReadIntentAll)
+	if err != nil {
+		return 0, fmt.Errorf("Reindex: %v", err)
+	}
+
+	var total uint64
+	var totalLock sync.Mutex
+	var eg errgroup.Group
+	for _, n := range indices {
+		client := c.client[n]
+		clientIndex := n
+		eg.Go(func() error {
+			// No deadline, a node may have a large number of values to resubmit.
+			r, err := client.Reindex(context.Background(), &wrappers.StringValue{Value: table})
+			if err != nil {
+				return fmt.Errorf("%v.Reindex(_) = _, %v, node = %s", client, err,
+					c.ClientConnections()[clientIndex].Target())
+			}
+			totalLock.Lock()
+			total += r.GetValue()
+			totalLock.Unlock()
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return 0, err
+	}
+	return total, nil
+}
@@ -27,7 +27,7 @@ func (c *BitmapIndex) Synchronize(nodeKey string) (int, error) {
 	if err != nil {
 		return -1, err
 	}
-	quorumSize := c.Conn.Replicas + 1 // usually 2+1
+	quorumSize := c.Conn.MaxReplicas() + 1 // usually 2+1
 	if clusterSizeTarget > quorumSize {
 		quorumSize = clusterSizeTarget
 	}
@@ -81,7 +81,7 @@ func (c *BitmapIndex) Synchronize(nodeKey string) (int, error) {
 					startingCount++
 				case "Syncing":
 					readyCount++
-				case "Active":
+				case "Active", "Draining":
 					readyCount++
 				default:
 					u.Infof("nodes %s in unknown state %v.", id, status.NodeState)
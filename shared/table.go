@@ -5,7 +5,9 @@ package shared
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/disney/quanta/qlbridge/value"
 	"github.com/hashicorp/consul/api"
@@ -28,6 +30,9 @@ type BasicTable struct {
 	TimeQuantumType  string                     `yaml:"timeQuantumType,omitempty"`
 	TimeQuantumField string                     `yaml:"timeQuantumField,omitempty"`
 	Selector         string                     `yaml:"selector,omitempty"`
+	Retention        string                     `yaml:"retention,omitempty"`       // i.e. 90d, 12w, 36h
+	RetentionAction  string                     `yaml:"retentionAction,omitempty"` // archive (default) or delete
+	Replicas         int                        `yaml:"replicas,omitempty"`        // overrides cluster replicas
 	Attributes       []BasicAttribute           `yaml:"attributes"`
 	attributeNameMap map[string]*BasicAttribute `yaml:"-"`
 	ConsulClient     *api.Client                `yaml:"-"`
@@ -88,6 +93,12 @@ type Value struct {
 	Desc  string      `yaml:"desc,omitempty" json:"desc,omitempty"`
 }
 
+// Retention actions for expired partitions.
+const (
+	RetentionArchive = "archive"
+	RetentionDelete  = "delete"
+)
+
 // DataType - Field data types.
 type DataType int
 
@@ -211,28 +222,37 @@ func LoadSchema(path string, name string, consulClient *api.Client) (*BasicTable
 	}
 
 	table.ConsulClient = consulClient
-	table.attributeNameMap = make(map[string]*BasicAttribute)
+	if err := table.initialize(); err != nil {
+		return nil, err
+	}
+	return &table, nil
+}
+
+// initialize - Build the attribute lookup table and validate the table structure.
+func (t *BasicTable) initialize() error {
+
+	t.attributeNameMap = make(map[string]*BasicAttribute)
 
 	i := 1
-	for j, v := range table.Attributes {
+	for j, v := range t.Attributes {
 
-		table.Attributes[j].Parent = &table
-		v.Parent = &table
+		t.Attributes[j].Parent = t
+		v.Parent = t
 
 		if v.SourceName == "" && v.FieldName == "" && v.ChildTable == "" {
-			return nil, fmt.Errorf("a valid attribute must have an input source name or field name.  Neither exists")
+			return fmt.Errorf("a valid attribute must have an input source name or field name.  Neither exists")
 		}
 
 		if v.MappingStrategy == "ParentRelation" {
 			if v.ForeignKey == "" {
-				return nil, fmt.Errorf("foreign key table name must be specified for %s", v.FieldName)
+				return fmt.Errorf("foreign key table name must be specified for %s", v.FieldName)
 			}
 			// Force field to be mapped by IntBSIMapper
 			v.MappingStrategy = "IntBSI"
 		}
 
 		if v.FieldName != "" {
-			table.attributeNameMap[v.FieldName] = &table.Attributes[j]
+			t.attributeNameMap[v.FieldName] = &t.Attributes[j]
 		}
 
 		if v.FieldName == "" {
@@ -241,61 +261,121 @@ func LoadSchema(path string, name string, consulClient *api.Client) (*BasicTable
 					// Child table name must be leaf in path ('.' is path sep)
 					idx := strings.LastIndex(v.SourceName, ".")
 					if idx >= 0 {
-						table.Attributes[j].ChildTable = v.SourceName[idx+1:]
+						t.Attributes[j].ChildTable = v.SourceName[idx+1:]
 					} else {
-						table.Attributes[j].ChildTable = v.SourceName
+						t.Attributes[j].ChildTable = v.SourceName
 					}
 				}
 				continue
 			}
 			v.FieldName = v.SourceName
-			table.attributeNameMap[v.SourceName] = &table.Attributes[j]
+			t.attributeNameMap[v.SourceName] = &t.Attributes[j]
 		}
 
 		// Enable lookup by alias (field name)
 		if v.SourceName == "" || v.SourceName != v.FieldName {
-			table.attributeNameMap[v.FieldName] = &table.Attributes[j]
+			t.attributeNameMap[v.FieldName] = &t.Attributes[j]
 		}
 
 		if v.Type == "NotExist" || v.Type == "NotDefined" || v.Type == "JSON" {
 			continue
 		}
-		table.Attributes[j].Ordinal = i
+		t.Attributes[j].Ordinal = i
 
 		i++
 	}
 
-	if table.PrimaryKey == "" && table.TimeQuantumField == "" {
+	if t.PrimaryKey == "" && t.TimeQuantumField == "" {
 		// If the table is partitioned then either a primary key (with time field in first position) or
 		// the time quantum field must be specified specified.
-		if table.TimeQuantumType != "" {
-			return nil, fmt.Errorf("The table %s is partitioned but 'timeQuantumField' is not specified", table.Name)
+		if t.TimeQuantumType != "" {
+			return fmt.Errorf("The table %s is partitioned but 'timeQuantumField' is not specified", t.Name)
 		}
 	} else {
-		pka, err := table.GetPrimaryKeyInfo()
+		pka, err := t.GetPrimaryKeyInfo()
 		if err != nil {
-			return nil,
-				fmt.Errorf("A primary key field was defined but it is not valid field name(s) [%s] - %v",
-					table.PrimaryKey, err)
+			return fmt.Errorf("A primary key field was defined but it is not valid field name(s) [%s] - %v",
+				t.PrimaryKey, err)
 		}
-		timeQuantumField := strings.TrimSpace(table.TimeQuantumField)
+		timeQuantumField := strings.TrimSpace(t.TimeQuantumField)
 		var timeQuantumAttr *BasicAttribute
-		if timeQuantumField == "" && table.TimeQuantumType != "" && len(pka) < 2 {
-			return nil, fmt.Errorf("time partitions enabled for but 'timeQuantumField' not specified")
+		if timeQuantumField == "" && t.TimeQuantumType != "" && len(pka) < 2 {
+			return fmt.Errorf("time partitions enabled for but 'timeQuantumField' not specified")
 		}
-		if timeQuantumField == "" && table.TimeQuantumType != "" && len(pka) >= 2 {
+		if timeQuantumField == "" && t.TimeQuantumType != "" && len(pka) >= 2 {
 			timeQuantumField = pka[0].FieldName
 		}
 		if timeQuantumField != "" {
-			if at, err := table.GetAttribute(timeQuantumField); err == nil {
+			if at, err := t.GetAttribute(timeQuantumField); err == nil {
 				timeQuantumAttr = at
 			}
 		}
-		if table.TimeQuantumType != "" && (timeQuantumAttr.Type != "Date" && timeQuantumAttr.Type != "DateTime") {
-			return nil, fmt.Errorf("time partitions enabled for %s, Type must be Date or DateTime", timeQuantumField)
+		if t.TimeQuantumType != "" && (timeQuantumAttr.Type != "Date" && timeQuantumAttr.Type != "DateTime") {
+			return fmt.Errorf("time partitions enabled for %s, Type must be Date or DateTime", timeQuantumField)
 		}
 	}
-	return &table, nil
+	if t.Retention != "" && t.TimeQuantumType == "" {
+		return fmt.Errorf("retention is specified for %s but the table is not time partitioned", t.Name)
+	}
+	if _, err := t.GetRetention(); err != nil {
+		return err
+	}
+	if t.Replicas < 0 {
+		return fmt.Errorf("replicas for %s must not be negative", t.Name)
+	}
+	return nil
+}
+
+// GetRetention - Parse the retention period.  Accepts days ("90d"), weeks ("12w") or a duration
+// ("36h").  Returns zero if retention is not enabled.
+func (t *BasicTable) GetRetention() (time.Duration, error) {
+
+	r := strings.TrimSpace(t.Retention)
+	if r == "" {
+		return 0, nil
+	}
+	if t.RetentionAction != "" && t.RetentionAction != RetentionArchive && t.RetentionAction != RetentionDelete {
+		return 0, fmt.Errorf("invalid retention action '%s' for %s, must be '%s' or '%s'", t.RetentionAction,
+			t.Name, RetentionArchive, RetentionDelete)
+	}
+	var period time.Duration
+	var err error
+	switch unit := r[len(r)-1]; unit {
+	case 'd', 'w':
+		var n int
+		if n, err = strconv.Atoi(r[:len(r)-1]); err == nil {
+			period = time.Duration(n) * 24 * time.Hour
+			if unit == 'w' {
+				period *= 7
+			}
+		}
+	default:
+		period, err = time.ParseDuration(r)
+	}
+	if err != nil || period <= 0 {
+		return 0, fmt.Errorf("invalid retention '%s' for %s", t.Retention, t.Name)
+	}
+	return period, nil
+}
+
+// RetentionCutoff - Partitions with a time before the cutoff are expired.  The cutoff is truncated
+// to the time quantum so that only whole partitions expire.  Returns a zero time if retention is not enabled.
+func (t *BasicTable) RetentionCutoff(now time.Time) (time.Time, error) {
+
+	period, err := t.GetRetention()
+	if err != nil || period == 0 || t.TimeQuantumType == "" {
+		return time.Time{}, err
+	}
+	c := now.UTC().Add(-period)
+	if t.TimeQuantumType == "YMD" {
+		return time.Date(c.Year(), c.Month(), c.Day(), 0, 0, 0, 0, time.UTC), nil
+	}
+	return time.Date(c.Year(), c.Month(), c.Day(), c.Hour(), 0, 0, 0, time.UTC), nil
+}
+
+// IsRetentionDelete - Expired partitions are deleted rather than archived.
+func (t *BasicTable) IsRetentionDelete() bool {
+	return t.RetentionAction == RetentionDelete
 }
 
 // GetAttribute - Get a tables attribute by name.
@@ -359,6 +439,15 @@ func (a *BasicAttribute) IsBSI() bool {
 
 // Compare - This table's structure to another table.
 func (t *BasicTable) Compare(other *BasicTable) (equal bool, warnings []string, err error) {
+	return t.compare(other, false)
+}
+
+// CompareAllowDrops - Compare tables, attributes missing from the other table are reported as warnings.
+func (t *BasicTable) CompareAllowDrops(other *BasicTable) (equal bool, warnings []string, err error) {
+	return t.compare(other, true)
+}
+
+func (t *BasicTable) compare(other *BasicTable, allowDrops bool) (equal bool, warnings []string, err error) {
 
 	warnings = make([]string, 0)
 
@@ -381,6 +470,19 @@ func (t *BasicTable) Compare(other *BasicTable) (equal bool, warnings []string,
 			fmt.Errorf("Cannot alter time quantum existing = %s, new = %s", t.TimeQuantumType,
 				other.TimeQuantumType)
 	}
+	if t.Retention != other.Retention || t.RetentionAction != other.RetentionAction {
+		warnings = append(warnings, fmt.Sprintf("table retention changed existing = %v %v, new = %v %v",
+			t.Retention, t.RetentionAction, other.Retention, other.RetentionAction))
+	}
+	// Existing shards are not copied to additional owners, so the replication factor cannot be increased.
+	if other.Replicas > t.Replicas {
+		return false, warnings, fmt.Errorf("cannot increase replicas existing = %d, new = %d", t.Replicas,
+			other.Replicas)
+	}
+	if t.Replicas != other.Replicas {
+		warnings = append(warnings, fmt.Sprintf("table replicas reduced existing = %d, new = %d", t.Replicas,
+			other.Replicas))
+	}
 	if t.Selector != other.Selector {
 		warnings = append(warnings, fmt.Sprintf("table selector changed existing = %v, new = %v",
 			t.Selector, other.Selector))
@@ -393,6 +495,10 @@ func (t *BasicTable) Compare(other *BasicTable) (equal bool, warnings []string,
 		}
 		otherAttr, err := other.GetAttribute(v.FieldName)
 		if err != nil {
+			if allowDrops {
+				warnings = append(warnings, fmt.Sprintf("attribute '%s' will be dropped", v.FieldName))
+				continue
+			}
 			return false, warnings, fmt.Errorf("attribute cannot be dropped: %s", v.FieldName)
 		}
 		attrEqual, attrWarnings, attrErr := v.Compare(otherAttr)
@@ -422,6 +528,21 @@ func (t *BasicTable) Compare(other *BasicTable) (equal bool, warnings []string,
 	return
 }
 
+// DroppedAttributes - Names of attributes of this table that do not exist in the other table.
+func (t *BasicTable) DroppedAttributes(other *BasicTable) []string {
+
+	dropped := make([]string, 0)
+	for _, v := range t.Attributes {
+		if v.FieldName == "" {
+			continue
+		}
+		if _, err := other.GetAttribute(v.FieldName); err != nil {
+			dropped = append(dropped, v.FieldName)
+		}
+	}
+	return dropped
+}
+
 // Compare - This attribute to another attribute.
 func (a *BasicAttribute) Compare(other *BasicAttribute) (equal bool, warnings []string, err error) {
 
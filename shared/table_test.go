@@ -2,6 +2,7 @@ package shared
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -83,4 +84,47 @@ func TestSchemaCompare(t *testing.T) {
 		assert.Equal(t, warnings[0],
 			"attribute 'state_name' description changed existing = '', new = 'State name.'")
 	}
+
+	new.Replicas = current.Replicas + 1
+	_, _, err = current.Compare(new)
+	assert.NotNil(t, err)
+	new.Replicas = current.Replicas
+}
+
+func TestRetention(t *testing.T) {
+
+	table := &BasicTable{Name: "events", TimeQuantumType: "YMD"}
+	cutoff, err := table.RetentionCutoff(time.Now())
+	assert.Nil(t, err)
+	assert.True(t, cutoff.IsZero())
+
+	for r, expected := range map[string]time.Duration{"90d": 90 * 24 * time.Hour, "2w": 14 * 24 * time.Hour,
+		"36h": 36 * time.Hour} {
+		table.Retention = r
+		period, err := table.GetRetention()
+		assert.Nil(t, err)
+		assert.Equal(t, expected, period)
+	}
+	for _, r := range []string{"d", "-3d", "0d", "90x"} {
+		table.Retention = r
+		_, err := table.GetRetention()
+		assert.NotNil(t, err, r)
+	}
+
+	table.Retention = "2d"
+	now := time.Date(2020, 3, 10, 13, 30, 0, 0, time.UTC)
+	cutoff, err = table.RetentionCutoff(now)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2020, 3, 8, 0, 0, 0, 0, time.UTC), cutoff)
+	table.TimeQuantumType = "YMDH"
+	cutoff, err = table.RetentionCutoff(now)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2020, 3, 8, 13, 0, 0, 0, time.UTC), cutoff)
+
+	assert.False(t, table.IsRetentionDelete())
+	table.RetentionAction = "purge"
+	_, err = table.GetRetention()
+	assert.NotNil(t, err)
+	table.RetentionAction = RetentionDelete
+	assert.True(t, table.IsRetentionDelete())
 }
@@ -0,0 +1,113 @@
+package shared
+
+//
+// Client side ORDER BY ... LIMIT processing.  Each node selects the top K column IDs for the BSI
+// partitions it owns and the partial results are merged here.
+//
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/RoaringBitmap/roaring/roaring64"
+	pb "github.com/disney/quanta/grpc"
+	"golang.org/x/sync/errgroup"
+)
+
+// TopK - Return up to k column IDs from the found set ordered by the value of a BSI field.  Columns
+// that have no value for the field are not returned.
+func (c *BitmapIndex) TopK(index, field string, k int, descending bool, fromTime, toTime int64,
+	foundSet *roaring64.Bitmap) ([]uint64, error) {
+
+	if k <= 0 {
+		return nil, fmt.Errorf("TopK: k must be greater than zero")
+	}
+	if foundSet == nil || foundSet.GetCardinality() == 0 {
+		return []uint64{}, nil
+	}
+
+	req := &pb.TopKRequest{Index: index, Field: field, K: uint32(k), Descending: descending,
+		FromTime: fromTime, ToTime: toTime}
+	var err error
+	if req.FoundSet, err = foundSet.MarshalBinary(); err != nil {
+		return nil, err
+	}
+
+	resultChan := make(chan *pb.TopKResponse, 100)
+	var eg errgroup.Group
+
+	// Send the same top k request to each readable node.
+	indices, err2 := c.SelectNodes(index, ReadIntentAll)
+	if err2 != nil {
+		return nil, fmt.Errorf("TopK: %v", err2)
+	}
+	for _, n := range indices {
+		client := c.client[n]
+		clientIndex := n
+		eg.Go(func() error {
+			tr, err := c.topKClient(client, req, clientIndex)
+			if err != nil {
+				return err
+			}
+			resultChan <- tr
+			return nil
+		})
+	}
+
+	if err := eg.Wait(); err != nil {
+		return nil, err
+	}
+	close(resultChan)
+
+	results := make([]*pb.TopKResult, 0)
+	for rs := range resultChan {
+		results = append(results, rs.GetResults()...)
+	}
+	return mergeTopK(results, k, descending), nil
+}
+
+// mergeTopK - Reduce the partial results, replicas will return the same column more than once.
+func mergeTopK(results []*pb.TopKResult, k int, descending bool) []uint64 {
+
+	seen := make(map[uint64]struct{}, len(results))
+	unique := make([]*pb.TopKResult, 0, len(results))
+	for _, v := range results {
+		if _, found := seen[v.ColumnId]; found {
+			continue
+		}
+		seen[v.ColumnId] = struct{}{}
+		unique = append(unique, v)
+	}
+	sort.Slice(unique, func(i, j int) bool {
+		if unique[i].Value == unique[j].Value {
+			return unique[i].ColumnId < unique[j].ColumnId
+		}
+		if descending {
+			return unique[i].Value > unique[j].Value
+		}
+		return unique[i].Value < unique[j].Value
+	})
+	if len(unique) > k {
+		unique = unique[:k]
+	}
+	columnIDs := make([]uint64, len(unique))
+	for i, v := range unique {
+		columnIDs[i] = v.ColumnId
+	}
+	return columnIDs
+}
+
+func (c *BitmapIndex) topKClient(client pb.BitmapIndexClient, req *pb.TopKRequest,
+	clientIndex int) (*pb.TopKResponse, error) {
+
+	ctx, cancel := context.WithTimeout(context.Background(), Deadline)
+	defer cancel()
+
+	result, err := client.TopK(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("%v.TopK(_) = _, %v, node = %s", client, err,
+			c.ClientConnections()[clientIndex].Target())
+	}
+	return result, nil
+}
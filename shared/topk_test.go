@@ -0,0 +1,23 @@
+package shared
+
+import (
+	"testing"
+
+	pb "github.com/disney/quanta/grpc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeTopK(t *testing.T) {
+
+	// Column 3 is returned twice (by a replica).
+	results := []*pb.TopKResult{
+		{ColumnId: 1, Value: 10},
+		{ColumnId: 3, Value: 30},
+		{ColumnId: 2, Value: -5},
+		{ColumnId: 3, Value: 30},
+		{ColumnId: 4, Value: 10},
+	}
+	assert.Equal(t, []uint64{3, 1, 4}, mergeTopK(results, 3, true))
+	assert.Equal(t, []uint64{2, 1}, mergeTopK(results, 2, false))
+	assert.Equal(t, []uint64{2, 1, 4, 3}, mergeTopK(results, 10, false))
+}
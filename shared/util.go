@@ -14,6 +14,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/araddon/dateparse"
@@ -646,6 +647,36 @@ func WaitTimeout(wg *errgroup.Group, timeout time.Duration, sigChan exec.SigChan
 	}
 }
 
+// WaitIdleTimeout - Like WaitTimeout, but the timeout is measured from the last progress.  Workers report
+// progress by storing the current time (Unix nanoseconds) in lastActive.
+func WaitIdleTimeout(wg *errgroup.Group, timeout time.Duration, sigChan exec.SigChan,
+	lastActive *atomic.Int64) (error, bool) {
+
+	c := make(chan error, 1)
+	go func() {
+		defer close(c)
+		c <- wg.Wait()
+	}()
+	lastActive.Store(time.Now().UnixNano())
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case err := <-c:
+			return err, false // completed normally
+		case <-timer.C:
+			idle := time.Since(time.Unix(0, lastActive.Load()))
+			if idle < timeout {
+				timer.Reset(timeout - idle)
+				continue
+			}
+			sigChan <- true
+			close(sigChan)
+			return nil, true // timed out
+		}
+	}
+}
+
 // SetUTCdefault sets the default time zone to UTC.
 // there are two possibilities depending upon if package 'time' has been initialized.
 // If it's been initialized then setting TZ to UTC won't work because it's already been read.
@@ -1,11 +1,15 @@
 package shared
 
 import (
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/disney/quanta/qlbridge/exec"
 	"github.com/hashicorp/consul/api"
 	"github.com/hashicorp/consul/sdk/testutil"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/sync/errgroup"
 )
 
 // FIXME: (atw) this creates a test consul which conflicts with the real one.
@@ -115,3 +119,30 @@ func notTestConstraints(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, 0, len(dependencies))
 }
+
+func TestWaitIdleTimeout(t *testing.T) {
+
+	// Work that keeps reporting progress runs past the timeout.
+	var lastActive atomic.Int64
+	var eg errgroup.Group
+	eg.Go(func() error {
+		for i := 0; i < 6; i++ {
+			time.Sleep(20 * time.Millisecond)
+			lastActive.Store(time.Now().UnixNano())
+		}
+		return nil
+	})
+	err, timedOut := WaitIdleTimeout(&eg, 50*time.Millisecond, make(exec.SigChan, 1), &lastActive)
+	assert.Nil(t, err)
+	assert.False(t, timedOut)
+
+	// Work that stalls times out and is signalled.
+	sigChan := make(exec.SigChan, 1)
+	eg.Go(func() error {
+		<-sigChan
+		return nil
+	})
+	err, timedOut = WaitIdleTimeout(&eg, 50*time.Millisecond, sigChan, &lastActive)
+	assert.Nil(t, err)
+	assert.True(t, timedOut)
+}
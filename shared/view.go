@@ -0,0 +1,276 @@
+package shared
+
+//
+// SQL views.
+//
+// A view is a named column subset of a single table filtered by a predicate.  Views are stored in Consul
+// under views/<name> rather than schema/<name> so that the nodes and consumers do not treat them as tables.
+// Queries on a view are rewritten by the query planner as queries on the base table with the view predicate
+// ANDed into the WHERE clause (see qlbridge plan.RewriteViews).
+//
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/disney/quanta/qlbridge/expr"
+	"github.com/disney/quanta/qlbridge/lex"
+	"github.com/disney/quanta/qlbridge/rel"
+	"github.com/hashicorp/consul/api"
+	"gopkg.in/yaml.v2"
+)
+
+// ViewPrefix - Consul key prefix of view definitions.
+const ViewPrefix = "views"
+
+// ParseCreateView - Parse a CREATE [OR REPLACE] VIEW statement.  The view is returned as a table with
+// IsViewOf set to the base table, DefaultPredicate set to the WHERE clause and an attribute (field name
+// only) for each view column.  The attributes are empty for SELECT *, the columns are expanded by CreateView.
+//
+//	CREATE VIEW open_orders AS SELECT order_id, customer_id, total FROM orders WHERE status = 'open'
+func ParseCreateView(sql string) (view *BasicTable, orReplace bool, err error) {
+
+	stmt, err := rel.ParseSql(sql)
+	if err != nil {
+		return nil, false, err
+	}
+	create, ok := stmt.(*rel.SqlCreate)
+	if !ok || create.Tok.T != lex.TokenView || create.Select == nil {
+		return nil, false, fmt.Errorf("expecting CREATE [OR REPLACE] VIEW <view> AS SELECT <columns> FROM <table> "+
+			"[WHERE <predicate>] [%s]", sql)
+	}
+	sel := create.Select
+	if len(sel.From) != 1 || sel.From[0].Name == "" || sel.From[0].SubQuery != nil {
+		return nil, false, fmt.Errorf("a view must select from a single table [%s]", sql)
+	}
+	if sel.Distinct || len(sel.GroupBy) > 0 || sel.Having != nil || len(sel.OrderBy) > 0 || sel.Limit > 0 ||
+		sel.Into != nil || (sel.Where != nil && sel.Where.Source != nil) {
+		return nil, false, fmt.Errorf("DISTINCT, GROUP BY, ORDER BY, LIMIT and sub-queries are not supported "+
+			"in views [%s]", sql)
+	}
+
+	from := sel.From[0]
+	view = &BasicTable{Name: unquoteDDL(create.Identity), IsViewOf: unquoteDDL(from.SourceName()),
+		Attributes: make([]BasicAttribute, 0)}
+	// Column references may be qualified with the table name or alias
+	unqualify := func(id *expr.IdentityNode) (string, error) {
+		left, right, hasLeft := id.LeftRight()
+		if !hasLeft {
+			return id.Text, nil
+		}
+		if !strings.EqualFold(left, view.IsViewOf) && !strings.EqualFold(left, from.Alias) {
+			return "", fmt.Errorf("unknown table %s for column %s", left, right)
+		}
+		return right, nil
+	}
+
+	for _, col := range sel.Columns {
+		if col.Star {
+			if len(sel.Columns) > 1 {
+				return nil, false, fmt.Errorf("* cannot be combined with other view columns [%s]", sql)
+			}
+			break
+		}
+		id, ok := col.Expr.(*expr.IdentityNode)
+		if !ok {
+			return nil, false, fmt.Errorf("view column %s must be a table column", col.Expr)
+		}
+		name, err := unqualify(id)
+		if err != nil {
+			return nil, false, err
+		}
+		if _, right, _ := col.LeftRight(); right != name {
+			return nil, false, fmt.Errorf("view column %s cannot be renamed to %s", name, right)
+		}
+		view.Attributes = append(view.Attributes, BasicAttribute{FieldName: name})
+	}
+
+	if sel.Where != nil && sel.Where.Expr != nil {
+		for _, id := range expr.FindAllIdentities(sel.Where.Expr) {
+			if id.IsBooleanIdentity() {
+				continue
+			}
+			name, err := unqualify(id)
+			if err != nil {
+				return nil, false, err
+			}
+			*id = *expr.NewIdentityNodeVal(name)
+		}
+		view.DefaultPredicate = sel.Where.Expr.String()
+	}
+	return view, create.OrReplace, nil
+}
+
+// ParseDropView - Parse DROP VIEW [IF EXISTS] <view> statements.
+func ParseDropView(sql string) (view string, ifExists bool, err error) {
+
+	words, err := splitDDL(sql)
+	if err != nil {
+		return "", false, err
+	}
+	if len(words) < 3 || !strings.EqualFold(words[0], "DROP") || !strings.EqualFold(words[1], "VIEW") {
+		return "", false, fmt.Errorf("expecting DROP VIEW [IF EXISTS] <view> [%s]", sql)
+	}
+	words = words[2:]
+	if len(words) > 2 && strings.EqualFold(words[0], "IF") && strings.EqualFold(words[1], "EXISTS") {
+		ifExists = true
+		words = words[2:]
+	}
+	if len(words) != 1 {
+		return "", false, fmt.Errorf("expecting DROP VIEW [IF EXISTS] <view> [%s]", sql)
+	}
+	view = unquoteDDL(words[0])
+	if i := strings.LastIndex(words[0], "."); i >= 0 {
+		view = unquoteDDL(words[0][i+1:])
+	}
+	return view, ifExists, nil
+}
+
+// CreateView - Verify a view against its base table and store it in Consul.
+func CreateView(consul *api.Client, view *BasicTable, orReplace bool) error {
+
+	if ok, _ := TableExists(consul, view.Name); ok {
+		return fmt.Errorf("table %s already exists", view.Name)
+	}
+	if v, err := LoadView(consul, view.Name); err != nil {
+		return err
+	} else if v != nil && !orReplace {
+		return fmt.Errorf("view %s already exists", view.Name)
+	}
+	if v, _ := LoadView(consul, view.IsViewOf); v != nil {
+		return fmt.Errorf("cannot create view %s on view %s", view.Name, view.IsViewOf)
+	}
+	if ok, _ := TableExists(consul, view.IsViewOf); !ok {
+		return fmt.Errorf("table %s doesn't exist", view.IsViewOf)
+	}
+	base, err := LoadSchema("", view.IsViewOf, consul)
+	if err != nil {
+		return err
+	}
+
+	if len(view.Attributes) == 0 {
+		for _, v := range base.Attributes {
+			if v.FieldName != "" {
+				view.Attributes = append(view.Attributes, BasicAttribute{FieldName: v.FieldName})
+			}
+		}
+	}
+	for _, v := range view.Attributes {
+		if _, err := base.GetAttribute(v.FieldName); err != nil {
+			return fmt.Errorf("column %s not found in table %s", v.FieldName, view.IsViewOf)
+		}
+	}
+	if view.DefaultPredicate != "" {
+		pred, err := expr.ParseExpression(view.DefaultPredicate)
+		if err != nil {
+			return fmt.Errorf("invalid view predicate %s - %v", view.DefaultPredicate, err)
+		}
+		for _, id := range expr.FindAllIdentities(pred) {
+			if id.IsBooleanIdentity() {
+				continue
+			}
+			if _, err := base.GetAttribute(id.Text); err != nil {
+				return fmt.Errorf("column %s not found in table %s", id.Text, view.IsViewOf)
+			}
+		}
+	}
+
+	b, err := yaml.Marshal(view)
+	if err != nil {
+		return err
+	}
+	lock, err := Lock(consul, "admin-tool", "admin-tool")
+	if err != nil {
+		return err
+	}
+	defer Unlock(consul, lock)
+
+	// The definition is written before the modification time so that listeners see a complete view.
+	root := ViewPrefix + SEP + view.Name + SEP
+	if _, err := consul.KV().Put(&api.KVPair{Key: root + "definition", Value: b}, nil); err != nil {
+		return fmt.Errorf("CreateView: %v", err)
+	}
+	current := time.Now().UTC().Format(time.RFC3339)
+	if _, err := consul.KV().Put(&api.KVPair{Key: root + "modificationTime", Value: ToBytes(current)}, nil); err != nil {
+		return fmt.Errorf("CreateView: %v", err)
+	}
+	return nil
+}
+
+// DropView - Remove a view from Consul.
+func DropView(consul *api.Client, name string) error {
+
+	lock, err := Lock(consul, "admin-tool", "admin-tool")
+	if err != nil {
+		return err
+	}
+	defer Unlock(consul, lock)
+
+	if _, err := consul.KV().DeleteTree(ViewPrefix+SEP+name+SEP, nil); err != nil {
+		return fmt.Errorf("DropView: %v", err)
+	}
+	return nil
+}
+
+// LoadView - Load a view definition from Consul.  Returns nil if the view does not exist.
+func LoadView(consul *api.Client, name string) (*BasicTable, error) {
+
+	if consul == nil || name == "" {
+		return nil, nil
+	}
+	kvPair, _, err := consul.KV().Get(ViewPrefix+SEP+name+SEP+"definition", nil)
+	if err != nil {
+		return nil, fmt.Errorf("LoadView: %v", err)
+	}
+	if kvPair == nil {
+		return nil, nil
+	}
+	var view BasicTable
+	if err := yaml.Unmarshal(kvPair.Value, &view); err != nil {
+		return nil, fmt.Errorf("LoadView %s: %v", name, err)
+	}
+	return &view, nil
+}
+
+// GetViews - Return a list of view names.  From Consul.
+func GetViews(consul *api.Client) ([]string, error) {
+
+	results := make([]string, 0)
+	keys := make(map[string]struct{}, 0)
+	pairs, _, err := consul.KV().List(ViewPrefix+SEP, nil)
+	if err != nil {
+		return results, err
+	}
+	for _, v := range pairs {
+		s := strings.Split(v.Key, SEP)
+		keys[s[1]] = struct{}{}
+	}
+	for v := range keys {
+		results = append(results, v)
+	}
+	return results, nil
+}
+
+// CheckForViewDependencies - Verify that no views are defined on a table.
+func CheckForViewDependencies(consul *api.Client, tableName string) error {
+
+	views, err := GetViews(consul)
+	if err != nil {
+		return fmt.Errorf("GetViews error %v", err)
+	}
+	dependencies := make([]string, 0)
+	for _, name := range views {
+		view, err := LoadView(consul, name)
+		if err != nil {
+			return err
+		}
+		if view != nil && view.IsViewOf == tableName {
+			dependencies = append(dependencies, name)
+		}
+	}
+	if len(dependencies) > 0 {
+		return fmt.Errorf("cannot drop table with views %v", dependencies)
+	}
+	return nil
+}
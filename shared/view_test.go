@@ -0,0 +1,50 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCreateView(t *testing.T) {
+
+	view, orReplace, err := ParseCreateView("CREATE OR REPLACE VIEW open_orders AS SELECT o.order_id, total " +
+		"FROM orders AS o WHERE o.status = 'open' AND total > 100")
+	require.NoError(t, err)
+	assert.True(t, orReplace)
+	assert.Equal(t, "open_orders", view.Name)
+	assert.Equal(t, "orders", view.IsViewOf)
+	require.Len(t, view.Attributes, 2)
+	assert.Equal(t, "order_id", view.Attributes[0].FieldName)
+	assert.Equal(t, "total", view.Attributes[1].FieldName)
+	assert.Equal(t, `status = "open" AND total > 100`, view.DefaultPredicate)
+
+	view, orReplace, err = ParseCreateView("create view all_orders as select * from orders")
+	require.NoError(t, err)
+	assert.False(t, orReplace)
+	assert.Empty(t, view.Attributes)
+	assert.Empty(t, view.DefaultPredicate)
+
+	for _, sql := range []string{
+		"CREATE VIEW v",
+		"CREATE TABLE v (id INT)",
+		"CREATE VIEW v AS SELECT id FROM a, b",
+		"CREATE VIEW v AS SELECT id AS x FROM orders",
+		"CREATE VIEW v AS SELECT count(*) FROM orders",
+		"CREATE VIEW v AS SELECT *, id FROM orders",
+		"CREATE VIEW v AS SELECT id FROM orders ORDER BY id",
+		"CREATE VIEW v AS SELECT c.id FROM orders",
+		"CREATE VIEW v AS SELECT id FROM orders WHERE id IN (SELECT id FROM customers)",
+	} {
+		_, _, err := ParseCreateView(sql)
+		assert.Error(t, err, sql)
+	}
+
+	name, ifExists, err := ParseDropView("DROP VIEW IF EXISTS quanta.`open_orders`")
+	require.NoError(t, err)
+	assert.True(t, ifExists)
+	assert.Equal(t, "open_orders", name)
+	_, _, err = ParseDropView("DROP TABLE open_orders")
+	assert.Error(t, err)
+}
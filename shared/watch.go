@@ -32,17 +32,26 @@ type SchemaChangeListener func(event SchemaChangeEvent)
 
 // RegisterSchemaChangeListener - Registration for event listeners.
 func RegisterSchemaChangeListener(conf *api.Config, cb SchemaChangeListener) error {
+	return registerListener(conf, "schema", cb)
+}
+
+// RegisterViewChangeListener - Registration for view change event listeners, Table is the view name.
+func RegisterViewChangeListener(conf *api.Config, cb SchemaChangeListener) error {
+	return registerListener(conf, ViewPrefix, cb)
+}
+
+func registerListener(conf *api.Config, prefix string, cb SchemaChangeListener) error {
 
 	watchParams := make(map[string]interface{})
 	watchParams["type"] = "keyprefix"
-	watchParams["prefix"] = "schema"
+	watchParams["prefix"] = prefix
 
 	watch, err := watch.Parse(watchParams)
 	if err != nil {
 		return err
 	}
 
-	watch.Handler = makeKvPairsHandler(conf, cb)
+	watch.Handler = makeKvPairsHandler(conf, prefix, cb)
 
 	go func() {
 		err = watch.Run(conf.Address)
@@ -54,7 +63,7 @@ func RegisterSchemaChangeListener(conf *api.Config, cb SchemaChangeListener) err
 	return nil
 }
 
-func makeKvPairsHandler(conf *api.Config, cb SchemaChangeListener) watch.HandlerFunc {
+func makeKvPairsHandler(conf *api.Config, prefix string, cb SchemaChangeListener) watch.HandlerFunc {
 
 	client, err := api.NewClient(conf)
 	if err != nil {
@@ -63,7 +72,7 @@ func makeKvPairsHandler(conf *api.Config, cb SchemaChangeListener) watch.Handler
 	}
 
 	kv := client.KV()
-	oldKvPairs, _, err := kv.List("schema", nil)
+	oldKvPairs, _, err := kv.List(prefix, nil)
 	if err != nil {
 		u.Error(err)
 		os.Exit(1)
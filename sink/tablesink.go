@@ -3,16 +3,40 @@ package sink
 // TableSink - Support for SELECT * INTO "<table>"
 
 import (
-	//u "github.com/araddon/gou"
 	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
 
+	u "github.com/araddon/gou"
+	"github.com/disney/quanta/core"
 	"github.com/disney/quanta/qlbridge/exec"
 	"github.com/disney/quanta/qlbridge/plan"
+	"github.com/disney/quanta/qlbridge/value"
+	"github.com/disney/quanta/rbac"
+)
+
+const (
+	defaultTableSinkBatchSize = 10000
+	userIDKey                 = "@userid"
 )
 
 type (
 	// TableSink - State for table implemention of Sink interface.
-	TableSink struct{}
+	TableSink struct {
+		pool      *core.SessionPool
+		conn      *core.Session
+		table     string
+		sourceMap map[string]string // Projected column name to row key used by the target mappers
+		batchSize int
+		pending   int
+		rowCount  int64
+	}
+
+	// sessionPoolSource - Data sources that can provide sessions for writing to a table.
+	sessionPoolSource interface {
+		GetSessionPool() *core.SessionPool
+	}
 )
 
 var (
@@ -25,25 +49,186 @@ var (
 func NewTableSink(ctx *plan.Context, outTable string, params map[string]interface{}) (exec.Sink, error) {
 	s := &TableSink{}
 	err := s.Open(ctx, outTable, params)
+	if err != nil {
+		u.Errorf("Error creating table sink '%v' for table '%v'\n", err, outTable)
+	}
 	return s, err
 }
 
 // Open output session to table
 func (s *TableSink) Open(ctx *plan.Context, destination string, params map[string]interface{}) error {
+
+	s.table = strings.Trim(destination, "'\"`")
+	s.batchSize = defaultTableSinkBatchSize
+	if bs, ok := params["batchsize"]; ok {
+		n, err := strconv.Atoi(fmt.Sprintf("%v", bs))
+		if err != nil || n <= 0 {
+			return fmt.Errorf("invalid batchsize '%v'", bs)
+		}
+		s.batchSize = n
+	}
+
+	if ctx == nil || ctx.Schema == nil {
+		return fmt.Errorf("schema not available for table sink")
+	}
+	src, ok := ctx.Schema.DS.(sessionPoolSource)
+	if !ok {
+		return fmt.Errorf("schema %s does not support INTO <table>", ctx.Schema.Name)
+	}
+	s.pool = src.GetSessionPool()
+	conn, err := s.pool.Borrow(s.table)
+	if err != nil {
+		return fmt.Errorf("cannot open session for table %s - %v", s.table, err)
+	}
+	s.conn = conn
+	tbuf, ok := conn.TableBuffers[s.table]
+	if !ok {
+		s.release()
+		return fmt.Errorf("table %s not found", s.table)
+	}
+
+	// Map the projected columns onto the target attributes
+	s.sourceMap = make(map[string]string)
+	columns := make([]string, 0)
+	if ctx.Projection != nil && ctx.Projection.Proj != nil {
+		for _, col := range ctx.Projection.Proj.Columns {
+			attr, err := tbuf.Table.GetAttribute(col.As)
+			if err != nil {
+				s.release()
+				return fmt.Errorf("column %s not found in table %s", col.As, s.table)
+			}
+			key := attr.FieldName
+			if attr.SourceName != "" {
+				key = attr.SourceName
+			}
+			s.sourceMap[col.As] = key
+			columns = append(columns, attr.FieldName)
+		}
+	}
+
+	if err := s.authorize(ctx, columns); err != nil {
+		s.release()
+		return err
+	}
+	return nil
+}
+
+// authorize - Verify that the session user can write to the target table.
+func (s *TableSink) authorize(ctx *plan.Context, columns []string) error {
+
+	if ctx.Session == nil {
+		return fmt.Errorf("User ID (%s) not set for session", userIDKey)
+	}
+	userID, ok := ctx.Session.Get(userIDKey)
+	if !ok {
+		return fmt.Errorf("User ID (%s) not set for session", userIDKey)
+	}
+	authCtx, err := rbac.NewAuthContext(s.conn.KVStore, userID.ToString(), false)
+	if err != nil {
+		return fmt.Errorf("RBAC error - %v", err)
+	}
+	if ok, err := authCtx.IsAuthorizedForTable(rbac.WriteDatabase, ctx.Schema.Name, s.table, columns); !ok {
+		return fmt.Errorf("%s not authorized on table %s.%s - %v", rbac.WriteDatabase.String(),
+			ctx.Schema.Name, s.table, err)
+	}
 	return nil
 }
 
 // Next - Write next batch of data to session.
 func (s *TableSink) Next(dest []driver.Value, colIndex map[string]int) error {
+
+	if s.conn == nil {
+		return fmt.Errorf("nil session, open call must have failed")
+	}
+	row := make(map[string]interface{}, len(colIndex))
+	for name, i := range colIndex {
+		if i >= len(dest) {
+			continue
+		}
+		key, ok := s.sourceMap[name]
+		if !ok {
+			return fmt.Errorf("column %s not found in table %s", name, s.table)
+		}
+		if v, ok := tableSinkValue(dest[i]); ok {
+			row[key] = v
+		}
+	}
+	if err := s.conn.PutRow(s.table, row, 0, false, false); err != nil {
+		return err
+	}
+	s.rowCount++
+	s.pending++
+	if s.pending >= s.batchSize {
+		if err := s.conn.Flush(); err != nil {
+			return err
+		}
+		s.pending = 0
+	}
 	return nil
 }
 
 // Close output session.
 func (s *TableSink) Close() error {
+
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Flush()
+	s.release()
+	if err != nil {
+		return err
+	}
+	u.Infof("Table sink: %d rows inserted into %s", s.rowCount, s.table)
 	return nil
 }
 
-// Cleanup output session.
+// Cleanup output session.  Buffered rows are discarded, rows that were already flushed cannot be backed out.
 func (s *TableSink) Cleanup() error {
+
+	if s.conn == nil {
+		return nil
+	}
+	s.conn.Discard()
+	s.release()
+	u.Warnf("Table sink: aborted after %d rows were inserted into %s, %d buffered rows were discarded",
+		s.rowCount-int64(s.pending), s.table, s.pending)
+	s.pending = 0
 	return nil
 }
+
+func (s *TableSink) release() {
+
+	if s.conn != nil {
+		s.pool.Return(s.table, s.conn)
+		s.conn = nil
+	}
+}
+
+// tableSinkValue - Convert a projected value into a type the mappers accept.  Returns false for nulls.
+func tableSinkValue(v driver.Value) (interface{}, bool) {
+
+	switch val := v.(type) {
+	case nil:
+		return nil, false
+	case value.Value:
+		if val.Nil() {
+			return nil, false
+		}
+		return tableSinkValue(val.Value())
+	case string:
+		if val == "" || val == "NULL" {
+			return nil, false
+		}
+		return val, true
+	case []byte:
+		return tableSinkValue(string(val))
+	case int32:
+		return int64(val), true
+	case uint64:
+		return int64(val), true
+	case float32:
+		return float64(val), true
+	default:
+		return val, true
+	}
+}
@@ -0,0 +1,107 @@
+package sink
+
+import (
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/disney/quanta/core"
+	"github.com/disney/quanta/qlbridge/plan"
+	"github.com/disney/quanta/qlbridge/schema"
+	"github.com/disney/quanta/qlbridge/value"
+	"github.com/disney/quanta/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableSinkOpen(t *testing.T) {
+
+	s := &TableSink{}
+	err := s.Open(nil, "orders", map[string]interface{}{"batchsize": "abc"})
+	assert.EqualError(t, err, "invalid batchsize 'abc'")
+	err = s.Open(nil, "orders", map[string]interface{}{"batchsize": 0})
+	assert.EqualError(t, err, "invalid batchsize '0'")
+
+	err = s.Open(nil, "orders", map[string]interface{}{"batchsize": 10})
+	assert.EqualError(t, err, "schema not available for table sink")
+	assert.Equal(t, 10, s.batchSize)
+	assert.Equal(t, "orders", s.table)
+
+	// Quoted table names are accepted, the schema must be able to provide sessions.
+	ctx := plan.NewContext(`SELECT * INTO "orders" FROM users`)
+	ctx.Schema = schema.NewSchema("mock")
+	err = s.Open(ctx, `"orders"`, nil)
+	assert.EqualError(t, err, "schema mock does not support INTO <table>")
+	assert.Equal(t, "orders", s.table)
+	assert.Equal(t, defaultTableSinkBatchSize, s.batchSize)
+}
+
+func TestTableSinkNext(t *testing.T) {
+
+	s := &TableSink{table: "orders", batchSize: 2}
+	err := s.Next([]driver.Value{"1"}, map[string]int{"id": 0})
+	assert.EqualError(t, err, "nil session, open call must have failed")
+
+	// Projected columns must map onto the target table.
+	s.conn = &core.Session{TableBuffers: make(map[string]*core.TableBuffer)}
+	s.sourceMap = map[string]string{"id": "/id"}
+	err = s.Next([]driver.Value{"1", "x"}, map[string]int{"id": 0, "other": 1})
+	assert.EqualError(t, err, "column other not found in table orders")
+
+	// Write errors are returned and the row is not counted.
+	err = s.Next([]driver.Value{"1"}, map[string]int{"id": 0})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot locate buffer for table orders")
+	assert.Equal(t, int64(0), s.rowCount)
+	assert.Equal(t, 0, s.pending)
+}
+
+func TestTableSinkClose(t *testing.T) {
+
+	s := &TableSink{table: "orders"}
+	assert.Nil(t, s.Close())
+	assert.Nil(t, s.Cleanup())
+}
+
+func TestTableSinkValue(t *testing.T) {
+
+	tests := []struct {
+		in    driver.Value
+		out   interface{}
+		isSet bool
+	}{
+		{nil, nil, false},
+		{"", nil, false},
+		{"NULL", nil, false},
+		{"abc", "abc", true},
+		{[]byte("abc"), "abc", true},
+		{[]byte("NULL"), nil, false},
+		{int32(5), int64(5), true},
+		{uint64(6), int64(6), true},
+		{int64(7), int64(7), true},
+		{float32(1.5), float64(1.5), true},
+		{2.5, 2.5, true},
+		{true, true, true},
+		{value.NewStringValue("xyz"), "xyz", true},
+		{value.NewIntValue(8), int64(8), true},
+		{value.NewNilValue(), nil, false},
+	}
+	for _, tt := range tests {
+		v, ok := tableSinkValue(tt.in)
+		assert.Equal(t, tt.isSet, ok, "%v", tt.in)
+		assert.Equal(t, tt.out, v, "%v", tt.in)
+	}
+}
+
+func TestTableSinkCleanup(t *testing.T) {
+
+	// Buffered rows are discarded, flushing this buffer would fail without a backend.
+	conn := &core.Session{BatchBuffer: shared.NewBatchBuffer(nil, nil, 10)}
+	require.NoError(t, conn.BatchBuffer.SetValue("orders", "amount", 1, 100, time.Unix(0, 0)))
+	s := &TableSink{table: "orders", pool: core.NewSessionPool(nil, nil, nil, "", 1), conn: conn,
+		rowCount: 1, pending: 1}
+	assert.Nil(t, s.Cleanup())
+	assert.True(t, conn.BatchBuffer.IsEmpty())
+	assert.Nil(t, s.conn)
+	assert.Equal(t, 0, s.pending)
+}
@@ -0,0 +1,105 @@
+package source
+
+//
+// EXPLAIN [ANALYZE] support.
+//
+// The plan collector is passed to the table sources in the Go context of the statement.  Each source adds the
+// bitmap query it sends to the nodes.  Without ANALYZE the queries are planned but not run and no rows are
+// returned, with ANALYZE the statement runs and the plan includes the fragment cardinalities and timings.
+//
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/disney/quanta/qlbridge/plan"
+	"github.com/disney/quanta/shared"
+)
+
+// Explain - Collects the query plans of a statement run with EXPLAIN [ANALYZE].
+type Explain struct {
+	Analyze bool
+	lock    sync.Mutex
+	steps   []*shared.PlanStep
+}
+
+type explainKey struct{}
+
+// NewExplain - Construct a plan collector.
+func NewExplain(analyze bool) *Explain {
+	return &Explain{Analyze: analyze, steps: make([]*shared.PlanStep, 0)}
+}
+
+// WithExplain - Run the statement of a context as EXPLAIN.
+func WithExplain(ctx context.Context, e *Explain) context.Context {
+	return context.WithValue(ctx, explainKey{}, e)
+}
+
+// explainFrom - Plan collector of a query, nil unless it is run as EXPLAIN.
+func explainFrom(ctx *plan.Context) *Explain {
+
+	e, _ := queryContext(ctx).Value(explainKey{}).(*Explain)
+	return e
+}
+
+// Steps - Plan steps in the order they were added.
+func (e *Explain) Steps() []*shared.PlanStep {
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return e.steps
+}
+
+// addQuery - Add the bitmap query of a table followed by steps that are not part of it (searches and polyfills).
+func (e *Explain) addQuery(table, predicate string, q *shared.BitmapQuery, stats *shared.FragmentStats,
+	other []*shared.PlanStep) {
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	id := len(e.steps) + 1
+	e.steps = append(e.steps, &shared.PlanStep{ID: id, Table: table, Operation: "QUERY", Predicate: predicate,
+		Stats: stats})
+	e.steps = append(e.steps, q.Explain(id, id)...)
+	for _, v := range other {
+		v.ID, v.Parent = len(e.steps)+1, id
+		e.steps = append(e.steps, v)
+	}
+}
+
+// addStep - Add a top level step.
+func (e *Explain) addStep(step *shared.PlanStep) {
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	step.ID = len(e.steps) + 1
+	e.steps = append(e.steps, step)
+}
+
+// explainQuery - Add the plan of the table query to the statement plan.
+func (m *SQLToQuanta) explainQuery(e *Explain, isDriver bool, searches []*shared.PlanStep,
+	stats *shared.FragmentStats) {
+
+	predicate := fmt.Sprintf("time %s to %s", m.q.FromTime, m.q.ToTime)
+	if m.rowNumSet.GetCardinality() > 0 {
+		predicate = fmt.Sprintf("@rownum lookup of %d rows, not sent to nodes", m.rowNumSet.GetCardinality())
+	}
+	if len(m.p.Stmt.JoinNodes()) > 0 {
+		if isDriver {
+			predicate += ", join driver"
+		} else {
+			predicate += ", joined"
+		}
+	}
+	if m.q.Consistency != shared.ConsistencyOne {
+		predicate += fmt.Sprintf(", consistency %s", m.q.Consistency)
+	}
+	if m.orderBy != nil {
+		predicate += fmt.Sprintf(", order by %s", m.orderBy.FieldName)
+	}
+	other := searches
+	for _, v := range m.polyFill {
+		other = append(other, &shared.PlanStep{Table: m.tbl.Name, Operation: "POLYFILL", Predicate: v})
+	}
+	e.addQuery(m.tbl.Name, predicate, m.q, stats, other)
+}
@@ -6,6 +6,7 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/RoaringBitmap/roaring/roaring64"
@@ -58,7 +59,7 @@ loopExit:
 	}
 }
 
-// Perform functions and inject pseudo-variables into the output
+// Perform functions and inject pseudo-variables into the output.  NULL values are output as nil.
 func decorateRow(row []driver.Value, proj *rel.Projection, rowCols map[string]int, columnID uint64) []driver.Value {
 
 	newRow := make([]driver.Value, len(proj.Columns))
@@ -74,11 +75,12 @@ func decorateRow(row []driver.Value, proj *rel.Projection, rowCols map[string]in
 		ri, rok := rowCols[v.As]
 		if rok {
 			if ri < len(row) {
-				newRow[i] = fmt.Sprintf("%s", row[ri])
+				if row[ri] != "NULL" {
+					newRow[i] = fmt.Sprintf("%s", row[ri])
+				}
 			} else {
 				u.Errorf("could not find index %v in incoming row at col %d - %#v, len(row) = %d, ROW = %#v",
 					ri, i, v, len(row), row)
-				newRow[i] = "NULL"
 			}
 		} else if strings.HasSuffix(v.As, "@rownum") {
 			newRow[i] = fmt.Sprintf("%d", columnID)
@@ -88,7 +90,7 @@ func decorateRow(row []driver.Value, proj *rel.Projection, rowCols map[string]in
 		}
 		nodeVal, ok := vm.Eval(ctx, v.Col.Expr)
 		if !ok || nodeVal.Value() == nil {
-			newRow[i] = "NULL"
+			newRow[i] = nil
 			continue
 		}
 		newRow[i] = nodeVal.ToString()
@@ -118,6 +120,13 @@ func outputProjection(outCh exec.MessageChan, sigChan exec.SigChan, proj *core.P
 		}
 	}
 	limitIsBatch := true
+	remaining := limit // Rows of the limit not yet claimed by a batch
+	var remainingLock sync.Mutex
+
+	// Project large limits in chunks so rows stream to the client as they are produced.
+	if batchSize > ProjectionBatchSize {
+		batchSize = ProjectionBatchSize
+	}
 
 	// Parallelize projection for SELECT ... INTO
 	if isExport {
@@ -139,14 +148,29 @@ func outputProjection(outCh exec.MessageChan, sigChan exec.SigChan, proj *core.P
 		}
 	}
 
+	// The timeout applies while no rows are produced or written, slow clients do not time out a streamed result.
+	var lastActive atomic.Int64
 	var eg errgroup.Group
 	for n := 0; n < nThreads; n++ {
 		eg.Go(func() error {
 			for {
-				colIDs, rows, err4 := proj.Next(batchSize)
+				count := batchSize
+				if limitIsBatch {
+					remainingLock.Lock()
+					if remaining < count {
+						count = remaining
+					}
+					remaining -= count
+					remainingLock.Unlock()
+					if count == 0 {
+						return nil
+					}
+				}
+				colIDs, rows, err4 := proj.Next(count)
 				if err4 != nil {
 					return err4
 				}
+				lastActive.Store(time.Now().UnixNano())
 				if len(rows) == 0 {
 					return nil
 				}
@@ -155,6 +179,10 @@ func outputProjection(outCh exec.MessageChan, sigChan exec.SigChan, proj *core.P
 					if isDistinct {
 						var sb strings.Builder
 						for _, fld := range rows[i] {
+							if fld == nil {
+								sb.WriteString("NULL")
+								continue
+							}
 							sb.WriteString(fld.(string))
 						}
 						key := sb.String()
@@ -173,22 +201,55 @@ func outputProjection(outCh exec.MessageChan, sigChan exec.SigChan, proj *core.P
 					default:
 					}
 					select {
+					case <-sigChan: // Consumer went away (i.e. closed the result set)
+						return nil
 					case outCh <- msg:
-						// continue
+						lastActive.Store(time.Now().UnixNano())
 					}
 				}
-				if len(rows) == batchSize && limitIsBatch {
+				if len(rows) < count && limitIsBatch {
 					return nil
 				}
 			}
 		})
 	}
-	err, timedOut := shared.WaitTimeout(&eg, time.Duration(timeout)*time.Second, sigChan)
+	err, timedOut := shared.WaitIdleTimeout(&eg, time.Duration(timeout)*time.Second, sigChan, &lastActive)
 	if err != nil {
 		return err
 	}
 	if timedOut {
-		return fmt.Errorf("timed out after %d seconds", timeout)
+		return fmt.Errorf("timed out after %d seconds without progress", timeout)
+	}
+	return nil
+}
+
+// outputOrderedProjection - Project a small set of column IDs and output the rows in the sequence given.
+func outputOrderedProjection(outCh exec.MessageChan, sigChan exec.SigChan, proj *core.Projector,
+	colNames, rowCols map[string]int, order []uint64, pro *rel.Projection) error {
+
+	if len(order) == 0 {
+		return nil
+	}
+	colIDs, rows, err := proj.Next(len(order))
+	if err != nil {
+		return err
+	}
+	rowMap := make(map[uint64][]driver.Value, len(rows))
+	for i, columnID := range colIDs {
+		rowMap[columnID] = decorateRow(rows[i], pro, rowCols, columnID)
+	}
+	for _, columnID := range order {
+		row, ok := rowMap[columnID]
+		if !ok {
+			continue
+		}
+		msg := datasource.NewSqlDriverMessageMap(columnID, row, colNames)
+		select {
+		case <-sigChan:
+			return nil
+		case outCh <- msg:
+			// continue
+		}
 	}
 	return nil
 }
@@ -5,7 +5,9 @@ package source
 import (
 	"database/sql/driver"
 	"fmt"
+	"strings"
 	"sync"
+	"time"
 
 	u "github.com/araddon/gou"
 
@@ -17,6 +19,7 @@ import (
 	"github.com/disney/quanta/qlbridge/lex"
 	"github.com/disney/quanta/qlbridge/plan"
 	"github.com/disney/quanta/qlbridge/rel"
+	"github.com/disney/quanta/shared"
 )
 
 var (
@@ -310,6 +313,30 @@ func (m *JoinMerge) Run() error {
 	}
 	joinTypes[m.driverTable] = innerJoin
 
+	// EXPLAIN shows the join without running it, EXPLAIN ANALYZE adds the elapsed time.
+	if e := explainFrom(m.Ctx); e != nil {
+		step := &shared.PlanStep{Table: m.driverTable, Operation: "JOIN",
+			Predicate: fmt.Sprintf("driver %s, tables %s", m.driverTable, strings.Join(m.allTables, ", "))}
+		if !innerJoin {
+			step.Predicate += ", outer"
+		}
+		if negate {
+			step.Predicate += ", anti join"
+		}
+		if !e.Analyze {
+			e.addStep(step)
+			return nil
+		}
+		start := time.Now()
+		defer func() {
+			step.Stats = &shared.FragmentStats{Elapsed: time.Since(start)}
+			if fs, ok := foundSets[m.driverTable]; ok {
+				step.Stats.Cardinality = fs.GetCardinality()
+			}
+			e.addStep(step)
+		}()
+	}
+
 	if orig.IsAggQuery() {
 		nm := m.Ctx.Projection.Proj.Columns[0].As
 		if nm == "count(*)" {
@@ -383,8 +410,8 @@ func (m *JoinMerge) Run() error {
 		}
 		defer sessionPool.Return(m.driverTable, con)
 		// driver table found set may have been reduced by join results
-		proj, err2 := core.NewProjection(con, foundSets, joinFields, projFields, m.driverTable, m.leftStmt.Name,
-			fromTime, toTime, joinTypes, negate)
+		proj, err2 := core.NewProjection(queryContext(m.Ctx), con, foundSets, joinFields, projFields, m.driverTable,
+			m.leftStmt.Name, fromTime, toTime, joinTypes, negate)
 		if err2 != nil {
 			return err2
 		}
@@ -440,7 +467,8 @@ func (m *JoinMerge) callJoin(table string, foundSets map[string]*roaring64.Bitma
 	u.Debugf("TABLE %s, JOINCOLS = %#v, FS = %d, FILTER = %#v, NEGATE = %v", table, joinCols,
 		foundSet.GetCardinality(), filterSetArray, negate)
 
-	rs, err := con.BitIndex.Join(table, joinCols, fromTime, toTime, foundSet, filterSetArray, false)
+	rs, err := con.BitIndex.JoinContext(queryContext(m.Ctx), table, joinCols, fromTime, toTime, foundSet,
+		filterSetArray, false)
 	if err != nil {
 		return nil, false, err
 	}
@@ -136,12 +136,25 @@ func (m *QuantaSource) Open(tableName string) (schema.Conn, error) {
 		u.Errorf("Could not find table for '%s'.'%s'", m.Schema.Name, tableName)
 		return nil, fmt.Errorf("Could not find '%v'.'%v' schema)", m.Schema.Name, tableName)
 	}
+	if tbl.IsViewOf != "" {
+		return nil, fmt.Errorf("view %s is read only, views are queried as table %s", tableName, tbl.IsViewOf)
+	}
 	return NewSQLToQuanta(m.sessionPool.TableCache, m, tbl), nil
 }
 
 // Table by name
 func (m *QuantaSource) Table(table string) (*schema.Table, error) {
 
+	if m.baseDir == "" {
+		view, err := shared.LoadView(m.sessionPool.AppHost.Consul, table)
+		if err != nil {
+			return nil, err
+		}
+		if view != nil {
+			return m.viewTable(view)
+		}
+	}
+
 	conn, err := m.sessionPool.Borrow(table)
 	if err != nil {
 		return nil, fmt.Errorf("error opening connection for table %s - %v", table, err)
@@ -171,8 +184,15 @@ func (m *QuantaSource) Table(table string) (*schema.Table, error) {
 		}
 		cols = append(cols, v.FieldName)
 		f := schema.NewField(v.FieldName, shared.ValueTypeFromString(v.Type),
-			0, v.Required, v.DefaultValue, v.ForeignKey, "-", v.Desc)
+			v.Size, !v.Required, v.DefaultValue, v.ForeignKey, "-", v.Desc)
 		f.Extra = v.MappingStrategy
+		f.Data = v.Type // Native type, distinguishes Date from DateTime for result set metadata
+		switch shared.TypeFromString(v.Type) {
+		case shared.Float:
+			f.AddContext(schema.FieldScaleKey, v.Scale)
+		case shared.DateTime:
+			f.AddContext(schema.FieldScaleKey, timeScale(v.MappingStrategy))
+		}
 		if v.ForeignKey != "" {
 			f.Key = fmt.Sprintf("FK: %s", v.ForeignKey)
 		} else {
@@ -203,6 +223,34 @@ func (m *QuantaSource) Table(table string) (*schema.Table, error) {
 	return tbl, nil
 }
 
+// viewTable - The schema of a view is the subset of the base table fields selected by the view.
+func (m *QuantaSource) viewTable(view *shared.BasicTable) (*schema.Table, error) {
+
+	base, err := m.Table(view.IsViewOf)
+	if err != nil {
+		return nil, fmt.Errorf("view %s - %v", view.Name, err)
+	}
+	tbl := schema.NewTable(view.Name)
+	tbl.IsViewOf = view.IsViewOf
+	tbl.DefaultPredicate = view.DefaultPredicate
+	cols := make([]string, 0, len(view.Attributes))
+	rows := make([][]driver.Value, 0, len(view.Attributes))
+	for _, v := range view.Attributes {
+		f, found := base.FieldMap[v.FieldName]
+		if !found {
+			return nil, fmt.Errorf("column %s of view %s not found in table %s", v.FieldName, view.Name,
+				view.IsViewOf)
+		}
+		field := *f
+		tbl.AddField(&field)
+		cols = append(cols, v.FieldName)
+		rows = append(rows, m.AsRow(&field))
+	}
+	tbl.SetColumns(cols)
+	tbl.SetRows(rows)
+	return tbl, nil
+}
+
 // AsRow - Return values as a row.
 func (m *QuantaSource) AsRow(f *schema.Field) []driver.Value {
 	row := make([]driver.Value, len(schema.DescribeFullCols))
@@ -211,7 +259,7 @@ func (m *QuantaSource) AsRow(f *schema.Field) []driver.Value {
 	row[0] = f.Name
 	row[1] = value.ValueType(f.Type).String() // should we send this through a dialect-writer?  bc dialect specific?
 	row[2] = f.Collation
-	row[3] = f.NoNulls
+	row[3] = !f.NoNulls
 	row[4] = f.Key
 	row[5] = f.DefVal
 	row[6] = f.Extra
@@ -220,6 +268,19 @@ func (m *QuantaSource) AsRow(f *schema.Field) []driver.Value {
 	return row
 }
 
+// timeScale - Fractional second digits of DateTime values for a mapping strategy.
+func timeScale(mappingStrategy string) int {
+
+	switch mappingStrategy {
+	case "SysMillisBSI":
+		return 3
+	case "SysMicroBSI":
+		return 6
+	default:
+		return 0
+	}
+}
+
 // Close this source
 func (m *QuantaSource) Close() error {
 
@@ -264,7 +325,12 @@ func (m *QuantaSource) ListTableNames() []string {
 			u.Errorf("shared.getTables failed: %v", errx)
 			return []string{}
 		}
-		return tables
+		views, errx := shared.GetViews(m.sessionPool.AppHost.Consul)
+		if errx != nil {
+			u.Errorf("shared.GetViews failed: %v", errx)
+			return tables
+		}
+		return append(tables, views...)
 	}
 
 	list := make([]string, 0)
@@ -12,6 +12,7 @@ import (
 	"github.com/disney/quanta/core"
 	"github.com/disney/quanta/qlbridge/datasource"
 	"github.com/disney/quanta/qlbridge/exec"
+	"github.com/disney/quanta/qlbridge/expr"
 	"github.com/disney/quanta/qlbridge/rel"
 	"github.com/disney/quanta/shared"
 )
@@ -119,6 +120,11 @@ func (m *ResultReader) Run() error {
 		return fmt.Errorf("no response")
 	}
 
+	// EXPLAIN without ANALYZE returns the plan only, joins pass their empty results on to the join merge.
+	if e := explainFrom(m.sql.Ctx); e != nil && !e.Analyze && len(m.sql.p.Stmt.JoinNodes()) == 0 {
+		return nil
+	}
+
 	if !m.response.Success {
 		return fmt.Errorf(m.response.ErrorMessage)
 	}
@@ -132,6 +138,10 @@ func (m *ResultReader) Run() error {
 		return err
 	}
 
+	if len(m.sql.groupBy) > 0 {
+		return m.outputGroupBy(outCh, sigChan, orig, fromTime, toTime)
+	}
+
 	if sql.CountStar() && len(m.sql.p.Stmt.JoinNodes()) == 0 {
 		// select count(*)
 		vals := make([]driver.Value, 1)
@@ -171,75 +181,8 @@ func (m *ResultReader) Run() error {
 		return nil
 	}
 
-	if m.sql.isSum || m.sql.isAvg || m.sql.isMin || m.sql.isMax {
-		projFields := []string{fmt.Sprintf("%s.%s", m.sql.tbl.Name, m.sql.aggField)}
-		attr, isBSI, errx := m.sql.ResolveField(m.sql.tbl.Name, m.sql.aggField)
-		if errx != nil {
-			return errx
-		}
-		if !isBSI {
-			return fmt.Errorf("field %s.%s must be a BSI", attr.Parent.Name, attr.FieldName)
-		}
-
-		foundSet := make(map[string]*roaring64.Bitmap)
-		foundSet[m.sql.tbl.Name] = m.response.Results
-		proj, errx := core.NewProjection(m.conn, foundSet, nil, projFields, "", "",
-			fromTime.UnixNano(), toTime.UnixNano(), nil, false)
-		if errx != nil {
-			return errx
-		}
-		vals := make([]driver.Value, 1)
-		if m.sql.isSum || m.sql.isAvg {
-			var sum int64
-			var count uint64
-			sum, count, errx = proj.Sum(m.sql.tbl.Name, m.sql.aggField)
-			if errx != nil {
-				return errx
-			}
-			switch shared.TypeFromString(attr.Type) {
-			case shared.Integer:
-				vals[0] = fmt.Sprintf("%d", sum)
-				if m.sql.isAvg && count != 0 {
-					vals[0] = fmt.Sprintf("%d", sum/int64(count))
-				}
-			case shared.Float:
-				fval := float64(sum)
-				f := fmt.Sprintf("%%.%df", attr.Scale)
-				if m.sql.isAvg && count != 0 {
-					fval = fval / float64(count)
-				}
-				vals[0] = fmt.Sprintf(f, float64(fval)/math.Pow10(attr.Scale))
-			default:
-			}
-		}
-		if m.sql.isMin || m.sql.isMax {
-			var minmax int64
-			if m.sql.isMin {
-				minmax, errx = proj.Min(m.sql.tbl.Name, m.sql.aggField)
-			} else {
-				minmax, errx = proj.Max(m.sql.tbl.Name, m.sql.aggField)
-			}
-			if errx != nil {
-				return errx
-			}
-			table := m.sql.conn.TableBuffers[m.sql.tbl.Name].Table
-			field, err := table.GetAttribute(m.sql.aggField)
-			if err != nil {
-				return err
-			}
-			switch shared.TypeFromString(field.Type) {
-			case shared.Integer:
-				vals[0] = fmt.Sprintf("%10d", minmax)
-			case shared.Float:
-				f := fmt.Sprintf("%%10.%df", field.Scale)
-				vals[0] = fmt.Sprintf(f, float64(minmax)/math.Pow10(field.Scale))
-			}
-		}
-		m.Vals = append(m.Vals, vals)
-		//u.Debugf("was a select sum(*) query %d", ct)
-		msg := datasource.NewSqlDriverMessageMap(uint64(1), vals, colNames)
-		outCh <- msg
-		return nil
+	if len(m.sql.aggregates) > 0 {
+		return m.outputAggregates(outCh, orig, fromTime, toTime)
 	}
 
 	table := m.conn.TableBuffers[m.sql.tbl.Name].Table
@@ -255,7 +198,7 @@ func (m *ResultReader) Run() error {
 			projFields := []string{fmt.Sprintf("%s.%s", m.sql.tbl.Name, m.sql.aggField)}
 			foundSet := make(map[string]*roaring64.Bitmap)
 			foundSet[m.sql.tbl.Name] = m.response.Results
-			proj, err3 := core.NewProjection(m.conn, foundSet, nil, projFields, "", "",
+			proj, err3 := core.NewProjection(queryContext(m.Ctx), m.conn, foundSet, nil, projFields, "", "",
 				fromTime.UnixNano(), toTime.UnixNano(), nil, false)
 			if err3 != nil {
 				return err3
@@ -308,12 +251,18 @@ func (m *ResultReader) Run() error {
 
 	foundSet := make(map[string]*roaring64.Bitmap)
 	foundSet[m.sql.tbl.Name] = m.response.Results
-	proj, err3 := core.NewProjection(m.conn, foundSet, nil, projFields, "", "",
+	proj, err3 := core.NewProjection(queryContext(m.Ctx), m.conn, foundSet, nil, projFields, "", "",
 		fromTime.UnixNano(), toTime.UnixNano(), nil, false)
 	if err3 != nil {
 		return err3
 	}
 
+	// ORDER BY was pushed down, output the page in sequence.
+	if m.sql.orderedIDs != nil {
+		return outputOrderedProjection(outCh, sigChan, proj, colNames, rowCols, m.sql.orderedIDs,
+			m.sql.p.Proj)
+	}
+
 	isExport := false
 
 	// Parallelize projection for SELECT ... INTO
@@ -328,3 +277,256 @@ func (m *ResultReader) Run() error {
 
 	return nil
 }
+
+// outputGroupBy - Aggregate the results by group on the server nodes and output one row per group.
+func (m *ResultReader) outputGroupBy(outCh exec.MessageChan, sigChan exec.SigChan, orig *rel.SqlSelect,
+	fromTime, toTime time.Time) error {
+
+	groupFields := make([]string, len(m.sql.groupBy))
+	groupIndex := make(map[string]int, len(m.sql.groupBy))
+	for i, v := range m.sql.groupBy {
+		groupFields[i] = v.FieldName
+		groupIndex[v.FieldName] = i
+	}
+
+	colNames := make(map[string]int, len(orig.Columns))
+	aggFields := make([]string, 0)
+	aggAttrs := make(map[string]*core.Attribute)
+	for i, col := range orig.Columns {
+		colNames[col.As] = i
+		fn, isFunc := col.Expr.(*expr.FuncNode)
+		if !isFunc || strings.ToLower(fn.Name) == "count" {
+			continue
+		}
+		val, ok := eval(fn.Args[0])
+		if !ok {
+			return fmt.Errorf("invalid argument: %v", fn.String())
+		}
+		attr, isBSI, err := m.sql.ResolveField(m.sql.tbl.Name, val.ToString())
+		if err != nil {
+			return err
+		}
+		if !isBSI {
+			return fmt.Errorf("field %s.%s must be a BSI", attr.Parent.Name, attr.FieldName)
+		}
+		if _, found := aggAttrs[attr.FieldName]; !found {
+			aggAttrs[attr.FieldName] = attr
+			aggFields = append(aggFields, attr.FieldName)
+		}
+	}
+
+	groups, err := m.conn.BitIndex.GroupBy(m.sql.tbl.Name, groupFields, aggFields, fromTime.UnixNano(),
+		toTime.UnixNano(), m.response.Results)
+	if err != nil {
+		return err
+	}
+
+	for i, g := range groups {
+		if i < m.offset {
+			continue
+		}
+		if m.limit > 0 && i >= m.offset+m.limit {
+			break
+		}
+		vals := make([]driver.Value, len(orig.Columns))
+		for j, col := range orig.Columns {
+			switch curNode := col.Expr.(type) {
+			case *expr.IdentityNode:
+				fieldName := curNode.Text
+				if _, r, isLR := curNode.LeftRight(); isLR {
+					fieldName = r
+				}
+				k := groupIndex[fieldName]
+				if g.Nulls[k] {
+					vals[j] = nil
+					continue
+				}
+				if vals[j], err = m.sql.groupBy[k].ToBackingValue([]uint64{g.RowIDs[k]}, m.conn); err != nil {
+					return err
+				}
+			case *expr.FuncNode:
+				funcName := strings.ToLower(curNode.Name)
+				if funcName == "count" {
+					vals[j] = fmt.Sprintf("%d", g.Count())
+					continue
+				}
+				val, _ := eval(curNode.Args[0])
+				attr := aggAttrs[val.ToString()]
+				agg, found := g.Aggregates[attr.FieldName]
+				if !found || agg.Count == 0 {
+					vals[j] = nil
+					continue
+				}
+				vals[j] = formatAggregate(attr, funcName, agg)
+			}
+		}
+		m.Vals = append(m.Vals, vals)
+		msg := datasource.NewSqlDriverMessageMap(uint64(i), vals, colNames)
+		select {
+		case <-sigChan:
+			return nil
+		case outCh <- msg:
+			// continue
+		}
+	}
+	return nil
+}
+
+// outputAggregates - Compute all of the aggregates in the select list and output them as a single row.
+// The BSI aggregates (sum, avg, min, max and count) are computed in one request to each node, as are the
+// distinct counts.
+func (m *ResultReader) outputAggregates(outCh exec.MessageChan, orig *rel.SqlSelect,
+	fromTime, toTime time.Time) error {
+
+	colNames := make(map[string]int, len(orig.Columns))
+	aggs := make([]*aggregate, len(orig.Columns))
+	aggFields := make([]string, 0)
+	aggAttrs := make(map[string]*core.Attribute)
+	pctFields := make([]string, 0)
+	distinctFields := make([]shared.DistinctField, 0)
+	distinctCols := make(map[int]int) // Column index to distinct count index
+	for i, col := range orig.Columns {
+		colNames[col.As] = i
+		agg, found := m.sql.aggregates[col.Expr.String()]
+		if !found {
+			return fmt.Errorf("column %s must be used in an aggregate or appear in a GROUP BY clause", col.As)
+		}
+		aggs[i] = agg
+		if agg.funcName == "count_distinct" {
+			distinctCols[i] = len(distinctFields)
+			distinctFields = append(distinctFields, shared.DistinctField{Field: agg.field, Precision: agg.precision})
+			continue
+		}
+		if agg.field == "" {
+			continue
+		}
+		attr, isBSI, err := m.sql.ResolveField(m.sql.tbl.Name, agg.field)
+		if err != nil {
+			return err
+		}
+		if agg.funcName == "percentile" {
+			pctFields = append(pctFields, fmt.Sprintf("%s.%s", m.sql.tbl.Name, agg.field))
+		} else if _, found := aggAttrs[agg.field]; !found && isBSI {
+			aggFields = append(aggFields, agg.field)
+		}
+		aggAttrs[agg.field] = attr
+	}
+
+	results, err := m.conn.BitIndex.Aggregate(m.sql.tbl.Name, aggFields, fromTime.UnixNano(),
+		toTime.UnixNano(), m.response.Results)
+	if err != nil {
+		return err
+	}
+
+	var distinctCounts []uint64
+	if len(distinctFields) > 0 {
+		distinctCounts, err = m.conn.BitIndex.CountDistinct(m.sql.tbl.Name, distinctFields, fromTime.UnixNano(),
+			toTime.UnixNano(), m.response.Results)
+		if err != nil {
+			return err
+		}
+	}
+
+	var proj *core.Projector
+	if len(pctFields) > 0 {
+		foundSet := map[string]*roaring64.Bitmap{m.sql.tbl.Name: m.response.Results}
+		proj, err = core.NewProjection(queryContext(m.Ctx), m.conn, foundSet, nil, pctFields, "", "",
+			fromTime.UnixNano(), toTime.UnixNano(), nil, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	vals := make([]driver.Value, len(orig.Columns))
+	for i, agg := range aggs {
+		switch agg.funcName {
+		case "percentile":
+			v, ct, err := proj.Percentile(m.sql.tbl.Name, agg.field, agg.percentile)
+			if err != nil {
+				return err
+			}
+			if ct == 0 {
+				vals[i] = nil
+				continue
+			}
+			vals[i] = formatBSIValue(aggAttrs[agg.field], v)
+		case "count_distinct":
+			vals[i] = fmt.Sprintf("%d", distinctCounts[distinctCols[i]])
+		case "count":
+			if agg.field == "" {
+				vals[i] = fmt.Sprintf("%d", m.response.Count)
+				continue
+			}
+			if r, found := results[agg.field]; found {
+				vals[i] = fmt.Sprintf("%d", r.Count)
+				continue
+			}
+			if aggAttrs[agg.field].IsBSI() {
+				vals[i] = "0"
+				continue
+			}
+			// Standard bitmap field, count the columns that have any value.
+			_, bitmapResults, err := m.conn.BitIndex.ProjectionContext(queryContext(m.Ctx), m.sql.tbl.Name,
+				[]string{agg.field}, fromTime.UnixNano(), toTime.UnixNano(), m.response.Results, false)
+			if err != nil {
+				return err
+			}
+			bm := roaring64.NewBitmap()
+			for _, rowBits := range bitmapResults[agg.field] {
+				bm.Or(rowBits)
+			}
+			vals[i] = fmt.Sprintf("%d", bm.GetCardinality())
+		default:
+			r, found := results[agg.field]
+			if !found || r.Count == 0 {
+				if agg.funcName == "sum" || agg.funcName == "avg" {
+					vals[i] = "0"
+				} else {
+					vals[i] = nil
+				}
+				continue
+			}
+			vals[i] = formatAggregate(aggAttrs[agg.field], agg.funcName, r)
+		}
+	}
+	m.Vals = append(m.Vals, vals)
+	msg := datasource.NewSqlDriverMessageMap(uint64(1), vals, colNames)
+	outCh <- msg
+	return nil
+}
+
+// formatAggregate - Format an aggregate value according to the field type and scale.
+func formatAggregate(attr *core.Attribute, funcName string, agg *shared.GroupAggregate) string {
+
+	var ival int64
+	switch funcName {
+	case "sum":
+		ival = agg.Sum
+	case "min", "max":
+		ival = agg.Min
+		if funcName == "max" {
+			ival = agg.Max
+		}
+	case "avg":
+		switch shared.TypeFromString(attr.Type) {
+		case shared.Float:
+			f := fmt.Sprintf("%%.%df", attr.Scale)
+			return fmt.Sprintf(f, agg.Avg()/math.Pow10(attr.Scale))
+		default:
+			return fmt.Sprintf("%d", agg.Sum/int64(agg.Count))
+		}
+	}
+	return formatBSIValue(attr, ival)
+}
+
+// formatBSIValue - Format a BSI value according to the field type and scale.
+func formatBSIValue(attr *core.Attribute, ival int64) string {
+
+	switch shared.TypeFromString(attr.Type) {
+	case shared.Float:
+		f := fmt.Sprintf("%%.%df", attr.Scale)
+		return fmt.Sprintf(f, float64(ival)/math.Pow10(attr.Scale))
+	default:
+		return fmt.Sprintf("%d", ival)
+	}
+}
@@ -0,0 +1,34 @@
+package source
+
+import (
+	"testing"
+
+	"github.com/disney/quanta/core"
+	"github.com/disney/quanta/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatAggregate(t *testing.T) {
+
+	integer := &core.Attribute{BasicAttribute: &shared.BasicAttribute{Type: "Integer"}}
+	float := &core.Attribute{BasicAttribute: &shared.BasicAttribute{Type: "Float", Scale: 2}}
+	agg := &shared.GroupAggregate{Sum: 1250, Count: 4, Min: 5, Max: 1000}
+
+	tests := []struct {
+		attr     *core.Attribute
+		funcName string
+		expected string
+	}{
+		{integer, "sum", "1250"},
+		{integer, "min", "5"},
+		{integer, "max", "1000"},
+		{integer, "avg", "312"},
+		{float, "sum", "12.50"},
+		{float, "min", "0.05"},
+		{float, "max", "10.00"},
+		{float, "avg", "3.12"},
+	}
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, formatAggregate(tc.attr, tc.funcName, agg), tc.funcName)
+	}
+}
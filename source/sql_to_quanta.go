@@ -5,6 +5,7 @@ package source
 import (
 	"database/sql/driver"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -31,6 +32,9 @@ var (
 	// DefaultLimit is page limit
 	DefaultLimit = 5000
 
+	// ProjectionBatchSize is the number of rows projected at a time
+	ProjectionBatchSize = 1000
+
 	// Ensure we implment appropriate interfaces
 	_ schema.Conn           = (*SQLToQuanta)(nil)
 	_ plan.SourcePlanner    = (*SQLToQuanta)(nil)
@@ -61,26 +65,37 @@ type SQLToQuanta struct {
 	offset         int
 	hasMultiValue  bool // Multi-Value vs Single-Value aggs
 	hasSingleValue bool // single value agg
-	isSum          bool
-	isAvg          bool
-	isMin          bool
-	isMax          bool
 	isTopn         bool
 	topn           int
 	aggField       string
+	aggregates     map[string]*aggregate // Aggregates in the select list keyed by expression
+	groupBy        []*core.Attribute
+	orderBy        *core.Attribute // BSI field for ORDER BY ... LIMIT pushdown
+	orderDesc      bool
+	orderedIDs     []uint64 // Column IDs of the requested page in ORDER BY sequence
 	startDate      string
 	endDate        string
 	s              *QuantaSource
 	conn           *core.Session
 	q              *shared.BitmapQuery
 	defaultWhere   bool
-	needsPolyFill  bool // polyfill?
+	needsPolyFill  bool     // polyfill?
+	polyFill       []string // Expressions evaluated by qlbridge, shown by EXPLAIN
 	funcAliases    map[string]struct{}
 	identAliases   map[string]expr.Node
 	tableAliases   map[string]string
 	whereProj      map[string]*core.Attribute
 	rowNumSet      *roaring64.Bitmap
 	tableCache     *core.TableCacheStruct
+	authorized     map[string]struct{} // RBAC checks that passed for the current mutation
+}
+
+// aggregate - An aggregate function in the select list that is computed natively.
+type aggregate struct {
+	funcName   string  // sum, avg, min, max, count, count_distinct or percentile
+	field      string  // Empty for count(*)
+	precision  int     // HLL precision for APPROX_COUNT_DISTINCT, 0 if exact
+	percentile float64 // Fraction between 0 and 1 for PERCENTILE and MEDIAN
 }
 
 // NewSQLToQuanta - Construct a new SQLToQuanta query translator.
@@ -91,6 +106,7 @@ func NewSQLToQuanta(tableCache *core.TableCacheStruct, s *QuantaSource, t *schem
 		s:      s,
 	}
 	m.funcAliases = make(map[string]struct{})
+	m.aggregates = make(map[string]*aggregate)
 	m.identAliases = make(map[string]expr.Node)
 	m.tableAliases = make(map[string]string)
 	m.whereProj = make(map[string]*core.Attribute)
@@ -168,6 +184,7 @@ func (m *SQLToQuanta) WalkSourceSelect(planner plan.Planner, p *plan.Source) (pl
 	sessionMap := make(map[string]interface{})
 	sessionMap[sessionPool] = m.s.sessionPool
 	sessionMap[basePath] = m.conn.BasePath
+	// GROUP BY is processed natively by the ResultReader so results pass through as is.
 	sessionMap[exec.GROUPBY_MAKER] = func(ctx *plan.Context, p *plan.GroupBy) exec.TaskRunner {
 		return NewNopTask(ctx)
 	}
@@ -184,17 +201,20 @@ func (m *SQLToQuanta) WalkSourceSelect(planner plan.Planner, p *plan.Source) (pl
 			p.Context().Session.Put(sk, nil, value.NewValue(v))
 		}
 	}
-	userID, ok := p.Context().Session.Get(userIDKey)
-	if !ok {
-		return nil, fmt.Errorf("User ID (%s) not set for session", userIDKey)
+	// Queries on a view are authorized against the view so that access to the base table is not required.
+	authTable, columns := m.tbl.Name, selectColumns(p.Stmt.Source)
+	if p.Stmt.View != "" {
+		if authTable, columns, err = m.viewColumns(p.Stmt.View, columns); err != nil {
+			return nil, err
+		}
 	}
-	authCtx, err2 := rbac.NewAuthContext(m.conn.KVStore, userID.ToString(), false)
-	if err2 != nil {
-		return nil, fmt.Errorf("RBAC error - %v", err2)
+	if err := m.authorizeTable(p.Context(), rbac.ViewDatabase, authTable, columns); err != nil {
+		return nil, err
 	}
-	u.Debugf("RBAC AuthContext created, USER ID = %v", userID.ToString())
-	if ok, err2 := authCtx.IsAuthorized(rbac.ViewDatabase, m.schema.Name); !ok {
-		return nil, fmt.Errorf("ViewDatabase not authorized on schema %s - %v", m.schema.Name, err2)
+	if orig, ok := p.Context().Stmt.(*rel.SqlSelect); ok && orig.Into != nil {
+		if err := m.authorizeTable(p.Context(), rbac.ExportData, authTable, nil); err != nil {
+			return nil, err
+		}
 	}
 
 	m.TaskBase = exec.NewTaskBase(p.Context())
@@ -320,32 +340,31 @@ func (m *SQLToQuanta) WalkSourceSelect(planner plan.Planner, p *plan.Source) (pl
 		}
 	}
 
-	/*
-	   if len(req.GroupBy) > 0 {
-	       err = m.walkGroupBy()
-	       if err != nil {
-	           u.Warnf("Could Not evaluate GroupBys %s %v", req.GroupBy.String(), err)
-	           return nil, err
-	       }
-	   }
-
-	   u.Debugf("OrderBy? %v", len(m.sel.OrderBy))
-	   if len(m.sel.OrderBy) > 0 {
-	       m.sort = make([]bson.M, len(m.sel.OrderBy))
-	       for i, col := range m.sel.OrderBy {
-	           // We really need to look at any funcs?   walk this out
-	           switch col.Order {
-	           case "ASC":
-	               m.sort[i] = bson.M{col.As: 1}
-	           case "DESC":
-	               m.sort[i] = bson.M{col.As: -1}
-	           default:
-	               // default sorder order = ?
-	               m.sort[i] = bson.M{col.As: -1}
-	           }
-	       }
-	   }
-	*/
+	if len(req.GroupBy) > 0 {
+		err = m.walkGroupBy()
+		if err != nil {
+			u.Warnf("Could Not evaluate GroupBys %s %v", req.GroupBy.String(), err)
+			return nil, err
+		}
+	}
+
+	m.orderBy = nil
+	if processingOrig && len(req.OrderBy) > 0 {
+		m.walkOrderBy()
+	}
+	if m.orderBy != nil {
+		// ORDER BY is processed natively so results are already in sequence.
+		sessionMap[exec.ORDER_MAKER] = func(ctx *plan.Context, p *plan.Order) exec.TaskRunner {
+			return NewNopTask(ctx)
+		}
+		v := sessionMap[exec.ORDER_MAKER]
+		sk := SchemaInfoString{k: exec.ORDER_MAKER}
+		p.Context().Session.Put(sk, nil, value.NewValue(v))
+	} else {
+		dm := make(map[string]value.Value)
+		dm[exec.ORDER_MAKER] = value.NilValueVal
+		p.Context().Session.Delete(dm)
+	}
 
 	if m.p.Complete {
 		sessionMap[exec.WHERE_MAKER] = func(ctx *plan.Context, p *plan.Where) exec.TaskRunner {
@@ -407,6 +426,315 @@ func (m *SQLToQuanta) WalkSourceSelect(planner plan.Planner, p *plan.Source) (pl
 	return nil, nil
 }
 
+// readConsistency - Read consistency level of the session (SET read_consistency = 'QUORUM').
+func readConsistency(ctx *plan.Context) (shared.ConsistencyLevel, error) {
+
+	if ctx == nil || ctx.Session == nil {
+		return shared.ConsistencyOne, nil
+	}
+	level, ok := ctx.Session.Get(shared.ReadConsistencyKey)
+	if !ok {
+		return shared.ConsistencyOne, nil
+	}
+	return shared.ParseConsistencyLevel(level.ToString())
+}
+
+// queryContext - Go context of a query, it is canceled when the query is killed or times out.
+func queryContext(ctx *plan.Context) context.Context {
+
+	if ctx == nil || ctx.Context == nil {
+		return context.Background()
+	}
+	return ctx.Context
+}
+
+// authorize - Verify that the session user holds a permission for the current table.  If columns
+// are provided then column level grants are also considered.
+func (m *SQLToQuanta) authorize(ctx *plan.Context, perm rbac.Permission, columns []string) error {
+	return m.authorizeTable(ctx, perm, m.tbl.Name, columns)
+}
+
+// authorizeTable - Verify that the session user holds a permission for a table or view.
+func (m *SQLToQuanta) authorizeTable(ctx *plan.Context, perm rbac.Permission, table string, columns []string) error {
+
+	if ctx == nil || ctx.Session == nil {
+		return fmt.Errorf("User ID (%s) not set for session", userIDKey)
+	}
+	userID, ok := ctx.Session.Get(userIDKey)
+	if !ok {
+		return fmt.Errorf("User ID (%s) not set for session", userIDKey)
+	}
+
+	// Inserts call Put for every row so don't repeat checks that have already passed.
+	key := fmt.Sprintf("%s/%s/%s/%v", userID.ToString(), perm.String(), table, columns)
+	if _, found := m.authorized[key]; found {
+		return nil
+	}
+	authCtx, err := rbac.NewAuthContext(m.conn.KVStore, userID.ToString(), false)
+	if err != nil {
+		return fmt.Errorf("RBAC error - %v", err)
+	}
+	u.Debugf("RBAC AuthContext created, USER ID = %v", userID.ToString())
+	if ok, err := authCtx.IsAuthorizedForTable(perm, m.schema.Name, table, columns); !ok {
+		return fmt.Errorf("%s not authorized on table %s.%s - %v", perm.String(), m.schema.Name, table, err)
+	}
+	if m.authorized != nil {
+		m.authorized[key] = struct{}{}
+	}
+	return nil
+}
+
+// viewColumns - The columns of a view referenced by a query on it.  The view predicate was ANDed into the
+// query, so base table columns that are not part of the view are not the caller's references.
+func (m *SQLToQuanta) viewColumns(view string, columns []string) (string, []string, error) {
+
+	tbl, err := m.s.Table(view)
+	if err != nil {
+		return "", nil, err
+	}
+	if columns == nil {
+		return view, nil, nil
+	}
+	viewCols := make([]string, 0, len(columns))
+	for _, col := range columns {
+		if tbl.HasField(col) {
+			viewCols = append(viewCols, col)
+		}
+	}
+	return view, viewCols, nil
+}
+
+// selectColumns - Columns referenced by the select list and predicates.  Returns nil for "SELECT *".
+func selectColumns(sel *rel.SqlSelect) []string {
+
+	if sel == nil {
+		return nil
+	}
+	names := make(map[string]struct{})
+	for _, col := range sel.Columns {
+		if col.Star {
+			return nil
+		}
+		if col.Expr != nil {
+			for _, x := range expr.FindAllIdentityField(col.Expr) {
+				names[x] = struct{}{}
+			}
+		}
+	}
+	if sel.Where != nil && sel.Where.Expr != nil {
+		for _, x := range expr.FindAllIdentityField(sel.Where.Expr) {
+			names[x] = struct{}{}
+		}
+	}
+	return columnNames(names)
+}
+
+// columnNames - Sorted column names with table qualifiers and pseudo columns removed.
+func columnNames(names map[string]struct{}) []string {
+
+	columns := make([]string, 0, len(names))
+	for k := range names {
+		if i := strings.LastIndex(k, "."); i >= 0 {
+			k = k[i+1:]
+		}
+		if k == "*" || k == "@rownum" || k == "" {
+			continue
+		}
+		columns = append(columns, k)
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+// walkGroupBy - Validate that the GROUP BY clause and select list can be processed natively.
+// Grouping is supported on standard bitmap fields that can be reverse mapped (StringEnum, IntDirect,
+// BoolDirect, buckets, date parts and Contains) and the select list may only contain grouping
+// columns or COUNT/SUM/AVG/MIN/MAX aggregates.
+func (m *SQLToQuanta) walkGroupBy() error {
+
+	orig, ok := m.p.Context().Stmt.(*rel.SqlSelect)
+	if !ok {
+		return fmt.Errorf("cannot get original select from context")
+	}
+	if len(orig.From) > 1 {
+		return fmt.Errorf("GROUP BY is not supported for joins")
+	}
+
+	m.groupBy = make([]*core.Attribute, 0)
+	groupFields := make(map[string]struct{})
+	for _, col := range m.sel.GroupBy {
+		n, isIdent := col.Expr.(*expr.IdentityNode)
+		if !isIdent {
+			return fmt.Errorf("GROUP BY expression %s not supported", col.String())
+		}
+		fieldName := n.Text
+		if _, r, isLR := n.LeftRight(); isLR {
+			fieldName = r
+		}
+		if x, found := m.identAliases[fieldName]; found {
+			if y, ok := x.(*expr.IdentityNode); ok {
+				n = y
+				fieldName = y.Text
+				if _, r, isLR := y.LeftRight(); isLR {
+					fieldName = r
+				}
+			}
+		}
+		attr, isBSI, err := m.ResolveField(m.ResolveTable(n), fieldName)
+		if err != nil {
+			return err
+		}
+		switch core.MapperTypeFromString(attr.MappingStrategy) {
+		case core.StringEnum, core.IntDirect, core.BoolDirect, core.IntLinear, core.IntBuckets,
+			core.FloatLinear, core.FloatBuckets, core.YearToDay, core.YearToMonth, core.Year,
+			core.DayOfYear, core.DayOfMonth, core.DayOfWeek, core.TimeOfDay, core.HourOfDay, core.Contains:
+		default:
+			isBSI = true
+		}
+		if isBSI {
+			return fmt.Errorf("GROUP BY field %s must be a standard bitmap field with reverse mapping", fieldName)
+		}
+		groupFields[attr.FieldName] = struct{}{}
+		m.groupBy = append(m.groupBy, attr)
+	}
+
+	for _, col := range orig.Columns {
+		switch curNode := col.Expr.(type) {
+		case *expr.IdentityNode:
+			fieldName := curNode.Text
+			if _, r, isLR := curNode.LeftRight(); isLR {
+				fieldName = r
+			}
+			if _, found := groupFields[fieldName]; !found {
+				return fmt.Errorf("column %s must appear in the GROUP BY clause or be used in an aggregate", fieldName)
+			}
+		case *expr.FuncNode:
+			switch strings.ToLower(curNode.Name) {
+			case "count", "sum", "avg", "min", "max":
+				if dn, ok := curNode.Args[0].(*expr.FuncNode); ok && strings.ToLower(dn.Name) == "distinct" {
+					return fmt.Errorf("COUNT(DISTINCT) not supported with GROUP BY")
+				}
+			default:
+				return fmt.Errorf("aggregate %s not supported with GROUP BY", curNode.Name)
+			}
+		default:
+			return fmt.Errorf("select expression %s not supported with GROUP BY", col.String())
+		}
+	}
+	return nil
+}
+
+// walkOrderBy - Determine if ORDER BY can be pushed down.  This is possible for a single table query
+// with a LIMIT that orders by one BSI field having a numeric ordering and where the predicate was
+// processed entirely by the backend.  Otherwise ordering is left to the query processor.
+func (m *SQLToQuanta) walkOrderBy() {
+
+	orig, ok := m.p.Context().Stmt.(*rel.SqlSelect)
+	if !ok || len(orig.From) > 1 || len(m.sel.OrderBy) != 1 || m.sel.Limit == 0 {
+		return
+	}
+	if len(m.p.Stmt.JoinNodes()) > 0 || len(m.groupBy) > 0 || orig.IsAggQuery() || orig.Distinct {
+		return
+	}
+	if !m.p.Complete || m.needsPolyFill || len(m.funcAliases) > 0 || m.rowNumSet.GetCardinality() > 0 {
+		return
+	}
+	col := m.sel.OrderBy[0]
+	n, isIdent := col.Expr.(*expr.IdentityNode)
+	if !isIdent {
+		return
+	}
+	fieldName := n.Text
+	if _, r, isLR := n.LeftRight(); isLR {
+		fieldName = r
+	}
+	if x, found := m.identAliases[fieldName]; found {
+		if y, ok := x.(*expr.IdentityNode); ok {
+			n = y
+			fieldName = y.Text
+			if _, r, isLR := y.LeftRight(); isLR {
+				fieldName = r
+			}
+		}
+	}
+	attr, isBSI, err := m.ResolveField(m.ResolveTable(n), fieldName)
+	if err != nil || !isBSI {
+		return
+	}
+	switch core.MapperTypeFromString(attr.MappingStrategy) {
+	case core.IntBSI, core.FloatScaleBSI, core.SysMillisBSI, core.SysMicroBSI, core.SysSecBSI:
+	default:
+		return // Hashed and custom values have no meaningful order.
+	}
+	m.orderBy = attr
+	m.orderDesc = !col.Asc()
+}
+
+// orderResults - Reduce the query results to the page of column IDs selected by ORDER BY ... LIMIT.
+// Column IDs with no value sort first when ascending and last when descending.
+func (m *SQLToQuanta) orderResults(response *shared.BitmapQueryResponse) error {
+
+	fromTime, err := time.Parse(shared.YMDHTimeFmt, m.startDate)
+	if err != nil {
+		return err
+	}
+	toTime, err := time.Parse(shared.YMDHTimeFmt, m.endDate)
+	if err != nil {
+		return err
+	}
+	table := m.orderBy.Parent.Name
+	want := m.offset + m.limit
+
+	// Find the columns that have no value for the ORDER BY field.
+	q := shared.NewBitmapQuery()
+	q.FromTime = m.startDate
+	q.ToTime = m.endDate
+	f := q.NewQueryFragment()
+	f.SetNullPredicate(table, m.orderBy.FieldName)
+	f.Operation = "DIFFERENCE"
+	f.Negate = true
+	q.AddFragment(f)
+	exists, err := m.conn.BitIndex.QueryContext(queryContext(m.Ctx), q)
+	if err != nil {
+		return err
+	}
+	nulls := roaring64.AndNot(response.Results, exists.Results)
+
+	ordered := make([]uint64, 0, want)
+	if !m.orderDesc {
+		ordered = appendColumnIDs(ordered, nulls, want)
+	}
+	if k := want - len(ordered); k > 0 {
+		ids, err := m.conn.BitIndex.TopK(table, m.orderBy.FieldName, k, m.orderDesc, fromTime.UnixNano(),
+			toTime.UnixNano(), roaring64.AndNot(response.Results, nulls))
+		if err != nil {
+			return err
+		}
+		ordered = append(ordered, ids...)
+	}
+	if m.orderDesc {
+		ordered = appendColumnIDs(ordered, nulls, want)
+	}
+
+	if m.offset >= len(ordered) {
+		m.orderedIDs = []uint64{}
+	} else {
+		m.orderedIDs = ordered[m.offset:]
+	}
+	response.Results = roaring64.BitmapOf(m.orderedIDs...)
+	return nil
+}
+
+// appendColumnIDs - Append column IDs from a bitmap until the slice reaches the maximum length.
+func appendColumnIDs(ids []uint64, bm *roaring64.Bitmap, max int) []uint64 {
+
+	iter := bm.Iterator()
+	for len(ids) < max && iter.HasNext() {
+		ids = append(ids, iter.Next())
+	}
+	return ids
+}
+
 // Walk() an expression, and its logic to create an appropriately
 // nested structure for quanta queries if possible.
 //
@@ -698,6 +1026,7 @@ func (m *SQLToQuanta) walkFilterBinary(node *expr.BinaryNode, q *shared.QueryFra
 			for _, x := range node.Args[0].(*expr.FuncNode).Args {
 				if x.NodeType() == "Identity" {
 					m.p.Complete = false
+					m.polyFill = append(m.polyFill, node.String())
 					break
 				}
 			}
@@ -707,6 +1036,7 @@ func (m *SQLToQuanta) walkFilterBinary(node *expr.BinaryNode, q *shared.QueryFra
 			if n, isId := node.Args[0].(*expr.IdentityNode); isId {
 				if _, ok := m.funcAliases[n.Text]; ok {
 					m.p.Complete = false
+					m.polyFill = append(m.polyFill, node.String())
 				}
 			}
 		}
@@ -1197,43 +1527,32 @@ func (m *SQLToQuanta) walkAggFunc(node *expr.FuncNode, q *shared.QueryFragment)
 		if !ok {
 			u.Warnf("Could not run node in backend: %v", node.String())
 			m.needsPolyFill = true
-		} else {
-			// "min_price" : { "min" : { "field" : "price" } }
-			//q = M{funcName: M{"field": val.ToString()}}
-		}
-		m.aggField = val.ToString()
-		if funcName == "sum" {
-			m.isSum = true
-			m.needsPolyFill = false
-		}
-		if funcName == "avg" {
-			m.isAvg = true
-			m.needsPolyFill = false
+			m.polyFill = append(m.polyFill, node.String())
+			return nil
 		}
-		if funcName == "min" {
-			m.isMin = true
-			m.needsPolyFill = false
-		}
-		if funcName == "max" {
-			m.isMax = true
-			m.needsPolyFill = false
-		}
-		_, bsi, err := m.ResolveField(m.ResolveTable(node), m.aggField)
+		fieldName := val.ToString()
+		_, bsi, err := m.ResolveField(m.ResolveTable(node), fieldName)
 		if err != nil {
 			return err
 		}
-		if !bsi && m.isSum {
-			return fmt.Errorf("can't sum a non-bsi field %s", m.aggField)
-		}
-		if !bsi && m.isAvg {
-			return fmt.Errorf("can't average a non-bsi field %s", m.aggField)
-		}
-		if !bsi && m.isMin {
-			return fmt.Errorf("can't find the minimum of a non-bsi field %s", m.aggField)
-		}
-		if !bsi && m.isMax {
-			return fmt.Errorf("can't find the maximum of a non-bsi field %s", m.aggField)
+		if funcName == "cardinality" {
+			m.aggField = fieldName
+			return nil
+		}
+		if !bsi {
+			switch funcName {
+			case "sum":
+				return fmt.Errorf("can't sum a non-bsi field %s", fieldName)
+			case "avg":
+				return fmt.Errorf("can't average a non-bsi field %s", fieldName)
+			case "min":
+				return fmt.Errorf("can't find the minimum of a non-bsi field %s", fieldName)
+			case "max":
+				return fmt.Errorf("can't find the maximum of a non-bsi field %s", fieldName)
+			}
 		}
+		m.needsPolyFill = false
+		m.aggregates[node.String()] = &aggregate{funcName: funcName, field: fieldName}
 		return nil
 		/*
 		   case "terms":
@@ -1260,6 +1579,38 @@ func (m *SQLToQuanta) walkAggFunc(node *expr.FuncNode, q *shared.QueryFragment)
 		       }
 		*/
 
+	case "percentile", "median":
+		m.hasSingleValue = true
+		if (funcName == "median" && len(node.Args) != 1) || (funcName == "percentile" && len(node.Args) != 2) {
+			return fmt.Errorf("invalid arguments: %v", node.String())
+		}
+		val, ok := eval(node.Args[0])
+		if !ok {
+			return fmt.Errorf("%s argument must be a field name", node.Name)
+		}
+		fieldName := val.ToString()
+		_, bsi, err := m.ResolveField(m.ResolveTable(node), fieldName)
+		if err != nil {
+			return err
+		}
+		if !bsi {
+			return fmt.Errorf("can't find the %s of a non-bsi field %s", funcName, fieldName)
+		}
+		pct := 0.5
+		if funcName == "percentile" {
+			pv, ok := eval(node.Args[1])
+			if !ok {
+				return fmt.Errorf("invalid percentile: %v", node.String())
+			}
+			v, ok := pv.(value.NumericValue)
+			if !ok || v.Float() < 0 || v.Float() > 1 {
+				return fmt.Errorf("percentile must be a number between 0 and 1: %v", node.String())
+			}
+			pct = v.Float()
+		}
+		m.needsPolyFill = false
+		m.aggregates[node.String()] = &aggregate{funcName: "percentile", field: fieldName, percentile: pct}
+		return nil
 	case "topn":
 		m.hasSingleValue = true
 		if len(node.Args) < 1 || len(node.Args) > 2 {
@@ -1301,8 +1652,15 @@ func (m *SQLToQuanta) walkAggFunc(node *expr.FuncNode, q *shared.QueryFragment)
 		if bsi {
 			return fmt.Errorf("can't rank BSI field %s", m.aggField)
 		}
-	case "count":
+	case "count", "approx_count_distinct":
 		m.hasSingleValue = true
+		if len(node.Args) == 0 {
+			return fmt.Errorf("%s requires an argument", funcName)
+		}
+		if dn, isDistinct := node.Args[0].(*expr.FuncNode); funcName == "approx_count_distinct" ||
+			isDistinct && strings.ToLower(dn.Name) == "distinct" {
+			return m.walkCountDistinct(node)
+		}
 		//u.Warnf("how do we want to use count(*)?  ?")
 		val, ok := eval(node.Args[0])
 		if !ok {
@@ -1310,10 +1668,12 @@ func (m *SQLToQuanta) walkAggFunc(node *expr.FuncNode, q *shared.QueryFragment)
 			return fmt.Errorf("invalid argument: %v", node.String())
 		}
 		if val.ToString() == "*" {
-			//return nil, nil
-			//return M{"$sum": 2}, nil
+			m.aggregates[node.String()] = &aggregate{funcName: funcName}
 		} else {
-			//return M{"exists": M{"field": val.ToString()}}, nil
+			if _, _, err := m.ResolveField(m.ResolveTable(node), val.ToString()); err != nil {
+				return err
+			}
+			m.aggregates[node.String()] = &aggregate{funcName: funcName, field: val.ToString()}
 		}
 
 		//default:
@@ -1326,6 +1686,58 @@ func (m *SQLToQuanta) walkAggFunc(node *expr.FuncNode, q *shared.QueryFragment)
 	return fmt.Errorf("not implemented")
 }
 
+// walkCountDistinct - COUNT(DISTINCT field) and APPROX_COUNT_DISTINCT(field [, precision]) are
+// processed by the backend.  The approximate form uses a HyperLogLog sketch for BSI fields.
+func (m *SQLToQuanta) walkCountDistinct(node *expr.FuncNode) error {
+
+	if len(node.Args) == 0 {
+		return fmt.Errorf("%s requires a field name argument", node.Name)
+	}
+	arg := node.Args[0]
+	precision := 0
+	if strings.ToLower(node.Name) == "approx_count_distinct" {
+		precision = shared.DefaultHLLPrecision
+		if len(node.Args) == 2 {
+			val, ok := eval(node.Args[1])
+			if !ok {
+				return fmt.Errorf("invalid precision: %v", node.String())
+			}
+			v, ok := val.(value.NumericValue)
+			if !ok {
+				return fmt.Errorf("precision must be an integer: %v", node.String())
+			}
+			precision = int(v.Int())
+			if precision < shared.MinHLLPrecision || precision > shared.MaxHLLPrecision {
+				return fmt.Errorf("precision must be between %d and %d", shared.MinHLLPrecision,
+					shared.MaxHLLPrecision)
+			}
+		}
+		if dn, ok := arg.(*expr.FuncNode); ok && strings.ToLower(dn.Name) == "distinct" && len(dn.Args) == 1 {
+			arg = dn.Args[0]
+		}
+	} else {
+		dn, ok := arg.(*expr.FuncNode)
+		if !ok || len(dn.Args) == 0 {
+			return fmt.Errorf("%s requires a field name argument", node.Name)
+		}
+		arg = dn.Args[0]
+	}
+	n, isIdent := arg.(*expr.IdentityNode)
+	if !isIdent {
+		return fmt.Errorf("%s argument must be a field name", node.Name)
+	}
+	fieldName := n.Text
+	if _, r, isLR := n.LeftRight(); isLR {
+		fieldName = r
+	}
+	if _, _, err := m.ResolveField(m.ResolveTable(n), fieldName); err != nil {
+		return err
+	}
+	m.aggregates[node.String()] = &aggregate{funcName: "count_distinct", field: fieldName, precision: precision}
+	m.needsPolyFill = false
+	return nil
+}
+
 func eval(cur expr.Node) (value.Value, bool) {
 	switch curNode := cur.(type) {
 	case *expr.IdentityNode:
@@ -1418,12 +1830,18 @@ func (m *SQLToQuanta) WalkExecSource(p *plan.Source) (exec.Task, error) {
 		}
 	}
 	orig := ctx.Stmt.(*rel.SqlSelect)
-	if orig.IsAggQuery() {
+	if orig.IsAggQuery() && len(m.groupBy) == 0 {
+		// One column per aggregate, returned as a single row.
 		ctx.Projection.Proj = rel.NewProjection()
 		ctx.Projection.Proj.Final = true
-		nm := orig.Columns[0].As
-		c1 := rel.NewResultColumn(nm, 0, rel.NewColumn(nm), value.IntType)
-		ctx.Projection.Proj.Columns = []*rel.ResultColumn{c1}
+		ctx.Projection.Proj.Columns = make([]*rel.ResultColumn, len(orig.Columns))
+		for i, col := range orig.Columns {
+			vt := value.NumberType
+			if agg, found := m.aggregates[col.Expr.String()]; !found || strings.HasPrefix(agg.funcName, "count") {
+				vt = value.IntType
+			}
+			ctx.Projection.Proj.Columns[i] = rel.NewResultColumn(col.As, i, rel.NewColumn(col.As), vt)
+		}
 	}
 	m.TaskBase = exec.NewTaskBase(ctx)
 	m.sel = p.Stmt.Source
@@ -1437,10 +1855,18 @@ func (m *SQLToQuanta) WalkExecSource(p *plan.Source) (exec.Task, error) {
 
 	var response *shared.BitmapQueryResponse
 
+	// EXPLAIN plans the query without running it, EXPLAIN ANALYZE runs it and collects fragment statistics.
+	explain := explainFrom(ctx)
+	planOnly := explain != nil && !explain.Analyze
+	searches := make([]*shared.PlanStep, 0)
+	if explain != nil {
+		m.q.Analyze = explain.Analyze
+	}
+
 	// handle "LIKE"
 	err = m.q.Visit(func(f *shared.QueryFragment) error {
 		// For like operator invoke search client and pass resulting hashcode list as BATCH_EQ
-		if f.Operation == "LIKE_UNION" || f.Operation == "LIKE_INTERSECT" {
+		if !planOnly && (f.Operation == "LIKE_UNION" || f.Operation == "LIKE_INTERSECT") {
 			start := time.Now()
 			results, err := m.conn.StringIndex.Search(f.Search)
 			if err != nil {
@@ -1448,6 +1874,11 @@ func (m *SQLToQuanta) WalkExecSource(p *plan.Source) (exec.Task, error) {
 			}
 			u.Infof("LIKE '%s' matches %d items.\n", f.Search, len(results))
 			elapsed := time.Since(start)
+			if explain != nil {
+				searches = append(searches, &shared.PlanStep{Table: f.Index, Operation: "SEARCH",
+					Predicate: fmt.Sprintf("%s LIKE '%s'", f.Field, f.Search),
+					Stats:     &shared.FragmentStats{Cardinality: uint64(len(results)), Elapsed: elapsed}})
+			}
 			u.Infof("Text search done in %v. Passing hashcodes to query.\n", elapsed)
 			values := make([]int64, len(results))
 			j := 0
@@ -1474,12 +1905,20 @@ func (m *SQLToQuanta) WalkExecSource(p *plan.Source) (exec.Task, error) {
 	m.q.Dump()
 	start := time.Now()
 	// If querying by row number no need to send query to backend.  Will only do a projection inside the resultreader.
-	if m.rowNumSet.GetCardinality() > 0 {
+	if planOnly {
+		response = &shared.BitmapQueryResponse{Success: true, Results: roaring64.NewBitmap()}
+		if m.q.Consistency, err = readConsistency(ctx); err != nil {
+			return nil, err
+		}
+	} else if m.rowNumSet.GetCardinality() > 0 {
 		response = &shared.BitmapQueryResponse{Success: true}
 		response.Results = m.rowNumSet
 		response.Count = m.rowNumSet.GetCardinality()
 	} else {
-		response, err = m.conn.BitIndex.Query(m.q)
+		if m.q.Consistency, err = readConsistency(ctx); err != nil {
+			return nil, err
+		}
+		response, err = m.conn.BitIndex.QueryContext(queryContext(ctx), m.q)
 	}
 	elapsed := time.Since(start)
 	u.Debugf("Elapsed time %s\n", elapsed)
@@ -1487,6 +1926,20 @@ func (m *SQLToQuanta) WalkExecSource(p *plan.Source) (exec.Task, error) {
 
 	if err != nil {
 		u.Errorf("%v", err)
+	} else if response.ColdPartitions > 0 {
+		u.Infof("Query read %d archived partitions, SQL = %v", response.ColdPartitions, m.sel)
+	}
+
+	// ORDER BY ... LIMIT pushdown, only the requested page of results is projected.
+	offset := m.offset
+	m.orderedIDs = nil
+	if err == nil && m.orderBy != nil && response.Success && !planOnly {
+		if err = m.orderResults(response); err != nil {
+			u.Errorf("%v", err)
+			return nil, err
+		}
+		offset = 0
+		u.Debugf("ORDER BY %s pushdown elapsed time %s\n", m.orderBy.FieldName, time.Since(start))
 	}
 	//query := m.sess.DB(m.schema.Name).C(m.tbl.Name).Find(m.filter)
 	// if len(m.sort) > 0 {
@@ -1497,33 +1950,23 @@ func (m *SQLToQuanta) WalkExecSource(p *plan.Source) (exec.Task, error) {
 	//dummyWhere, _ := expr.ParseExpression("1=1")
 	//m.sel.Where = rel.NewSqlWhere(dummyWhere)
 
+	if explain != nil && err == nil {
+		isDriver := false
+		if len(p.Stmt.JoinNodes()) > 0 {
+			isDriver = m.conn.IsDriverForJoin(m.tbl.Name, p.Stmt.JoinNodes()[0].String())
+		}
+		var stats *shared.FragmentStats
+		if explain.Analyze {
+			stats = &shared.FragmentStats{Cardinality: response.Count, Elapsed: time.Since(start)}
+		}
+		m.explainQuery(explain, isDriver, searches, stats)
+	}
+
 	//u.LogTraceDf(u.WARN, 16, "hello")
-	resultReader := NewResultReader(m.conn, m, response, m.limit, m.offset)
+	resultReader := NewResultReader(m.conn, m, response, m.limit, offset)
 	m.resp = resultReader
 
 	//u.Debugf("sqltopql: %p  resultreader: %p sourceplan: %p argsource:%p ", m, m.resp, m.p, p)
-	/*
-	   if len(m.sel.OrderBy) > 0 {
-	       sorts := make([]string, len(m.sel.OrderBy))
-	       for i, col := range m.sel.OrderBy {
-	           // TODO: look at any funcs?   walk these expressions?
-	           switch col.Order {
-	           case "ASC":
-	               sorts[i] = col.As
-	           case "DESC":
-	               sorts[i] = fmt.Sprintf("-%s", col.As)
-	           default:
-	               // default sorder order = "naturalorder"
-	               sorts[i] = col.As
-	           }
-	       }
-	       //query = query.Sort(sorts...)
-	       op := plan.NewOrder(m.sel)
-	       ot := exec.NewOrder(ctx, op)
-	       resultReader.Add(ot)
-	       m.needsPolyFill = true
-	   }
-	*/
 
 	return resultReader, err
 }
@@ -1535,6 +1978,7 @@ func (m *SQLToQuanta) CreateMutator(pc interface{}) (schema.ConnMutator, error)
 	if ctx, ok := pc.(*plan.Context); ok && ctx != nil {
 		m.TaskBase = exec.NewTaskBase(ctx)
 		m.stmt = ctx.Stmt
+		m.authorized = make(map[string]struct{})
 		return m, nil
 	}
 	return nil, fmt.Errorf("expected *plan.Context but got %T", pc)
@@ -1555,6 +1999,22 @@ func (m *SQLToQuanta) PatchWhere(ctx context.Context, where expr.Node, patch int
 	}
 	defer m.s.sessionPool.Return(m.tbl.Name, m.conn)
 
+	// Columns referenced by the predicate are authorized along with the updated columns.
+	names := make(map[string]struct{})
+	if pm, ok := patch.(map[string]driver.Value); ok {
+		for k := range pm {
+			names[k] = struct{}{}
+		}
+	}
+	if where != nil {
+		for _, x := range expr.FindAllIdentityField(where) {
+			names[x] = struct{}{}
+		}
+	}
+	if err := m.authorize(m.Ctx, rbac.WriteDatabase, columnNames(names)); err != nil {
+		return 0, err
+	}
+
 	if where != nil {
 		_, err = m.walkNode(where, frag)
 		if err != nil {
@@ -1589,9 +2049,8 @@ func (m *SQLToQuanta) PatchWhere(ctx context.Context, where expr.Node, patch int
 		}
 	}
 
-	results := response.Results.ToArray()
-	if len(results) != 1 {
-		return 0, fmt.Errorf("expecting 1 result from update query but got %d", response.Count)
+	if response.Results.GetCardinality() == 0 {
+		return 0, nil
 	}
 
 	valueMap := make(map[string]*rel.ValueColumn)
@@ -1599,17 +2058,48 @@ func (m *SQLToQuanta) PatchWhere(ctx context.Context, where expr.Node, patch int
 		valueMap[k] = &rel.ValueColumn{Value: value.NewValue(v)}
 	}
 
+	// Group the matching column IDs by time partition
 	var timeFmt = shared.YMDHTimeFmt
-	updColID := results[0]
-	partition := time.Unix(0, int64(updColID))
 	table := m.conn.TableBuffers[m.tbl.Name].Table
 	if table.TimeQuantumType == "YMD" {
 		timeFmt = shared.YMDTimeFmt
 	}
-	partStr := partition.Format(timeFmt)
-	partition, _ = time.Parse(timeFmt, partStr)
+	partitions := make(map[int64]*roaring64.Bitmap)
+	itr := response.Results.Iterator()
+	for itr.HasNext() {
+		updColID := itr.Next()
+		partition := time.Unix(0, int64(updColID))
+		partStr := partition.Format(timeFmt)
+		partition, _ = time.Parse(timeFmt, partStr)
+		if cols, ok := partitions[partition.UnixNano()]; ok {
+			cols.Add(updColID)
+		} else {
+			partitions[partition.UnixNano()] = roaring64.BitmapOf(updColID)
+		}
+	}
+
+	return m.updatePartitions(m.tbl.Name, partitions, valueMap)
+}
+
+// updatePartitions - Buffer the updates of each time partition and flush them.  On error the buffered
+// operations are discarded so that they are not applied by the next borrower of the pooled session.
+func (m *SQLToQuanta) updatePartitions(table string, partitions map[int64]*roaring64.Bitmap,
+	valueMap map[string]*rel.ValueColumn) (int64, error) {
 
-	return m.updateRow(m.tbl.Name, updColID, valueMap, partition)
+	var updated int64
+	for ts, cols := range partitions {
+		count, err := m.updateRow(table, cols, valueMap, time.Unix(0, ts))
+		if err != nil {
+			m.conn.BatchBuffer.Discard()
+			return updated, err
+		}
+		updated += count
+	}
+	if err := m.conn.Flush(); err != nil {
+		m.conn.BatchBuffer.Discard()
+		return 0, fmt.Errorf("Update flush failed - %v", err)
+	}
+	return updated, nil
 }
 
 // Put Interface for inserts.  Updates are handled by PatchWhere
@@ -1644,6 +2134,10 @@ func (m *SQLToQuanta) Put(ctx context.Context, key schema.Key, val interface{})
 		return nil, fmt.Errorf("%T not yet supported ", q)
 	}
 
+	if err := m.authorize(m.Ctx, rbac.WriteDatabase, cols); err != nil {
+		return nil, err
+	}
+
 	// Everything from here on is an INSERT
 	var row []driver.Value
 	var vMap map[string]interface{} = make(map[string]interface{})
@@ -1800,15 +2294,22 @@ func (m *SQLToQuanta) Put(ctx context.Context, key schema.Key, val interface{})
 	return newKey, nil
 }
 
-// Call Client.Update - TODO, This fuctionality should be merged with PutRow()
-func (m *SQLToQuanta) updateRow(table string, columnID uint64, updValueMap map[string]*rel.ValueColumn,
+// Apply updates to all columns within a time partition via the session BatchBuffer.
+// TODO, This fuctionality should be merged with PutRow()
+func (m *SQLToQuanta) updateRow(table string, columnIDs *roaring64.Bitmap, updValueMap map[string]*rel.ValueColumn,
 	timePartition time.Time) (int64, error) {
 
 	tbuf, ok := m.conn.TableBuffers[table]
 	if !ok {
 		return 0, fmt.Errorf("table %s is not open for this session", table)
 	}
-	for k, vc := range updValueMap {
+	names := make([]string, 0, len(updValueMap))
+	for k := range updValueMap {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	for _, k := range names {
+		vc := updValueMap[k]
 		a, err := tbuf.Table.GetAttribute(k)
 		if err != nil {
 			return 0, fmt.Errorf("attribute %s.%s is not defined", table, k)
@@ -1817,12 +2318,44 @@ func (m *SQLToQuanta) updateRow(table string, columnID uint64, updValueMap map[s
 		if err != nil {
 			return 0, err
 		}
-		err = m.conn.BitIndex.Update(table, a.FieldName, columnID, int64(rowID), timePartition, a.IsBSI(), a.Exclusive)
-		if err != nil {
-			return 0, err
+		if a.IsBSI() {
+			itr := columnIDs.Iterator()
+			for itr.HasNext() {
+				if err := m.conn.BatchBuffer.SetValue(table, a.FieldName, itr.Next(), int64(rowID),
+					timePartition); err != nil {
+					return 0, err
+				}
+			}
+			continue
+		}
+		if a.Exclusive {
+			// Clear the existing values for the columns being updated
+			_, bitmaps, err := m.conn.BitIndex.Projection(table, []string{a.FieldName}, timePartition.UnixNano(),
+				timePartition.UnixNano(), columnIDs, false)
+			if err != nil {
+				return 0, err
+			}
+			for oldRowID, bm := range bitmaps[a.FieldName] {
+				if oldRowID == rowID {
+					continue
+				}
+				itr := bm.Iterator()
+				for itr.HasNext() {
+					if err := m.conn.BatchBuffer.ClearBit(table, a.FieldName, itr.Next(), oldRowID,
+						timePartition); err != nil {
+						return 0, err
+					}
+				}
+			}
+		}
+		itr := columnIDs.Iterator()
+		for itr.HasNext() {
+			if err := m.conn.BatchBuffer.SetBit(table, a.FieldName, itr.Next(), rowID, timePartition); err != nil {
+				return 0, err
+			}
 		}
 	}
-	return 1, nil
+	return int64(columnIDs.GetCardinality()), nil
 }
 
 // PutMulti - Multiple put operation handler.
@@ -1859,6 +2392,11 @@ func (m *SQLToQuanta) DeleteExpression(p interface{}, where expr.Node) (int, err
 	}
 	defer m.s.sessionPool.Return(m.tbl.Name, m.conn)
 
+	// Deletes remove entire rows so a grant for the whole table is required.
+	if err := m.authorize(m.Ctx, rbac.WriteDatabase, nil); err != nil {
+		return 0, err
+	}
+
 	if where != nil {
 		_, err = m.walkNode(where, frag)
 		if err != nil {
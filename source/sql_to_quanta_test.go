@@ -0,0 +1,44 @@
+package source
+
+import (
+	"testing"
+	"time"
+
+	"github.com/RoaringBitmap/roaring/roaring64"
+	"github.com/disney/quanta/core"
+	"github.com/disney/quanta/qlbridge/rel"
+	"github.com/disney/quanta/qlbridge/value"
+	"github.com/disney/quanta/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdatePartitionsDiscardsOnError(t *testing.T) {
+
+	table, err := core.LoadTable(core.NewTableCacheStruct(), "../core/testdata", nil, "cities", nil)
+	require.NoError(t, err)
+	session := &core.Session{TableBuffers: map[string]*core.TableBuffer{table.Name: {Table: table}},
+		BatchBuffer: shared.NewBatchBuffer(nil, nil, 1000)}
+	m := &SQLToQuanta{conn: session}
+
+	// Several rows in two partitions, population is buffered before the invalid ranking value fails.
+	day := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC).UnixNano()
+	next := day + int64(24*time.Hour)
+	partitions := map[int64]*roaring64.Bitmap{
+		day:  roaring64.BitmapOf(uint64(day)+1, uint64(day)+2),
+		next: roaring64.BitmapOf(uint64(next) + 1),
+	}
+	valueMap := map[string]*rel.ValueColumn{
+		"population": {Value: value.NewIntValue(10)},
+		"ranking":    {Value: value.NewStringValue("abc")},
+	}
+	_, err = m.updatePartitions(table.Name, partitions, valueMap)
+	require.Error(t, err)
+	assert.True(t, session.BatchBuffer.IsEmpty())
+
+	// Without the failing column the updates are buffered.
+	delete(valueMap, "ranking")
+	_, err = m.updateRow(table.Name, partitions[day], valueMap, time.Unix(0, day))
+	require.NoError(t, err)
+	assert.False(t, session.BatchBuffer.IsEmpty())
+}
@@ -111,10 +111,20 @@ func main() {
 	err = ctx.GrantRole(rbac.DomainUser, "USER001", "quanta", true)
 	check(err)
 
+	// MOLIG004 loads the test tables.  Table grants require a DomainAdmin grantor.
+	admin, err := rbac.NewAuthContext(sharedKV, "sqlrunner", true)
+	check(err)
+	err = admin.GrantRole(rbac.DomainAdmin, "sqlrunner", "quanta", true)
+	check(err)
+
 	ctx, err = rbac.NewAuthContext(sharedKV, "MOLIG004", true)
 	check(err)
 	err = ctx.GrantRole(rbac.DomainUser, "MOLIG004", "quanta", true)
 	check(err)
+	for _, table := range []string{"customers_qa", "orders_qa"} {
+		err = admin.GrantTable(rbac.WriteDatabase, "MOLIG004", "quanta", table, nil)
+		check(err)
+	}
 
 	for rep := 0; rep < *repeats; rep++ {
 		executeFile(rep, *scriptFile, proxyConnect, *validate)
@@ -184,6 +184,10 @@ func StartProxy(count int, testConfigPath string) *LocalProxyControl {
 		u.Error(errx)
 		os.Exit(1)
 	}
+	if errx = shared.RegisterViewChangeListener(consulConfig, proxy.SchemaChangeListener); errx != nil {
+		u.Error(errx)
+		os.Exit(1)
+	}
 
 	fmt.Println("Proxy RegisterSchemaChangeListener done")
 